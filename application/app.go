@@ -14,17 +14,23 @@ type usage interface {
 	PrintCommandUsage(command, message string)
 }
 
+type plugins interface {
+	Command(name string) (commands.Command, bool)
+}
+
 type App struct {
 	commands      CommandSet
 	configuration Configuration
 	usage         usage
+	plugins       plugins
 }
 
-func New(commands CommandSet, configuration Configuration, usage usage) App {
+func New(commands CommandSet, configuration Configuration, usage usage, plugins plugins) App {
 	return App{
 		commands:      commands,
 		configuration: configuration,
 		usage:         usage,
+		plugins:       plugins,
 	}
 }
 
@@ -34,6 +40,9 @@ func (a App) Run() error {
 
 func (a App) getCommand(commandString string) (commands.Command, error) {
 	command, ok := a.commands[commandString]
+	if !ok {
+		command, ok = a.plugins.Command(commandString)
+	}
 	if !ok {
 		a.usage.Print()
 		return nil, fmt.Errorf("unknown command: %s", commandString)
@@ -20,6 +20,7 @@ var _ = Describe("App", func() {
 		someCmd    *fakes.Command
 		errorCmd   *fakes.Command
 		usage      *fakes.Usage
+		plugins    *fakes.Plugins
 	)
 
 	var NewAppWithConfiguration = func(configuration application.Configuration) application.App {
@@ -32,6 +33,7 @@ var _ = Describe("App", func() {
 		},
 			configuration,
 			usage,
+			plugins,
 		)
 	}
 
@@ -44,6 +46,7 @@ var _ = Describe("App", func() {
 		someCmd.ExecuteCall.PassState = true
 
 		usage = &fakes.Usage{}
+		plugins = &fakes.Plugins{}
 
 		app = NewAppWithConfiguration(application.Configuration{})
 	})
@@ -173,7 +176,7 @@ var _ = Describe("App", func() {
 						}, application.Configuration{
 							Command:         "some",
 							SubcommandFlags: []string{"-v"},
-						}, usage)
+						}, usage, plugins)
 					})
 
 					It("returns an error", func() {
@@ -208,6 +211,26 @@ var _ = Describe("App", func() {
 					err := app.Run()
 					Expect(err).To(MatchError("unknown command: some-unknown-command"))
 					Expect(usage.PrintCall.CallCount).To(Equal(1))
+					Expect(plugins.CommandCall.Receives.Name).To(Equal("some-unknown-command"))
+				})
+
+				Context("when there is a plugin for the command", func() {
+					It("executes the plugin", func() {
+						pluginCmd := &fakes.Command{}
+						plugins.CommandCall.Returns.Command = pluginCmd
+						plugins.CommandCall.Returns.OK = true
+
+						app = NewAppWithConfiguration(application.Configuration{
+							Command:         "cf-deploy",
+							SubcommandFlags: []string{"--some-flag"},
+						})
+						Expect(app.Run()).To(Succeed())
+
+						Expect(plugins.CommandCall.Receives.Name).To(Equal("cf-deploy"))
+						Expect(pluginCmd.ExecuteCall.CallCount).To(Equal(1))
+						Expect(pluginCmd.ExecuteCall.Receives.SubcommandFlags).To(Equal([]string{"--some-flag"}))
+						Expect(usage.PrintCall.CallCount).To(Equal(0))
+					})
 				})
 			})
 
@@ -5,13 +5,19 @@ import "github.com/cloudfoundry/bosh-bootloader/storage"
 type GlobalConfiguration struct {
 	StateDir string
 	Debug    bool
+	Output   string
 }
 
 type StringSlice []string
 
+// ContainsAny ignores elements after "--", which are passed through to the
+// subcommand untouched.
 func (s StringSlice) ContainsAny(targets ...string) bool {
 	for _, target := range targets {
 		for _, element := range s {
+			if element == "--" {
+				break
+			}
 			if element == target {
 				return true
 			}
@@ -17,5 +17,10 @@ var _ = Describe("StringSlice", func() {
 			stringSlice := application.StringSlice{"apple", "banana", "cat", "dog", "elephant"}
 			Expect(stringSlice.ContainsAny("zebra", "kangaroo")).To(BeFalse())
 		})
+
+		It("ignores the elements after a double dash", func() {
+			stringSlice := application.StringSlice{"--jumpbox", "--", "grep", "-v", "zebra"}
+			Expect(stringSlice.ContainsAny("-v", "zebra")).To(BeFalse())
+		})
 	})
 })
@@ -0,0 +1,7 @@
+package application
+
+import "time"
+
+func SetLoggerNow(l *Logger, now func() time.Time) {
+	l.now = now
+}
@@ -1,9 +1,11 @@
 package application
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 type Logger struct {
@@ -11,6 +13,31 @@ type Logger struct {
 	writer    io.Writer
 	reader    io.Reader
 	noConfirm bool
+
+	json      bool
+	events    io.Writer
+	rawOutput bool
+	envID     string
+	now       func() time.Time
+	phase     string
+	step      string
+	stepStart time.Time
+}
+
+type event struct {
+	Time     string   `json:"time"`
+	Event    string   `json:"event"`
+	Phase    string   `json:"phase,omitempty"`
+	EnvID    string   `json:"env_id,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Duration *float64 `json:"duration_seconds,omitempty"`
+	Command  string   `json:"command,omitempty"`
+	ExitCode *int     `json:"exit_code,omitempty"`
+	Answer   *bool    `json:"answer,omitempty"`
+}
+
+type exitCoder interface {
+	ExitCode() int
 }
 
 func NewLogger(writer io.Writer, reader io.Reader) *Logger {
@@ -19,6 +46,7 @@ func NewLogger(writer io.Writer, reader io.Reader) *Logger {
 		writer:    writer,
 		reader:    reader,
 		noConfirm: false,
+		now:       time.Now,
 	}
 }
 
@@ -31,39 +59,171 @@ func (l *Logger) clear() {
 	l.newline = true
 }
 
+// JSON switches the logger to writing one JSON event per line.
+func (l *Logger) JSON() {
+	l.json = true
+	l.events = l.writer
+}
+
+// JSONEvents writes the steps, prompts and child exits of the logger as
+// JSON events to events, but leaves what is printed with Printf and Println
+// untouched, since commands print their data with them.
+func (l *Logger) JSONEvents(events io.Writer) {
+	l.json = true
+	l.events = events
+	l.rawOutput = true
+}
+
+func (l *Logger) SetEnvID(envID string) {
+	l.envID = envID
+}
+
+// SetPhase sets the phase of bbl up or bbl destroy that the following events
+// belong to, and completes the step of the previous phase. An empty phase
+// ends the current one.
+func (l *Logger) SetPhase(phase string) {
+	if l.json {
+		l.completeStep()
+	}
+	l.phase = phase
+}
+
+func (l *Logger) emit(e event) {
+	e.Time = l.now().UTC().Format(time.RFC3339Nano)
+	e.Phase = l.phase
+	e.EnvID = l.envID
+
+	line, _ := json.Marshal(e)
+	l.events.Write(append(line, '\n'))
+}
+
+func (l *Logger) completeStep() {
+	if l.step == "" {
+		return
+	}
+
+	duration := l.now().Sub(l.stepStart).Seconds()
+	l.emit(event{Event: "step_completed", Message: l.step, Duration: &duration})
+	l.step = ""
+}
+
 func (l *Logger) Step(message string, a ...interface{}) {
+	message = fmt.Sprintf(message, a...)
+
+	if l.json {
+		l.completeStep()
+		l.step = message
+		l.stepStart = l.now()
+		l.emit(event{Event: "step", Message: message})
+		return
+	}
+
 	l.clear()
-	fmt.Fprintf(l.writer, "step: %s\n", fmt.Sprintf(message, a...))
+	fmt.Fprintf(l.writer, "step: %s\n", message)
 	l.newline = true
 }
 
 func (l *Logger) Dot() {
+	if l.json {
+		return
+	}
+
 	l.writer.Write([]byte("\u2022"))
 	l.newline = false
 }
 
 func (l *Logger) Printf(message string, a ...interface{}) {
+	message = fmt.Sprintf(message, a...)
+
+	if l.json && !l.rawOutput {
+		l.message(message)
+		return
+	}
+
 	l.clear()
-	fmt.Fprintf(l.writer, "%s", fmt.Sprintf(message, a...))
+	fmt.Fprintf(l.writer, "%s", message)
 }
 
 func (l *Logger) Println(message string) {
+	if l.json && !l.rawOutput {
+		l.message(message)
+		return
+	}
+
 	l.clear()
 	fmt.Fprintf(l.writer, "%s\n", message)
 }
 
+func (l *Logger) message(message string) {
+	message = strings.TrimRight(message, "\n")
+	if strings.TrimSpace(message) == "" {
+		return
+	}
+
+	name := "message"
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(message)), "warning") {
+		name = "warning"
+	}
+	l.emit(event{Event: name, Message: message})
+}
+
+// ChildExited records the exit code of a terraform or bosh process.
+func (l *Logger) ChildExited(command string, exitCode int) {
+	if !l.json {
+		return
+	}
+
+	l.emit(event{Event: "child_exited", Command: command, ExitCode: &exitCode})
+}
+
+// Finish completes the current step and records the error the command
+// failed with, if any.
+func (l *Logger) Finish(err error) {
+	if !l.json {
+		return
+	}
+
+	l.completeStep()
+	if err == nil {
+		return
+	}
+
+	e := event{Event: "error", Message: err.Error()}
+	if exitErr, ok := err.(exitCoder); ok {
+		exitCode := exitErr.ExitCode()
+		e.ExitCode = &exitCode
+	}
+	l.emit(e)
+}
+
 func (l *Logger) NoConfirm() {
 	l.noConfirm = true
 }
 
+// Prompt asks for confirmation on the reader. In json mode the question is
+// written as a prompt event before bbl waits for the answer, and the answer
+// follows as a prompt_answered event.
 func (l *Logger) Prompt(message string) bool {
+	if l.json {
+		l.emit(event{Event: "prompt", Message: message})
+	}
+	proceed := l.prompt(message)
+	if l.json {
+		l.emit(event{Event: "prompt_answered", Message: message, Answer: &proceed})
+	}
+	return proceed
+}
+
+func (l *Logger) prompt(message string) bool {
 	if l.noConfirm {
 		return true
 	}
 
-	l.clear()
-	fmt.Fprintf(l.writer, "%s (y/N): ", message)
-	l.newline = true
+	if !l.json {
+		l.clear()
+		fmt.Fprintf(l.writer, "%s (y/N): ", message)
+		l.newline = true
+	}
 
 	var proceed string
 	fmt.Fscanln(l.reader, &proceed)
@@ -2,8 +2,11 @@ package application_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
+	"time"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
 
@@ -127,4 +130,145 @@ SUCCESS!
 `))
 		})
 	})
+
+	Describe("JSON", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			application.SetLoggerNow(logger, func() time.Time { return now })
+
+			logger.JSON()
+			logger.SetEnvID("some-env-id")
+		})
+
+		lines := func() []string {
+			return strings.Split(strings.TrimSpace(writer.String()), "\n")
+		}
+
+		It("writes steps as events with the duration of completed steps", func() {
+			logger.SetPhase("terraform")
+			logger.Step("terraform %s", "apply")
+			logger.Dot()
+			now = now.Add(90 * time.Second)
+			logger.SetPhase("jumpbox")
+			logger.Step("creating jumpbox")
+			logger.Finish(nil)
+
+			Expect(lines()).To(Equal([]string{
+				`{"time":"2026-01-02T03:04:05Z","event":"step","phase":"terraform","env_id":"some-env-id","message":"terraform apply"}`,
+				`{"time":"2026-01-02T03:05:35Z","event":"step_completed","phase":"terraform","env_id":"some-env-id","message":"terraform apply","duration_seconds":90}`,
+				`{"time":"2026-01-02T03:05:35Z","event":"step","phase":"jumpbox","env_id":"some-env-id","message":"creating jumpbox"}`,
+				`{"time":"2026-01-02T03:05:35Z","event":"step_completed","phase":"jumpbox","env_id":"some-env-id","message":"creating jumpbox","duration_seconds":0}`,
+			}))
+		})
+
+		It("takes the phase from SetPhase rather than from the step", func() {
+			logger.Step("terraform apply")
+			logger.SetPhase("director")
+			logger.Step("creating jumpbox")
+			logger.SetPhase("")
+			logger.Step("rendering manifests")
+
+			Expect(lines()).To(Equal([]string{
+				`{"time":"2026-01-02T03:04:05Z","event":"step","env_id":"some-env-id","message":"terraform apply"}`,
+				`{"time":"2026-01-02T03:04:05Z","event":"step_completed","env_id":"some-env-id","message":"terraform apply","duration_seconds":0}`,
+				`{"time":"2026-01-02T03:04:05Z","event":"step","phase":"director","env_id":"some-env-id","message":"creating jumpbox"}`,
+				`{"time":"2026-01-02T03:04:05Z","event":"step_completed","phase":"director","env_id":"some-env-id","message":"creating jumpbox","duration_seconds":0}`,
+				`{"time":"2026-01-02T03:04:05Z","event":"step","env_id":"some-env-id","message":"rendering manifests"}`,
+			}))
+		})
+
+		It("writes messages and warnings as events", func() {
+			logger.Printf("some %s\n", "message")
+			logger.Println("Warning: something is off")
+			logger.Println("")
+
+			Expect(lines()).To(Equal([]string{
+				`{"time":"2026-01-02T03:04:05Z","event":"message","env_id":"some-env-id","message":"some message"}`,
+				`{"time":"2026-01-02T03:04:05Z","event":"warning","env_id":"some-env-id","message":"Warning: something is off"}`,
+			}))
+		})
+
+		It("keeps the layout of multi-line messages", func() {
+			logger.Printf("  some-name  some-value\n  other-name other-value\n")
+
+			Expect(lines()).To(Equal([]string{
+				`{"time":"2026-01-02T03:04:05Z","event":"message","env_id":"some-env-id","message":"  some-name  some-value\n  other-name other-value"}`,
+			}))
+		})
+
+		It("writes prompts and their answers as events", func() {
+			fmt.Fprintf(reader, "yes\n")
+
+			Expect(logger.Prompt("Delete?")).To(BeTrue())
+			Expect(lines()).To(Equal([]string{
+				`{"time":"2026-01-02T03:04:05Z","event":"prompt","env_id":"some-env-id","message":"Delete?"}`,
+				`{"time":"2026-01-02T03:04:05Z","event":"prompt_answered","env_id":"some-env-id","message":"Delete?","answer":true}`,
+			}))
+		})
+
+		It("writes the prompt before it waits for the answer", func() {
+			var writtenBeforeRead string
+			answer := strings.NewReader("no\n")
+			logger = application.NewLogger(writer, readerFunc(func(p []byte) (int, error) {
+				if writtenBeforeRead == "" {
+					writtenBeforeRead = writer.String()
+				}
+				return answer.Read(p)
+			}))
+			logger.JSON()
+
+			Expect(logger.Prompt("Delete?")).To(BeFalse())
+			Expect(writtenBeforeRead).To(ContainSubstring(`"event":"prompt","message":"Delete?"`))
+		})
+
+		It("writes child exit codes as events", func() {
+			logger.ChildExited("terraform apply", 1)
+
+			Expect(writer.String()).To(Equal(`{"time":"2026-01-02T03:04:05Z","event":"child_exited","env_id":"some-env-id","command":"terraform apply","exit_code":1}` + "\n"))
+		})
+
+		It("writes the error the command failed with", func() {
+			logger.Finish(errors.New("failed to apply"))
+
+			Expect(writer.String()).To(Equal(`{"time":"2026-01-02T03:04:05Z","event":"error","env_id":"some-env-id","message":"failed to apply"}` + "\n"))
+		})
+	})
+
+	Describe("JSONEvents", func() {
+		var events *bytes.Buffer
+
+		BeforeEach(func() {
+			application.SetLoggerNow(logger, func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) })
+
+			events = bytes.NewBuffer([]byte{})
+			logger.JSONEvents(events)
+		})
+
+		It("writes steps as events to the events writer and prints everything else as is", func() {
+			logger.SetPhase("terraform")
+			logger.Step("terraform apply")
+			logger.Printf("export BOSH_CLIENT=admin\n")
+			logger.Println(`{"version":"1.2.3"}`)
+
+			Expect(events.String()).To(Equal(`{"time":"2026-01-02T03:04:05Z","event":"step","phase":"terraform","message":"terraform apply"}` + "\n"))
+			Expect(writer.String()).To(Equal("export BOSH_CLIENT=admin\n" + `{"version":"1.2.3"}` + "\n"))
+		})
+	})
+
+	Context("when not writing JSON", func() {
+		It("does not write child exits or finishing", func() {
+			logger.ChildExited("terraform apply", 1)
+			logger.Finish(errors.New("failed to apply"))
+
+			Expect(writer.String()).To(Equal(""))
+		})
+	})
 })
+
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
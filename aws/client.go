@@ -10,7 +10,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
 	awsroute53 "github.com/aws/aws-sdk-go/service/route53"
+	awssts "github.com/aws/aws-sdk-go/service/sts"
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -18,6 +23,11 @@ type EC2Client interface {
 	DescribeAvailabilityZones(*awsec2.DescribeAvailabilityZonesInput) (*awsec2.DescribeAvailabilityZonesOutput, error)
 	DescribeInstances(*awsec2.DescribeInstancesInput) (*awsec2.DescribeInstancesOutput, error)
 	DescribeVpcs(*awsec2.DescribeVpcsInput) (*awsec2.DescribeVpcsOutput, error)
+	CreateVpc(*awsec2.CreateVpcInput) (*awsec2.CreateVpcOutput, error)
+	CreateInternetGateway(*awsec2.CreateInternetGatewayInput) (*awsec2.CreateInternetGatewayOutput, error)
+	AllocateAddress(*awsec2.AllocateAddressInput) (*awsec2.AllocateAddressOutput, error)
+	DescribeAddresses(*awsec2.DescribeAddressesInput) (*awsec2.DescribeAddressesOutput, error)
+	DescribeAccountAttributes(*awsec2.DescribeAccountAttributesInput) (*awsec2.DescribeAccountAttributesOutput, error)
 }
 
 type Route53Client interface {
@@ -38,7 +48,12 @@ type DNSZones interface {
 
 type Client struct {
 	ec2Client     EC2Client
+	regionalEC2   func(region string) EC2Client
 	route53Client Route53Client
+	iamClient     IAMClient
+	stsClient     STSClient
+	elbClient     ELBClient
+	elbv2Client   ELBV2Client
 	logger        logger
 }
 
@@ -49,8 +64,16 @@ func NewClient(creds storage.AWS, logger logger) Client {
 	}
 
 	return Client{
-		ec2Client:     awsec2.New(session.New(config)),
+		ec2Client: awsec2.New(session.New(config)),
+		regionalEC2: func(region string) EC2Client {
+			regionConfig := config.Copy().WithRegion(region)
+			return awsec2.New(session.New(regionConfig))
+		},
 		route53Client: awsroute53.New(session.New(config)),
+		iamClient:     awsiam.New(session.New(config)),
+		stsClient:     awssts.New(session.New(config)),
+		elbClient:     awselb.New(session.New(config)),
+		elbv2Client:   awselbv2.New(session.New(config)),
 		logger:        logger,
 	}
 }
@@ -149,37 +172,48 @@ func (c Client) ValidateSafeToDelete(vpcID, envID string) error {
 	vms = c.removeOneVM(vms, "jumpbox/0")
 
 	if len(vms) > 0 {
-		return fmt.Errorf("vpc %s is not safe to delete; vms still exist: [%s]", vpcID, strings.Join(vms, ", "))
+		return helpers.VMsExistError{Network: fmt.Sprintf("vpc %s", vpcID), VMs: vms}
 	}
 
 	return nil
 }
 
-func (c Client) flattenVMs(reservations []*awsec2.Reservation) []string {
-	vms := []string{}
+func (c Client) flattenVMs(reservations []*awsec2.Reservation) []helpers.VM {
+	vms := []helpers.VM{}
 	for _, reservation := range reservations {
 		for _, instance := range reservation.Instances {
-			vms = append(vms, c.vmName(instance))
+			vms = append(vms, c.vm(instance))
 		}
 	}
 	return vms
 }
 
-func (c Client) vmName(instance *awsec2.Instance) string {
-	name := "unnamed"
+func (c Client) vm(instance *awsec2.Instance) helpers.VM {
+	vm := helpers.VM{
+		Name: "unnamed",
+		ID:   awslib.StringValue(instance.InstanceId),
+	}
 
 	for _, tag := range instance.Tags {
-		if awslib.StringValue(tag.Key) == "Name" && awslib.StringValue(tag.Value) != "" {
-			name = awslib.StringValue(tag.Value)
+		value := awslib.StringValue(tag.Value)
+		switch awslib.StringValue(tag.Key) {
+		case "Name":
+			if value != "" {
+				vm.Name = value
+			}
+		case "deployment":
+			vm.Deployment = value
+		case "director":
+			vm.Director = value
 		}
 	}
 
-	return name
+	return vm
 }
 
-func (c Client) removeOneVM(vms []string, vmToRemove string) []string {
+func (c Client) removeOneVM(vms []helpers.VM, vmToRemove string) []helpers.VM {
 	for index, vm := range vms {
-		if vm == vmToRemove {
+		if vm.Name == vmToRemove {
 			return append(vms[:index], vms[index+1:]...)
 		}
 	}
@@ -278,14 +278,26 @@ var _ = Describe("Client", func() {
 						reservationContainingInstance("NAT"),
 						reservationContainingInstance("bosh/0"),
 						reservationContainingInstance("first-bosh-deployed-vm"),
-						reservationContainingInstance("second-bosh-deployed-vm"),
+						{
+							Instances: []*awsec2.Instance{{
+								InstanceId: awslib.String("i-123"),
+								Tags: []*awsec2.Tag{
+									{Key: awslib.String("Name"), Value: awslib.String("second-bosh-deployed-vm")},
+									{Key: awslib.String("deployment"), Value: awslib.String("cf")},
+									{Key: awslib.String("director"), Value: awslib.String("some-director")},
+								},
+							}},
+						},
 					},
 				}
 			})
 
-			It("returns an error", func() {
+			It("returns an error listing the vms", func() {
 				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [first-bosh-deployed-vm, second-bosh-deployed-vm]"))
+				Expect(err).To(MatchError(`bbl environment is not safe to delete; vms still exist in vpc some-vpc-id:
+  first-bosh-deployed-vm (not managed by bosh)
+  second-bosh-deployed-vm (id: i-123, deployment: cf, director: some-director)
+Delete them, or run bbl destroy --delete-deployments to delete every bosh deployment first.`))
 			})
 		})
 
@@ -301,7 +313,7 @@ var _ = Describe("Client", func() {
 
 			It("returns an error", func() {
 				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [not-bosh, not-nat]"))
+				Expect(err).To(MatchError(ContainSubstring("vms still exist in vpc some-vpc-id:\n  not-bosh (not managed by bosh)\n  not-nat (not managed by bosh)\n")))
 			})
 		})
 
@@ -320,7 +332,7 @@ var _ = Describe("Client", func() {
 
 			It("returns an error", func() {
 				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [NAT, bosh/0, bosh/0]"))
+				Expect(err).To(MatchError(ContainSubstring("vms still exist in vpc some-vpc-id:\n  NAT (not managed by bosh)\n  bosh/0 (not managed by bosh)\n  bosh/0 (not managed by bosh)\n")))
 			})
 		})
 
@@ -342,7 +354,7 @@ var _ = Describe("Client", func() {
 
 			It("returns an error", func() {
 				err := client.ValidateSafeToDelete("some-vpc-id", "")
-				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete; vms still exist: [unnamed, unnamed, unnamed]"))
+				Expect(err).To(MatchError(ContainSubstring("vms still exist in vpc some-vpc-id:\n  unnamed (not managed by bosh)\n  unnamed (not managed by bosh)\n  unnamed (not managed by bosh)\n")))
 			})
 		})
 
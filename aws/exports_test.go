@@ -14,6 +14,32 @@ func NewClientWithInjectedRoute53Client(route53Client Route53Client, logger logg
 	}
 }
 
+func NewClientWithInjectedClients(ec2Client EC2Client, route53Client Route53Client, iamClient IAMClient, stsClient STSClient, logger logger) Client {
+	return Client{
+		ec2Client:     ec2Client,
+		route53Client: route53Client,
+		iamClient:     iamClient,
+		stsClient:     stsClient,
+		logger:        logger,
+	}
+}
+
+func NewClientWithInjectedQuotaClients(ec2Client EC2Client, elbClient ELBClient, elbv2Client ELBV2Client, logger logger) Client {
+	return Client{
+		ec2Client:   ec2Client,
+		elbClient:   elbClient,
+		elbv2Client: elbv2Client,
+		logger:      logger,
+	}
+}
+
+func (c Client) WithRegionalEC2Clients(clients map[string]EC2Client) Client {
+	c.regionalEC2 = func(region string) EC2Client {
+		return clients[region]
+	}
+	return c
+}
+
 func (c Client) GetEC2Client() EC2Client {
 	return c.ec2Client
 }
@@ -0,0 +1,208 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	awslib "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	awsroute53 "github.com/aws/aws-sdk-go/service/route53"
+	awssts "github.com/aws/aws-sdk-go/service/sts"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type IAMClient interface {
+	SimulatePrincipalPolicy(*awsiam.SimulatePrincipalPolicyInput) (*awsiam.SimulatePolicyResponse, error)
+}
+
+type STSClient interface {
+	GetCallerIdentity(*awssts.GetCallerIdentityInput) (*awssts.GetCallerIdentityOutput, error)
+}
+
+var basePermissions = []string{
+	"ec2:AllocateAddress",
+	"ec2:AssociateRouteTable",
+	"ec2:AuthorizeSecurityGroupIngress",
+	"ec2:CreateInternetGateway",
+	"ec2:CreateRoute",
+	"ec2:CreateRouteTable",
+	"ec2:CreateSecurityGroup",
+	"ec2:CreateSubnet",
+	"ec2:CreateVpc",
+	"ec2:ImportKeyPair",
+	"ec2:RunInstances",
+	"iam:AddRoleToInstanceProfile",
+	"iam:AttachRolePolicy",
+	"iam:CreateInstanceProfile",
+	"iam:CreatePolicy",
+	"iam:CreateRole",
+	"iam:PassRole",
+	"iam:PutRolePolicy",
+	"kms:CreateKey",
+}
+
+var lbPermissions = map[string][]string{
+	"cf": {
+		"elasticloadbalancing:ConfigureHealthCheck",
+		"elasticloadbalancing:CreateLoadBalancer",
+		"iam:UploadServerCertificate",
+	},
+	"concourse": {
+		"elasticloadbalancing:CreateListener",
+		"elasticloadbalancing:CreateLoadBalancer",
+		"elasticloadbalancing:CreateTargetGroup",
+	},
+}
+
+var dnsPermissions = []string{
+	"route53:ChangeResourceRecordSets",
+	"route53:CreateHostedZone",
+	"route53:ListHostedZonesByName",
+}
+
+// RequiredPermissions returns the IAM actions bbl needs to pave an
+// environment with the load balancer in the state.
+func RequiredPermissions(state storage.State) []string {
+	permissions := append([]string{}, basePermissions...)
+	permissions = append(permissions, lbPermissions[state.LB.Type]...)
+	if state.LB.Domain != "" {
+		permissions = append(permissions, dnsPermissions...)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+// CheckPermissions returns the required IAM actions the credentials are
+// missing. EC2 and Route53 calls are tried directly, and the other actions
+// are simulated against the policies of the caller. The simulator does not
+// know the resources and conditions of the real calls, so the actions it
+// denies are returned in an error rather than as missing, as is any error
+// that stops the actions from being checked.
+func (c Client) CheckPermissions(state storage.State) ([]string, error) {
+	missing := map[string]struct{}{}
+	tried := map[string]struct{}{}
+
+	dryRuns := map[string]func() error{
+		"ec2:CreateVpc": func() error {
+			_, err := c.ec2Client.CreateVpc(&awsec2.CreateVpcInput{CidrBlock: awslib.String("10.0.0.0/16"), DryRun: awslib.Bool(true)})
+			return err
+		},
+		"ec2:CreateInternetGateway": func() error {
+			_, err := c.ec2Client.CreateInternetGateway(&awsec2.CreateInternetGatewayInput{DryRun: awslib.Bool(true)})
+			return err
+		},
+		"ec2:AllocateAddress": func() error {
+			_, err := c.ec2Client.AllocateAddress(&awsec2.AllocateAddressInput{Domain: awslib.String("vpc"), DryRun: awslib.Bool(true)})
+			return err
+		},
+	}
+	for action, dryRun := range dryRuns {
+		tried[action] = struct{}{}
+		err := dryRun()
+		switch errorCode(err) {
+		case "DryRunOperation":
+		case "UnauthorizedOperation":
+			missing[action] = struct{}{}
+		default:
+			return sortedKeys(missing), fmt.Errorf("Dry run %s: %s", action, err)
+		}
+	}
+
+	if state.LB.Domain != "" {
+		tried["route53:ListHostedZonesByName"] = struct{}{}
+		_, err := c.route53Client.ListHostedZonesByName(&awsroute53.ListHostedZonesByNameInput{MaxItems: awslib.String("1")})
+		if errorCode(err) == "AccessDenied" {
+			missing["route53:ListHostedZonesByName"] = struct{}{}
+		} else if err != nil {
+			return sortedKeys(missing), fmt.Errorf("List hosted zones: %s", err)
+		}
+	}
+
+	denied, err := c.simulate(RequiredPermissions(state))
+	if err != nil {
+		return sortedKeys(missing), err
+	}
+
+	var unverified []string
+	for _, action := range denied {
+		if _, ok := tried[action]; !ok {
+			unverified = append(unverified, action)
+		}
+	}
+	if len(unverified) > 0 {
+		return sortedKeys(missing), fmt.Errorf("The IAM policy simulator denies %s, which may still be allowed by policies that depend on resources or conditions", strings.Join(unverified, ", "))
+	}
+
+	return sortedKeys(missing), nil
+}
+
+func (c Client) simulate(actions []string) ([]string, error) {
+	identity, err := c.stsClient.GetCallerIdentity(&awssts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("Get caller identity: %s", err)
+	}
+
+	arn := awslib.StringValue(identity.Arn)
+	if strings.HasSuffix(arn, ":root") {
+		return nil, nil
+	}
+	arn = principalARN(arn)
+
+	input := &awsiam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: awslib.String(arn),
+		ActionNames:     awslib.StringSlice(actions),
+	}
+
+	var denied []string
+	for {
+		output, err := c.iamClient.SimulatePrincipalPolicy(input)
+		if err != nil {
+			return nil, fmt.Errorf("Simulate the policies of %s: %s", arn, err)
+		}
+
+		for _, result := range output.EvaluationResults {
+			if awslib.StringValue(result.EvalDecision) != awsiam.PolicyEvaluationDecisionTypeAllowed {
+				denied = append(denied, awslib.StringValue(result.EvalActionName))
+			}
+		}
+
+		if !awslib.BoolValue(output.IsTruncated) {
+			return denied, nil
+		}
+		input.Marker = output.Marker
+	}
+}
+
+// principalARN turns the session ARN of an assumed role into the ARN of
+// the role, which is what the IAM policy simulator expects.
+func principalARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[2] != "sts" || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return arn
+	}
+
+	role := strings.Split(strings.TrimPrefix(parts[5], "assumed-role/"), "/")[0]
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parts[1], parts[4], role)
+}
+
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code()
+	}
+	return ""
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+package aws_test
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/cloudfoundry/bosh-bootloader/aws"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	awslib "github.com/aws/aws-sdk-go/aws"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	awssts "github.com/aws/aws-sdk-go/service/sts"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Permissions", func() {
+	Describe("RequiredPermissions", func() {
+		It("adds the actions for the load balancer and dns", func() {
+			base := aws.RequiredPermissions(storage.State{})
+			Expect(base).To(ContainElement("ec2:CreateVpc"))
+			Expect(base).NotTo(ContainElement("elasticloadbalancing:CreateLoadBalancer"))
+
+			cf := aws.RequiredPermissions(storage.State{LB: storage.LB{Type: "cf", Domain: "example.com"}})
+			Expect(cf).To(ContainElement("iam:UploadServerCertificate"))
+			Expect(cf).To(ContainElement("route53:CreateHostedZone"))
+
+			concourse := aws.RequiredPermissions(storage.State{LB: storage.LB{Type: "concourse"}})
+			Expect(concourse).To(ContainElement("elasticloadbalancing:CreateTargetGroup"))
+			Expect(concourse).NotTo(ContainElement("route53:CreateHostedZone"))
+		})
+	})
+
+	Describe("CheckPermissions", func() {
+		var (
+			ec2Client     *fakes.AWSEC2Client
+			route53Client *fakes.AWSRoute53Client
+			iamClient     *fakes.AWSIAMClient
+			stsClient     *fakes.AWSSTSClient
+			client        aws.Client
+			dryRunOK      error
+		)
+
+		BeforeEach(func() {
+			ec2Client = &fakes.AWSEC2Client{}
+			route53Client = &fakes.AWSRoute53Client{}
+			iamClient = &fakes.AWSIAMClient{}
+			stsClient = &fakes.AWSSTSClient{}
+
+			dryRunOK = awserr.New("DryRunOperation", "Request would have succeeded", nil)
+			ec2Client.CreateVpcCall.Returns.Error = dryRunOK
+			ec2Client.CreateInternetGatewayCall.Returns.Error = dryRunOK
+			ec2Client.AllocateAddressCall.Returns.Error = dryRunOK
+
+			stsClient.GetCallerIdentityCall.Returns.Output = &awssts.GetCallerIdentityOutput{
+				Arn: awslib.String("arn:aws:sts::123456789012:assumed-role/bbl-ci/session"),
+			}
+			iamClient.SimulatePrincipalPolicyCall.Returns.Output = &awsiam.SimulatePolicyResponse{
+				EvaluationResults: []*awsiam.EvaluationResult{
+					{EvalActionName: awslib.String("ec2:RunInstances"), EvalDecision: awslib.String("allowed")},
+					{EvalActionName: awslib.String("iam:PassRole"), EvalDecision: awslib.String("implicitDeny")},
+				},
+			}
+
+			client = aws.NewClientWithInjectedClients(ec2Client, route53Client, iamClient, stsClient, &fakes.Logger{})
+		})
+
+		It("dry runs ec2 calls and simulates the other actions for the role of the caller", func() {
+			missing, err := client.CheckPermissions(storage.State{})
+			Expect(err).To(MatchError("The IAM policy simulator denies iam:PassRole, which may still be allowed by policies that depend on resources or conditions"))
+			Expect(missing).To(BeEmpty())
+
+			Expect(awslib.BoolValue(ec2Client.CreateVpcCall.Receives.Input.DryRun)).To(BeTrue())
+			Expect(awslib.BoolValue(ec2Client.AllocateAddressCall.Receives.Input.DryRun)).To(BeTrue())
+
+			input := iamClient.SimulatePrincipalPolicyCall.Receives.Input
+			Expect(awslib.StringValue(input.PolicySourceArn)).To(Equal("arn:aws:iam::123456789012:role/bbl-ci"))
+			Expect(awslib.StringValueSlice(input.ActionNames)).To(Equal(aws.RequiredPermissions(storage.State{})))
+			Expect(route53Client.ListHostedZonesByNameCall.Receives.Input).To(BeNil())
+		})
+
+		It("reports ec2 calls that are not authorized", func() {
+			ec2Client.CreateVpcCall.Returns.Error = awserr.New("UnauthorizedOperation", "You are not authorized", nil)
+
+			missing, err := client.CheckPermissions(storage.State{})
+			Expect(err).To(HaveOccurred())
+			Expect(missing).To(Equal([]string{"ec2:CreateVpc"}))
+		})
+
+		It("checks route53 when the load balancer has a domain", func() {
+			route53Client.ListHostedZonesByNameCall.Returns.Error = awserr.New("AccessDenied", "denied", nil)
+
+			missing, err := client.CheckPermissions(storage.State{LB: storage.LB{Type: "cf", Domain: "example.com"}})
+			Expect(err).To(HaveOccurred())
+			Expect(missing).To(Equal([]string{"route53:ListHostedZonesByName"}))
+		})
+
+		It("does not report simulator denials of the actions it tried", func() {
+			iamClient.SimulatePrincipalPolicyCall.Returns.Output = &awsiam.SimulatePolicyResponse{
+				EvaluationResults: []*awsiam.EvaluationResult{
+					{EvalActionName: awslib.String("ec2:CreateVpc"), EvalDecision: awslib.String("implicitDeny")},
+					{EvalActionName: awslib.String("ec2:RunInstances"), EvalDecision: awslib.String("allowed")},
+				},
+			}
+
+			missing, err := client.CheckPermissions(storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+		})
+
+		It("does not simulate the policies of the root user", func() {
+			stsClient.GetCallerIdentityCall.Returns.Output = &awssts.GetCallerIdentityOutput{
+				Arn: awslib.String("arn:aws:iam::123456789012:root"),
+			}
+
+			missing, err := client.CheckPermissions(storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missing).To(BeEmpty())
+			Expect(iamClient.SimulatePrincipalPolicyCall.CallCount).To(Equal(0))
+		})
+
+		Context("when the policies cannot be simulated", func() {
+			BeforeEach(func() {
+				ec2Client.CreateVpcCall.Returns.Error = awserr.New("UnauthorizedOperation", "You are not authorized", nil)
+				iamClient.SimulatePrincipalPolicyCall.Returns.Error = errors.New("AccessDenied")
+			})
+
+			It("returns what it found along with the error", func() {
+				missing, err := client.CheckPermissions(storage.State{})
+				Expect(err).To(MatchError("Simulate the policies of arn:aws:iam::123456789012:role/bbl-ci: AccessDenied"))
+				Expect(missing).To(Equal([]string{"ec2:CreateVpc"}))
+			})
+		})
+
+		Context("when a dry run fails for another reason", func() {
+			It("returns an error", func() {
+				ec2Client.CreateInternetGatewayCall.Returns.Error = errors.New("no route to host")
+
+				_, err := client.CheckPermissions(storage.State{})
+				Expect(err).To(MatchError("Dry run ec2:CreateInternetGateway: no route to host"))
+			})
+		})
+	})
+})
@@ -0,0 +1,207 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+
+	awslib "github.com/aws/aws-sdk-go/aws"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// EC2 does not report the VPC limit of the account, so the default limit is
+// assumed and marked as such.
+const defaultVPCLimit = 5
+
+type ELBClient interface {
+	DescribeAccountLimits(*awselb.DescribeAccountLimitsInput) (*awselb.DescribeAccountLimitsOutput, error)
+	DescribeLoadBalancers(*awselb.DescribeLoadBalancersInput) (*awselb.DescribeLoadBalancersOutput, error)
+}
+
+type ELBV2Client interface {
+	DescribeAccountLimits(*awselbv2.DescribeAccountLimitsInput) (*awselbv2.DescribeAccountLimitsOutput, error)
+	DescribeLoadBalancers(*awselbv2.DescribeLoadBalancersInput) (*awselbv2.DescribeLoadBalancersOutput, error)
+}
+
+// CheckQuotas returns the VPC, elastic IP and load balancer quotas of the
+// region next to what the template creates for the load balancer in the
+// state: a VPC, elastic IPs for the jumpbox and the NAT instance, three
+// classic ELBs for cf and a network load balancer for concourse. Each site
+// adds a VPC and an elastic IP for its NAT gateway in the region of the site.
+func (c Client) CheckQuotas(state storage.State) ([]storage.Quota, error) {
+	regions := []string{state.AWS.Region}
+	vpcsRequired := map[string]int{state.AWS.Region: 1}
+	eipsRequired := map[string]int{state.AWS.Region: 2}
+	for _, site := range state.AWS.Sites {
+		if _, ok := vpcsRequired[site.Region]; !ok {
+			regions = append(regions, site.Region)
+		}
+		vpcsRequired[site.Region]++
+		eipsRequired[site.Region]++
+	}
+
+	var quotas []storage.Quota
+	for _, region := range regions {
+		ec2Client, suffix := c.ec2Client, ""
+		if region != state.AWS.Region {
+			ec2Client, suffix = c.regionalEC2(region), fmt.Sprintf(" in %s", region)
+		}
+
+		regionQuotas, err := c.networkQuotas(ec2Client, vpcsRequired[region], eipsRequired[region])
+		if err != nil {
+			return nil, err
+		}
+		for _, quota := range regionQuotas {
+			quota.Name += suffix
+			quotas = append(quotas, quota)
+		}
+	}
+
+	switch state.LB.Type {
+	case "cf":
+		quota, err := c.classicLoadBalancerQuota(3)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, quota)
+	case "concourse":
+		quota, err := c.networkLoadBalancerQuota(1)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, quota)
+	}
+
+	return quotas, nil
+}
+
+func (c Client) networkQuotas(ec2Client EC2Client, vpcsRequired, eipsRequired int) ([]storage.Quota, error) {
+	vpcs, err := ec2Client.DescribeVpcs(&awsec2.DescribeVpcsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("Describe vpcs: %s", err)
+	}
+
+	addresses, err := ec2Client.DescribeAddresses(&awsec2.DescribeAddressesInput{
+		Filters: []*awsec2.Filter{{
+			Name:   awslib.String("domain"),
+			Values: []*string{awslib.String("vpc")},
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Describe addresses: %s", err)
+	}
+
+	eipLimit, err := eipLimit(ec2Client)
+	if err != nil {
+		return nil, err
+	}
+
+	return []storage.Quota{
+		{Name: "VPCs", Required: vpcsRequired, InUse: len(vpcs.Vpcs), Limit: defaultVPCLimit, Assumed: true},
+		{Name: "Elastic IPs", Required: eipsRequired, InUse: len(addresses.Addresses), Limit: eipLimit},
+	}, nil
+}
+
+func eipLimit(ec2Client EC2Client) (int, error) {
+	attributes, err := ec2Client.DescribeAccountAttributes(&awsec2.DescribeAccountAttributesInput{
+		AttributeNames: []*string{awslib.String("vpc-max-elastic-ips")},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Describe account attributes: %s", err)
+	}
+
+	for _, attribute := range attributes.AccountAttributes {
+		for _, value := range attribute.AttributeValues {
+			return strconv.Atoi(awslib.StringValue(value.AttributeValue))
+		}
+	}
+
+	return 0, fmt.Errorf("The vpc-max-elastic-ips limit was not returned")
+}
+
+func (c Client) classicLoadBalancerQuota(required int) (storage.Quota, error) {
+	limits, err := c.elbClient.DescribeAccountLimits(&awselb.DescribeAccountLimitsInput{})
+	if err != nil {
+		return storage.Quota{}, fmt.Errorf("Describe elb account limits: %s", err)
+	}
+
+	max := map[string]*string{}
+	for _, l := range limits.Limits {
+		max[awslib.StringValue(l.Name)] = l.Max
+	}
+
+	limit, err := findLimit(max, "classic-load-balancers")
+	if err != nil {
+		return storage.Quota{}, err
+	}
+
+	inUse := 0
+	input := &awselb.DescribeLoadBalancersInput{}
+	for {
+		output, err := c.elbClient.DescribeLoadBalancers(input)
+		if err != nil {
+			return storage.Quota{}, fmt.Errorf("Describe load balancers: %s", err)
+		}
+		inUse += len(output.LoadBalancerDescriptions)
+
+		if output.NextMarker == nil {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	return storage.Quota{Name: "Classic load balancers", Required: required, InUse: inUse, Limit: limit}, nil
+}
+
+func (c Client) networkLoadBalancerQuota(required int) (storage.Quota, error) {
+	limits, err := c.elbv2Client.DescribeAccountLimits(&awselbv2.DescribeAccountLimitsInput{})
+	if err != nil {
+		return storage.Quota{}, fmt.Errorf("Describe elbv2 account limits: %s", err)
+	}
+
+	max := map[string]*string{}
+	for _, l := range limits.Limits {
+		max[awslib.StringValue(l.Name)] = l.Max
+	}
+
+	limit, err := findLimit(max, "network-load-balancers")
+	if err != nil {
+		return storage.Quota{}, err
+	}
+
+	inUse := 0
+	input := &awselbv2.DescribeLoadBalancersInput{}
+	for {
+		output, err := c.elbv2Client.DescribeLoadBalancers(input)
+		if err != nil {
+			return storage.Quota{}, fmt.Errorf("Describe load balancers: %s", err)
+		}
+		for _, lb := range output.LoadBalancers {
+			if awslib.StringValue(lb.Type) == awselbv2.LoadBalancerTypeEnumNetwork {
+				inUse++
+			}
+		}
+
+		if output.NextMarker == nil {
+			break
+		}
+		input.Marker = output.NextMarker
+	}
+
+	return storage.Quota{Name: "Network load balancers", Required: required, InUse: inUse, Limit: limit}, nil
+}
+
+func findLimit(max map[string]*string, name string) (int, error) {
+	value, ok := max[name]
+	if !ok {
+		return 0, fmt.Errorf("The %s limit was not returned", name)
+	}
+
+	limit, err := strconv.Atoi(awslib.StringValue(value))
+	if err != nil {
+		return 0, fmt.Errorf("Parse the %s limit: %s", name, err)
+	}
+	return limit, nil
+}
@@ -0,0 +1,160 @@
+package aws_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/aws"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	awslib "github.com/aws/aws-sdk-go/aws"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quotas", func() {
+	var (
+		ec2Client   *fakes.AWSEC2Client
+		elbClient   *fakes.AWSELBClient
+		elbv2Client *fakes.AWSELBV2Client
+		client      aws.Client
+	)
+
+	BeforeEach(func() {
+		ec2Client = &fakes.AWSEC2Client{}
+		elbClient = &fakes.AWSELBClient{}
+		elbv2Client = &fakes.AWSELBV2Client{}
+		client = aws.NewClientWithInjectedQuotaClients(ec2Client, elbClient, elbv2Client, &fakes.Logger{})
+
+		ec2Client.DescribeVpcsCall.Returns.Output = &awsec2.DescribeVpcsOutput{
+			Vpcs: []*awsec2.Vpc{{}, {}},
+		}
+		ec2Client.DescribeAddressesCall.Returns.Output = &awsec2.DescribeAddressesOutput{
+			Addresses: []*awsec2.Address{{}, {}, {}, {}},
+		}
+		ec2Client.DescribeAccountAttributesCall.Returns.Output = &awsec2.DescribeAccountAttributesOutput{
+			AccountAttributes: []*awsec2.AccountAttribute{{
+				AttributeName:   awslib.String("vpc-max-elastic-ips"),
+				AttributeValues: []*awsec2.AccountAttributeValue{{AttributeValue: awslib.String("5")}},
+			}},
+		}
+		elbClient.DescribeAccountLimitsCall.Returns.Output = &awselb.DescribeAccountLimitsOutput{
+			Limits: []*awselb.Limit{{Name: awslib.String("classic-load-balancers"), Max: awslib.String("20")}},
+		}
+		elbClient.DescribeLoadBalancersCall.Returns.Output = &awselb.DescribeLoadBalancersOutput{
+			LoadBalancerDescriptions: []*awselb.LoadBalancerDescription{{}},
+		}
+		elbv2Client.DescribeAccountLimitsCall.Returns.Output = &awselbv2.DescribeAccountLimitsOutput{
+			Limits: []*awselbv2.Limit{{Name: awslib.String("network-load-balancers"), Max: awslib.String("20")}},
+		}
+		elbv2Client.DescribeLoadBalancersCall.Returns.Output = &awselbv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []*awselbv2.LoadBalancer{
+				{Type: awslib.String("network")},
+				{Type: awslib.String("application")},
+			},
+		}
+	})
+
+	It("returns the vpc and elastic ip quotas", func() {
+		quotas, err := client.CheckQuotas(storage.State{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(*ec2Client.DescribeAddressesCall.Receives.Input.Filters[0].Values[0]).To(Equal("vpc"))
+		Expect(quotas).To(Equal([]storage.Quota{
+			{Name: "VPCs", Required: 1, InUse: 2, Limit: 5, Assumed: true},
+			{Name: "Elastic IPs", Required: 2, InUse: 4, Limit: 5},
+		}))
+		Expect(elbClient.DescribeAccountLimitsCall.CallCount).To(Equal(0))
+		Expect(elbv2Client.DescribeAccountLimitsCall.CallCount).To(Equal(0))
+	})
+
+	Context("when the environment has sites", func() {
+		var siteEC2Client *fakes.AWSEC2Client
+
+		BeforeEach(func() {
+			siteEC2Client = &fakes.AWSEC2Client{}
+			siteEC2Client.DescribeVpcsCall.Returns.Output = &awsec2.DescribeVpcsOutput{Vpcs: []*awsec2.Vpc{{}}}
+			siteEC2Client.DescribeAddressesCall.Returns.Output = &awsec2.DescribeAddressesOutput{}
+			siteEC2Client.DescribeAccountAttributesCall.Returns.Output = &awsec2.DescribeAccountAttributesOutput{
+				AccountAttributes: []*awsec2.AccountAttribute{{
+					AttributeName:   awslib.String("vpc-max-elastic-ips"),
+					AttributeValues: []*awsec2.AccountAttributeValue{{AttributeValue: awslib.String("10")}},
+				}},
+			}
+			client = client.WithRegionalEC2Clients(map[string]aws.EC2Client{"eu-west-1": siteEC2Client})
+		})
+
+		It("adds a vpc and an elastic ip in the region of each site", func() {
+			quotas, err := client.CheckQuotas(storage.State{
+				AWS: storage.AWS{
+					Region: "us-east-1",
+					Sites: []storage.AWSSite{
+						{Name: "east", Region: "us-east-1"},
+						{Name: "west", Region: "eu-west-1"},
+						{Name: "other_west", Region: "eu-west-1"},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quotas).To(Equal([]storage.Quota{
+				{Name: "VPCs", Required: 2, InUse: 2, Limit: 5, Assumed: true},
+				{Name: "Elastic IPs", Required: 3, InUse: 4, Limit: 5},
+				{Name: "VPCs in eu-west-1", Required: 2, InUse: 1, Limit: 5, Assumed: true},
+				{Name: "Elastic IPs in eu-west-1", Required: 2, InUse: 0, Limit: 10},
+			}))
+		})
+	})
+
+	Context("when the lb type is cf", func() {
+		It("adds the classic load balancer quota", func() {
+			quotas, err := client.CheckQuotas(storage.State{LB: storage.LB{Type: "cf"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(ContainElement(storage.Quota{Name: "Classic load balancers", Required: 3, InUse: 1, Limit: 20}))
+		})
+	})
+
+	Context("when the lb type is concourse", func() {
+		It("adds the network load balancer quota", func() {
+			quotas, err := client.CheckQuotas(storage.State{LB: storage.LB{Type: "concourse"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(ContainElement(storage.Quota{Name: "Network load balancers", Required: 1, InUse: 1, Limit: 20}))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the vpcs cannot be described", func() {
+			ec2Client.DescribeVpcsCall.Returns.Error = errors.New("failed to describe")
+
+			_, err := client.CheckQuotas(storage.State{})
+			Expect(err).To(MatchError("Describe vpcs: failed to describe"))
+		})
+
+		It("returns an error when the elastic ip limit is missing", func() {
+			ec2Client.DescribeAccountAttributesCall.Returns.Output = &awsec2.DescribeAccountAttributesOutput{}
+
+			_, err := client.CheckQuotas(storage.State{})
+			Expect(err).To(MatchError("The vpc-max-elastic-ips limit was not returned"))
+		})
+
+		It("returns an error when the elb limits cannot be described", func() {
+			elbClient.DescribeAccountLimitsCall.Returns.Error = errors.New("failed to describe")
+
+			_, err := client.CheckQuotas(storage.State{LB: storage.LB{Type: "cf"}})
+			Expect(err).To(MatchError("Describe elb account limits: failed to describe"))
+		})
+
+		It("returns an error when a limit cannot be parsed", func() {
+			elbv2Client.DescribeAccountLimitsCall.Returns.Output = &awselbv2.DescribeAccountLimitsOutput{
+				Limits: []*awselbv2.Limit{{Name: awslib.String("network-load-balancers"), Max: awslib.String("lots")}},
+			}
+
+			_, err := client.CheckQuotas(storage.State{LB: storage.LB{Type: "concourse"}})
+			Expect(err).To(MatchError(ContainSubstring("Parse the network-load-balancers limit")))
+		})
+	})
+})
@@ -6,11 +6,15 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/arm/compute"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
 )
 
 type Client struct {
-	azureVMsClient    AzureVMsClient
-	azureGroupsClient AzureGroupsClient
+	azureVMsClient           AzureVMsClient
+	azureGroupsClient        AzureGroupsClient
+	azurePermissionsClient   AzurePermissionsClient
+	azureComputeUsageClient  AzureComputeUsageClient
+	azureNetworkUsagesClient AzureNetworkUsagesClient
 }
 
 type AzureVMsClient interface {
@@ -44,32 +48,38 @@ func (c Client) ValidateSafeToDelete(networkName string, envID string) error {
 		return fmt.Errorf("List instances: %s", err)
 	}
 
+	vms := []helpers.VM{}
 	for _, instance := range *instances.Value {
-		var vm string
+		vm := helpers.VM{}
 		if instance.Name != nil {
-			vm = *instance.Name
+			vm.Name = *instance.Name
 		}
-
-		if instance.Tags == nil {
-			return fmt.Errorf("bbl environment is not safe to delete; vms still exist in resource group: %s: %s", resourceGroup, vm)
-		}
-
-		tags := *instance.Tags
-
-		var deployment string
-		if tags["deployment"] != nil {
-			deployment = fmt.Sprintf(" (deployment: %s)", *tags["deployment"])
+		if instance.ID != nil {
+			vm.ID = *instance.ID
 		}
 
 		var job string
-		if tags["job"] != nil {
-			job = *tags["job"]
+		if instance.Tags != nil {
+			tags := *instance.Tags
+			if tags["deployment"] != nil {
+				vm.Deployment = *tags["deployment"]
+			}
+			if tags["director"] != nil {
+				vm.Director = *tags["director"]
+			}
+			if tags["job"] != nil {
+				job = *tags["job"]
+			}
 		}
 
 		if job != "bosh" && job != "jumpbox" {
-			return fmt.Errorf("bbl environment is not safe to delete; vms still exist in resource group: %s%s: %s", resourceGroup, deployment, vm)
+			vms = append(vms, vm)
 		}
 	}
 
+	if len(vms) > 0 {
+		return helpers.VMsExistError{Network: fmt.Sprintf("resource group %s", resourceGroup), VMs: vms}
+	}
+
 	return nil
 }
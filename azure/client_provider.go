@@ -2,6 +2,7 @@ package azure
 
 import (
 	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	azurestorage "github.com/Azure/azure-sdk-for-go/arm/storage"
 	"github.com/Azure/go-autorest/autorest"
@@ -33,9 +34,28 @@ func NewClient(azureConfig storage.Azure) (Client, error) {
 	groupsClient.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
 	groupsClient.Sender = autorest.CreateSender(autorest.AsIs())
 
+	permissionsClient := azurePermissionsClient{
+		Client:         autorest.NewClientWithUserAgent(""),
+		baseURI:        azure.PublicCloud.ResourceManagerEndpoint,
+		subscriptionID: azureConfig.SubscriptionID,
+	}
+	permissionsClient.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
+	permissionsClient.Sender = autorest.CreateSender(autorest.AsIs())
+
+	computeUsageClient := compute.NewUsageClient(azureConfig.SubscriptionID)
+	computeUsageClient.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
+	computeUsageClient.Sender = autorest.CreateSender(autorest.AsIs())
+
+	networkUsagesClient := network.NewUsagesClient(azureConfig.SubscriptionID)
+	networkUsagesClient.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
+	networkUsagesClient.Sender = autorest.CreateSender(autorest.AsIs())
+
 	client := Client{
-		azureVMsClient:    vmsClient,
-		azureGroupsClient: groupsClient,
+		azureVMsClient:           vmsClient,
+		azureGroupsClient:        groupsClient,
+		azurePermissionsClient:   permissionsClient,
+		azureComputeUsageClient:  computeUsageClient,
+		azureNetworkUsagesClient: networkUsagesClient,
 	}
 
 	_, err = ac.List()
@@ -107,7 +107,9 @@ var _ = Describe("Client", func() {
 				boshString := "bosh"
 				jobString := "some-job"
 				deploymentString := "some-deployment"
+				directorString := "some-director"
 				vmNameString := "some-other-vm"
+				vmIDString := "some-vm-id"
 
 				azureClient.ListCall.Returns.Result = compute.VirtualMachineListResult{
 					Value: &[]compute.VirtualMachine{
@@ -118,9 +120,11 @@ var _ = Describe("Client", func() {
 						},
 						{
 							Name: &vmNameString,
+							ID:   &vmIDString,
 							Tags: &map[string]*string{
 								"job":        &jobString,
 								"deployment": &deploymentString,
+								"director":   &directorString,
 							},
 						},
 					},
@@ -129,7 +133,9 @@ var _ = Describe("Client", func() {
 
 			It("returns a helpful error message", func() {
 				err := client.ValidateSafeToDelete("", "some-env-id")
-				Expect(err).To(MatchError(`bbl environment is not safe to delete; vms still exist in resource group: some-env-id-bosh (deployment: some-deployment): some-other-vm`))
+				Expect(err).To(MatchError(`bbl environment is not safe to delete; vms still exist in resource group some-env-id-bosh:
+  some-other-vm (id: some-vm-id, deployment: some-deployment, director: some-director)
+Delete them, or run bbl destroy --delete-deployments to delete every bosh deployment first.`))
 			})
 		})
 
@@ -148,7 +154,7 @@ var _ = Describe("Client", func() {
 
 			It("returns a helpful error message", func() {
 				err := client.ValidateSafeToDelete("", "some-env-id")
-				Expect(err).To(MatchError(`bbl environment is not safe to delete; vms still exist in resource group: some-env-id-bosh: some-other-vm`))
+				Expect(err).To(MatchError(ContainSubstring("vms still exist in resource group some-env-id-bosh:\n  some-other-vm (not managed by bosh)\n")))
 			})
 		})
 
@@ -1,5 +1,7 @@
 package azure
 
+import "github.com/Azure/go-autorest/autorest"
+
 func NewClientWithInjectedVMsClient(azureVMsClient AzureVMsClient) Client {
 	return Client{
 		azureVMsClient: azureVMsClient,
@@ -11,3 +13,24 @@ func NewClientWithInjectedGroupsClient(azureGroupsClient AzureGroupsClient) Clie
 		azureGroupsClient: azureGroupsClient,
 	}
 }
+
+func NewClientWithInjectedPermissionsClient(azurePermissionsClient AzurePermissionsClient) Client {
+	return Client{
+		azurePermissionsClient: azurePermissionsClient,
+	}
+}
+
+func NewClientWithInjectedUsageClients(azureComputeUsageClient AzureComputeUsageClient, azureNetworkUsagesClient AzureNetworkUsagesClient) Client {
+	return Client{
+		azureComputeUsageClient:  azureComputeUsageClient,
+		azureNetworkUsagesClient: azureNetworkUsagesClient,
+	}
+}
+
+func NewPermissionsClient(baseURI, subscriptionID string) AzurePermissionsClient {
+	return azurePermissionsClient{
+		Client:         autorest.NewClientWithUserAgent(""),
+		baseURI:        baseURI,
+		subscriptionID: subscriptionID,
+	}
+}
@@ -0,0 +1,145 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const permissionsAPIVersion = "2015-07-01"
+
+type Permission struct {
+	Actions    []string `json:"actions"`
+	NotActions []string `json:"notActions"`
+}
+
+type AzurePermissionsClient interface {
+	ListForSubscription() ([]Permission, error)
+}
+
+var basePermissions = []string{
+	"Microsoft.Compute/virtualMachines/write",
+	"Microsoft.Network/networkSecurityGroups/write",
+	"Microsoft.Network/publicIPAddresses/write",
+	"Microsoft.Network/virtualNetworks/write",
+	"Microsoft.Resources/subscriptions/resourceGroups/write",
+	"Microsoft.Storage/storageAccounts/write",
+}
+
+var lbPermissions = map[string][]string{
+	"cf":        {"Microsoft.Network/applicationGateways/write"},
+	"concourse": {"Microsoft.Network/loadBalancers/write"},
+}
+
+var dnsPermissions = []string{
+	"Microsoft.Network/dnsZones/write",
+}
+
+// RequiredPermissions returns the actions bbl needs on the subscription to
+// pave an environment with the load balancer in the state.
+func RequiredPermissions(state storage.State) []string {
+	permissions := append([]string{}, basePermissions...)
+	permissions = append(permissions, lbPermissions[state.LB.Type]...)
+	if state.LB.Domain != "" {
+		permissions = append(permissions, dnsPermissions...)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+// CheckPermissions returns the required actions that none of the role
+// assignments of the service principal allow.
+func (c Client) CheckPermissions(state storage.State) ([]string, error) {
+	permissions, err := c.azurePermissionsClient.ListForSubscription()
+	if err != nil {
+		return nil, fmt.Errorf("List permissions: %s", err)
+	}
+
+	missing := []string{}
+	for _, action := range RequiredPermissions(state) {
+		if !allowed(permissions, action) {
+			missing = append(missing, action)
+		}
+	}
+	return missing, nil
+}
+
+func allowed(permissions []Permission, action string) bool {
+	for _, permission := range permissions {
+		if matchesAny(permission.Actions, action) && !matchesAny(permission.NotActions, action) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []string, action string) bool {
+	for _, pattern := range patterns {
+		expression := strings.Replace(regexp.QuoteMeta(pattern), `\*`, ".*", -1)
+		if regexp.MustCompile("(?i)^" + expression + "$").MatchString(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// azurePermissionsClient lists the effective permissions of the caller,
+// since the authorization API is not vendored.
+type azurePermissionsClient struct {
+	autorest.Client
+	baseURI        string
+	subscriptionID string
+}
+
+// ListForSubscription follows the nextLink of every page, since the
+// permissions of a service principal with many role assignments are paged.
+func (a azurePermissionsClient) ListForSubscription() ([]Permission, error) {
+	request, err := autorest.Prepare(&http.Request{},
+		autorest.AsGet(),
+		autorest.WithBaseURL(a.baseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/providers/Microsoft.Authorization/permissions",
+			map[string]interface{}{"subscriptionId": autorest.Encode("path", a.subscriptionID)}),
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": permissionsAPIVersion}))
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	var permissions []Permission
+	for {
+		response, err := autorest.SendWithSender(a, request)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Value    []Permission `json:"value"`
+			NextLink string       `json:"nextLink"`
+		}
+		err = autorest.Respond(response,
+			a.ByInspecting(),
+			azure.WithErrorUnlessStatusCode(http.StatusOK),
+			autorest.ByUnmarshallingJSON(&result),
+			autorest.ByClosing())
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, result.Value...)
+
+		if result.NextLink == "" {
+			return permissions, nil
+		}
+
+		request, err = autorest.Prepare(&http.Request{},
+			autorest.AsGet(),
+			autorest.WithBaseURL(result.NextLink))
+		if err != nil {
+			return nil, fmt.Errorf("Parse next link: %s", err)
+		}
+	}
+}
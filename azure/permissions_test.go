@@ -0,0 +1,113 @@
+package azure_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry/bosh-bootloader/azure"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Permissions", func() {
+	Describe("RequiredPermissions", func() {
+		It("adds the actions for the load balancer and dns", func() {
+			base := azure.RequiredPermissions(storage.State{})
+			Expect(base).To(ContainElement("Microsoft.Network/virtualNetworks/write"))
+			Expect(base).NotTo(ContainElement("Microsoft.Network/applicationGateways/write"))
+
+			cf := azure.RequiredPermissions(storage.State{LB: storage.LB{Type: "cf", Domain: "example.com"}})
+			Expect(cf).To(ContainElement("Microsoft.Network/applicationGateways/write"))
+			Expect(cf).To(ContainElement("Microsoft.Network/dnsZones/write"))
+
+			concourse := azure.RequiredPermissions(storage.State{LB: storage.LB{Type: "concourse"}})
+			Expect(concourse).To(ContainElement("Microsoft.Network/loadBalancers/write"))
+			Expect(concourse).NotTo(ContainElement("Microsoft.Network/dnsZones/write"))
+		})
+	})
+
+	Describe("CheckPermissions", func() {
+		var (
+			permissionsClient *fakes.AzurePermissionsClient
+			client            azure.Client
+			state             storage.State
+		)
+
+		BeforeEach(func() {
+			permissionsClient = &fakes.AzurePermissionsClient{}
+			client = azure.NewClientWithInjectedPermissionsClient(permissionsClient)
+			state = storage.State{LB: storage.LB{Type: "concourse"}}
+		})
+
+		It("returns the required actions that no role assignment allows", func() {
+			permissionsClient.ListForSubscriptionCall.Returns.Permissions = []azure.Permission{
+				{Actions: []string{"microsoft.network/*"}, NotActions: []string{"Microsoft.Network/loadBalancers/*"}},
+				{Actions: []string{"Microsoft.Compute/virtualMachines/*", "Microsoft.Storage/*/write"}},
+			}
+
+			missing, err := client.CheckPermissions(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(permissionsClient.ListForSubscriptionCall.CallCount).To(Equal(1))
+			Expect(missing).To(Equal([]string{
+				"Microsoft.Network/loadBalancers/write",
+				"Microsoft.Resources/subscriptions/resourceGroups/write",
+			}))
+		})
+
+		Context("when the service principal is an owner or contributor", func() {
+			It("returns no missing actions", func() {
+				permissionsClient.ListForSubscriptionCall.Returns.Permissions = []azure.Permission{
+					{Actions: []string{"*"}, NotActions: []string{"Microsoft.Authorization/*/Write"}},
+				}
+
+				missing, err := client.CheckPermissions(state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(missing).To(BeEmpty())
+			})
+		})
+
+		Context("when listing the permissions fails", func() {
+			It("returns an error", func() {
+				permissionsClient.ListForSubscriptionCall.Returns.Error = errors.New("failed to list")
+
+				_, err := client.CheckPermissions(state)
+				Expect(err).To(MatchError("List permissions: failed to list"))
+			})
+		})
+	})
+
+	Describe("ListForSubscription", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("page") == "2" {
+					fmt.Fprint(w, `{"value": [{"actions": ["Microsoft.Network/*"]}]}`)
+					return
+				}
+
+				Expect(r.URL.Path).To(Equal("/subscriptions/some-subscription-id/providers/Microsoft.Authorization/permissions"))
+				fmt.Fprintf(w, `{"value": [{"actions": ["Microsoft.Compute/*"], "notActions": []}], "nextLink": "%s/next?page=2"}`, server.URL)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("lists the permissions of every page", func() {
+			permissions, err := azure.NewPermissionsClient(server.URL, "some-subscription-id").ListForSubscription()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(permissions).To(Equal([]azure.Permission{
+				{Actions: []string{"Microsoft.Compute/*"}, NotActions: []string{}},
+				{Actions: []string{"Microsoft.Network/*"}},
+			}))
+		})
+	})
+})
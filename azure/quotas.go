@@ -0,0 +1,106 @@
+package azure
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	defaultDirectorCores = 1
+	defaultJumpboxCores  = 1
+)
+
+var vmSizeCores = regexp.MustCompile(`^(?i)Standard_[A-Z]+(\d+)`)
+
+type AzureComputeUsageClient interface {
+	List(location string) (compute.ListUsagesResult, error)
+}
+
+type AzureNetworkUsagesClient interface {
+	List(location string) (network.UsagesListResult, error)
+}
+
+// CheckQuotas returns the core and public IP quotas of the region next to
+// what the jumpbox and director VMs and the load balancer in the state need.
+func (c Client) CheckQuotas(state storage.State) ([]storage.Quota, error) {
+	location := state.Azure.Region
+
+	computeUsages, err := c.azureComputeUsageClient.List(location)
+	if err != nil {
+		return nil, fmt.Errorf("List compute usages in %s: %s", location, err)
+	}
+
+	networkUsages, err := c.azureNetworkUsagesClient.List(location)
+	if err != nil {
+		return nil, fmt.Errorf("List network usages in %s: %s", location, err)
+	}
+
+	quotas := []storage.Quota{}
+
+	if computeUsages.Value != nil {
+		for _, usage := range *computeUsages.Value {
+			if usage.Name != nil && usage.Name.Value != nil && *usage.Name.Value == "cores" {
+				quotas = append(quotas, storage.Quota{
+					Name:     "Cores",
+					Required: vmCores(state.Sizing.DirectorVMType, defaultDirectorCores) + vmCores(state.Sizing.JumpboxVMType, defaultJumpboxCores),
+					InUse:    int(int32Value(usage.CurrentValue)),
+					Limit:    int(int64Value(usage.Limit)),
+				})
+			}
+		}
+	}
+
+	publicIPs := 1
+	if state.LB.Type == "cf" || state.LB.Type == "concourse" {
+		publicIPs++
+	}
+
+	if networkUsages.Value != nil {
+		for _, usage := range *networkUsages.Value {
+			if usage.Name != nil && usage.Name.Value != nil && *usage.Name.Value == "PublicIPAddresses" {
+				quotas = append(quotas, storage.Quota{
+					Name:     "Public IP addresses",
+					Required: publicIPs,
+					InUse:    int(int64Value(usage.CurrentValue)),
+					Limit:    int(int64Value(usage.Limit)),
+				})
+			}
+		}
+	}
+
+	return quotas, nil
+}
+
+// vmCores reads the core count from VM size names like Standard_D2_v2 and
+// Standard_F4s, and falls back to the cores of the default VM size.
+func vmCores(vmSize string, defaultCores int) int {
+	matches := vmSizeCores.FindStringSubmatch(vmSize)
+	if matches == nil {
+		return defaultCores
+	}
+
+	cores, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return defaultCores // not tested
+	}
+	return cores
+}
+
+func int32Value(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
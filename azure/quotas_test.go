@@ -0,0 +1,88 @@
+package azure_test
+
+import (
+	"errors"
+
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+	"github.com/cloudfoundry/bosh-bootloader/azure"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quotas", func() {
+	var (
+		computeUsageClient  *fakes.AzureComputeUsageClient
+		networkUsagesClient *fakes.AzureNetworkUsagesClient
+		client              azure.Client
+		state               storage.State
+	)
+
+	BeforeEach(func() {
+		computeUsageClient = &fakes.AzureComputeUsageClient{}
+		networkUsagesClient = &fakes.AzureNetworkUsagesClient{}
+		client = azure.NewClientWithInjectedUsageClients(computeUsageClient, networkUsagesClient)
+		state = storage.State{Azure: storage.Azure{Region: "westus"}}
+
+		cores, coresInUse, coresLimit := "cores", int32(9), int64(10)
+		vms, vmsInUse, vmsLimit := "virtualMachines", int32(1), int64(100)
+		computeUsageClient.ListCall.Returns.Result = compute.ListUsagesResult{
+			Value: &[]compute.Usage{
+				{Name: &compute.UsageName{Value: &vms}, CurrentValue: &vmsInUse, Limit: &vmsLimit},
+				{Name: &compute.UsageName{Value: &cores}, CurrentValue: &coresInUse, Limit: &coresLimit},
+			},
+		}
+
+		publicIPs, publicIPsInUse, publicIPsLimit := "PublicIPAddresses", int64(3), int64(60)
+		networkUsagesClient.ListCall.Returns.Result = network.UsagesListResult{
+			Value: &[]network.Usage{
+				{Name: &network.UsageName{Value: &publicIPs}, CurrentValue: &publicIPsInUse, Limit: &publicIPsLimit},
+			},
+		}
+	})
+
+	It("returns the core and public ip quotas of the region", func() {
+		quotas, err := client.CheckQuotas(state)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(computeUsageClient.ListCall.Receives.Location).To(Equal("westus"))
+		Expect(networkUsagesClient.ListCall.Receives.Location).To(Equal("westus"))
+		Expect(quotas).To(Equal([]storage.Quota{
+			{Name: "Cores", Required: 2, InUse: 9, Limit: 10},
+			{Name: "Public IP addresses", Required: 1, InUse: 3, Limit: 60},
+		}))
+	})
+
+	It("counts the load balancer and the cores of the requested vm sizes", func() {
+		state.LB = storage.LB{Type: "concourse"}
+		state.Sizing = storage.Sizing{DirectorVMType: "Standard_D4_v2"}
+
+		quotas, err := client.CheckQuotas(state)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quotas).To(Equal([]storage.Quota{
+			{Name: "Cores", Required: 5, InUse: 9, Limit: 10},
+			{Name: "Public IP addresses", Required: 2, InUse: 3, Limit: 60},
+		}))
+	})
+
+	Context("when the compute usages cannot be listed", func() {
+		It("returns an error", func() {
+			computeUsageClient.ListCall.Returns.Error = errors.New("failed to list")
+
+			_, err := client.CheckQuotas(state)
+			Expect(err).To(MatchError("List compute usages in westus: failed to list"))
+		})
+	})
+
+	Context("when the network usages cannot be listed", func() {
+		It("returns an error", func() {
+			networkUsagesClient.ListCall.Returns.Error = errors.New("failed to list")
+
+			_, err := client.CheckQuotas(state)
+			Expect(err).To(MatchError("List network usages in westus: failed to list"))
+		})
+	})
+})
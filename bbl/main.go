@@ -19,6 +19,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/config"
 	"github.com/cloudfoundry/bosh-bootloader/gcp"
 	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	"github.com/cloudfoundry/bosh-bootloader/runtimeconfig"
 	"github.com/cloudfoundry/bosh-bootloader/ssh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
@@ -45,6 +46,12 @@ import (
 
 var Version = "dev"
 
+// exitCoder is implemented by the errors of commands that run another
+// command, so that bbl exits with its status.
+type exitCoder interface {
+	ExitCode() int
+}
+
 func main() {
 	log.SetFlags(0)
 
@@ -52,12 +59,30 @@ func main() {
 	stderrLogger := application.NewLogger(os.Stderr, os.Stdin)
 	stateBootstrap := storage.NewStateBootstrap(stderrLogger, Version)
 
+	// The managers, the iaas clients and the commands that change the
+	// environment print steps and warnings but no data, so in json mode they
+	// log to stderr as events, while the stdout logger keeps printing the data
+	// of the query commands as is.
+	eventLogger := logger
+
 	globals, _, err := config.ParseArgs(os.Args)
-	if err != nil {
-		log.Fatalf("\n\n%s\n", err)
-	}
 	if globals.NoConfirm {
 		logger.NoConfirm()
+		stderrLogger.NoConfirm()
+	}
+	if globals.LogFormat == "json" {
+		logger.JSONEvents(os.Stderr)
+		stderrLogger.JSON()
+		eventLogger = stderrLogger
+	}
+
+	fatal := func(err error) {
+		eventLogger.Finish(err)
+		log.Fatalf("\n\n%s\n", err)
+	}
+
+	if err != nil {
+		fatal(err)
 	}
 
 	// File IO
@@ -72,36 +97,39 @@ func main() {
 
 	appConfig, err := newConfig.Bootstrap(os.Args)
 	if err != nil {
-		log.Fatalf("\n\n%s\n", err)
+		fatal(err)
 	}
+	logger.SetEnvID(appConfig.State.EnvID)
+	stderrLogger.SetEnvID(appConfig.State.EnvID)
 
 	needsIAASCreds := config.NeedsIAASCreds(appConfig.Command) && !appConfig.ShowCommandHelp
 	if needsIAASCreds {
 		err = config.ValidateIAAS(appConfig.State)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 	}
 
 	// Utilities
 	envIDGenerator := helpers.NewEnvIDGenerator(rand.Reader)
 	stateValidator := application.NewStateValidator(appConfig.Global.StateDir)
+	output := commands.NewOutput(logger, appConfig.Global.Output)
 	certificateValidator := certs.NewValidator()
 	lbArgsHandler := commands.NewLBArgsHandler(certificateValidator)
-	sshCLI := ssh.NewCLI(os.Stdin, os.Stdout, os.Stderr)
-	pathFinder := helpers.NewPathFinder()
+	knownHosts := ssh.NewKnownHosts(filepath.Join(appConfig.Global.StateDir, storage.KNOWN_HOSTS_FILE), afs)
+	sshClient := ssh.NewClient(knownHosts, afs, os.Stdin, os.Stdout, os.Stderr)
 
 	// Terraform
 	terraformOutputBuffer := bytes.NewBuffer([]byte{})
 	dotTerraformDir := filepath.Join(appConfig.Global.StateDir, "terraform", ".terraform")
-	bufferingCLI := terraform.NewCLI(terraformOutputBuffer, terraformOutputBuffer, dotTerraformDir)
+	bufferingCLI := terraform.NewCLI(terraformOutputBuffer, terraformOutputBuffer, dotTerraformDir, eventLogger)
 	var (
 		terraformCLI terraform.CLI
 		out          io.Writer
 	)
 	if appConfig.Global.Debug {
 		errBuffer := io.MultiWriter(os.Stderr, terraformOutputBuffer)
-		terraformCLI = terraform.NewCLI(errBuffer, terraformOutputBuffer, dotTerraformDir)
+		terraformCLI = terraform.NewCLI(errBuffer, terraformOutputBuffer, dotTerraformDir, eventLogger)
 		out = os.Stdout
 	} else {
 		terraformCLI = bufferingCLI
@@ -114,20 +142,22 @@ func main() {
 	socks5Proxy := proxy.NewSocks5Proxy(hostKey, nil)
 	boshPath, err := config.GetBOSHPath()
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
-	boshCommand := bosh.NewCLI(os.Stderr, boshPath)
-	boshExecutor := bosh.NewExecutor(boshCommand, afs)
+	boshCommand := bosh.NewCLI(os.Stderr, boshPath, eventLogger)
+	boshExecutor := bosh.NewExecutor(boshCommand, afs, eventLogger)
 	sshKeyGetter := bosh.NewSSHKeyGetter(stateStore, afs)
 	allProxyGetter := bosh.NewAllProxyGetter(sshKeyGetter, afs)
 	credhubGetter := bosh.NewCredhubGetter(stateStore, afs)
-	boshManager := bosh.NewManager(boshExecutor, logger, stateStore, sshKeyGetter, afs)
+	boshManager := bosh.NewManager(boshExecutor, eventLogger, stateStore, sshKeyGetter, knownHosts, afs)
 	boshClientProvider := bosh.NewClientProvider(socks5Proxy, sshKeyGetter)
 
 	// Clients that require IAAS credentials.
 	var (
 		networkClient            helpers.NetworkClient
 		networkDeletionValidator commands.NetworkDeletionValidator
+		permissionChecker        commands.PermissionChecker
+		quotaChecker             commands.QuotaChecker
 
 		awsClient aws.Client
 		leftovers commands.FilteredDeleter
@@ -135,55 +165,61 @@ func main() {
 	if needsIAASCreds {
 		switch appConfig.State.IAAS {
 		case "aws":
-			awsClient = aws.NewClient(appConfig.State.AWS, logger)
+			awsClient = aws.NewClient(appConfig.State.AWS, eventLogger)
 
 			networkDeletionValidator = awsClient
+			permissionChecker = awsClient
+			quotaChecker = awsClient
 			networkClient = awsClient
 
 			leftovers, err = awsleftovers.NewLeftovers(logger, appConfig.State.AWS.AccessKeyID, appConfig.State.AWS.SecretAccessKey, appConfig.State.AWS.Region)
 			if err != nil {
-				log.Fatalf("\n\n%s\n", err)
+				fatal(err)
 			}
 
 		case "gcp":
 			gcpClient, err := gcp.NewClient(appConfig.State.GCP, "")
 			if err != nil {
-				log.Fatalf("\n\n%s\n", err)
+				fatal(err)
 			}
 
 			networkDeletionValidator = gcpClient
+			permissionChecker = gcpClient
+			quotaChecker = gcpClient
 			networkClient = gcpClient
 
 			gcpZonerHack := config.NewGCPZonerHack(gcpClient)
 			stateWithZones, err := gcpZonerHack.SetZones(appConfig.State)
 			if err != nil {
-				log.Fatalf("\n\n%s\n", err)
+				fatal(err)
 			}
 			appConfig.State = stateWithZones
 
 			leftovers, err = gcpleftovers.NewLeftovers(logger, appConfig.State.GCP.ServiceAccountKeyPath)
 			if err != nil {
-				log.Fatalf("\n\n%s\n", err)
+				fatal(err)
 			}
 
 		case "azure":
 			azureClient, err := azure.NewClient(appConfig.State.Azure)
 			if err != nil {
-				log.Fatalf("\n\n%s\n", err)
+				fatal(err)
 			}
 
 			networkDeletionValidator = azureClient
+			permissionChecker = azureClient
+			quotaChecker = azureClient
 			networkClient = azureClient
 
 			leftovers, err = azureleftovers.NewLeftovers(logger, appConfig.State.Azure.ClientID, appConfig.State.Azure.ClientSecret, appConfig.State.Azure.SubscriptionID, appConfig.State.Azure.TenantID)
 			if err != nil {
-				log.Fatalf("\n\n%s\n", err)
+				fatal(err)
 			}
 		case "vsphere":
 			vSphereLogger := application.NewLogger(os.Stdout, os.Stdin)
 			leftovers, err = vsphereleftovers.NewLeftovers(vSphereLogger, appConfig.State.VSphere.VCenterIP, appConfig.State.VSphere.VCenterUser, appConfig.State.VSphere.VCenterPassword, appConfig.State.VSphere.VCenterDC)
 			if err != nil {
-				log.Fatalf("\n\n%s\n", err)
+				fatal(err)
 			}
 		}
 	}
@@ -203,34 +239,34 @@ func main() {
 		templateGenerator = awsterraform.NewTemplateGenerator()
 		inputGenerator = awsterraform.NewInputGenerator(awsClient)
 
-		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, logger)
+		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, eventLogger)
 
 		cloudConfigOpsGenerator = awscloudconfig.NewOpsGenerator(terraformManager, awsClient)
 
-		lbsCmd = commands.NewAWSLBs(terraformManager, logger)
+		lbsCmd = commands.NewAWSLBs(terraformManager, logger, output)
 	case "azure":
 		templateGenerator = azureterraform.NewTemplateGenerator()
 		inputGenerator = azureterraform.NewInputGenerator()
 
-		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, logger)
+		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, eventLogger)
 
 		cloudConfigOpsGenerator = azurecloudconfig.NewOpsGenerator(terraformManager)
 
-		lbsCmd = commands.NewAzureLBs(terraformManager, logger)
+		lbsCmd = commands.NewAzureLBs(terraformManager, logger, output)
 	case "gcp":
 		templateGenerator = gcpterraform.NewTemplateGenerator()
 		inputGenerator = gcpterraform.NewInputGenerator()
 
-		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, logger)
+		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, eventLogger)
 
 		cloudConfigOpsGenerator = gcpcloudconfig.NewOpsGenerator(terraformManager)
 
-		lbsCmd = commands.NewGCPLBs(terraformManager, logger)
+		lbsCmd = commands.NewGCPLBs(terraformManager, logger, output)
 	case "vsphere":
 		templateGenerator = vsphereterraform.NewTemplateGenerator()
 		inputGenerator = vsphereterraform.NewInputGenerator()
 
-		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, logger)
+		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, eventLogger)
 
 		cloudConfigOpsGenerator = vspherecloudconfig.NewOpsGenerator(terraformManager)
 
@@ -238,51 +274,70 @@ func main() {
 		templateGenerator = openstackterraform.NewTemplateGenerator()
 		inputGenerator = openstackterraform.NewInputGenerator()
 
-		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, logger)
+		terraformManager = terraform.NewManager(terraformExecutor, templateGenerator, inputGenerator, terraformOutputBuffer, eventLogger)
 
 		cloudConfigOpsGenerator = openstackcloudconfig.NewOpsGenerator(terraformManager)
 	}
 
-	cloudConfigManager := cloudconfig.NewManager(logger, boshCommand, stateStore, cloudConfigOpsGenerator, boshClientProvider, terraformManager, afs)
+	cloudConfigManager := cloudconfig.NewManager(eventLogger, boshCommand, stateStore, cloudConfigOpsGenerator, boshClientProvider, terraformManager, afs)
+	runtimeConfigManager := runtimeconfig.NewManager(eventLogger, boshCommand, stateStore, boshClientProvider, terraformManager, afs)
 
 	// Commands
 	var envIDManager helpers.EnvIDManager
 	if appConfig.State.IAAS != "" {
 		envIDManager = helpers.NewEnvIDManager(envIDGenerator, networkClient)
 	}
-	plan := commands.NewPlan(boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager, lbArgsHandler, stderrLogger, Version)
-	up := commands.NewUp(plan, boshManager, cloudConfigManager, stateStore, terraformManager)
-	usage := commands.NewUsage(logger)
+	patchCompatibility := commands.NewPatchCompatibility(templateGenerator, afs, appConfig.Global.StateDir, Version)
+	managedFiles := storage.NewManagedFiles(afs, appConfig.Global.StateDir)
+	plan := commands.NewPlan(boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager, lbArgsHandler, patchCompatibility, permissionChecker, managedFiles, stderrLogger, afs, Version)
+	timingsLog := storage.NewTimingsLog(appConfig.Global.StateDir, afs)
+	hooks := helpers.NewHooks(appConfig.Global.StateDir, afs, os.Stderr)
+	up := commands.NewUp(plan, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, terraformManager, stderrLogger, timingsLog, hooks, managedFiles, quotaChecker)
+	pluginFinder := helpers.NewPluginFinder(os.Getenv("PATH"))
+	usage := commands.NewUsage(logger, pluginFinder)
 
 	commandSet := application.CommandSet{}
 	commandSet["help"] = usage
-	commandSet["version"] = commands.NewVersion(Version, logger)
-	commandSet["outputs"] = commands.NewOutputs(logger, terraformManager, stateValidator)
+	commandSet["version"] = commands.NewVersion(Version, logger, output)
+	commandSet["outputs"] = commands.NewOutputs(logger, terraformManager, stateValidator, output)
 	commandSet["up"] = up
 	commandSet["plan"] = plan
 	sshKeyDeleter := bosh.NewSSHKeyDeleter(stateStore, afs)
 	commandSet["rotate"] = commands.NewRotate(stateValidator, sshKeyDeleter, up)
-	commandSet["destroy"] = commands.NewDestroy(plan, logger, boshManager, stateStore, stateValidator, terraformManager, networkDeletionValidator)
+	commandSet["destroy"] = commands.NewDestroy(plan, eventLogger, boshManager, stateStore, stateValidator, terraformManager, networkDeletionValidator, timingsLog, hooks, boshClientProvider)
 	commandSet["down"] = commandSet["destroy"]
 	commandSet["cleanup-leftovers"] = commands.NewCleanupLeftovers(leftovers)
 	commandSet["leftovers"] = commandSet["cleanup-leftovers"]
+	commandSet["patch"] = commands.NewPatch(logger, afs, appConfig.Global.StateDir)
+	terraformVersionValidator := terraform.NewManager(terraformExecutor, nil, nil, terraformOutputBuffer, eventLogger)
+	commandSet["doctor"] = commands.NewDoctor(terraformVersionValidator, boshManager, helpers.NewPathFinder(), stateBootstrap, sshKeyGetter, afs, logger, appConfig.Global.StateDir)
 	commandSet["lbs"] = commands.NewLBs(lbsCmd, stateValidator)
-	commandSet["jumpbox-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.JumpboxAddressPropertyName)
-	commandSet["director-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorAddressPropertyName)
-	commandSet["director-username"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorUsernamePropertyName)
-	commandSet["director-password"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorPasswordPropertyName)
-	commandSet["director-ca-cert"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorCACertPropertyName)
-	commandSet["ssh-key"] = commands.NewSSHKey(logger, stateValidator, sshKeyGetter)
+	commandSet["jumpbox-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.JumpboxAddressPropertyName, output)
+	commandSet["director-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorAddressPropertyName, output)
+	commandSet["director-username"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorUsernamePropertyName, output)
+	commandSet["director-password"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorPasswordPropertyName, output)
+	commandSet["director-ca-cert"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorCACertPropertyName, output)
+	commandSet["ssh-key"] = commands.NewSSHKey(logger, stateValidator, sshKeyGetter, output)
 	commandSet["validate"] = commands.NewValidate(plan, stateStore, terraformManager)
-	commandSet["director-ssh-key"] = commands.NewDirectorSSHKey(logger, stateValidator, sshKeyGetter)
-	commandSet["env-id"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.EnvIDPropertyName)
+	commandSet["director-ssh-key"] = commands.NewDirectorSSHKey(logger, stateValidator, sshKeyGetter, output)
+	commandSet["env-id"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.EnvIDPropertyName, output)
 	commandSet["latest-error"] = commands.NewLatestError(logger, stateValidator)
-	commandSet["print-env"] = commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, afs)
-	commandSet["ssh"] = commands.NewSSH(sshCLI, sshKeyGetter, pathFinder, afs, ssh.RandomPort{})
+	printEnv := commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, afs, appConfig.Global.StateDir)
+	commandSet["print-env"] = printEnv
+	commandSet["env-exec"] = commands.NewEnvExec(stateValidator, printEnv, helpers.NewCommandRunner(), afs)
+	commandSet["ssh"] = commands.NewSSH(sshClient, sshKeyGetter)
+	commandSet["scp"] = commands.NewSCP(sshClient, sshKeyGetter)
+	commandSet["tunnel"] = commands.NewTunnel(sshClient, sshKeyGetter, helpers.NewBackground(), logger, afs, appConfig.Global.StateDir)
 
-	app := application.New(commandSet, appConfig, usage)
+	plugins := commands.NewPlugins(pluginFinder, printEnv, terraformManager, helpers.NewCommandRunner(), afs, appConfig.Global.StateDir)
+	app := application.New(commandSet, appConfig, usage, plugins)
 
 	err = app.Run()
+	logger.Finish(nil)
+	eventLogger.Finish(err)
+	if exitErr, ok := err.(exitCoder); ok {
+		os.Exit(exitErr.ExitCode())
+	}
 	if err != nil {
 		log.Fatalf("\n\n%s\n", err)
 	}
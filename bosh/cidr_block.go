@@ -7,12 +7,14 @@ import (
 )
 
 type CIDRBlock struct {
+	// CIDRSize is the number of addresses in the block. It is capped at the
+	// largest int for IPv6 blocks that hold more addresses than that.
 	CIDRSize int
+	hostBits uint
 	firstIP  IP
 }
 
 func ParseCIDRBlock(cidrBlock string) (CIDRBlock, error) {
-	const HIGHEST_BITMASK = 32
 	const CIDR_PARTS = 2
 
 	cidrParts := strings.Split(cidrBlock, "/")
@@ -31,17 +33,32 @@ func ParseCIDRBlock(cidrBlock string) (CIDRBlock, error) {
 		return CIDRBlock{}, err
 	}
 
-	if maskBits < 0 || maskBits > HIGHEST_BITMASK {
+	highestBitmask := 32
+	if ip.IsIPv6() {
+		highestBitmask = 128
+	}
+
+	if maskBits < 0 || maskBits > highestBitmask {
 		return CIDRBlock{}, fmt.Errorf("mask bits out of range")
 	}
 
-	cidrSize := 1 << (HIGHEST_BITMASK - uint(maskBits))
+	hostBits := uint(highestBitmask - maskBits)
+	cidrSize := int(^uint(0) >> 1)
+	if hostBits < strconv.IntSize-1 {
+		cidrSize = 1 << hostBits
+	}
+
 	return CIDRBlock{
 		CIDRSize: cidrSize,
+		hostBits: hostBits,
 		firstIP:  ip,
 	}, nil
 }
 
+func (c CIDRBlock) IsIPv6() bool {
+	return c.firstIP.IsIPv6()
+}
+
 func (c CIDRBlock) GetFirstIP() IP {
 	return c.GetNthIP(0)
 }
@@ -51,5 +68,36 @@ func (c CIDRBlock) GetNthIP(n int) IP {
 }
 
 func (c CIDRBlock) GetLastIP() IP {
-	return c.GetNthIP(c.CIDRSize - 1)
+	return c.firstIP.addHostBits(c.hostBits)
+}
+
+func (c CIDRBlock) String() string {
+	bits := 32
+	if c.IsIPv6() {
+		bits = 128
+	}
+	return fmt.Sprintf("%s/%d", c.firstIP, bits-int(c.hostBits))
+}
+
+// Subnet returns the num-th block of the blocks that have newBits more mask
+// bits than c, like cidrsubnet in terraform.
+func (c CIDRBlock) Subnet(newBits, num int) CIDRBlock {
+	hostBits := c.hostBits - uint(newBits)
+	return CIDRBlock{
+		CIDRSize: 1 << hostBits,
+		hostBits: hostBits,
+		firstIP:  c.firstIP.Add(num << hostBits),
+	}
+}
+
+// Overlaps returns true when the two blocks share any address.
+func (c CIDRBlock) Overlaps(other CIDRBlock) bool {
+	return c.Contains(other) || other.Contains(c)
+}
+
+func (c CIDRBlock) Contains(other CIDRBlock) bool {
+	if c.IsIPv6() != other.IsIPv6() {
+		return false
+	}
+	return !other.GetFirstIP().less(c.GetFirstIP()) && !c.GetLastIP().less(other.GetLastIP())
 }
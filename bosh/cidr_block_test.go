@@ -39,6 +39,82 @@ var _ = Describe("CIDRBlock", func() {
 		})
 	})
 
+	Context("with an ipv6 cidr block", func() {
+		BeforeEach(func() {
+			var err error
+			cidrBlock, err = bosh.ParseCIDRBlock("2600:1f14:0:100::/64")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the nth and last ips of the block", func() {
+			Expect(cidrBlock.IsIPv6()).To(BeTrue())
+			Expect(cidrBlock.GetNthIP(6).String()).To(Equal("2600:1f14:0:100::6"))
+			Expect(cidrBlock.GetLastIP().String()).To(Equal("2600:1f14:0:100:ffff:ffff:ffff:ffff"))
+		})
+
+		It("caps the size of the block", func() {
+			Expect(cidrBlock.CIDRSize).To(Equal(int(^uint(0) >> 1)))
+		})
+
+		It("contains smaller blocks of the same family only", func() {
+			inner, err := bosh.ParseCIDRBlock("2600:1f14:0:100:1::/80")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(inner)).To(BeTrue())
+
+			ipv4, err := bosh.ParseCIDRBlock("10.0.0.0/24")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(ipv4)).To(BeFalse())
+		})
+
+		It("rejects mask bits beyond 128", func() {
+			_, err := bosh.ParseCIDRBlock("2600::/129")
+			Expect(err).To(MatchError("mask bits out of range"))
+		})
+	})
+
+	Describe("Contains", func() {
+		It("returns true when the other block lies within the cidr block", func() {
+			inner, err := bosh.ParseCIDRBlock("10.0.20.0/24")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(inner)).To(BeTrue())
+		})
+
+		It("returns false when the other block extends past the cidr block", func() {
+			outer, err := bosh.ParseCIDRBlock("10.0.16.0/16")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(outer)).To(BeFalse())
+
+			elsewhere, err := bosh.ParseCIDRBlock("10.0.32.0/24")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(elsewhere)).To(BeFalse())
+		})
+	})
+
+	Describe("Overlaps", func() {
+		It("returns true when either block contains the other", func() {
+			outer, err := bosh.ParseCIDRBlock("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Overlaps(outer)).To(BeTrue())
+			Expect(outer.Overlaps(cidrBlock)).To(BeTrue())
+
+			elsewhere, err := bosh.ParseCIDRBlock("10.1.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Overlaps(elsewhere)).To(BeFalse())
+		})
+	})
+
+	Describe("Subnet", func() {
+		It("returns the nth subnet like terraform's cidrsubnet", func() {
+			network, err := bosh.ParseCIDRBlock("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(network.Subnet(4, 0).String()).To(Equal("10.0.0.0/20"))
+			Expect(network.Subnet(4, 1).String()).To(Equal("10.0.16.0/20"))
+			Expect(network.Subnet(8, 2).String()).To(Equal("10.0.2.0/24"))
+			Expect(network.Subnet(8, 2).CIDRSize).To(Equal(256))
+		})
+	})
+
 	Describe("ParseCIDRBlock", func() {
 		Context("failure cases", func() {
 			Context("when input string is not a valid CIDR block", func() {
@@ -1,19 +1,27 @@
 package bosh
 
 import (
+	"fmt"
 	"io"
 	"os/exec"
+	"strings"
 )
 
+type exitLogger interface {
+	ChildExited(command string, exitCode int)
+}
+
 type CLI struct {
 	stderr io.Writer
 	path   string
+	logger exitLogger
 }
 
-func NewCLI(stderr io.Writer, path string) CLI {
+func NewCLI(stderr io.Writer, path string, logger exitLogger) CLI {
 	return CLI{
 		stderr: stderr,
 		path:   path,
+		logger: logger,
 	}
 }
 
@@ -24,7 +32,25 @@ func (c CLI) Run(stdout io.Writer, workingDirectory string, args []string) error
 	command.Stdout = stdout
 	command.Stderr = c.stderr
 
-	return command.Run()
+	err := command.Run()
+	c.logger.ChildExited(commandName(args), exitCode(command))
+	return err
+}
+
+func commandName(args []string) string {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return fmt.Sprintf("bosh %s", arg)
+		}
+	}
+	return "bosh"
+}
+
+func exitCode(command *exec.Cmd) int {
+	if command.ProcessState == nil {
+		return -1
+	}
+	return command.ProcessState.ExitCode()
 }
 
 func (c CLI) GetBOSHPath() string {
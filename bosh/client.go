@@ -8,6 +8,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,9 +17,28 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+const (
+	RuntimeConfigType = "runtime"
+	CPIConfigType     = "cpi"
+)
+
 type Client interface {
 	UpdateCloudConfig(yaml []byte) error
+	UpdateConfig(configType, name string, yaml []byte) error
+	DeleteConfig(configType, name string) error
+	Configs(configType string) ([]Config, error)
 	Info() (Info, error)
+	Deployments() ([]string, error)
+	DeleteDeployment(name string) error
+	OrphanedDisks() ([]string, error)
+	DeleteOrphanedDisk(cid string) error
+}
+
+type Config struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
 }
 
 type Info struct {
@@ -26,9 +47,16 @@ type Info struct {
 	Version string `json:"version"`
 }
 
+type task struct {
+	ID     int    `json:"id"`
+	State  string `json:"state"`
+	Result string `json:"result"`
+}
+
 var (
-	MAX_RETRIES = 5
-	RETRY_DELAY = 10 * time.Second
+	MAX_RETRIES        = 5
+	RETRY_DELAY        = 10 * time.Second
+	TASK_POLL_INTERVAL = 5 * time.Second
 )
 
 type client struct {
@@ -79,38 +107,283 @@ func (c client) UpdateCloudConfig(yaml []byte) error {
 	}
 	request.Header.Set("Content-Type", "text/yaml")
 
-	urlParts, err := url.Parse(c.directorAddress)
+	response, err := c.makeAuthenticatedRequest(request)
 	if err != nil {
-		return err //not tested
+		return err
 	}
 
-	boshHost, _, err := net.SplitHostPort(urlParts.Host)
+	if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return nil
+}
+
+func (c client) UpdateConfig(configType, name string, yaml []byte) error {
+	body, err := json.Marshal(Config{
+		Name:    name,
+		Type:    configType,
+		Content: string(yaml),
+	})
 	if err != nil {
 		return err //not tested
 	}
 
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s/configs", c.directorAddress), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
 
-	conf := &clientcredentials.Config{
-		ClientID:     c.username,
-		ClientSecret: c.password,
-		TokenURL:     fmt.Sprintf("https://%s:8443/oauth/token", boshHost),
+	response, err := c.makeAuthenticatedRequest(request)
+	if err != nil {
+		return err
 	}
+	defer response.Body.Close()
 
-	httpClient := conf.Client(ctx)
-	response, err := makeRequests(httpClient, request)
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return nil
+}
+
+func (c client) DeleteConfig(configType, name string) error {
+	query := url.Values{}
+	query.Set("type", configType)
+	query.Set("name", name)
+
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/configs?%s", c.directorAddress, query.Encode()), strings.NewReader(""))
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode != http.StatusCreated {
+	response, err := c.makeAuthenticatedRequest(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
 		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
 	}
 
 	return nil
 }
 
+func (c client) Configs(configType string) ([]Config, error) {
+	query := url.Values{}
+	query.Set("type", configType)
+	query.Set("latest", "true")
+
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/configs?%s", c.directorAddress, query.Encode()), strings.NewReader(""))
+	if err != nil {
+		return []Config{}, err
+	}
+
+	response, err := c.makeAuthenticatedRequest(request)
+	if err != nil {
+		return []Config{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return []Config{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var configs []Config
+	if err := json.NewDecoder(response.Body).Decode(&configs); err != nil {
+		return []Config{}, err
+	}
+
+	return configs, nil
+}
+
+func (c client) Deployments() ([]string, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/deployments", c.directorAddress), strings.NewReader(""))
+	if err != nil {
+		return []string{}, err
+	}
+
+	response, err := c.makeAuthenticatedRequest(request)
+	if err != nil {
+		return []string{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return []string{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var deployments []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&deployments); err != nil {
+		return []string{}, err
+	}
+
+	names := []string{}
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
+	}
+	return names, nil
+}
+
+func (c client) DeleteDeployment(name string) error {
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/deployments/%s?force=true", c.directorAddress, url.PathEscape(name)), strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+
+	return c.runTask(request)
+}
+
+func (c client) OrphanedDisks() ([]string, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/disks", c.directorAddress), strings.NewReader(""))
+	if err != nil {
+		return []string{}, err
+	}
+
+	response, err := c.makeAuthenticatedRequest(request)
+	if err != nil {
+		return []string{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return []string{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var disks []struct {
+		CID string `json:"disk_cid"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&disks); err != nil {
+		return []string{}, err
+	}
+
+	cids := []string{}
+	for _, disk := range disks {
+		cids = append(cids, disk.CID)
+	}
+	return cids, nil
+}
+
+func (c client) DeleteOrphanedDisk(cid string) error {
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/disks/%s", c.directorAddress, url.PathEscape(cid)), strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+
+	return c.runTask(request)
+}
+
+// runTask makes a request that the director answers by redirecting to a
+// task, and waits for the task to finish.
+func (c client) runTask(request *http.Request) error {
+	httpClient, err := c.authenticatedClient()
+	if err != nil {
+		return err //not tested
+	}
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	response, err := makeRequests(httpClient, request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusFound {
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	location, err := url.Parse(response.Header.Get("Location"))
+	if err != nil {
+		return err //not tested
+	}
+
+	taskID, err := strconv.Atoi(path.Base(location.Path))
+	if err != nil {
+		return fmt.Errorf("unexpected task location %q", response.Header.Get("Location"))
+	}
+
+	return c.waitForTask(taskID)
+}
+
+func (c client) waitForTask(id int) error {
+	for {
+		t, err := c.task(id)
+		if err != nil {
+			return err
+		}
+
+		switch t.State {
+		case "queued", "processing", "cancelling":
+			time.Sleep(TASK_POLL_INTERVAL)
+		case "done":
+			return nil
+		default:
+			return fmt.Errorf("task %d %s: %s", id, t.State, t.Result)
+		}
+	}
+}
+
+func (c client) task(id int) (task, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/tasks/%d", c.directorAddress, id), strings.NewReader(""))
+	if err != nil {
+		return task{}, err
+	}
+
+	response, err := c.makeAuthenticatedRequest(request)
+	if err != nil {
+		return task{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return task{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var t task
+	if err := json.NewDecoder(response.Body).Decode(&t); err != nil {
+		return task{}, err
+	}
+	return t, nil
+}
+
+func (c client) makeAuthenticatedRequest(request *http.Request) (*http.Response, error) {
+	httpClient, err := c.authenticatedClient()
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	return makeRequests(httpClient, request)
+}
+
+func (c client) authenticatedClient() (*http.Client, error) {
+	urlParts, err := url.Parse(c.directorAddress)
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	boshHost, _, err := net.SplitHostPort(urlParts.Host)
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+
+	conf := &clientcredentials.Config{
+		ClientID:     c.username,
+		ClientSecret: c.password,
+		TokenURL:     fmt.Sprintf("https://%s:8443/oauth/token", boshHost),
+	}
+
+	return conf.Client(ctx), nil
+}
+
 func makeRequests(httpClient *http.Client, request *http.Request) (*http.Response, error) {
 	var (
 		response *http.Response
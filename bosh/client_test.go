@@ -25,14 +25,21 @@ var _ = Describe("Client", func() {
 		fakeBOSH    *httptest.Server
 		ca          []byte
 		cloudConfig []byte
+		configBody  []byte
+		configQuery url.Values
 		token       string
 		httpClient  *http.Client
 		failStatus  int
+		taskStates  []string
+		deleted     []string
 	)
 
 	BeforeEach(func() {
 		bosh.MAX_RETRIES = 1
 		bosh.RETRY_DELAY = 1 * time.Millisecond
+		bosh.TASK_POLL_INTERVAL = 1 * time.Millisecond
+		taskStates = []string{"processing", "done"}
+		deleted = []string{}
 
 		var err error
 		ca, err = ioutil.ReadFile("fixtures/some-fake-ca.crt")
@@ -86,6 +93,62 @@ var _ = Describe("Client", func() {
 				var err error
 				cloudConfig, err = ioutil.ReadAll(req.Body)
 				Expect(err).NotTo(HaveOccurred())
+			case "/configs":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				token = req.Header.Get("Authorization")
+				configQuery = req.URL.Query()
+
+				switch req.Method {
+				case "GET":
+					w.Write([]byte(`[{
+					  "id": "1",
+					  "name": "dns",
+					  "type": "runtime",
+					  "content": "some: runtime-config"
+					}]`))
+				case "POST":
+					var err error
+					configBody, err = ioutil.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					w.WriteHeader(http.StatusCreated)
+				case "DELETE":
+					w.WriteHeader(http.StatusNoContent)
+				}
+			case "/deployments", "/disks":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				token = req.Header.Get("Authorization")
+				if req.URL.Path == "/deployments" {
+					w.Write([]byte(`[{"name": "cf"}, {"name": "concourse"}]`))
+				} else {
+					w.Write([]byte(`[{"disk_cid": "some-disk-cid"}]`))
+				}
+			case "/deployments/cf", "/disks/some-disk-cid":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				Expect(req.Method).To(Equal("DELETE"))
+				token = req.Header.Get("Authorization")
+				deleted = append(deleted, req.URL.RequestURI())
+
+				w.Header().Set("Location", "https://10.0.0.6:25555/tasks/42")
+				w.WriteHeader(http.StatusFound)
+			case "/tasks/42":
+				state := taskStates[0]
+				if len(taskStates) > 1 {
+					taskStates = taskStates[1:]
+				}
+				w.Write([]byte(fmt.Sprintf(`{"id": 42, "state": %q, "result": "some-result"}`, state)))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				Expect(err).NotTo(HaveOccurred())
@@ -215,4 +278,228 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("UpdateConfig", func() {
+		BeforeEach(func() {
+			dialer := &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+		})
+
+		It("uses UAA to get a token in order to upload the named config", func() {
+			fakeBOSH.StartTLS()
+
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			err := client.UpdateConfig(bosh.RuntimeConfigType, "dns", []byte("runtime: config"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(token).To(Equal("Bearer some-uaa-token"))
+			Expect(configBody).To(MatchJSON(`{
+				"id": "",
+				"name": "dns",
+				"type": "runtime",
+				"content": "runtime: config"
+			}`))
+		})
+
+		Context("when the response is not StatusCreated", func() {
+			BeforeEach(func() {
+				failStatus = http.StatusBadRequest
+			})
+
+			It("returns an error", func() {
+				fakeBOSH.StartTLS()
+
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				err := client.UpdateConfig(bosh.CPIConfigType, "default", []byte("cpi: config"))
+				Expect(err).To(MatchError("unexpected http response 400 Bad Request"))
+			})
+		})
+	})
+
+	Describe("DeleteConfig", func() {
+		BeforeEach(func() {
+			dialer := &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+		})
+
+		It("deletes the named config", func() {
+			fakeBOSH.StartTLS()
+
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			err := client.DeleteConfig(bosh.RuntimeConfigType, "dns")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(token).To(Equal("Bearer some-uaa-token"))
+			Expect(configQuery.Get("type")).To(Equal("runtime"))
+			Expect(configQuery.Get("name")).To(Equal("dns"))
+		})
+
+		Context("when the response is not StatusNoContent", func() {
+			BeforeEach(func() {
+				failStatus = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				fakeBOSH.StartTLS()
+
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				err := client.DeleteConfig(bosh.RuntimeConfigType, "dns")
+				Expect(err).To(MatchError("unexpected http response 500 Internal Server Error"))
+			})
+		})
+	})
+
+	Describe("Configs", func() {
+		BeforeEach(func() {
+			dialer := &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+		})
+
+		It("returns the latest configs of the given type", func() {
+			fakeBOSH.StartTLS()
+
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			configs, err := client.Configs(bosh.RuntimeConfigType)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(configQuery.Get("type")).To(Equal("runtime"))
+			Expect(configQuery.Get("latest")).To(Equal("true"))
+			Expect(configs).To(Equal([]bosh.Config{{
+				ID:      "1",
+				Name:    "dns",
+				Type:    "runtime",
+				Content: "some: runtime-config",
+			}}))
+		})
+
+		Context("when the response is not StatusOK", func() {
+			BeforeEach(func() {
+				failStatus = http.StatusUnauthorized
+			})
+
+			It("returns an error", func() {
+				fakeBOSH.StartTLS()
+
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				_, err := client.Configs(bosh.RuntimeConfigType)
+				Expect(err).To(MatchError("unexpected http response 401 Unauthorized"))
+			})
+		})
+	})
+
+	Describe("deployments and orphaned disks", func() {
+		BeforeEach(func() {
+			dialer := &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+		})
+
+		It("lists the deployments and orphaned disks", func() {
+			fakeBOSH.StartTLS()
+
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			deployments, err := client.Deployments()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployments).To(Equal([]string{"cf", "concourse"}))
+
+			disks, err := client.OrphanedDisks()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disks).To(Equal([]string{"some-disk-cid"}))
+			Expect(token).To(Equal("Bearer some-uaa-token"))
+		})
+
+		It("deletes them and waits for the tasks to finish", func() {
+			fakeBOSH.StartTLS()
+
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			err := client.DeleteDeployment("cf")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(taskStates).To(Equal([]string{"done"}))
+
+			err = client.DeleteOrphanedDisk("some-disk-cid")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted).To(Equal([]string{"/deployments/cf?force=true", "/disks/some-disk-cid"}))
+		})
+
+		Context("when the task fails", func() {
+			BeforeEach(func() {
+				taskStates = []string{"error"}
+			})
+
+			It("returns an error", func() {
+				fakeBOSH.StartTLS()
+
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				err := client.DeleteDeployment("cf")
+				Expect(err).To(MatchError("task 42 error: some-result"))
+			})
+		})
+
+		Context("when the director does not start a task", func() {
+			BeforeEach(func() {
+				failStatus = http.StatusNotFound
+			})
+
+			It("returns an error", func() {
+				fakeBOSH.StartTLS()
+
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				err := client.DeleteOrphanedDisk("some-disk-cid")
+				Expect(err).To(MatchError("unexpected http response 404 Not Found"))
+
+				_, err = client.Deployments()
+				Expect(err).To(MatchError("unexpected http response 404 Not Found"))
+			})
+		})
+	})
 })
@@ -0,0 +1,222 @@
+package bosh
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// parseCreateEnvArgs returns the arguments of the bosh create-env command in
+// a create-env script, with the variables expanded by lookup. It follows the
+// shell's rules for quotes, escapes, comments and continuation lines, and
+// stops at the end of the create-env command. It returns an error for the
+// constructs it cannot evaluate, such as command substitution or
+// redirections, rather than guessing at their result.
+func parseCreateEnvArgs(script string, lookup func(string) string) ([]string, error) {
+	p := shellParser{script: script, lookup: lookup}
+	return p.createEnvArgs()
+}
+
+type shellParser struct {
+	script string
+	lookup func(string) string
+
+	words  []string
+	word   strings.Builder
+	inWord bool
+
+	// unsupported is the first construct in the current command that the
+	// parser cannot evaluate.
+	unsupported string
+}
+
+func (p *shellParser) createEnvArgs() ([]string, error) {
+	s := p.script
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			if i+1 == len(s) {
+				p.write("\\")
+				continue
+			}
+			i++
+			if s[i] != '\n' {
+				p.write(s[i : i+1])
+			}
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end == -1 {
+				return nil, errors.New("unterminated single quote")
+			}
+			p.write(s[i+1 : i+1+end])
+			i += end + 1
+		case c == '"':
+			end, err := p.doubleQuoted(i + 1)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case c == '$':
+			i = p.expand(i, false)
+		case c == '`':
+			p.unsupport("command substitution with `")
+		case c == '#' && !p.inWord:
+			for i+1 < len(s) && s[i+1] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t':
+			p.endWord()
+		case c == '\n' || c == ';' || c == '&' || c == '|':
+			args, found, err := p.endCommand()
+			if found || err != nil {
+				return args, err
+			}
+		case c == '<' || c == '>' || c == '(' || c == ')':
+			p.unsupport(fmt.Sprintf("%q", c))
+		default:
+			p.write(s[i : i+1])
+		}
+	}
+
+	args, found, err := p.endCommand()
+	if found || err != nil {
+		return args, err
+	}
+	return nil, errors.New("no bosh create-env command found")
+}
+
+// doubleQuoted reads a double quoted string that starts at i and returns
+// the index of its closing quote.
+func (p *shellParser) doubleQuoted(i int) (int, error) {
+	s := p.script
+	p.inWord = true
+	for ; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return i, nil
+		case '\\':
+			if i+1 < len(s) && strings.IndexByte("$`\"\\\n", s[i+1]) != -1 {
+				i++
+				if s[i] != '\n' {
+					p.write(s[i : i+1])
+				}
+				continue
+			}
+			p.write("\\")
+		case '$':
+			i = p.expand(i, true)
+		case '`':
+			p.unsupport("command substitution with `")
+		default:
+			p.write(s[i : i+1])
+		}
+	}
+	return 0, errors.New("unterminated double quote")
+}
+
+// expand writes the value of the variable that starts with the $ at i and
+// returns the index of its last character.
+func (p *shellParser) expand(i int, quoted bool) int {
+	s := p.script
+	if quoted {
+		p.inWord = true
+	}
+
+	if i+1 == len(s) {
+		p.write("$")
+		return i
+	}
+
+	switch c := s[i+1]; {
+	case c == '{':
+		end := strings.IndexByte(s[i+2:], '}')
+		if end == -1 {
+			p.unsupport("unterminated ${")
+			return len(s) - 1
+		}
+		name := s[i+2 : i+2+end]
+		if !isShellName(name) {
+			p.unsupport(fmt.Sprintf("${%s}", name))
+		} else {
+			p.writeValue(p.lookup(name))
+		}
+		return i + 2 + end
+	case c == '(':
+		p.unsupport("command substitution with $(")
+		return i + 1
+	case isShellNameStart(c):
+		end := i + 1
+		for end+1 < len(s) && isShellNameChar(s[end+1]) {
+			end++
+		}
+		p.writeValue(p.lookup(s[i+1 : end+1]))
+		return end
+	}
+
+	p.write("$")
+	return i
+}
+
+func (p *shellParser) write(text string) {
+	p.word.WriteString(text)
+	p.inWord = true
+}
+
+// writeValue writes the value of an expansion, which on its own does not
+// make a word when it is empty and unquoted.
+func (p *shellParser) writeValue(value string) {
+	p.word.WriteString(value)
+	if value != "" {
+		p.inWord = true
+	}
+}
+
+func (p *shellParser) unsupport(construct string) {
+	if p.unsupported == "" {
+		p.unsupported = construct
+	}
+}
+
+func (p *shellParser) endWord() {
+	if p.inWord {
+		p.words = append(p.words, p.word.String())
+	}
+	p.word.Reset()
+	p.inWord = false
+}
+
+func (p *shellParser) endCommand() ([]string, bool, error) {
+	p.endWord()
+	words, unsupported := p.words, p.unsupported
+	p.words, p.unsupported = nil, ""
+
+	for i, word := range words {
+		if word == "create-env" {
+			if unsupported != "" {
+				return nil, true, fmt.Errorf("the create-env command uses %s, which bbl cannot evaluate", unsupported)
+			}
+			return words[i+1:], true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func isShellName(name string) bool {
+	if name == "" || !isShellNameStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isShellNameChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isShellNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isShellNameChar(c byte) bool {
+	return isShellNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,90 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseCreateEnvArgs", func() {
+	var env map[string]string
+
+	BeforeEach(func() {
+		env = map[string]string{
+			"BBL_STATE_DIR": "/some/state dir",
+			"SECRET":        "some-secret",
+		}
+	})
+
+	It("returns the expanded words after create-env", func() {
+		script := "#!/bin/sh\nbosh create-env ${BBL_STATE_DIR}/jumpbox.yml -v secret=\"$SECRET\"\n"
+
+		args, err := bosh.ParseCreateEnvArgs(script, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal([]string{"/some/state dir/jumpbox.yml", "-v", "secret=some-secret"}))
+	})
+
+	It("follows continuation lines and stops at the end of the command", func() {
+		script := "#!/bin/sh\nset -e\nbosh create-env \\\n  a.yml \\\n  -o b.yml # the ops file\necho done\n"
+
+		args, err := bosh.ParseCreateEnvArgs(script, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal([]string{"a.yml", "-o", "b.yml"}))
+	})
+
+	It("stops at command separators", func() {
+		args, err := bosh.ParseCreateEnvArgs("bosh create-env a.yml && echo done; echo again", env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal([]string{"a.yml"}))
+	})
+
+	It("keeps quoted text together and only expands outside single quotes", func() {
+		script := `bosh create-env "my manifest.yml" -v 'literal=$SECRET' -v escaped=\$SECRET -v quote="a \"b\"" -v empty=''`
+
+		args, err := bosh.ParseCreateEnvArgs(script, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal([]string{
+			"my manifest.yml",
+			"-v", "literal=$SECRET",
+			"-v", "escaped=$SECRET",
+			"-v", `quote=a "b"`,
+			"-v", "empty=",
+		}))
+	})
+
+	It("ignores constructs outside the create-env command", func() {
+		script := "#!/bin/sh\nexport NOW=$(date)\nbosh create-env a.yml\n"
+
+		args, err := bosh.ParseCreateEnvArgs(script, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal([]string{"a.yml"}))
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when there is no create-env command", func() {
+			_, err := bosh.ParseCreateEnvArgs("#!/bin/sh\necho hi\n", env)
+			Expect(err).To(MatchError("no bosh create-env command found"))
+		})
+
+		It("returns an error for command substitution", func() {
+			_, err := bosh.ParseCreateEnvArgs("bosh create-env a.yml -v now=$(date)", env)
+			Expect(err).To(MatchError("the create-env command uses command substitution with $(, which bbl cannot evaluate"))
+		})
+
+		It("returns an error for parameter expansions with operators", func() {
+			_, err := bosh.ParseCreateEnvArgs("bosh create-env a.yml -v name=${NAME:-default}", env)
+			Expect(err).To(MatchError("the create-env command uses ${NAME:-default}, which bbl cannot evaluate"))
+		})
+
+		It("returns an error for redirections", func() {
+			_, err := bosh.ParseCreateEnvArgs("bosh create-env a.yml > create-env.log", env)
+			Expect(err).To(MatchError("the create-env command uses '>', which bbl cannot evaluate"))
+		})
+
+		It("returns an error for unterminated quotes", func() {
+			_, err := bosh.ParseCreateEnvArgs("bosh create-env \"a.yml", env)
+			Expect(err).To(MatchError("unterminated double quote"))
+		})
+	})
+})
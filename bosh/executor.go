@@ -13,23 +13,30 @@ import (
 
 	"github.com/cloudfoundry/bosh-bootloader/fileio"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	yaml "gopkg.in/yaml.v2"
 )
 
 type executorFs interface {
 	fileio.FileReader
 	fileio.FileWriter
 	fileio.Stater
+	fileio.DirReader
+	fileio.TempDirer
+	fileio.AllRemover
 }
 
 type Executor struct {
-	cli cli
-	fs  executorFs
+	cli    cli
+	fs     executorFs
+	logger exitLogger
 }
 
 type DirInput struct {
 	StateDir   string
 	VarsDir    string
 	Deployment string
+	SourceDir  string
+	Sizing     storage.Sizing
 }
 
 type cli interface {
@@ -48,11 +55,98 @@ var (
 	boshDeploymentRepo    = "vendor/github.com/cloudfoundry/bosh-deployment"
 )
 
-func NewExecutor(cmd cli, fs executorFs) Executor {
+var gitRevParse = revParseHEAD
+
+func revParseHEAD(dir string) (string, error) {
+	output, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	return strings.TrimSpace(string(output)), err
+}
+
+func NewExecutor(cmd cli, fs executorFs, logger exitLogger) Executor {
 	return Executor{
-		cli: cmd,
-		fs:  fs,
+		cli:    cmd,
+		fs:     fs,
+		logger: logger,
+	}
+}
+
+// SourceCommit returns the git commit checked out in a deployment source
+// directory, or an empty string when it is not a git repository.
+func (e Executor) SourceCommit(dir string) string {
+	commit, err := gitRevParse(dir)
+	if err != nil {
+		return ""
 	}
+	return commit
+}
+
+func (e Executor) getSourceFiles(sourceDir, destPath string) ([]setupFile, error) {
+	files := []setupFile{}
+
+	infos, err := e.fs.ReadDir(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		source := filepath.Join(sourceDir, info.Name())
+		dest := filepath.Join(destPath, info.Name())
+
+		if info.IsDir() {
+			if strings.HasPrefix(info.Name(), ".") {
+				continue
+			}
+
+			nested, err := e.getSourceFiles(source, dest)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, nested...)
+			continue
+		}
+
+		contents, err := e.fs.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, setupFile{
+			source:   source,
+			dest:     dest,
+			contents: contents,
+		})
+	}
+
+	return files, nil
+}
+
+func (e Executor) validateSource(sourceDir, deploymentDir string, required []string) error {
+	for _, path := range required {
+		if !strings.HasPrefix(path, deploymentDir) {
+			continue
+		}
+
+		relativePath := strings.TrimPrefix(path, deploymentDir+string(filepath.Separator))
+		if !e.exists(filepath.Join(sourceDir, relativePath)) {
+			return fmt.Errorf("%s is missing %s", sourceDir, relativePath)
+		}
+	}
+	return nil
+}
+
+// removeStaleSourceFiles empties the deployment dir before the files of an
+// external deployment source are copied into it, so that files removed from
+// the source are not picked up from an earlier copy.
+func (e Executor) removeStaleSourceFiles(input DirInput, deploymentDir string) error {
+	if input.SourceDir == "" {
+		return nil
+	}
+
+	err := e.fs.RemoveAll(deploymentDir)
+	if err != nil {
+		return fmt.Errorf("remove the previous copy of the deployment source: %s", err)
+	}
+	return nil
 }
 
 func (e Executor) getSetupFiles(sourcePath, destPath string) []setupFile {
@@ -71,8 +165,59 @@ func (e Executor) getSetupFiles(sourcePath, destPath string) []setupFile {
 	return files
 }
 
+func (e Executor) getJumpboxSetupFiles(input DirInput, deploymentDir, iaas string) ([]setupFile, error) {
+	files, err := e.getJumpboxSourceFiles(input, deploymentDir, iaas)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasJumpboxSizing(input.Sizing) {
+		files = append(files, setupFile{
+			source:   filepath.Join(jumpboxDeploymentRepo, iaas, "jumpbox-sizing-ops.yml"),
+			dest:     jumpboxSizingOpsPath(input.StateDir, iaas),
+			contents: []byte(JumpboxSizingOps(iaas, input.Sizing)),
+		})
+	}
+
+	return files, nil
+}
+
+func (e Executor) getJumpboxSourceFiles(input DirInput, deploymentDir, iaas string) ([]setupFile, error) {
+	if input.SourceDir == "" {
+		return e.getSetupFiles(jumpboxDeploymentRepo, deploymentDir), nil
+	}
+
+	required := []string{
+		filepath.Join(deploymentDir, "jumpbox.yml"),
+		filepath.Join(deploymentDir, iaas, "cpi.yml"),
+	}
+	if iaas == "vsphere" {
+		required = append(required, filepath.Join(deploymentDir, "vsphere", "resource-pool.yml"))
+	}
+
+	err := e.validateSource(input.SourceDir, deploymentDir, required)
+	if err != nil {
+		return nil, fmt.Errorf("Jumpbox deployment source: %s", err)
+	}
+
+	files, err := e.getSourceFiles(input.SourceDir, deploymentDir)
+	if err != nil {
+		return nil, fmt.Errorf("Jumpbox read deployment source: %s", err)
+	}
+
+	return files, nil
+}
+
 func (e Executor) PlanJumpbox(input DirInput, deploymentDir, iaas string) error {
-	setupFiles := e.getSetupFiles(jumpboxDeploymentRepo, deploymentDir)
+	setupFiles, err := e.getJumpboxSetupFiles(input, deploymentDir, iaas)
+	if err != nil {
+		return err
+	}
+
+	err = e.removeStaleSourceFiles(input, deploymentDir)
+	if err != nil {
+		return fmt.Errorf("Jumpbox %s", err)
+	}
 
 	for _, f := range setupFiles {
 		os.MkdirAll(filepath.Dir(f.dest), os.ModePerm)
@@ -105,6 +250,10 @@ func (e Executor) PlanJumpbox(input DirInput, deploymentDir, iaas string) error
 		}
 	}
 
+	if hasJumpboxSizing(input.Sizing) {
+		sharedArgs = append(sharedArgs, "-o", jumpboxSizingOpsPath(input.StateDir, iaas))
+	}
+
 	jumpboxState := filepath.Join(input.VarsDir, "jumpbox-state.json")
 
 	boshArgs := append([]string{filepath.Join(deploymentDir, "jumpbox.yml"), "--state", jumpboxState}, sharedArgs...)
@@ -144,7 +293,7 @@ func (e Executor) PlanJumpbox(input DirInput, deploymentDir, iaas string) error
 
 	createEnvCmd := []byte(formatScript(boshPath, input.StateDir, "create-env", boshArgs))
 	createJumpboxScript := filepath.Join(input.StateDir, "create-jumpbox.sh")
-	err := e.fs.WriteFile(createJumpboxScript, createEnvCmd, 0750)
+	err = e.fs.WriteFile(createJumpboxScript, createEnvCmd, 0750)
 	if err != nil {
 		return err
 	}
@@ -159,8 +308,24 @@ func (e Executor) PlanJumpbox(input DirInput, deploymentDir, iaas string) error
 	return nil
 }
 
-func (e Executor) getDirectorSetupFiles(stateDir, deploymentDir, iaas string) []setupFile {
-	files := e.getSetupFiles(boshDeploymentRepo, deploymentDir)
+func (e Executor) getDirectorSetupFiles(input DirInput, deploymentDir, iaas string) ([]setupFile, error) {
+	stateDir := input.StateDir
+
+	files := []setupFile{}
+	if input.SourceDir == "" {
+		files = e.getSetupFiles(boshDeploymentRepo, deploymentDir)
+	} else {
+		required := append([]string{filepath.Join(deploymentDir, "bosh.yml")}, e.getDirectorOpsFiles(stateDir, deploymentDir, iaas)...)
+		err := e.validateSource(input.SourceDir, deploymentDir, required)
+		if err != nil {
+			return nil, fmt.Errorf("Director deployment source: %s", err)
+		}
+
+		files, err = e.getSourceFiles(input.SourceDir, deploymentDir)
+		if err != nil {
+			return nil, fmt.Errorf("Director read deployment source: %s", err)
+		}
+	}
 
 	statePath := filepath.Join(stateDir, "bbl-ops-files", iaas)
 	assetPath := filepath.Join(boshDeploymentRepo, iaas)
@@ -179,7 +344,15 @@ func (e Executor) getDirectorSetupFiles(stateDir, deploymentDir, iaas string) []
 		})
 	}
 
-	return files
+	if hasDirectorSizing(input.Sizing) {
+		files = append(files, setupFile{
+			source:   filepath.Join(assetPath, "director-sizing-ops.yml"),
+			dest:     directorSizingOpsPath(stateDir, iaas),
+			contents: []byte(DirectorSizingOps(iaas, input.Sizing)),
+		})
+	}
+
+	return files, nil
 }
 
 func (e Executor) getDirectorOpsFiles(stateDir, deploymentDir, iaas string) []string {
@@ -202,7 +375,15 @@ func (e Executor) getDirectorOpsFiles(stateDir, deploymentDir, iaas string) []st
 }
 
 func (e Executor) PlanDirector(input DirInput, deploymentDir, iaas string) error {
-	setupFiles := e.getDirectorSetupFiles(input.StateDir, deploymentDir, iaas)
+	setupFiles, err := e.getDirectorSetupFiles(input, deploymentDir, iaas)
+	if err != nil {
+		return err
+	}
+
+	err = e.removeStaleSourceFiles(input, deploymentDir)
+	if err != nil {
+		return fmt.Errorf("Director %s", err)
+	}
 
 	for _, f := range setupFiles {
 		if f.source != "" {
@@ -222,6 +403,10 @@ func (e Executor) PlanDirector(input DirInput, deploymentDir, iaas string) error
 		sharedArgs = append(sharedArgs, "-o", f)
 	}
 
+	if hasDirectorSizing(input.Sizing) {
+		sharedArgs = append(sharedArgs, "-o", directorSizingOpsPath(input.StateDir, iaas))
+	}
+
 	boshState := filepath.Join(input.VarsDir, "bosh-state.json")
 
 	boshArgs := append([]string{filepath.Join(deploymentDir, "bosh.yml"), "--state", boshState}, sharedArgs...)
@@ -260,7 +445,7 @@ func (e Executor) PlanDirector(input DirInput, deploymentDir, iaas string) error
 	boshPath := e.cli.GetBOSHPath()
 
 	createEnvCmd := []byte(formatScript(boshPath, input.StateDir, "create-env", boshArgs))
-	err := e.fs.WriteFile(filepath.Join(input.StateDir, "create-director.sh"), createEnvCmd, 0750)
+	err = e.fs.WriteFile(filepath.Join(input.StateDir, "create-director.sh"), createEnvCmd, 0750)
 	if err != nil {
 		return err
 	}
@@ -304,25 +489,8 @@ func (e Executor) CreateEnv(input DirInput, state storage.State) (string, error)
 		createEnvScript = strings.Replace(createEnvScript, "-override", "", -1)
 	}
 
-	switch state.IAAS {
-	case "aws":
-		os.Setenv("BBL_AWS_ACCESS_KEY_ID", state.AWS.AccessKeyID)
-		os.Setenv("BBL_AWS_SECRET_ACCESS_KEY", state.AWS.SecretAccessKey)
-	case "azure":
-		os.Setenv("BBL_AZURE_CLIENT_ID", state.Azure.ClientID)
-		os.Setenv("BBL_AZURE_CLIENT_SECRET", state.Azure.ClientSecret)
-		os.Setenv("BBL_AZURE_SUBSCRIPTION_ID", state.Azure.SubscriptionID)
-		os.Setenv("BBL_AZURE_TENANT_ID", state.Azure.TenantID)
-	case "gcp":
-		os.Setenv("BBL_GCP_SERVICE_ACCOUNT_KEY_PATH", state.GCP.ServiceAccountKeyPath)
-		os.Setenv("BBL_GCP_ZONE", state.GCP.Zone)
-		os.Setenv("BBL_GCP_PROJECT_ID", state.GCP.ProjectID)
-	case "vsphere":
-		os.Setenv("BBL_VSPHERE_VCENTER_USER", state.VSphere.VCenterUser)
-		os.Setenv("BBL_VSPHERE_VCENTER_PASSWORD", state.VSphere.VCenterPassword)
-	case "openstack":
-		os.Setenv("BBL_OPENSTACK_USERNAME", state.OpenStack.Username)
-		os.Setenv("BBL_OPENSTACK_PASSWORD", state.OpenStack.Password)
+	for name, value := range storage.CredentialEnv(state) {
+		os.Setenv(name, value)
 	}
 
 	cmd := exec.Command(createEnvScript)
@@ -330,6 +498,7 @@ func (e Executor) CreateEnv(input DirInput, state storage.State) (string, error)
 	cmd.Stderr = os.Stderr
 
 	err = cmd.Run()
+	e.logger.ChildExited("bosh create-env", exitCode(cmd))
 	if err != nil {
 		return "", fmt.Errorf("Running %s: %s", createEnvScript, err)
 	}
@@ -340,6 +509,157 @@ func (e Executor) CreateEnv(input DirInput, state storage.State) (string, error)
 	return string(contents), nil
 }
 
+// Interpolate renders the manifest that the create-env script for
+// input.Deployment would deploy, honouring a create-<deployment>-override.sh
+// script when one exists. When redact is true the vars store, any
+// credentials passed with -v or --var-file and the sensitive keys of the
+// vars files are left out, so secrets remain as ((variables)) in the result.
+func (e Executor) Interpolate(input DirInput, state storage.State, redact bool) (string, error) {
+	createEnvScript := filepath.Join(input.StateDir, fmt.Sprintf("create-%s-override.sh", input.Deployment))
+	_, err := e.fs.Stat(createEnvScript)
+	if err != nil {
+		createEnvScript = strings.Replace(createEnvScript, "-override", "", -1)
+	}
+
+	contents, err := e.fs.ReadFile(createEnvScript)
+	if err != nil {
+		return "", fmt.Errorf("Read %s: %s", createEnvScript, err)
+	}
+
+	env := scriptEnv(input.StateDir, state)
+	lookup := func(key string) string {
+		if value, ok := env[key]; ok {
+			return value
+		}
+		return os.Getenv(key)
+	}
+
+	createEnvArgs, err := parseCreateEnvArgs(string(contents), lookup)
+	if err != nil {
+		return "", fmt.Errorf("Parse %s: %s", createEnvScript, err)
+	}
+
+	var (
+		redactedDir   string
+		redactedCount int
+	)
+	if redact {
+		redactedDir, err = e.fs.TempDir("", "bbl-redacted-vars")
+		if err != nil {
+			return "", fmt.Errorf("Create temp dir: %s", err)
+		}
+		defer e.fs.RemoveAll(redactedDir)
+	}
+
+	args := []string{"interpolate"}
+	for i := 0; i < len(createEnvArgs); i++ {
+		arg := createEnvArgs[i]
+		if !strings.HasPrefix(arg, "-") {
+			args = append(args, arg)
+			continue
+		}
+
+		flag, value := arg, ""
+		if parts := strings.SplitN(arg, "=", 2); len(parts) == 2 {
+			flag, value = parts[0], parts[1]
+		} else if booleanCreateEnvFlags[flag] {
+			continue
+		} else if i+1 < len(createEnvArgs) {
+			i++
+			value = createEnvArgs[i]
+		}
+
+		switch flag {
+		case "--state":
+		case "--vars-store":
+			if !redact && e.exists(value) {
+				args = append(args, "--vars-file", value)
+			}
+		case "--vars-file", "-l":
+			if !e.exists(value) {
+				continue
+			}
+			if redact {
+				value, err = e.redactVarsFile(value, redactedDir, redactedCount)
+				if err != nil {
+					return "", err
+				}
+				redactedCount++
+			}
+			args = append(args, flag, value)
+		case "-v", "--var", "--var-file":
+			if !redact {
+				args = append(args, flag, value)
+			}
+		default:
+			args = append(args, flag, value)
+		}
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	err = e.cli.Run(buf, input.StateDir, args)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+var sensitiveVarName = regexp.MustCompile(`(?i)private_key|secret|password|credential|token`)
+
+// redactVarsFile writes a copy of the vars file at path without the keys
+// that hold credentials, such as the private_key terraform output. The copy
+// is prefixed with index, so vars files with the same name do not overwrite
+// each other.
+func (e Executor) redactVarsFile(path, dir string, index int) (string, error) {
+	contents, err := e.fs.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Read %s: %s", path, err)
+	}
+
+	vars := yaml.MapSlice{}
+	err = yaml.Unmarshal(contents, &vars)
+	if err != nil {
+		return "", fmt.Errorf("Parse %s: %s", path, err)
+	}
+
+	redacted := yaml.MapSlice{}
+	for _, item := range vars {
+		if name, ok := item.Key.(string); ok && sensitiveVarName.MatchString(name) {
+			continue
+		}
+		redacted = append(redacted, item)
+	}
+
+	redactedPath := filepath.Join(dir, fmt.Sprintf("%d-%s", index, filepath.Base(path)))
+	err = e.fs.WriteFile(redactedPath, mustMarshal(redacted), storage.StateMode)
+	if err != nil {
+		return "", fmt.Errorf("Write %s: %s", redactedPath, err)
+	}
+
+	return redactedPath, nil
+}
+
+var booleanCreateEnvFlags = map[string]bool{
+	"--recreate":        true,
+	"--skip-drain":      true,
+	"-n":                true,
+	"--non-interactive": true,
+	"--tty":             true,
+}
+
+func scriptEnv(stateDir string, state storage.State) map[string]string {
+	env := storage.CredentialEnv(state)
+	env["BBL_STATE_DIR"] = stateDir
+
+	return env
+}
+
+func (e Executor) exists(path string) bool {
+	_, err := e.fs.Stat(path)
+	return err == nil
+}
+
 func (e Executor) DeleteEnv(input DirInput, state storage.State) error {
 	isDeletable, err := e.deploymentExists(input.VarsDir, input.Deployment)
 	if err != nil {
@@ -357,22 +677,8 @@ func (e Executor) DeleteEnv(input DirInput, state storage.State) error {
 		deleteEnvScript = strings.Replace(deleteEnvScript, "-override", "", -1)
 	}
 
-	switch state.IAAS {
-	case "aws":
-		os.Setenv("BBL_AWS_ACCESS_KEY_ID", state.AWS.AccessKeyID)
-		os.Setenv("BBL_AWS_SECRET_ACCESS_KEY", state.AWS.SecretAccessKey)
-	case "azure":
-		os.Setenv("BBL_AZURE_CLIENT_ID", state.Azure.ClientID)
-		os.Setenv("BBL_AZURE_CLIENT_SECRET", state.Azure.ClientSecret)
-		os.Setenv("BBL_AZURE_SUBSCRIPTION_ID", state.Azure.SubscriptionID)
-		os.Setenv("BBL_AZURE_TENANT_ID", state.Azure.TenantID)
-	case "gcp":
-		os.Setenv("BBL_GCP_SERVICE_ACCOUNT_KEY_PATH", state.GCP.ServiceAccountKeyPath)
-		os.Setenv("BBL_GCP_ZONE", state.GCP.Zone)
-		os.Setenv("BBL_GCP_PROJECT_ID", state.GCP.ProjectID)
-	case "vsphere":
-		os.Setenv("BBL_VSPHERE_VCENTER_USER", state.VSphere.VCenterUser)
-		os.Setenv("BBL_VSPHERE_VCENTER_PASSWORD", state.VSphere.VCenterPassword)
+	for name, value := range storage.CredentialEnv(state) {
+		os.Setenv(name, value)
 	}
 
 	cmd := exec.Command(deleteEnvScript)
@@ -380,6 +686,7 @@ func (e Executor) DeleteEnv(input DirInput, state storage.State) error {
 	cmd.Stderr = os.Stderr
 
 	err = cmd.Run()
+	e.logger.ChildExited("bosh delete-env", exitCode(cmd))
 	if err != nil {
 		return fmt.Errorf("Run bosh delete-env %s: %s", input.Deployment, err)
 	}
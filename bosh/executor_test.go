@@ -21,6 +21,7 @@ var _ = Describe("Executor", func() {
 	var (
 		fs                    *afero.Afero
 		cli                   *fakes.BOSHCLI
+		logger                *fakes.Logger
 		stateDir              string
 		deploymentDir         string
 		varsDir               string
@@ -40,6 +41,7 @@ var _ = Describe("Executor", func() {
 			return nil
 		}
 		cli.GetBOSHPathCall.Returns.Path = "bosh-path"
+		logger = &fakes.Logger{}
 
 		var err error
 		stateDir, err = fs.TempDir("", "")
@@ -62,7 +64,7 @@ var _ = Describe("Executor", func() {
 			StateDir: stateDir,
 		}
 
-		executor = bosh.NewExecutor(cli, fs)
+		executor = bosh.NewExecutor(cli, fs, logger)
 	})
 
 	Describe("PlanJumpbox", func() {
@@ -466,7 +468,7 @@ var _ = Describe("Executor", func() {
 			stateDir, err = fs.TempDir("", "")
 			Expect(err).NotTo(HaveOccurred())
 
-			executor = bosh.NewExecutor(cli, fs)
+			executor = bosh.NewExecutor(cli, fs, logger)
 
 			dirInput = bosh.DirInput{
 				Deployment: "some-deployment",
@@ -621,6 +623,9 @@ var _ = Describe("Executor", func() {
 				vars, err := executor.CreateEnv(dirInput, state)
 				Expect(err).To(MatchError(fmt.Sprintf("Running %s: exit status 1", createEnvPath)))
 				Expect(vars).To(Equal(""))
+
+				Expect(logger.ChildExitedCall.Receives.Command).To(Equal("bosh create-env"))
+				Expect(logger.ChildExitedCall.Receives.ExitCode).To(Equal(1))
 			})
 		})
 	})
@@ -646,7 +651,7 @@ var _ = Describe("Executor", func() {
 			stateDir, err = fs.TempDir("", "")
 			Expect(err).NotTo(HaveOccurred())
 
-			executor = bosh.NewExecutor(cli, fs)
+			executor = bosh.NewExecutor(cli, fs, logger)
 
 			dirInput = bosh.DirInput{
 				Deployment: "director",
@@ -841,6 +846,332 @@ var _ = Describe("Executor", func() {
 		})
 	})
 
+	Describe("PlanJumpbox with a deployment source", func() {
+		var sourceDir string
+
+		BeforeEach(func() {
+			sourceDir = filepath.Join(stateDir, "jumpbox-deployment-checkout")
+			dirInput.SourceDir = sourceDir
+
+			Expect(fs.WriteFile(filepath.Join(sourceDir, "jumpbox.yml"), []byte("some-jumpbox-manifest"), storage.StateMode)).To(Succeed())
+			Expect(fs.WriteFile(filepath.Join(sourceDir, "aws", "cpi.yml"), []byte("some-cpi-ops"), storage.StateMode)).To(Succeed())
+			Expect(fs.WriteFile(filepath.Join(sourceDir, ".git", "HEAD"), []byte("some-ref"), storage.StateMode)).To(Succeed())
+		})
+
+		It("copies the checkout into the deployment dir instead of the embedded files", func() {
+			err := executor.PlanJumpbox(dirInput, deploymentDir, "aws")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := fs.ReadFile(filepath.Join(deploymentDir, "jumpbox.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-jumpbox-manifest"))
+
+			contents, err = fs.ReadFile(filepath.Join(deploymentDir, "aws", "cpi.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-cpi-ops"))
+
+			_, err = fs.Stat(filepath.Join(deploymentDir, ".git", "HEAD"))
+			Expect(err).To(HaveOccurred())
+			_, err = fs.Stat(filepath.Join(deploymentDir, "gcp", "cpi.yml"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error when a referenced file is missing", func() {
+			err := executor.PlanJumpbox(dirInput, deploymentDir, "gcp")
+			Expect(err).To(MatchError(fmt.Sprintf("Jumpbox deployment source: %s is missing %s", sourceDir, filepath.Join("gcp", "cpi.yml"))))
+		})
+	})
+
+	Describe("PlanDirector with a deployment source", func() {
+		var sourceDir string
+
+		BeforeEach(func() {
+			sourceDir = filepath.Join(stateDir, "bosh-deployment-checkout")
+			dirInput.SourceDir = sourceDir
+
+			for _, file := range []string{"bosh.yml", "jumpbox-user.yml", "uaa.yml", "credhub.yml", filepath.Join("gcp", "cpi.yml")} {
+				Expect(fs.WriteFile(filepath.Join(sourceDir, file), []byte("some-"+file), storage.StateMode)).To(Succeed())
+			}
+		})
+
+		It("copies the checkout into the deployment dir", func() {
+			err := executor.PlanDirector(dirInput, deploymentDir, "gcp")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := fs.ReadFile(filepath.Join(deploymentDir, "bosh.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-bosh.yml"))
+
+			_, err = fs.Stat(filepath.Join(stateDir, "bbl-ops-files", "gcp", "bosh-director-ephemeral-ip-ops.yml"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes files of an earlier copy that are no longer in the checkout", func() {
+			Expect(fs.WriteFile(filepath.Join(deploymentDir, "removed-ops.yml"), []byte("stale"), storage.StateMode)).To(Succeed())
+
+			err := executor.PlanDirector(dirInput, deploymentDir, "gcp")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Stat(filepath.Join(deploymentDir, "removed-ops.yml"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("returns an error when a referenced ops file is missing", func() {
+			Expect(fs.Remove(filepath.Join(sourceDir, "jumpbox-user.yml"))).To(Succeed())
+
+			err := executor.PlanDirector(dirInput, deploymentDir, "gcp")
+			Expect(err).To(MatchError(fmt.Sprintf("Director deployment source: %s is missing jumpbox-user.yml", sourceDir)))
+		})
+	})
+
+	Describe("PlanDirector with sizing", func() {
+		BeforeEach(func() {
+			dirInput.Sizing = storage.Sizing{
+				DirectorVMType:   "n1-standard-4",
+				DirectorDiskSize: 100,
+				StemcellLine:     "ubuntu-xenial",
+				StemcellVersion:  "97.28",
+				StemcellSHA1:     "some-stemcell-sha1",
+			}
+		})
+
+		It("writes a sizing ops file and passes it to create-env", func() {
+			err := executor.PlanDirector(dirInput, deploymentDir, "gcp")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "gcp", "director-sizing-ops.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal(`---
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/machine_type
+  value: n1-standard-4
+- type: replace
+  path: /disk_pools/name=disks/disk_size
+  value: 102400
+- type: replace
+  path: /resource_pools/name=vms/stemcell
+  value:
+    url: https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-xenial-go_agent?v=97.28
+    sha1: some-stemcell-sha1
+`))
+
+			script, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(script)).To(ContainSubstring("-o  ${BBL_STATE_DIR}/bbl-ops-files/gcp/director-sizing-ops.yml"))
+		})
+
+		Context("when no sizing is set", func() {
+			It("does not write a sizing ops file", func() {
+				dirInput.Sizing = storage.Sizing{}
+
+				err := executor.PlanDirector(dirInput, deploymentDir, "gcp")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = fs.Stat(filepath.Join(stateDir, "bbl-ops-files", "gcp", "director-sizing-ops.yml"))
+				Expect(err).To(HaveOccurred())
+
+				script, err := fs.ReadFile(filepath.Join(stateDir, "create-director.sh"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(script)).NotTo(ContainSubstring("director-sizing-ops.yml"))
+			})
+		})
+	})
+
+	Describe("PlanJumpbox with sizing", func() {
+		It("writes a sizing ops file and passes it to create-env", func() {
+			dirInput.Sizing = storage.Sizing{
+				DirectorVMType: "m4.xlarge",
+				JumpboxVMType:  "t2.small",
+			}
+
+			err := executor.PlanJumpbox(dirInput, deploymentDir, "aws")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := fs.ReadFile(filepath.Join(stateDir, "bbl-ops-files", "aws", "jumpbox-sizing-ops.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal(`---
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/instance_type
+  value: t2.small
+`))
+
+			script, err := fs.ReadFile(filepath.Join(stateDir, "create-jumpbox.sh"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(script)).To(ContainSubstring("-o  ${BBL_STATE_DIR}/bbl-ops-files/aws/jumpbox-sizing-ops.yml"))
+		})
+	})
+
+	Describe("SourceCommit", func() {
+		AfterEach(func() {
+			bosh.ResetGitRevParse()
+		})
+
+		It("returns the commit checked out in the source dir", func() {
+			bosh.SetGitRevParse(func(dir string) (string, error) {
+				Expect(dir).To(Equal("some-checkout"))
+				return "some-commit", nil
+			})
+
+			Expect(executor.SourceCommit("some-checkout")).To(Equal("some-commit"))
+		})
+
+		It("returns an empty commit when the source dir is not a git repository", func() {
+			bosh.SetGitRevParse(func(dir string) (string, error) {
+				return "", errors.New("not a git repository")
+			})
+
+			Expect(executor.SourceCommit("some-checkout")).To(BeEmpty())
+		})
+	})
+
+	Describe("Interpolate", func() {
+		var state storage.State
+
+		BeforeEach(func() {
+			state = storage.State{
+				IAAS: "aws",
+				AWS: storage.AWS{
+					AccessKeyID:     "some-access-key-id",
+					SecretAccessKey: "some-secret-access-key",
+				},
+			}
+			dirInput.Deployment = "jumpbox"
+
+			err := executor.PlanJumpbox(dirInput, deploymentDir, "aws")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = fs.WriteFile(filepath.Join(varsDir, "jumpbox-vars-store.yml"), []byte("some-vars-store"), storage.StateMode)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("interpolates the manifest with the args from the create-env script", func() {
+			manifest, err := executor.Interpolate(dirInput, state, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manifest).To(Equal("some-manifest"))
+			Expect(cli.RunCallCount()).To(Equal(1))
+			_, workingDirectory, args := cli.RunArgsForCall(0)
+			Expect(workingDirectory).To(Equal(stateDir))
+			Expect(args).To(Equal([]string{
+				"interpolate", filepath.Join(deploymentDir, "jumpbox.yml"),
+				"--vars-file", filepath.Join(varsDir, "jumpbox-vars-store.yml"),
+				"-o", filepath.Join(deploymentDir, "aws", "cpi.yml"),
+				"-v", "access_key_id=some-access-key-id",
+				"-v", "secret_access_key=some-secret-access-key",
+			}))
+		})
+
+		Context("when redact is true", func() {
+			It("leaves out the vars store and credentials", func() {
+				_, err := executor.Interpolate(dirInput, state, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, args := cli.RunArgsForCall(0)
+				Expect(args).To(Equal([]string{
+					"interpolate", filepath.Join(deploymentDir, "jumpbox.yml"),
+					"-o", filepath.Join(deploymentDir, "aws", "cpi.yml"),
+				}))
+			})
+
+			It("leaves the credentials out of the vars files", func() {
+				varsFile := "internal_cidr: 10.0.0.0/24\nprivate_key: some-private-key\nclient_secret: some-client-secret\ndefault_key_name: some-key-name\n"
+				err := fs.WriteFile(filepath.Join(varsDir, "jumpbox-vars-file.yml"), []byte(varsFile), storage.StateMode)
+				Expect(err).NotTo(HaveOccurred())
+
+				var redactedVarsFile string
+				cli.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
+					for i, arg := range args {
+						if arg == "--vars-file" {
+							redactedVarsFile = args[i+1]
+							contents, err := fs.ReadFile(redactedVarsFile)
+							Expect(err).NotTo(HaveOccurred())
+							stdout.Write(contents)
+						}
+					}
+					return nil
+				}
+
+				manifest, err := executor.Interpolate(dirInput, state, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(redactedVarsFile).NotTo(Equal(filepath.Join(varsDir, "jumpbox-vars-file.yml")))
+				Expect(manifest).To(ContainSubstring("internal_cidr: 10.0.0.0/24"))
+				Expect(manifest).To(ContainSubstring("default_key_name: some-key-name"))
+				Expect(manifest).NotTo(ContainSubstring("private_key"))
+				Expect(manifest).NotTo(ContainSubstring("some-private-key"))
+				Expect(manifest).NotTo(ContainSubstring("some-client-secret"))
+
+				_, err = fs.Stat(redactedVarsFile)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+
+			It("keeps vars files with the same name apart", func() {
+				for _, dir := range []string{"a", "b"} {
+					err := fs.MkdirAll(filepath.Join(stateDir, dir), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+					err = fs.WriteFile(filepath.Join(stateDir, dir, "vars.yml"), []byte(fmt.Sprintf("from: %s\n", dir)), storage.StateMode)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				override := "#!/bin/sh\nbosh-path create-env ${BBL_STATE_DIR}/deployment/jumpbox.yml -l ${BBL_STATE_DIR}/a/vars.yml -l ${BBL_STATE_DIR}/b/vars.yml\n"
+				err := fs.WriteFile(filepath.Join(stateDir, "create-jumpbox-override.sh"), []byte(override), storage.ScriptMode)
+				Expect(err).NotTo(HaveOccurred())
+
+				var varsFiles []string
+				cli.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
+					for i, arg := range args {
+						if arg == "-l" {
+							contents, err := fs.ReadFile(args[i+1])
+							Expect(err).NotTo(HaveOccurred())
+							varsFiles = append(varsFiles, string(contents))
+						}
+					}
+					return nil
+				}
+
+				_, err = executor.Interpolate(dirInput, state, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(varsFiles).To(Equal([]string{"from: a\n", "from: b\n"}))
+			})
+		})
+
+		Context("when the user provides a create-env override", func() {
+			BeforeEach(func() {
+				override := "#!/bin/sh\nbosh-path create-env \\\n  ${BBL_STATE_DIR}/deployment/jumpbox.yml \\\n  --state ${BBL_STATE_DIR}/vars/jumpbox-state.json  --recreate  -o ${BBL_STATE_DIR}/my-ops.yml\n"
+				err := fs.WriteFile(filepath.Join(stateDir, "create-jumpbox-override.sh"), []byte(override), storage.ScriptMode)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("interpolates with the args from the override", func() {
+				_, err := executor.Interpolate(dirInput, state, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, args := cli.RunArgsForCall(0)
+				Expect(args).To(Equal([]string{
+					"interpolate", filepath.Join(deploymentDir, "jumpbox.yml"),
+					"-o", filepath.Join(stateDir, "my-ops.yml"),
+				}))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the script does not run create-env", func() {
+				err := fs.WriteFile(filepath.Join(stateDir, "create-jumpbox-override.sh"), []byte("#!/bin/sh\necho hi\n"), storage.ScriptMode)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = executor.Interpolate(dirInput, state, false)
+				Expect(err).To(MatchError(fmt.Sprintf("Parse %s: no bosh create-env command found", filepath.Join(stateDir, "create-jumpbox-override.sh"))))
+			})
+
+			It("returns an error when bosh interpolate fails", func() {
+				cli.RunReturns(errors.New("starfruit"))
+
+				_, err := executor.Interpolate(dirInput, state, false)
+				Expect(err).To(MatchError("starfruit"))
+			})
+		})
+	})
+
 	Describe("Version", func() {
 		var (
 			cli      *fakes.BOSHCLI
@@ -853,7 +1184,7 @@ var _ = Describe("Executor", func() {
 				return nil
 			}
 
-			executor = bosh.NewExecutor(cli, fs)
+			executor = bosh.NewExecutor(cli, fs, logger)
 		})
 
 		It("returns the correctly trimmed version", func() {
@@ -29,3 +29,17 @@ func SetProxySOCKS5(f func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dia
 func ResetProxySOCKS5() {
 	proxySOCKS5 = proxy.SOCKS5
 }
+
+func SetGitRevParse(f func(string) (string, error)) {
+	gitRevParse = f
+}
+
+func ResetGitRevParse() {
+	gitRevParse = revParseHEAD
+}
+
+func ParseCreateEnvArgs(script string, env map[string]string) ([]string, error) {
+	return parseCreateEnvArgs(script, func(key string) string {
+		return env[key]
+	})
+}
@@ -2,15 +2,25 @@ package bosh
 
 import (
 	"fmt"
+	"math/bits"
+	"net"
 	"strconv"
 	"strings"
 )
 
+// IP is an IPv4 or IPv6 address held as a 128-bit number so that offsets
+// can be added to either family.
 type IP struct {
-	ip int64
+	hi   uint64
+	lo   uint64
+	ipv6 bool
 }
 
 func ParseIP(ip string) (IP, error) {
+	if strings.Contains(ip, ":") {
+		return parseIPv6(ip)
+	}
+
 	const IP_PARTS = 4
 	const MAX_IP_PART = int64(256)
 
@@ -35,26 +45,85 @@ func ParseIP(ip string) (IP, error) {
 	}
 
 	return IP{
-		ip: ipValue,
+		lo: uint64(ipValue),
 	}, nil
 }
 
+func parseIPv6(ip string) (IP, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.To4() != nil {
+		return IP{}, fmt.Errorf(`'%s' is not a valid ip address`, ip)
+	}
+
+	var value IP
+	value.ipv6 = true
+	for i := 0; i < 8; i++ {
+		value.hi = value.hi<<8 | uint64(parsed[i])
+		value.lo = value.lo<<8 | uint64(parsed[i+8])
+	}
+	return value, nil
+}
+
+func (i IP) IsIPv6() bool {
+	return i.ipv6
+}
+
 func (i IP) Add(offset int) IP {
-	return IP{
-		ip: i.ip + int64(offset),
+	if offset < 0 {
+		return i.Subtract(-offset)
 	}
+
+	lo, carry := bits.Add64(i.lo, uint64(offset), 0)
+	return IP{hi: i.hi + carry, lo: lo, ipv6: i.ipv6}
 }
 
 func (i IP) Subtract(offset int) IP {
-	return IP{
-		ip: i.ip - int64(offset),
+	if offset < 0 {
+		return i.Add(-offset)
+	}
+
+	lo, borrow := bits.Sub64(i.lo, uint64(offset), 0)
+	return IP{hi: i.hi - borrow, lo: lo, ipv6: i.ipv6}
+}
+
+// addHostBits returns the address 2^hostBits - 1 past i, which is the last
+// address of a block of that many host bits starting at i.
+func (i IP) addHostBits(hostBits uint) IP {
+	var hi, lo uint64
+	switch {
+	case hostBits >= 128:
+		hi, lo = ^uint64(0), ^uint64(0)
+	case hostBits >= 64:
+		hi, lo = 1<<(hostBits-64)-1, ^uint64(0)
+	default:
+		lo = 1<<hostBits - 1
+	}
+
+	lo, carry := bits.Add64(i.lo, lo, 0)
+	hi, _ = bits.Add64(i.hi, hi, carry)
+	return IP{hi: hi, lo: lo, ipv6: i.ipv6}
+}
+
+func (i IP) less(other IP) bool {
+	if i.hi != other.hi {
+		return i.hi < other.hi
 	}
+	return i.lo < other.lo
 }
 
 func (i IP) String() string {
-	first := i.ip & 0xff000000 >> 24
-	second := i.ip & 0xff0000 >> 16
-	third := i.ip & 0xff00 >> 8
-	fourth := i.ip & 0xff
+	if i.ipv6 {
+		ip := make(net.IP, net.IPv6len)
+		for n := 0; n < 8; n++ {
+			ip[n] = byte(i.hi >> uint(56-8*n))
+			ip[n+8] = byte(i.lo >> uint(56-8*n))
+		}
+		return ip.String()
+	}
+
+	first := i.lo & 0xff000000 >> 24
+	second := i.lo & 0xff0000 >> 16
+	third := i.lo & 0xff00 >> 8
+	fourth := i.lo & 0xff
 	return fmt.Sprintf("%v.%v.%v.%v", first, second, third, fourth)
 }
@@ -13,6 +13,14 @@ var _ = Describe("IP", func() {
 			ip, err := bosh.ParseIP("10.0.16.255")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ip.String()).To(Equal("10.0.16.255"))
+			Expect(ip.IsIPv6()).To(BeFalse())
+		})
+
+		It("parses ipv6 addresses", func() {
+			ip, err := bosh.ParseIP("2600:1f14:0:100::1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.String()).To(Equal("2600:1f14:0:100::1"))
+			Expect(ip.IsIPv6()).To(BeTrue())
 		})
 
 		Context("failure cases", func() {
@@ -31,6 +39,11 @@ var _ = Describe("IP", func() {
 				Expect(err).To(MatchError(ContainSubstring("values out of range")))
 			})
 
+			It("returns an error if an ipv6 address cannot be parsed", func() {
+				_, err := bosh.ParseIP("2600::1::1")
+				Expect(err).To(MatchError("'2600::1::1' is not a valid ip address"))
+			})
+
 			It("returns an error if ip has too many parts", func() {
 				_, err := bosh.ParseIP("1.1.1.1.1.1.1")
 				Expect(err).To(MatchError(ContainSubstring("not a valid ip address")))
@@ -45,6 +58,12 @@ var _ = Describe("IP", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ip.String()).To(Equal("10.0.16.2"))
 		})
+
+		It("carries across the 64-bit boundary of an ipv6 address", func() {
+			ip, err := bosh.ParseIP("2600:1f14:0:100:ffff:ffff:ffff:ffff")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.Add(2).String()).To(Equal("2600:1f14:0:101::1"))
+		})
 	})
 
 	Describe("Subtract", func() {
@@ -54,6 +73,12 @@ var _ = Describe("IP", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ip.String()).To(Equal("10.0.16.1"))
 		})
+
+		It("borrows across the 64-bit boundary of an ipv6 address", func() {
+			ip, err := bosh.ParseIP("2600:1f14:0:101::")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.Subtract(1).String()).To(Equal("2600:1f14:0:100:ffff:ffff:ffff:ffff"))
+		})
 	})
 
 	Describe("String", func() {
@@ -1,6 +1,7 @@
 package bosh
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,6 +20,7 @@ var (
 )
 
 type managerFs interface {
+	fileio.FileReader
 	fileio.FileWriter
 	fileio.TempDirer
 }
@@ -28,6 +30,7 @@ type Manager struct {
 	logger       logger
 	stateStore   stateStore
 	sshKeyGetter sshKeyGetter
+	knownHosts   knownHosts
 	fs           managerFs
 }
 
@@ -45,6 +48,8 @@ type executor interface {
 	CreateEnv(DirInput, storage.State) (string, error)
 	DeleteEnv(DirInput, storage.State) error
 	WriteDeploymentVars(DirInput, string) error
+	Interpolate(DirInput, storage.State, bool) (string, error)
+	SourceCommit(string) string
 	Path() string
 	Version() (string, error)
 }
@@ -65,12 +70,17 @@ type sshKeyGetter interface {
 	Get(string) (string, error)
 }
 
-func NewManager(executor executor, logger logger, stateStore stateStore, sshKeyGetter sshKeyGetter, fs deleterFs) *Manager {
+type knownHosts interface {
+	Forget(host string) error
+}
+
+func NewManager(executor executor, logger logger, stateStore stateStore, sshKeyGetter sshKeyGetter, knownHosts knownHosts, fs deleterFs) *Manager {
 	return &Manager{
 		executor:     executor,
 		logger:       logger,
 		stateStore:   stateStore,
 		sshKeyGetter: sshKeyGetter,
+		knownHosts:   knownHosts,
 		fs:           fs,
 	}
 }
@@ -88,6 +98,18 @@ func (m *Manager) Version() (string, error) {
 	return version, err
 }
 
+// RecordDeploymentSources records the commit checked out in any external
+// bosh-deployment or jumpbox-deployment directory configured in the state.
+func (m *Manager) RecordDeploymentSources(state storage.State) storage.State {
+	if state.BOSHDeployment.Path != "" {
+		state.BOSHDeployment.Commit = m.executor.SourceCommit(state.BOSHDeployment.Path)
+	}
+	if state.JumpboxDeployment.Path != "" {
+		state.JumpboxDeployment.Commit = m.executor.SourceCommit(state.JumpboxDeployment.Path)
+	}
+	return state
+}
+
 func (m *Manager) InitializeJumpbox(state storage.State) error {
 	varsDir, err := m.stateStore.GetVarsDir()
 	if err != nil {
@@ -102,8 +124,10 @@ func (m *Manager) InitializeJumpbox(state storage.State) error {
 	}
 
 	iaasInputs := DirInput{
-		StateDir: stateDir,
-		VarsDir:  varsDir,
+		StateDir:  stateDir,
+		VarsDir:   varsDir,
+		SourceDir: state.JumpboxDeployment.Path,
+		Sizing:    state.Sizing,
 	}
 
 	err = m.executor.PlanJumpbox(iaasInputs, deploymentDir, state.IAAS)
@@ -135,6 +159,8 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs terraform.
 		return storage.State{}, fmt.Errorf("Write deployment vars: %s", err)
 	}
 
+	previousVM := m.vmCID(varsDir, "jumpbox-state.json")
+
 	_, err = m.executor.CreateEnv(dirInput, state)
 	if err != nil {
 		return storage.State{}, NewManagerCreateError(state, err)
@@ -145,6 +171,13 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs terraform.
 		URL: terraformOutputs.GetString("jumpbox_url"),
 	}
 
+	if m.vmCID(varsDir, "jumpbox-state.json") != previousVM {
+		err = m.knownHosts.Forget(strings.Split(state.Jumpbox.URL, ":")[0])
+		if err != nil {
+			return storage.State{}, fmt.Errorf("Forget jumpbox host key: %s", err)
+		}
+	}
+
 	dir, err := m.fs.TempDir("", "bosh-jumpbox")
 	if err != nil {
 		return storage.State{}, fmt.Errorf("Create temp dir for jumpbox private key: %s", err)
@@ -181,8 +214,10 @@ func (m *Manager) InitializeDirector(state storage.State) error {
 	}
 
 	iaasInputs := DirInput{
-		StateDir: stateDir,
-		VarsDir:  varsDir,
+		StateDir:  stateDir,
+		VarsDir:   varsDir,
+		SourceDir: state.BOSHDeployment.Path,
+		Sizing:    state.Sizing,
 	}
 
 	err = m.executor.PlanDirector(iaasInputs, directorDeploymentDir, state.IAAS)
@@ -193,6 +228,34 @@ func (m *Manager) InitializeDirector(state storage.State) error {
 	return nil
 }
 
+func (m *Manager) RenderJumpbox(state storage.State, redact bool) (string, error) {
+	return m.render("jumpbox", state, redact)
+}
+
+func (m *Manager) RenderDirector(state storage.State, redact bool) (string, error) {
+	return m.render("director", state, redact)
+}
+
+func (m *Manager) render(deployment string, state storage.State, redact bool) (string, error) {
+	varsDir, err := m.stateStore.GetVarsDir()
+	if err != nil {
+		return "", err
+	}
+
+	dirInput := DirInput{
+		Deployment: deployment,
+		StateDir:   m.stateStore.GetStateDir(),
+		VarsDir:    varsDir,
+	}
+
+	manifest, err := m.executor.Interpolate(dirInput, state, redact)
+	if err != nil {
+		return "", fmt.Errorf("Interpolate %s: %s", deployment, err)
+	}
+
+	return manifest, nil
+}
+
 func (m *Manager) CreateDirector(state storage.State, terraformOutputs terraform.Outputs) (storage.State, error) {
 	m.logger.Step("creating bosh director")
 
@@ -214,6 +277,8 @@ func (m *Manager) CreateDirector(state storage.State, terraformOutputs terraform
 		return storage.State{}, fmt.Errorf("Write deployment vars: %s", err)
 	}
 
+	previousVM := m.vmCID(varsDir, "bosh-state.json")
+
 	variables, err := m.executor.CreateEnv(dirInput, state)
 	if err != nil {
 		state.BOSH = storage.BOSH{
@@ -227,13 +292,23 @@ func (m *Manager) CreateDirector(state storage.State, terraformOutputs terraform
 	internalCIDR := terraformOutputs.GetString("internal_cidr")
 	parsedInternalCIDR, err := ParseCIDRBlock(internalCIDR)
 	if err != nil {
-		internalCIDR = "10.0.0.0/24"
+		internalCIDR = state.Network.InternalCIDR
+		if internalCIDR == "" {
+			internalCIDR = "10.0.0.0/24"
+		}
 		parsedInternalCIDR, _ = ParseCIDRBlock(internalCIDR)
 	}
 
 	internalIP := terraformOutputs.GetString("director__internal_ip")
 	if internalIP == "" {
-		internalIP = parsedInternalCIDR.GetNthIP(6).String()
+		internalIP = parsedInternalCIDR.GetNthIP(state.Network.DirectorOffset()).String()
+	}
+
+	if m.vmCID(varsDir, "bosh-state.json") != previousVM {
+		err = m.knownHosts.Forget(internalIP)
+		if err != nil {
+			return storage.State{}, fmt.Errorf("Forget director host key: %s", err)
+		}
 	}
 
 	state.BOSH = storage.BOSH{
@@ -250,6 +325,25 @@ func (m *Manager) CreateDirector(state storage.State, terraformOutputs terraform
 	return state, nil
 }
 
+// vmCID returns the CID of the VM recorded in a create-env state file, or
+// an empty string when there is none. create-env replaces the VM, along
+// with its host key, whenever the CID changes.
+func (m *Manager) vmCID(varsDir, stateFile string) string {
+	contents, err := m.fs.ReadFile(filepath.Join(varsDir, stateFile))
+	if err != nil {
+		return ""
+	}
+
+	var createEnvState struct {
+		CurrentVMCID string `json:"current_vm_cid"`
+	}
+	if err := json.Unmarshal(contents, &createEnvState); err != nil {
+		return ""
+	}
+
+	return createEnvState.CurrentVMCID
+}
+
 func (m *Manager) DeleteDirector(state storage.State, terraformOutputs terraform.Outputs) error {
 	if state.BOSH.IsEmpty() {
 		return nil
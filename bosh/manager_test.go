@@ -2,6 +2,7 @@ package bosh_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
@@ -21,6 +22,7 @@ var _ = Describe("Manager", func() {
 		logger       *fakes.Logger
 		stateStore   *fakes.StateStore
 		sshKeyGetter *fakes.SSHKeyGetter
+		knownHosts   *fakes.KnownHosts
 		fs           *fakes.FileIO
 
 		boshManager      *bosh.Manager
@@ -37,6 +39,7 @@ var _ = Describe("Manager", func() {
 		logger = &fakes.Logger{}
 		sshKeyGetter = &fakes.SSHKeyGetter{}
 		sshKeyGetter.GetCall.Returns.PrivateKey = "some-jumpbox-private-key"
+		knownHosts = &fakes.KnownHosts{}
 		fs = &fakes.FileIO{}
 
 		stateStore = &fakes.StateStore{}
@@ -45,7 +48,7 @@ var _ = Describe("Manager", func() {
 		stateStore.GetDirectorDeploymentDirCall.Returns.Directory = "some-director-deployment-dir"
 		stateStore.GetJumpboxDeploymentDirCall.Returns.Directory = "some-jumpbox-deployment-dir"
 
-		boshManager = bosh.NewManager(boshExecutor, logger, stateStore, sshKeyGetter, fs)
+		boshManager = bosh.NewManager(boshExecutor, logger, stateStore, sshKeyGetter, knownHosts, fs)
 
 		boshVars = `admin_password: some-admin-password
 director_ssl:
@@ -102,6 +105,24 @@ director_ssl:
 				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
 			})
 
+			It("passes the bosh deployment source to the executor", func() {
+				state.BOSHDeployment = storage.DeploymentSource{Path: "some-bosh-deployment-checkout"}
+
+				err := boshManager.InitializeDirector(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.PlanDirectorCall.Receives.DirInput.SourceDir).To(Equal("some-bosh-deployment-checkout"))
+			})
+
+			It("passes the sizing to the executor", func() {
+				state.Sizing = storage.Sizing{DirectorDiskSize: 128}
+
+				err := boshManager.InitializeDirector(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.PlanDirectorCall.Receives.DirInput.Sizing).To(Equal(storage.Sizing{DirectorDiskSize: 128}))
+			})
+
 			Context("when create env args fails", func() {
 				BeforeEach(func() {
 					boshExecutor.PlanDirectorCall.Returns.Error = errors.New("failed to interpolate")
@@ -164,6 +185,36 @@ director_ssl:
 				}))
 			})
 
+			It("keeps the recorded host key when create-env keeps the vm", func() {
+				fs.ReadFileCall.Returns.Contents = []byte(`{"current_vm_cid": "some-vm"}`)
+
+				_, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fs.ReadFileCall.Receives.Filename).To(Equal("some-bbl-vars-dir/bosh-state.json"))
+				Expect(knownHosts.ForgetCall.CallCount).To(Equal(0))
+			})
+
+			It("forgets the recorded host key when create-env replaces the vm", func() {
+				fs.ReadFileCall.Fake = func(string) ([]byte, error) {
+					return []byte(fmt.Sprintf(`{"current_vm_cid": "some-vm-%d"}`, boshExecutor.CreateEnvCall.CallCount)), nil
+				}
+
+				_, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(knownHosts.ForgetCall.Receives.Host).To(Equal("10.2.0.6"))
+			})
+
+			It("places the director at the configured ip offset", func() {
+				state.Network = storage.Network{DirectorIPOffset: 10}
+
+				stateWithDirector, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stateWithDirector.BOSH.DirectorAddress).To(Equal("https://10.2.0.10:25555"))
+			})
+
 			Context("when an error occurs", func() {
 				Context("when get vars dir fails", func() {
 					It("returns an error", func() {
@@ -174,6 +225,21 @@ director_ssl:
 					})
 				})
 
+				Context("when forgetting the host key fails", func() {
+					It("returns an error", func() {
+						fs.ReadFileCall.Fake = func(string) ([]byte, error) {
+							if boshExecutor.CreateEnvCall.CallCount == 0 {
+								return nil, errors.New("no state yet")
+							}
+							return []byte(`{"current_vm_cid": "some-vm"}`), nil
+						}
+						knownHosts.ForgetCall.Returns.Error = errors.New("quince")
+
+						_, err := boshManager.CreateDirector(state, terraformOutputs)
+						Expect(err).To(MatchError("Forget director host key: quince"))
+					})
+				})
+
 				Context("when the executor's create env call fails", func() {
 					BeforeEach(func() {
 						boshExecutor.CreateEnvCall.Returns.Error = errors.New("lychee")
@@ -235,6 +301,24 @@ director_ssl:
 				Expect(boshExecutor.PlanJumpboxCall.Receives.DirInput.StateDir).To(Equal("some-state-dir"))
 			})
 
+			It("passes the jumpbox deployment source to the executor", func() {
+				state.JumpboxDeployment = storage.DeploymentSource{Path: "some-jumpbox-deployment-checkout"}
+
+				err := boshManager.InitializeJumpbox(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.PlanJumpboxCall.Receives.DirInput.SourceDir).To(Equal("some-jumpbox-deployment-checkout"))
+			})
+
+			It("passes the sizing to the executor", func() {
+				state.Sizing = storage.Sizing{JumpboxVMType: "t2.small"}
+
+				err := boshManager.InitializeJumpbox(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.PlanJumpboxCall.Receives.DirInput.Sizing).To(Equal(storage.Sizing{JumpboxVMType: "t2.small"}))
+			})
+
 			Context("when an error occurs", func() {
 				Context("when get vars dir fails", func() {
 					It("returns an error", func() {
@@ -271,6 +355,18 @@ director_ssl:
 				}))
 			})
 
+			It("forgets the recorded host key when create-env replaces the vm", func() {
+				fs.ReadFileCall.Fake = func(string) ([]byte, error) {
+					return []byte(fmt.Sprintf(`{"current_vm_cid": "some-vm-%d"}`, boshExecutor.CreateEnvCall.CallCount)), nil
+				}
+
+				_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fs.ReadFileCall.Receives.Filename).To(Equal("some-bbl-vars-dir/jumpbox-state.json"))
+				Expect(knownHosts.ForgetCall.Receives.Host).To(Equal("some-jumpbox-url"))
+			})
+
 			It("returns a bbl state with bosh and jumpbox deployment values", func() {
 				state, err := boshManager.CreateJumpbox(state, terraformOutputs)
 				Expect(err).NotTo(HaveOccurred())
@@ -509,6 +605,70 @@ director_ssl:
 		})
 	})
 
+	Describe("RecordDeploymentSources", func() {
+		It("records the commit of each configured deployment source", func() {
+			boshExecutor.SourceCommitCall.Returns.Commit = "some-commit"
+
+			state := boshManager.RecordDeploymentSources(storage.State{
+				BOSHDeployment: storage.DeploymentSource{Path: "some-bosh-deployment-checkout"},
+			})
+
+			Expect(boshExecutor.SourceCommitCall.Receives).To(Equal([]string{"some-bosh-deployment-checkout"}))
+			Expect(state.BOSHDeployment).To(Equal(storage.DeploymentSource{Path: "some-bosh-deployment-checkout", Commit: "some-commit"}))
+			Expect(state.JumpboxDeployment).To(Equal(storage.DeploymentSource{}))
+		})
+	})
+
+	Describe("RenderJumpbox", func() {
+		It("interpolates the jumpbox manifest", func() {
+			boshExecutor.InterpolateCall.Returns.Manifest = "some-jumpbox-manifest"
+			state := storage.State{IAAS: "aws"}
+
+			manifest, err := boshManager.RenderJumpbox(state, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manifest).To(Equal("some-jumpbox-manifest"))
+			Expect(boshExecutor.InterpolateCall.Receives.DirInput).To(Equal(bosh.DirInput{
+				Deployment: "jumpbox",
+				StateDir:   "some-state-dir",
+				VarsDir:    "some-bbl-vars-dir",
+			}))
+			Expect(boshExecutor.InterpolateCall.Receives.State).To(Equal(state))
+			Expect(boshExecutor.InterpolateCall.Receives.Redact).To(BeTrue())
+		})
+
+		Context("when the executor fails to interpolate", func() {
+			It("returns an error", func() {
+				boshExecutor.InterpolateCall.Returns.Error = errors.New("lychee")
+
+				_, err := boshManager.RenderJumpbox(storage.State{}, false)
+				Expect(err).To(MatchError("Interpolate jumpbox: lychee"))
+			})
+		})
+	})
+
+	Describe("RenderDirector", func() {
+		It("interpolates the director manifest", func() {
+			boshExecutor.InterpolateCall.Returns.Manifest = "some-director-manifest"
+
+			manifest, err := boshManager.RenderDirector(storage.State{}, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manifest).To(Equal("some-director-manifest"))
+			Expect(boshExecutor.InterpolateCall.Receives.DirInput.Deployment).To(Equal("director"))
+			Expect(boshExecutor.InterpolateCall.Receives.Redact).To(BeFalse())
+		})
+
+		Context("when getting the vars dir fails", func() {
+			It("returns an error", func() {
+				stateStore.GetVarsDirCall.Returns.Error = errors.New("durian")
+
+				_, err := boshManager.RenderDirector(storage.State{}, false)
+				Expect(err).To(MatchError("durian"))
+			})
+		})
+	})
+
 	Describe("GetJumpboxDeploymentVars", func() {
 		It("removes the jumpbox__ prefix from variable names", func() {
 			vars := boshManager.GetJumpboxDeploymentVars(storage.State{}, terraform.Outputs{Map: map[string]interface{}{
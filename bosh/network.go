@@ -0,0 +1,113 @@
+package bosh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	minNetworkSize     = 1 << 12
+	defaultNetworkCIDR = "10.0.0.0/16"
+)
+
+// ValidateNetwork checks the requested network CIDR, internal CIDR, IP
+// offsets and allowed source CIDRs. Without an internal CIDR the jumpbox
+// and director live in the first 256th of the network, a /24 of a /16.
+// On aws and gcp the terraform templates use the rest of the first
+// sixteenth for the load balancer subnets and the other sixteenths for the
+// cloud config subnets, so the internal CIDR has to stay clear of them.
+func ValidateNetwork(iaas string, network storage.Network, internalCIDR string) error {
+	internalSize := 256
+
+	var networkBlock CIDRBlock
+	if network.NetworkCIDR != "" {
+		var err error
+		networkBlock, err = ParseCIDRBlock(network.NetworkCIDR)
+		if err != nil {
+			return fmt.Errorf("Invalid network CIDR %q: %s", network.NetworkCIDR, err)
+		}
+		if networkBlock.CIDRSize < minNetworkSize {
+			return fmt.Errorf("The network CIDR %s is too small. Use a /20 or larger network.", network.NetworkCIDR)
+		}
+		internalSize = networkBlock.CIDRSize / 256
+	}
+
+	if internalCIDR != "" {
+		internalBlock, err := ParseCIDRBlock(internalCIDR)
+		if err != nil {
+			return fmt.Errorf("Invalid internal CIDR %q: %s", internalCIDR, err)
+		}
+
+		if network.NetworkCIDR != "" && !networkBlock.Contains(internalBlock) {
+			return fmt.Errorf("The internal CIDR %s is not within the network CIDR %s.", internalCIDR, network.NetworkCIDR)
+		}
+		if iaas == "aws" || iaas == "gcp" {
+			err = validateInternalSubnet(iaas, network, internalBlock, internalCIDR)
+			if err != nil {
+				return err
+			}
+		}
+		internalSize = internalBlock.CIDRSize
+	}
+
+	offsets := []struct {
+		name   string
+		offset int
+	}{
+		{"jumpbox", network.JumpboxOffset()},
+		{"director", network.DirectorOffset()},
+	}
+	for _, o := range offsets {
+		if o.offset < 2 || o.offset > internalSize-2 {
+			return fmt.Errorf("The %s IP offset %d is outside the usable range of the internal CIDR (2-%d).", o.name, o.offset, internalSize-2)
+		}
+	}
+
+	if network.JumpboxOffset() == network.DirectorOffset() {
+		return errors.New("The jumpbox and director IP offsets must differ.")
+	}
+
+	for _, cidr := range network.AllowedCIDRs {
+		block, err := ParseCIDRBlock(cidr)
+		if err != nil {
+			return fmt.Errorf("Invalid allowed CIDR %q: %s", cidr, err)
+		}
+		if block.IsIPv6() {
+			return fmt.Errorf("The allowed CIDR %s is not an IPv4 CIDR.", cidr)
+		}
+	}
+
+	return nil
+}
+
+// validateInternalSubnet checks that the internal CIDR lies within
+// cidrsubnet(network, 4, 0) and, on aws, outside of the load balancer
+// subnets cidrsubnet(network, 8, 2) and up, one for each availability zone.
+func validateInternalSubnet(iaas string, network storage.Network, internalBlock CIDRBlock, internalCIDR string) error {
+	networkCIDR := network.NetworkCIDR
+	if networkCIDR == "" {
+		networkCIDR = defaultNetworkCIDR
+	}
+	networkBlock, err := ParseCIDRBlock(networkCIDR)
+	if err != nil {
+		return fmt.Errorf("Invalid network CIDR %q: %s", networkCIDR, err) // not tested
+	}
+
+	first := networkBlock.Subnet(4, 0)
+	if !first.Contains(internalBlock) {
+		return fmt.Errorf("The internal CIDR %s is not within %s, the first sixteenth of the network CIDR %s. The rest of the network holds the cloud config subnets.", internalCIDR, first, networkCIDR)
+	}
+
+	if iaas == "aws" {
+		for i := 2; i < 16; i++ {
+			lbSubnet := networkBlock.Subnet(8, i)
+			if lbSubnet.Overlaps(internalBlock) {
+				return fmt.Errorf("The internal CIDR %s overlaps %s, which is reserved for the load balancer subnets. Use a CIDR within %s.", internalCIDR, lbSubnet, networkBlock.Subnet(7, 0))
+			}
+		}
+	}
+
+	return nil
+}
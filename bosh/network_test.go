@@ -0,0 +1,105 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateNetwork", func() {
+	It("accepts the defaults", func() {
+		Expect(bosh.ValidateNetwork("", storage.Network{}, "")).To(Succeed())
+	})
+
+	It("accepts an internal cidr within the network cidr", func() {
+		network := storage.Network{
+			NetworkCIDR:      "172.16.0.0/16",
+			InternalCIDR:     "172.16.8.0/24",
+			JumpboxIPOffset:  10,
+			DirectorIPOffset: 11,
+		}
+		Expect(bosh.ValidateNetwork("", network, network.InternalCIDR)).To(Succeed())
+	})
+
+	It("accepts allowed cidrs", func() {
+		network := storage.Network{AllowedCIDRs: []string{"203.0.113.0/24", "0.0.0.0/0"}}
+		Expect(bosh.ValidateNetwork("", network, "")).To(Succeed())
+	})
+
+	Context("on aws and gcp", func() {
+		It("accepts an internal cidr in front of the load balancer subnets", func() {
+			network := storage.Network{NetworkCIDR: "172.16.0.0/16"}
+			Expect(bosh.ValidateNetwork("aws", network, "172.16.0.0/23")).To(Succeed())
+			Expect(bosh.ValidateNetwork("aws", storage.Network{}, "10.0.1.0/24")).To(Succeed())
+		})
+
+		It("accepts an internal cidr anywhere in the first sixteenth on gcp", func() {
+			network := storage.Network{NetworkCIDR: "172.16.0.0/16"}
+			Expect(bosh.ValidateNetwork("gcp", network, "172.16.8.0/24")).To(Succeed())
+		})
+
+		It("rejects an internal cidr outside of the first sixteenth", func() {
+			network := storage.Network{NetworkCIDR: "10.0.0.0/16"}
+			err := bosh.ValidateNetwork("gcp", network, "10.0.32.0/24")
+			Expect(err).To(MatchError("The internal CIDR 10.0.32.0/24 is not within 10.0.0.0/20, the first sixteenth of the network CIDR 10.0.0.0/16. The rest of the network holds the cloud config subnets."))
+
+			err = bosh.ValidateNetwork("aws", storage.Network{}, "10.0.16.0/24")
+			Expect(err).To(MatchError(ContainSubstring("is not within 10.0.0.0/20, the first sixteenth of the network CIDR 10.0.0.0/16")))
+		})
+
+		It("rejects an internal cidr that overlaps the load balancer subnets on aws", func() {
+			network := storage.Network{NetworkCIDR: "10.0.0.0/16"}
+			err := bosh.ValidateNetwork("aws", network, "10.0.2.0/24")
+			Expect(err).To(MatchError("The internal CIDR 10.0.2.0/24 overlaps 10.0.2.0/24, which is reserved for the load balancer subnets. Use a CIDR within 10.0.0.0/23."))
+
+			err = bosh.ValidateNetwork("aws", network, "10.0.0.0/20")
+			Expect(err).To(MatchError(ContainSubstring("overlaps 10.0.2.0/24")))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("rejects an unparseable network cidr", func() {
+			err := bosh.ValidateNetwork("", storage.Network{NetworkCIDR: "banana"}, "")
+			Expect(err).To(MatchError(`Invalid network CIDR "banana": "banana" cannot parse CIDR block`))
+		})
+
+		It("rejects an unparseable internal cidr", func() {
+			err := bosh.ValidateNetwork("", storage.Network{}, "10.0.0.0/abc")
+			Expect(err).To(MatchError(ContainSubstring(`Invalid internal CIDR "10.0.0.0/abc"`)))
+		})
+
+		It("rejects an internal cidr outside of the network cidr", func() {
+			network := storage.Network{NetworkCIDR: "172.16.0.0/16"}
+			err := bosh.ValidateNetwork("", network, "10.0.0.0/24")
+			Expect(err).To(MatchError("The internal CIDR 10.0.0.0/24 is not within the network CIDR 172.16.0.0/16."))
+		})
+
+		It("rejects offsets outside of the internal cidr", func() {
+			err := bosh.ValidateNetwork("", storage.Network{DirectorIPOffset: 300}, "")
+			Expect(err).To(MatchError("The director IP offset 300 is outside the usable range of the internal CIDR (2-254)."))
+
+			err = bosh.ValidateNetwork("", storage.Network{JumpboxIPOffset: 1}, "10.0.0.0/28")
+			Expect(err).To(MatchError("The jumpbox IP offset 1 is outside the usable range of the internal CIDR (2-14)."))
+		})
+
+		It("rejects a network cidr that is too small to split into subnets", func() {
+			err := bosh.ValidateNetwork("", storage.Network{NetworkCIDR: "10.0.0.0/24"}, "")
+			Expect(err).To(MatchError("The network CIDR 10.0.0.0/24 is too small. Use a /20 or larger network."))
+		})
+
+		It("rejects equal jumpbox and director offsets", func() {
+			err := bosh.ValidateNetwork("", storage.Network{JumpboxIPOffset: 6}, "")
+			Expect(err).To(MatchError("The jumpbox and director IP offsets must differ."))
+		})
+
+		It("rejects unparseable and ipv6 allowed cidrs", func() {
+			err := bosh.ValidateNetwork("", storage.Network{AllowedCIDRs: []string{"203.0.113.0/24", "banana"}}, "")
+			Expect(err).To(MatchError(ContainSubstring(`Invalid allowed CIDR "banana"`)))
+
+			err = bosh.ValidateNetwork("", storage.Network{AllowedCIDRs: []string{"2001:db8::/32"}}, "")
+			Expect(err).To(MatchError("The allowed CIDR 2001:db8::/32 is not an IPv4 CIDR."))
+		})
+	})
+})
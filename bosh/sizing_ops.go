@@ -0,0 +1,92 @@
+package bosh
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+var (
+	vmTypeCloudProperties = map[string]string{
+		"aws":       "instance_type",
+		"azure":     "instance_type",
+		"gcp":       "machine_type",
+		"openstack": "instance_type",
+	}
+
+	stemcellInfixes = map[string]string{
+		"aws":       "aws-xen-hvm",
+		"azure":     "azure-hyperv",
+		"gcp":       "google-kvm",
+		"openstack": "openstack-kvm",
+		"vsphere":   "vsphere-esxi",
+	}
+
+	StemcellLines = []string{"ubuntu-trusty", "ubuntu-xenial"}
+)
+
+func SupportsVMType(iaas string) bool {
+	_, ok := vmTypeCloudProperties[iaas]
+	return ok
+}
+
+func DirectorSizingOps(iaas string, sizing storage.Sizing) string {
+	ops := "---\n"
+	if sizing.DirectorVMType != "" && SupportsVMType(iaas) {
+		ops += vmTypeOp(iaas, sizing.DirectorVMType)
+	}
+	if sizing.DirectorDiskSize != 0 {
+		ops += fmt.Sprintf(`- type: replace
+  path: /disk_pools/name=disks/disk_size
+  value: %d
+`, sizing.DirectorDiskSize*1024)
+	}
+	if sizing.StemcellLine != "" {
+		ops += stemcellOp(iaas, sizing)
+	}
+	return ops
+}
+
+func JumpboxSizingOps(iaas string, sizing storage.Sizing) string {
+	ops := "---\n"
+	if sizing.JumpboxVMType != "" && SupportsVMType(iaas) {
+		ops += vmTypeOp(iaas, sizing.JumpboxVMType)
+	}
+	if sizing.StemcellLine != "" {
+		ops += stemcellOp(iaas, sizing)
+	}
+	return ops
+}
+
+func hasDirectorSizing(sizing storage.Sizing) bool {
+	return sizing.DirectorVMType != "" || sizing.DirectorDiskSize != 0 || sizing.StemcellLine != ""
+}
+
+func hasJumpboxSizing(sizing storage.Sizing) bool {
+	return sizing.JumpboxVMType != "" || sizing.StemcellLine != ""
+}
+
+func directorSizingOpsPath(stateDir, iaas string) string {
+	return filepath.Join(stateDir, "bbl-ops-files", iaas, "director-sizing-ops.yml")
+}
+
+func jumpboxSizingOpsPath(stateDir, iaas string) string {
+	return filepath.Join(stateDir, "bbl-ops-files", iaas, "jumpbox-sizing-ops.yml")
+}
+
+func vmTypeOp(iaas, vmType string) string {
+	return fmt.Sprintf(`- type: replace
+  path: /resource_pools/name=vms/cloud_properties/%s
+  value: %s
+`, vmTypeCloudProperties[iaas], vmType)
+}
+
+func stemcellOp(iaas string, sizing storage.Sizing) string {
+	return fmt.Sprintf(`- type: replace
+  path: /resource_pools/name=vms/stemcell
+  value:
+    url: https://bosh.io/d/stemcells/bosh-%s-%s-go_agent?v=%s
+    sha1: %s
+`, stemcellInfixes[iaas], sizing.StemcellLine, sizing.StemcellVersion, sizing.StemcellSHA1)
+}
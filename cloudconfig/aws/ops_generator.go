@@ -34,6 +34,7 @@ type op struct {
 
 type az struct {
 	Name            string
+	CPI             string            `yaml:"cpi,omitempty"`
 	CloudProperties azCloudProperties `yaml:"cloud_properties"`
 }
 
@@ -72,6 +73,24 @@ type lbCloudProperties struct {
 	SecurityGroups []string `yaml:"security_groups"`
 }
 
+type cpiConfig struct {
+	CPIs []cpi `yaml:"cpis"`
+}
+
+type cpi struct {
+	Name       string
+	Type       string
+	Properties cpiProperties
+}
+
+type cpiProperties struct {
+	AccessKeyID           string   `yaml:"access_key_id"`
+	SecretAccessKey       string   `yaml:"secret_access_key"`
+	DefaultKeyName        string   `yaml:"default_key_name"`
+	DefaultSecurityGroups []string `yaml:"default_security_groups"`
+	Region                string
+}
+
 var marshal func(interface{}) ([]byte, error) = yaml.Marshal
 
 func NewOpsGenerator(terraformManager terraformManager, availabilityZones availabilityZones) OpsGenerator {
@@ -120,7 +139,7 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 	internalAZSubnetIDMap := terraformOutputs.GetStringMap("internal_az_subnet_id_mapping")
 	internalAZSubnetCIDRMap := terraformOutputs.GetStringMap("internal_az_subnet_cidr_mapping")
 
-	azs, err := generateAZs(0, internalAZSubnetIDMap, internalAZSubnetCIDRMap)
+	azs, err := generateAZs(0, internalAZSubnetIDMap, internalAZSubnetCIDRMap, "internal_az_subnet_cidr_mapping")
 	if err != nil {
 		return "", err
 	}
@@ -135,10 +154,29 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 		}
 	}
 
+	if state.Network.DualStack {
+		internalAZSubnetIPv6CIDRMap := terraformOutputs.GetStringMap("internal_az_subnet_ipv6_cidr_mapping")
+		for i, az := range azs {
+			azName := az[fmt.Sprintf("az%d_name", i+1)]
+			cidr, ok := internalAZSubnetIPv6CIDRMap[azName]
+			if !ok {
+				return "", errors.New("missing AZ in terraform output: internal_az_subnet_ipv6_cidr_mapping")
+			}
+
+			ipv6AZ, err := azifyIPv6(i, cidr)
+			if err != nil {
+				return "", err
+			}
+			for key, value := range ipv6AZ {
+				varsYAML[key] = value
+			}
+		}
+	}
+
 	isoSegAZSubnetIDMap := terraformOutputs.GetStringMap("iso_az_subnet_id_mapping")
 	isoSegAZSubnetCIDRMap := terraformOutputs.GetStringMap("iso_az_subnet_cidr_mapping")
 	if len(isoSegAZSubnetIDMap) > 0 && len(isoSegAZSubnetCIDRMap) > 0 {
-		isoSegAzs, err := generateAZs(len(azs), isoSegAZSubnetIDMap, isoSegAZSubnetCIDRMap)
+		isoSegAzs, err := generateAZs(len(azs), isoSegAZSubnetIDMap, isoSegAZSubnetCIDRMap, "iso_az_subnet_cidr_mapping")
 		if err == nil {
 			for _, az := range isoSegAzs {
 				for key, value := range az {
@@ -148,6 +186,23 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 		}
 	}
 
+	for _, site := range state.AWS.Sites {
+		siteCIDRMapping := fmt.Sprintf("%s_site_az_subnet_cidr_mapping", site.Name)
+		siteAZSubnetIDMap := terraformOutputs.GetStringMap(fmt.Sprintf("%s_site_az_subnet_id_mapping", site.Name))
+		siteAZSubnetCIDRMap := terraformOutputs.GetStringMap(siteCIDRMapping)
+
+		siteAZs, err := generateAZs(0, siteAZSubnetIDMap, siteAZSubnetCIDRMap, siteCIDRMapping)
+		if err != nil {
+			return "", fmt.Errorf("Generate availability zones for site %s: %s", site.Name, err)
+		}
+
+		for _, az := range siteAZs {
+			for key, value := range az {
+				varsYAML[fmt.Sprintf("%s_%s", site.Name, key)] = value
+			}
+		}
+	}
+
 	varsBytes, err := marshal(varsYAML)
 	if err != nil {
 		panic(err) // not tested; cannot occur
@@ -155,7 +210,69 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 	return string(varsBytes), nil
 }
 
-func generateAZs(startingIndex int, idMap, cidrMap map[string]string) ([]map[string]string, error) {
+// GenerateCPIConfig returns a cpi config with one cpi per region when the
+// environment has additional sites, and an empty string otherwise.
+func (o OpsGenerator) GenerateCPIConfig(state storage.State) (string, error) {
+	if len(state.AWS.Sites) == 0 {
+		return "", nil
+	}
+
+	terraformOutputs, err := o.terraformManager.GetOutputs()
+	if err != nil {
+		return "", fmt.Errorf("Get terraform outputs: %s", err)
+	}
+
+	requiredOutputs := []string{"default_key_name", "internal_security_group"}
+	for _, site := range state.AWS.Sites {
+		requiredOutputs = append(requiredOutputs,
+			fmt.Sprintf("%s_site_default_key_name", site.Name),
+			fmt.Sprintf("%s_site_internal_security_group", site.Name),
+		)
+	}
+
+	for _, output := range requiredOutputs {
+		if _, ok := terraformOutputs.Map[output]; !ok {
+			return "", fmt.Errorf("missing %s terraform output", output)
+		}
+	}
+
+	cpis := []cpi{
+		newCPI("default", state.AWS, state.AWS.Region,
+			terraformOutputs.GetString("default_key_name"),
+			terraformOutputs.GetString("internal_security_group"),
+		),
+	}
+
+	for _, site := range state.AWS.Sites {
+		cpis = append(cpis, newCPI(site.Name, state.AWS, site.Region,
+			terraformOutputs.GetString(fmt.Sprintf("%s_site_default_key_name", site.Name)),
+			terraformOutputs.GetString(fmt.Sprintf("%s_site_internal_security_group", site.Name)),
+		))
+	}
+
+	cpiConfigYAML, err := marshal(cpiConfig{CPIs: cpis})
+	if err != nil {
+		return "", err
+	}
+
+	return string(cpiConfigYAML), nil
+}
+
+func newCPI(name string, credentials storage.AWS, region, keyName, securityGroup string) cpi {
+	return cpi{
+		Name: name,
+		Type: "aws",
+		Properties: cpiProperties{
+			AccessKeyID:           credentials.AccessKeyID,
+			SecretAccessKey:       credentials.SecretAccessKey,
+			DefaultKeyName:        keyName,
+			DefaultSecurityGroups: []string{securityGroup},
+			Region:                region,
+		},
+	}
+}
+
+func generateAZs(startingIndex int, idMap, cidrMap map[string]string, cidrMapping string) ([]map[string]string, error) {
 	var azNames []string
 	for azName := range idMap {
 		azNames = append(azNames, azName)
@@ -166,7 +283,7 @@ func generateAZs(startingIndex int, idMap, cidrMap map[string]string) ([]map[str
 	for azIndex, azName := range azNames {
 		cidr, ok := cidrMap[azName]
 		if !ok {
-			return []map[string]string{}, errors.New("missing AZ in terraform output: internal_az_subnet_cidr_mapping")
+			return []map[string]string{}, fmt.Errorf("missing AZ in terraform output: %s", cidrMapping)
 		}
 
 		az, err := azify(
@@ -220,9 +337,15 @@ func (o OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		return []op{}, fmt.Errorf("Retrieve availability zones: %s", err)
 	}
 
+	var defaultCPI string
+	if len(state.AWS.Sites) > 0 {
+		defaultCPI = "default"
+	}
+
 	for i := range azs {
 		azOp := createOp("replace", "/azs/-", az{
 			Name: fmt.Sprintf("z%d", i+1),
+			CPI:  defaultCPI,
 			CloudProperties: azCloudProperties{
 				AvailabilityZone: fmt.Sprintf("((az%d_name))", i+1),
 			},
@@ -233,6 +356,26 @@ func (o OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		subnets = append(subnets, subnet)
 	}
 
+	for _, site := range state.AWS.Sites {
+		siteAZs, err := o.availabilityZones.RetrieveAZs(site.Region)
+		if err != nil {
+			return []op{}, fmt.Errorf("Retrieve availability zones for site %s: %s", site.Name, err)
+		}
+
+		for i := range siteAZs {
+			azOp := createOp("replace", "/azs/-", az{
+				Name: fmt.Sprintf("%s-z%d", site.Name, i+1),
+				CPI:  site.Name,
+				CloudProperties: azCloudProperties{
+					AvailabilityZone: fmt.Sprintf("((%s_az%d_name))", site.Name, i+1),
+				},
+			})
+			ops = append(ops, azOp)
+
+			subnets = append(subnets, generateSiteNetworkSubnet(site.Name, i))
+		}
+	}
+
 	ops = append(ops, createOp("replace", "/networks/-", network{
 		Name:    "private",
 		Subnets: subnets,
@@ -245,6 +388,19 @@ func (o OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		Type:    "manual",
 	}))
 
+	if state.Network.DualStack {
+		ipv6Subnets := []networkSubnet{}
+		for i := range azs {
+			ipv6Subnets = append(ipv6Subnets, generateIPv6NetworkSubnet(i))
+		}
+
+		ops = append(ops, createOp("replace", "/networks/-", network{
+			Name:    "default-ipv6",
+			Subnets: ipv6Subnets,
+			Type:    "manual",
+		}))
+	}
+
 	switch state.LB.Type {
 	case "cf":
 		lbSecurityGroups := []map[string]string{
@@ -307,6 +463,30 @@ func azify(az int, azName, cidr, subnet string) (map[string]string, error) {
 	}, nil
 }
 
+// azifyIPv6 mirrors azify for the IPv6 block of an internal subnet. AWS
+// reserves the same addresses in IPv6 subnets as it does in IPv4 subnets.
+func azifyIPv6(az int, cidr string) (map[string]string, error) {
+	parsedCidr, err := bosh.ParseCIDRBlock(cidr)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	gateway := parsedCidr.GetNthIP(1).String()
+	firstReserved := parsedCidr.GetNthIP(2).String()
+	secondReserved := parsedCidr.GetNthIP(3).String()
+	lastReserved := parsedCidr.GetLastIP().String()
+	lastStatic := parsedCidr.GetLastIP().Subtract(1).String()
+	firstStatic := parsedCidr.GetLastIP().Subtract(65).String()
+
+	return map[string]string{
+		fmt.Sprintf("az%d_ipv6_gateway", az+1):    gateway,
+		fmt.Sprintf("az%d_ipv6_range", az+1):      cidr,
+		fmt.Sprintf("az%d_ipv6_reserved_1", az+1): fmt.Sprintf("%s-%s", firstReserved, secondReserved),
+		fmt.Sprintf("az%d_ipv6_reserved_2", az+1): lastReserved,
+		fmt.Sprintf("az%d_ipv6_static", az+1):     fmt.Sprintf("%s-%s", firstStatic, lastStatic),
+	}, nil
+}
+
 func generateNetworkSubnet(az int) networkSubnet {
 	az++
 	return networkSubnet{
@@ -326,3 +506,43 @@ func generateNetworkSubnet(az int) networkSubnet {
 		},
 	}
 }
+
+func generateIPv6NetworkSubnet(az int) networkSubnet {
+	az++
+	return networkSubnet{
+		AZ:      fmt.Sprintf("z%d", az),
+		Gateway: fmt.Sprintf("((az%d_ipv6_gateway))", az),
+		Range:   fmt.Sprintf("((az%d_ipv6_range))", az),
+		Reserved: []string{
+			fmt.Sprintf("((az%d_ipv6_reserved_1))", az),
+			fmt.Sprintf("((az%d_ipv6_reserved_2))", az),
+		},
+		Static: []string{
+			fmt.Sprintf("((az%d_ipv6_static))", az),
+		},
+		CloudProperties: networkSubnetCloudProperties{
+			Subnet:         fmt.Sprintf("((az%d_subnet))", az),
+			SecurityGroups: []string{"((internal_security_group))"},
+		},
+	}
+}
+
+func generateSiteNetworkSubnet(site string, az int) networkSubnet {
+	az++
+	return networkSubnet{
+		AZ:      fmt.Sprintf("%s-z%d", site, az),
+		Gateway: fmt.Sprintf("((%s_az%d_gateway))", site, az),
+		Range:   fmt.Sprintf("((%s_az%d_range))", site, az),
+		Reserved: []string{
+			fmt.Sprintf("((%s_az%d_reserved_1))", site, az),
+			fmt.Sprintf("((%s_az%d_reserved_2))", site, az),
+		},
+		Static: []string{
+			fmt.Sprintf("((%s_az%d_static))", site, az),
+		},
+		CloudProperties: networkSubnetCloudProperties{
+			Subnet:         fmt.Sprintf("((%s_az%d_subnet))", site, az),
+			SecurityGroups: []string{fmt.Sprintf("((%s_site_internal_security_group))", site)},
+		},
+	}
+}
@@ -15,6 +15,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
 )
 
 var _ = Describe("OpsGenerator", func() {
@@ -158,6 +159,81 @@ iso_az_subnet_id_mapping:
 `))
 		})
 
+		Context("when there are sites", func() {
+			BeforeEach(func() {
+				incomingState.AWS.Sites = []storage.AWSSite{{Name: "west", Region: "us-west-2", VPCCIDR: "10.1.0.0/16"}}
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["west_site_az_subnet_id_mapping"] = map[string]interface{}{
+					"us-west-2a": "some-west-subnet-id-1",
+				}
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["west_site_az_subnet_cidr_mapping"] = map[string]interface{}{
+					"us-west-2a": "10.1.16.0/20",
+				}
+			})
+
+			It("includes vars for each site availability zone", func() {
+				varsYAML, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var vars map[string]interface{}
+				Expect(yaml.Unmarshal([]byte(varsYAML), &vars)).To(Succeed())
+				Expect(vars).To(HaveKeyWithValue("west_az1_name", "us-west-2a"))
+				Expect(vars).To(HaveKeyWithValue("west_az1_gateway", "10.1.16.1"))
+				Expect(vars).To(HaveKeyWithValue("west_az1_range", "10.1.16.0/20"))
+				Expect(vars).To(HaveKeyWithValue("west_az1_reserved_1", "10.1.16.2-10.1.16.3"))
+				Expect(vars).To(HaveKeyWithValue("west_az1_reserved_2", "10.1.31.255"))
+				Expect(vars).To(HaveKeyWithValue("west_az1_static", "10.1.31.190-10.1.31.254"))
+				Expect(vars).To(HaveKeyWithValue("west_az1_subnet", "some-west-subnet-id-1"))
+			})
+
+			Context("when a site az is missing from the cidr map", func() {
+				BeforeEach(func() {
+					terraformManager.GetOutputsCall.Returns.Outputs.Map["west_site_az_subnet_cidr_mapping"] = map[string]interface{}{}
+				})
+
+				It("returns an error", func() {
+					_, err := opsGenerator.GenerateVars(incomingState)
+					Expect(err).To(MatchError("Generate availability zones for site west: missing AZ in terraform output: west_site_az_subnet_cidr_mapping"))
+				})
+			})
+		})
+
+		Context("when the network is dual stack", func() {
+			BeforeEach(func() {
+				incomingState.Network.DualStack = true
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["internal_az_subnet_ipv6_cidr_mapping"] = map[string]interface{}{
+					"us-east-1a": "2600:1f18:7f2:3d01::/64",
+					"us-east-1b": "2600:1f18:7f2:3d02::/64",
+					"us-east-1c": "2600:1f18:7f2:3d03::/64",
+				}
+			})
+
+			It("includes ipv6 vars for each internal availability zone", func() {
+				varsYAML, err := opsGenerator.GenerateVars(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				var vars map[string]interface{}
+				Expect(yaml.Unmarshal([]byte(varsYAML), &vars)).To(Succeed())
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_gateway", "2600:1f18:7f2:3d01::1"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_range", "2600:1f18:7f2:3d01::/64"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_reserved_1", "2600:1f18:7f2:3d01::2-2600:1f18:7f2:3d01::3"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_reserved_2", "2600:1f18:7f2:3d01:ffff:ffff:ffff:ffff"))
+				Expect(vars).To(HaveKeyWithValue("az1_ipv6_static", "2600:1f18:7f2:3d01:ffff:ffff:ffff:ffbe-2600:1f18:7f2:3d01:ffff:ffff:ffff:fffe"))
+				Expect(vars).To(HaveKeyWithValue("az3_ipv6_range", "2600:1f18:7f2:3d03::/64"))
+				Expect(vars).NotTo(HaveKey("az4_ipv6_range"))
+			})
+
+			Context("when an az is missing from the ipv6 cidr map", func() {
+				BeforeEach(func() {
+					terraformManager.GetOutputsCall.Returns.Outputs.Map["internal_az_subnet_ipv6_cidr_mapping"] = map[string]interface{}{}
+				})
+
+				It("returns an error", func() {
+					_, err := opsGenerator.GenerateVars(incomingState)
+					Expect(err).To(MatchError("missing AZ in terraform output: internal_az_subnet_ipv6_cidr_mapping"))
+				})
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when the az subnet id map has a key not in the cidr map", func() {
 				BeforeEach(func() {
@@ -267,6 +343,101 @@ iso_az_subnet_id_mapping:
 			})
 		})
 
+		Context("when the network is dual stack", func() {
+			BeforeEach(func() {
+				incomingState.Network.DualStack = true
+				availabilityZones.RetrieveAZsCall.Returns.AZs = []string{"us-east-1a"}
+			})
+
+			It("adds an ipv6 network alongside the default network", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`
+- type: replace
+  path: /networks/-
+  value:
+    name: default-ipv6
+    type: manual
+    subnets:
+    - az: z1
+      gateway: ((az1_ipv6_gateway))
+      range: ((az1_ipv6_range))
+      reserved:
+      - ((az1_ipv6_reserved_1))
+      - ((az1_ipv6_reserved_2))
+      static:
+      - ((az1_ipv6_static))
+      cloud_properties:
+        subnet: ((az1_subnet))
+        security_groups:
+        - ((internal_security_group))`))
+			})
+		})
+
+		Context("when there are sites", func() {
+			BeforeEach(func() {
+				incomingState.AWS.Sites = []storage.AWSSite{{Name: "west", Region: "us-west-2", VPCCIDR: "10.1.0.0/16"}}
+				availabilityZones.RetrieveAZsCall.Stub = func(region string) ([]string, error) {
+					if region == "us-west-2" {
+						return []string{"us-west-2a"}, nil
+					}
+					return []string{"us-east-1a", "us-east-1b", "us-east-1c"}, nil
+				}
+			})
+
+			It("assigns a cpi to every az and adds the site subnets to the networks", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`
+- type: replace
+  path: /azs/-
+  value:
+    name: z1
+    cpi: default
+    cloud_properties:
+      availability_zone: ((az1_name))`))
+				Expect(opsYAML).To(ContainSubstring(`
+- type: replace
+  path: /azs/-
+  value:
+    name: west-z1
+    cpi: west
+    cloud_properties:
+      availability_zone: ((west_az1_name))`))
+				Expect(opsYAML).To(ContainSubstring(`
+    - az: west-z1
+      gateway: ((west_az1_gateway))
+      range: ((west_az1_range))
+      reserved:
+      - ((west_az1_reserved_1))
+      - ((west_az1_reserved_2))
+      static:
+      - ((west_az1_static))
+      cloud_properties:
+        subnet: ((west_az1_subnet))
+        security_groups:
+        - ((west_site_internal_security_group))`))
+			})
+
+			Context("when retrieving the site availability zones fails", func() {
+				BeforeEach(func() {
+					availabilityZones.RetrieveAZsCall.Stub = func(region string) ([]string, error) {
+						if region == "us-west-2" {
+							return nil, errors.New("kiwi")
+						}
+						return []string{"us-east-1a"}, nil
+					}
+				})
+
+				It("returns an error", func() {
+					_, err := opsGenerator.Generate(incomingState)
+					Expect(err).To(MatchError("Retrieve availability zones for site west: kiwi"))
+				})
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when ops fails to marshal", func() {
 				It("returns an error", func() {
@@ -280,4 +451,68 @@ iso_az_subnet_id_mapping:
 			})
 		})
 	})
+
+	Describe("GenerateCPIConfig", func() {
+		It("returns an empty cpi config when there are no sites", func() {
+			cpiConfig, err := opsGenerator.GenerateCPIConfig(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cpiConfig).To(BeEmpty())
+			Expect(terraformManager.GetOutputsCall.CallCount).To(Equal(0))
+		})
+
+		Context("when there are sites", func() {
+			BeforeEach(func() {
+				incomingState.AWS.AccessKeyID = "some-access-key-id"
+				incomingState.AWS.SecretAccessKey = "some-secret-access-key"
+				incomingState.AWS.Sites = []storage.AWSSite{{Name: "west", Region: "us-west-2", VPCCIDR: "10.1.0.0/16"}}
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["default_key_name"] = "some-key-name"
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["west_site_default_key_name"] = "some-west-key-name"
+				terraformManager.GetOutputsCall.Returns.Outputs.Map["west_site_internal_security_group"] = "some-west-internal-security-group"
+			})
+
+			It("returns a cpi for the main region and one for each site", func() {
+				cpiConfig, err := opsGenerator.GenerateCPIConfig(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cpiConfig).To(MatchYAML(`
+cpis:
+- name: default
+  type: aws
+  properties:
+    access_key_id: some-access-key-id
+    secret_access_key: some-secret-access-key
+    default_key_name: some-key-name
+    default_security_groups: [some-internal-security-group]
+    region: us-east-1
+- name: west
+  type: aws
+  properties:
+    access_key_id: some-access-key-id
+    secret_access_key: some-secret-access-key
+    default_key_name: some-west-key-name
+    default_security_groups: [some-west-internal-security-group]
+    region: us-west-2
+`))
+			})
+
+			Context("when terraform fails to get outputs", func() {
+				It("returns an error", func() {
+					terraformManager.GetOutputsCall.Returns.Error = errors.New("breadfruit")
+					_, err := opsGenerator.GenerateCPIConfig(incomingState)
+					Expect(err).To(MatchError("Get terraform outputs: breadfruit"))
+				})
+			})
+
+			DescribeTable("when a terraform output is missing", func(outputKey string) {
+				delete(terraformManager.GetOutputsCall.Returns.Outputs.Map, outputKey)
+				_, err := opsGenerator.GenerateCPIConfig(incomingState)
+				Expect(err).To(MatchError(fmt.Sprintf("missing %s terraform output", outputKey)))
+			},
+				Entry("when default_key_name is missing", "default_key_name"),
+				Entry("when west_site_default_key_name is missing", "west_site_default_key_name"),
+				Entry("when west_site_internal_security_group is missing", "west_site_internal_security_group"),
+			)
+		})
+	})
 })
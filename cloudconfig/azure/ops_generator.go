@@ -167,3 +167,7 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		string(cloudConfigOpsYAML),
 	}, "\n"), nil
 }
+
+func (o OpsGenerator) GenerateCPIConfig(state storage.State) (string, error) {
+	return "", nil
+}
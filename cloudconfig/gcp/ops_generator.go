@@ -274,3 +274,7 @@ func generateNetworkSubnet(az int) networkSubnet {
 		},
 	}
 }
+
+func (o OpsGenerator) GenerateCPIConfig(state storage.State) (string, error) {
+	return "", nil
+}
@@ -39,6 +39,7 @@ type command interface {
 type OpsGenerator interface {
 	Generate(state storage.State) (string, error)
 	GenerateVars(state storage.State) (string, error)
+	GenerateCPIConfig(state storage.State) (string, error)
 }
 
 type boshClientProvider interface {
@@ -150,12 +151,15 @@ func (m Manager) Interpolate() (string, error) {
 		return "", err
 	}
 
-	args := []string{
-		"interpolate", filepath.Join(cloudConfigDir, "cloud-config.yml"),
-		"--vars-file", filepath.Join(varsDir, "cloud-config-vars.yml"),
-		"-o", filepath.Join(cloudConfigDir, "ops.yml"),
+	args := []string{"interpolate", filepath.Join(cloudConfigDir, "cloud-config.yml")}
+
+	varsFile := filepath.Join(varsDir, "cloud-config-vars.yml")
+	if _, err := m.fs.Stat(varsFile); err == nil {
+		args = append(args, "--vars-file", varsFile)
 	}
 
+	args = append(args, "-o", filepath.Join(cloudConfigDir, "ops.yml"))
+
 	files, err := m.fs.ReadDir(cloudConfigDir)
 	if err != nil {
 		return "", fmt.Errorf("Read cloud config dir: %s", err)
@@ -183,6 +187,24 @@ func (m Manager) Update(state storage.State) error {
 		return err // not tested
 	}
 
+	cpiConfig, err := m.opsGenerator.GenerateCPIConfig(state)
+	if err != nil {
+		return fmt.Errorf("Generate cpi config: %s", err)
+	}
+
+	if cpiConfig != "" {
+		m.logger.Step("applying cpi config")
+		err = boshClient.UpdateConfig(bosh.CPIConfigType, "default", []byte(cpiConfig))
+		if err != nil {
+			return fmt.Errorf("Update cpi config: %s", err)
+		}
+	} else {
+		err = m.deleteCPIConfig(boshClient)
+		if err != nil {
+			return err
+		}
+	}
+
 	m.logger.Step("generating cloud config")
 
 	err = m.GenerateVars(state)
@@ -203,3 +225,26 @@ func (m Manager) Update(state storage.State) error {
 
 	return nil
 }
+
+// deleteCPIConfig deletes the cpi config bbl applied for earlier sites, so
+// that the director stops using the CPIs of removed sites.
+func (m Manager) deleteCPIConfig(boshClient bosh.Client) error {
+	configs, err := boshClient.Configs(bosh.CPIConfigType)
+	if err != nil {
+		return fmt.Errorf("List cpi configs: %s", err)
+	}
+
+	for _, config := range configs {
+		if config.Name != "default" {
+			continue
+		}
+
+		m.logger.Step("deleting cpi config")
+		err = boshClient.DeleteConfig(bosh.CPIConfigType, "default")
+		if err != nil {
+			return fmt.Errorf("Delete cpi config: %s", err)
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -316,6 +317,20 @@ var _ = Describe("Manager", func() {
 			Expect(cloudConfigYAML).To(Equal("some-cloud-config"))
 		})
 
+		Context("when the cloud config vars have not been generated", func() {
+			BeforeEach(func() {
+				fileIO.StatCall.Returns.Error = errors.New("no such file")
+			})
+
+			It("interpolates without a vars file", func() {
+				_, err := manager.Interpolate()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, args := cli.RunArgsForCall(0)
+				Expect(args).NotTo(ContainElement("--vars-file"))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when getting the cloud config dir fails", func() {
 				BeforeEach(func() {
@@ -373,6 +388,90 @@ var _ = Describe("Manager", func() {
 			}))
 		})
 
+		Context("when the ops generator returns a cpi config", func() {
+			BeforeEach(func() {
+				opsGenerator.GenerateCPIConfigCall.Returns.CPIConfigYAML = "some-cpi-config"
+			})
+
+			It("applies the cpi config before the cloud config", func() {
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsGenerator.GenerateCPIConfigCall.Receives.State).To(Equal(incomingState))
+				Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(1))
+				Expect(boshClient.UpdateConfigCall.Receives[0]).To(Equal(fakes.UpdateConfigReceive{
+					ConfigType: "cpi",
+					Name:       "default",
+					Yaml:       []byte("some-cpi-config"),
+				}))
+				Expect(logger.StepCall.Messages).To(Equal([]string{
+					"applying cpi config",
+					"generating cloud config",
+					"applying cloud config",
+				}))
+			})
+
+			Context("when bosh client fails to update the cpi config", func() {
+				BeforeEach(func() {
+					boshClient.UpdateConfigCall.Returns.Error = errors.New("failed to update")
+				})
+
+				It("returns an error", func() {
+					err := manager.Update(incomingState)
+					Expect(err).To(MatchError("Update cpi config: failed to update"))
+					Expect(boshClient.UpdateCloudConfigCall.CallCount).To(Equal(0))
+				})
+			})
+		})
+
+		It("does not apply a cpi config when none is generated", func() {
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+			Expect(boshClient.DeleteConfigCall.CallCount).To(Equal(0))
+		})
+
+		Context("when a cpi config was applied before and none is generated anymore", func() {
+			BeforeEach(func() {
+				boshClient.ConfigsCall.Returns.Configs = []bosh.Config{{Name: "default", Type: "cpi"}}
+			})
+
+			It("deletes the cpi config", func() {
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClient.ConfigsCall.Receives.ConfigType).To(Equal("cpi"))
+				Expect(boshClient.DeleteConfigCall.Receives).To(Equal([]fakes.DeleteConfigReceive{
+					{ConfigType: "cpi", Name: "default"},
+				}))
+				Expect(logger.StepCall.Messages).To(Equal([]string{
+					"deleting cpi config",
+					"generating cloud config",
+					"applying cloud config",
+				}))
+			})
+
+			Context("when listing the cpi configs fails", func() {
+				It("returns an error", func() {
+					boshClient.ConfigsCall.Returns.Error = errors.New("failed to list")
+
+					err := manager.Update(incomingState)
+					Expect(err).To(MatchError("List cpi configs: failed to list"))
+				})
+			})
+
+			Context("when deleting the cpi config fails", func() {
+				It("returns an error", func() {
+					boshClient.DeleteConfigCall.Returns.Error = errors.New("failed to delete")
+
+					err := manager.Update(incomingState)
+					Expect(err).To(MatchError("Delete cpi config: failed to delete"))
+					Expect(boshClient.UpdateCloudConfigCall.CallCount).To(Equal(0))
+				})
+			})
+		})
+
 		It("updates the bosh director with a cloud config provided a valid bbl state", func() {
 			err := manager.Update(incomingState)
 			Expect(err).NotTo(HaveOccurred())
@@ -396,6 +495,17 @@ var _ = Describe("Manager", func() {
 				})
 			})
 
+			Context("when the ops generator fails to generate a cpi config", func() {
+				BeforeEach(func() {
+					opsGenerator.GenerateCPIConfigCall.Returns.Error = errors.New("failed to generate")
+				})
+
+				It("returns an error", func() {
+					err := manager.Update(storage.State{})
+					Expect(err).To(MatchError("Generate cpi config: failed to generate"))
+				})
+			})
+
 			Context("when bosh client fails to update cloud config", func() {
 				BeforeEach(func() {
 					boshClient.UpdateCloudConfigCall.Returns.Error = errors.New("failed to update")
@@ -50,7 +50,7 @@ const (
       gateway: ((internal_gw))
       azs: [z1, z2, z3]
       dns: [8.8.8.8]
-      reserved: [((jumpbox__internal_ip))]
+      reserved: [((jumpbox__internal_ip)), ((director__internal_ip))]
       cloud_properties:
         name: ((network_name))
 
@@ -38,3 +38,7 @@ func (o OpsGenerator) GenerateVars(state storage.State) (string, error) {
 	}
 	return string(varsBytes), nil
 }
+
+func (o OpsGenerator) GenerateCPIConfig(state storage.State) (string, error) {
+	return "", nil
+}
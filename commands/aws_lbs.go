@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"errors"
 	"strings"
 
@@ -11,12 +10,14 @@ import (
 type AWSLBs struct {
 	terraformManager terraformManager
 	logger           logger
+	output           Output
 }
 
-func NewAWSLBs(terraformManager terraformManager, logger logger) AWSLBs {
+func NewAWSLBs(terraformManager terraformManager, logger logger, output Output) AWSLBs {
 	return AWSLBs{
 		terraformManager: terraformManager,
 		logger:           logger,
+		output:           output,
 	}
 }
 
@@ -26,10 +27,15 @@ func (l AWSLBs) Execute(subcommandFlags []string, state storage.State) error {
 		return err
 	}
 
+	output := l.output
+	if len(subcommandFlags) > 0 && subcommandFlags[0] == "--json" {
+		output = NewOutput(l.logger, OutputJSON)
+	}
+
 	switch state.LB.Type {
 	case "cf":
-		if len(subcommandFlags) > 0 && subcommandFlags[0] == "--json" {
-			lbOutput, err := json.Marshal(struct {
+		if !output.Text() {
+			return output.Print(struct {
 				RouterLBName           string   `json:"cf_router_lb,omitempty"`
 				RouterLBURL            string   `json:"cf_router_lb_url,omitempty"`
 				SSHProxyLBName         string   `json:"cf_ssh_proxy_lb,omitempty"`
@@ -46,24 +52,28 @@ func (l AWSLBs) Execute(subcommandFlags []string, state storage.State) error {
 				TCPRouterLBURL:         terraformOutputs.GetString("cf_tcp_lb_url"),
 				SystemDomainDNSServers: terraformOutputs.GetStringSlice("env_dns_zone_name_servers"),
 			})
-			if err != nil {
-				// not tested
-				return err
-			}
+		}
 
-			l.logger.Println(string(lbOutput))
-		} else {
-			l.logger.Printf("CF Router LB: %s [%s]\n", terraformOutputs.GetString("cf_router_lb_name"), terraformOutputs.GetString("cf_router_lb_url"))
-			l.logger.Printf("CF SSH Proxy LB: %s [%s]\n", terraformOutputs.GetString("cf_ssh_lb_name"), terraformOutputs.GetString("cf_ssh_lb_url"))
-			l.logger.Printf("CF TCP Router LB: %s [%s]\n", terraformOutputs.GetString("cf_tcp_lb_name"), terraformOutputs.GetString("cf_tcp_lb_url"))
+		l.logger.Printf("CF Router LB: %s [%s]\n", terraformOutputs.GetString("cf_router_lb_name"), terraformOutputs.GetString("cf_router_lb_url"))
+		l.logger.Printf("CF SSH Proxy LB: %s [%s]\n", terraformOutputs.GetString("cf_ssh_lb_name"), terraformOutputs.GetString("cf_ssh_lb_url"))
+		l.logger.Printf("CF TCP Router LB: %s [%s]\n", terraformOutputs.GetString("cf_tcp_lb_name"), terraformOutputs.GetString("cf_tcp_lb_url"))
 
-			dnsServers := terraformOutputs.GetStringSlice("env_dns_zone_name_servers")
+		dnsServers := terraformOutputs.GetStringSlice("env_dns_zone_name_servers")
 
-			if len(dnsServers) > 0 {
-				l.logger.Printf("CF System Domain DNS servers: %s\n", strings.Join(dnsServers, " "))
-			}
+		if len(dnsServers) > 0 {
+			l.logger.Printf("CF System Domain DNS servers: %s\n", strings.Join(dnsServers, " "))
 		}
 	case "concourse":
+		if !output.Text() {
+			return output.Print(struct {
+				ConcourseLBName string `json:"concourse_lb,omitempty"`
+				ConcourseLBURL  string `json:"concourse_lb_url,omitempty"`
+			}{
+				ConcourseLBName: terraformOutputs.GetString("concourse_lb_name"),
+				ConcourseLBURL:  terraformOutputs.GetString("concourse_lb_url"),
+			})
+		}
+
 		l.logger.Printf("Concourse LB: %s [%s]\n", terraformOutputs.GetString("concourse_lb_name"), terraformOutputs.GetString("concourse_lb_url"))
 	default:
 		return errors.New("no lbs found")
@@ -26,7 +26,7 @@ var _ = Describe("AWSLBs", func() {
 		terraformManager = &fakes.TerraformManager{}
 		logger = &fakes.Logger{}
 
-		command = commands.NewAWSLBs(terraformManager, logger)
+		command = commands.NewAWSLBs(terraformManager, logger, commands.NewOutput(logger, ""))
 	})
 
 	Describe("Execute", func() {
@@ -48,6 +48,21 @@ var _ = Describe("AWSLBs", func() {
 				}}
 			})
 
+			It("prints LB names and URLs as yaml", func() {
+				command = commands.NewAWSLBs(terraformManager, logger, commands.NewOutput(logger, "yaml"))
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(Equal([]string{`cf_router_lb: some-router-lb-name
+cf_router_lb_url: some-router-lb-url
+cf_ssh_proxy_lb: some-ssh-lb-name
+cf_ssh_proxy_lb_url: some-ssh-lb-url
+cf_tcp_lb: some-tcp-lb-name
+cf_tcp_lb_url: some-tcp-lb-url
+`}))
+			})
+
 			It("prints LB names and URLs for router and ssh proxy", func() {
 				err := command.Execute([]string{}, incomingState)
 				Expect(err).NotTo(HaveOccurred())
@@ -129,6 +144,18 @@ var _ = Describe("AWSLBs", func() {
 					"Concourse LB: some-concourse-lb-name [some-concourse-lb-url]\n",
 				}))
 			})
+
+			It("prints LB name and URL as json", func() {
+				command = commands.NewAWSLBs(terraformManager, logger, commands.NewOutput(logger, "json"))
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(`{
+					"concourse_lb": "some-concourse-lb-name",
+					"concourse_lb_url": "some-concourse-lb-url"
+				}`))
+			})
 		})
 
 		Context("when lb type is not cf or concourse", func() {
@@ -9,12 +9,14 @@ import (
 type AzureLBs struct {
 	terraformManager terraformManager
 	logger           logger
+	output           Output
 }
 
-func NewAzureLBs(terraformManager terraformManager, logger logger) AzureLBs {
+func NewAzureLBs(terraformManager terraformManager, logger logger, output Output) AzureLBs {
 	return AzureLBs{
 		terraformManager: terraformManager,
 		logger:           logger,
+		output:           output,
 	}
 }
 
@@ -26,8 +28,26 @@ func (l AzureLBs) Execute(subcommandFlags []string, state storage.State) error {
 
 	switch state.LB.Type {
 	case "cf":
+		if !l.output.Text() {
+			return l.output.Print(struct {
+				CFLBName string `json:"cf_lb,omitempty"`
+			}{
+				CFLBName: terraformOutputs.GetString("cf_app_gateway_name"),
+			})
+		}
+
 		l.logger.Printf("CF LB: %s\n", terraformOutputs.GetString("cf_app_gateway_name"))
 	case "concourse":
+		if !l.output.Text() {
+			return l.output.Print(struct {
+				ConcourseLBName string `json:"concourse_lb,omitempty"`
+				ConcourseLBIP   string `json:"concourse_lb_ip,omitempty"`
+			}{
+				ConcourseLBName: terraformOutputs.GetString("concourse_lb_name"),
+				ConcourseLBIP:   terraformOutputs.GetString("concourse_lb_ip"),
+			})
+		}
+
 		l.logger.Printf("Concourse LB: %s (%s)\n", terraformOutputs.GetString("concourse_lb_name"), terraformOutputs.GetString("concourse_lb_ip"))
 	default:
 		return errors.New("no lbs found")
@@ -26,7 +26,7 @@ var _ = Describe("Azure LBs", func() {
 		terraformManager = &fakes.TerraformManager{}
 		logger = &fakes.Logger{}
 
-		command = commands.NewAzureLBs(terraformManager, logger)
+		command = commands.NewAzureLBs(terraformManager, logger, commands.NewOutput(logger, ""))
 	})
 
 	Describe("Execute", func() {
@@ -52,6 +52,15 @@ var _ = Describe("Azure LBs", func() {
 					"CF LB: some-app-gateway-name\n",
 				}))
 			})
+
+			It("prints LB name as json", func() {
+				command = commands.NewAzureLBs(terraformManager, logger, commands.NewOutput(logger, "json"))
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(`{"cf_lb": "some-app-gateway-name"}`))
+			})
 		})
 
 		Context("when the lb type is concourse", func() {
@@ -77,6 +86,18 @@ var _ = Describe("Azure LBs", func() {
 					"Concourse LB: some-load-balancer-name (5.6.7.8)\n",
 				}))
 			})
+
+			It("prints LB name and ip as json", func() {
+				command = commands.NewAzureLBs(terraformManager, logger, commands.NewOutput(logger, "json"))
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(`{
+					"concourse_lb": "some-load-balancer-name",
+					"concourse_lb_ip": "5.6.7.8"
+				}`))
+			})
 		})
 
 		Context("when lb type is not cf or concourse", func() {
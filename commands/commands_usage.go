@@ -54,21 +54,45 @@ const (
   --lb-chain                 Path to SSL certificate chain (supported when iaas="aws")
   --lb-domain                Creates a DNS zone and records for the given domain (supported when type="cf")`
 
+	SizingUsage = `
+
+  Sizing options:
+  --director-vm-type         VM type for the BOSH director (not supported on vsphere)
+  --director-disk-size       Persistent disk size for the BOSH director in GB
+  --jumpbox-vm-type          VM type for the jumpbox (not supported on vsphere)
+  --stemcell-line            Stemcell line for the jumpbox and director: "ubuntu-trusty" or "ubuntu-xenial"
+  --stemcell-version         Stemcell version on bosh.io (required with --stemcell-line)
+  --stemcell-sha1            SHA1 of the stemcell tarball (required with --stemcell-line)`
+
 	PlanCommandUsage = `Populates a state directory with the latest config without applying it
 
   --iaas                     IAAS to deploy your BOSH director onto: "aws", "azure", "gcp", "vsphere"   env: $BBL_IAAS
   --name                     Name to assign to your BOSH director (optional)                            env: $BBL_ENV_NAME
+  --render                   Directory to write the interpolated jumpbox, director and cloud config manifests to (optional)
+  --redact                   Leave credentials out of the manifests written by --render (optional)
+  --force                    Back up bbl-managed files that were modified locally and overwrite them (optional)
+  --bosh-deployment-dir      Local bosh-deployment checkout to use instead of the embedded copy (optional) env: $BBL_BOSH_DEPLOYMENT_DIR
+  --jumpbox-deployment-dir   Local jumpbox-deployment checkout to use instead of the embedded copy (optional) env: $BBL_JUMPBOX_DEPLOYMENT_DIR
+  --network-cidr             CIDR of the network bbl creates on aws, gcp and azure (optional)          env: $BBL_NETWORK_CIDR
+  --internal-cidr            CIDR of the subnet for the jumpbox and director (optional)                 env: $BBL_INTERNAL_CIDR
+  --jumpbox-ip-offset        Offset of the jumpbox IP within the internal CIDR, default 5 (optional)     env: $BBL_JUMPBOX_IP_OFFSET
+  --director-ip-offset       Offset of the director IP within the internal CIDR, default 6 (optional)    env: $BBL_DIRECTOR_IP_OFFSET
+  --dual-stack               Also assign IPv6 CIDRs on aws, or IPv6 cf LB ingress on gcp (optional)     env: $BBL_DUAL_STACK
+  --allowed-cidrs            Source CIDR allowed to reach the jumpbox and director, repeatable (optional) env: $BBL_ALLOWED_CIDRS
 `
 
 	UpCommandUsage = `Deploys BOSH director on an IAAS
 
   --iaas                     IAAS to deploy your BOSH director onto: "aws", "azure", "gcp", "vsphere"   env: $BBL_IAAS
   --name                     Name to assign to your BOSH director (optional)                            env: $BBL_ENV_NAME
+  --allowed-cidrs            Source CIDR allowed to reach the jumpbox and director, repeatable (optional) env: $BBL_ALLOWED_CIDRS
+  --force                    Back up bbl-managed files that were modified locally and overwrite them (optional)
 `
 
 	DestroyCommandUsage = `Tears down BOSH director infrastructure
 
-  [--no-confirm]       Do not ask for confirmation (optional)`
+  [--no-confirm]            Do not ask for confirmation (optional)
+  [--delete-deployments]    Delete every deployment and orphaned disk on the director first (optional)`
 
 	CleanupLeftoversCommandUsage = `Cleans up orphaned IAAS resources
 
@@ -89,9 +113,56 @@ const (
 	DirectorSSHKeyCommandUsage = "Prints SSH private key for the director."
 
 	SSHCommandUsage = `Opens an SSH connection to the director or the jumpbox.
+Runs the command after -- instead of a shell and exits with its status.
 
   --jumpbox                Open a connection to the jumpbox
   --director               Open a connection to the director
+
+  bbl ssh --director -- sudo monit summary
+`
+
+	EnvExecCommandUsage = `Runs a command with the BOSH environment variables set, and exits with its status.
+The jumpbox private key is removed when the command exits.
+
+  bbl env-exec [--use-tunnel] -- <command>
+
+  --use-tunnel             Proxy through the tunnel started by bbl tunnel
+`
+
+	PluginCommandUsage = `Runs the plugin at %s with the arguments given to bbl.
+The plugin gets BBL_STATE_DIR, BBL_IAAS, BBL_ENV_ID, BBL_TERRAFORM_OUTPUTS and the
+print-env variables in its environment.
+`
+
+	DoctorCommandUsage = `Checks the workstation and the state directory for common problems and prints how to fix them:
+the terraform and bosh-cli versions, nc or connect-proxy, bbl-state.json, state file permissions,
+the vars stores of deployed VMs and the jumpbox SSH key. Exits non-zero when a check fails.
+`
+
+	PatchCommandUsage = `Adds, lists and removes plan patches in the state directory.
+bbl records the files of each patch and their hashes in bbl-patches.json.
+
+  bbl patch add [--force] <dir>      Copies a plan patch into the state directory
+  bbl patch list                     Prints the added plan patches and the files modified since
+  bbl patch remove [--force] <name>  Deletes the files of a plan patch
+
+  [--force]                Replace or remove files that were modified locally, backing up existing files a patch replaces
+`
+
+	TunnelCommandUsage = `Runs a SOCKS5 proxy and port forwards through the jumpbox.
+Prints the proxy address, for example socks5://127.0.0.1:1080.
+
+  [--port]                 Local port for the proxy (Default: a random port)
+  [-L]                     Forward <local-port>:<host>[:<port>], where <host> may be "director". Repeatable
+  [--background]           Run in the background and write tunnel.pid to the state directory
+`
+
+	SCPCommandUsage = `Copies files to or from the director or the jumpbox.
+Exactly one of <source> and <destination> starts with jumpbox: or director:.
+
+  bbl scp [-r] <source> <destination>
+
+  -r                       Copy directories recursively
 `
 
 	RotateCommandUsage = "Rotates SSH key for the jumpbox user."
@@ -106,17 +177,21 @@ const (
 
 	DirectorCACertCommandUsage = "Prints BOSH director CA certificate"
 
-	PrintEnvCommandUsage = "Prints required BOSH environment variables"
+	PrintEnvCommandUsage = `Prints required BOSH environment variables
+
+  --use-tunnel             Proxy through the tunnel started by bbl tunnel
+  --shell                  Shell to print commands for: posix, fish or powershell (Default: posix)
+  --format                 Print a file instead: dotenv or json`
 
 	LatestErrorCommandUsage = "Prints the output from the latest call to terraform"
 )
 
 func (Up) Usage() string {
-	return fmt.Sprintf("%s%s%s", UpCommandUsage, Credentials, LBUsage)
+	return fmt.Sprintf("%s%s%s%s", UpCommandUsage, Credentials, LBUsage, SizingUsage)
 }
 
 func (Plan) Usage() string {
-	return fmt.Sprintf("%s%s%s", PlanCommandUsage, Credentials, LBUsage)
+	return fmt.Sprintf("%s%s%s%s", PlanCommandUsage, Credentials, LBUsage, SizingUsage)
 }
 
 func (Destroy) Usage() string {
@@ -152,6 +227,22 @@ func (s SSH) Usage() string {
 	return SSHCommandUsage
 }
 
+func (e EnvExec) Usage() string {
+	return EnvExecCommandUsage
+}
+
+func (p Plugin) Usage() string {
+	return fmt.Sprintf(PluginCommandUsage, p.path)
+}
+
+func (t Tunnel) Usage() string {
+	return TunnelCommandUsage
+}
+
+func (s SCP) Usage() string {
+	return SCPCommandUsage
+}
+
 func (s StateQuery) Usage() string {
 	switch s.propertyName {
 	case EnvIDPropertyName:
@@ -20,6 +20,8 @@ var _ = Describe("Commands Usage", func() {
 
   --iaas                     IAAS to deploy your BOSH director onto: "aws", "azure", "gcp", "vsphere"   env: $BBL_IAAS
   --name                     Name to assign to your BOSH director (optional)                            env: $BBL_ENV_NAME
+  --allowed-cidrs            Source CIDR allowed to reach the jumpbox and director, repeatable (optional) env: $BBL_ALLOWED_CIDRS
+  --force                    Back up bbl-managed files that were modified locally and overwrite them (optional)
 
   --aws-access-key-id                AWS Access Key ID                env: $BBL_AWS_ACCESS_KEY_ID
   --aws-secret-access-key            AWS Secret Access Key            env: $BBL_AWS_SECRET_ACCESS_KEY
@@ -63,7 +65,15 @@ var _ = Describe("Commands Usage", func() {
   --lb-cert                  Path to SSL certificate (supported when type="cf")
   --lb-key                   Path to SSL certificate key (supported when type="cf")
   --lb-chain                 Path to SSL certificate chain (supported when iaas="aws")
-  --lb-domain                Creates a DNS zone and records for the given domain (supported when type="cf")`))
+  --lb-domain                Creates a DNS zone and records for the given domain (supported when type="cf")
+
+  Sizing options:
+  --director-vm-type         VM type for the BOSH director (not supported on vsphere)
+  --director-disk-size       Persistent disk size for the BOSH director in GB
+  --jumpbox-vm-type          VM type for the jumpbox (not supported on vsphere)
+  --stemcell-line            Stemcell line for the jumpbox and director: "ubuntu-trusty" or "ubuntu-xenial"
+  --stemcell-version         Stemcell version on bosh.io (required with --stemcell-line)
+  --stemcell-sha1            SHA1 of the stemcell tarball (required with --stemcell-line)`))
 			})
 		})
 	})
@@ -77,7 +87,18 @@ var _ = Describe("Commands Usage", func() {
 
   --iaas                     IAAS to deploy your BOSH director onto: "aws", "azure", "gcp", "vsphere"   env: $BBL_IAAS
   --name                     Name to assign to your BOSH director (optional)                            env: $BBL_ENV_NAME
-%s%s`, commands.Credentials, commands.LBUsage)))
+  --render                   Directory to write the interpolated jumpbox, director and cloud config manifests to (optional)
+  --redact                   Leave credentials out of the manifests written by --render (optional)
+  --force                    Back up bbl-managed files that were modified locally and overwrite them (optional)
+  --bosh-deployment-dir      Local bosh-deployment checkout to use instead of the embedded copy (optional) env: $BBL_BOSH_DEPLOYMENT_DIR
+  --jumpbox-deployment-dir   Local jumpbox-deployment checkout to use instead of the embedded copy (optional) env: $BBL_JUMPBOX_DEPLOYMENT_DIR
+  --network-cidr             CIDR of the network bbl creates on aws, gcp and azure (optional)          env: $BBL_NETWORK_CIDR
+  --internal-cidr            CIDR of the subnet for the jumpbox and director (optional)                 env: $BBL_INTERNAL_CIDR
+  --jumpbox-ip-offset        Offset of the jumpbox IP within the internal CIDR, default 5 (optional)     env: $BBL_JUMPBOX_IP_OFFSET
+  --director-ip-offset       Offset of the director IP within the internal CIDR, default 6 (optional)    env: $BBL_DIRECTOR_IP_OFFSET
+  --dual-stack               Also assign IPv6 CIDRs on aws, or IPv6 cf LB ingress on gcp (optional)     env: $BBL_DUAL_STACK
+  --allowed-cidrs            Source CIDR allowed to reach the jumpbox and director, repeatable (optional) env: $BBL_ALLOWED_CIDRS
+%s%s%s`, commands.Credentials, commands.LBUsage, commands.SizingUsage)))
 			})
 		})
 	})
@@ -89,7 +110,8 @@ var _ = Describe("Commands Usage", func() {
 				usageText := command.Usage()
 				Expect(usageText).To(Equal(fmt.Sprintf(`Tears down BOSH director infrastructure
 
-  [--no-confirm]       Do not ask for confirmation (optional)
+  [--no-confirm]            Do not ask for confirmation (optional)
+  [--delete-deployments]    Delete every deployment and orphaned disk on the director first (optional)
 
   Credentials for your IaaS are required:%s`, commands.Credentials)))
 			})
@@ -126,9 +148,60 @@ var _ = Describe("Commands Usage", func() {
 				command := commands.SSH{}
 				usageText := command.Usage()
 				Expect(usageText).To(Equal(`Opens an SSH connection to the director or the jumpbox.
+Runs the command after -- instead of a shell and exits with its status.
 
   --jumpbox                Open a connection to the jumpbox
   --director               Open a connection to the director
+
+  bbl ssh --director -- sudo monit summary
+`))
+			})
+		})
+	})
+
+	Describe("EnvExec", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.EnvExec{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Runs a command with the BOSH environment variables set, and exits with its status.
+The jumpbox private key is removed when the command exits.
+
+  bbl env-exec [--use-tunnel] -- <command>
+
+  --use-tunnel             Proxy through the tunnel started by bbl tunnel
+`))
+			})
+		})
+	})
+
+	Describe("Tunnel", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.Tunnel{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Runs a SOCKS5 proxy and port forwards through the jumpbox.
+Prints the proxy address, for example socks5://127.0.0.1:1080.
+
+  [--port]                 Local port for the proxy (Default: a random port)
+  [-L]                     Forward <local-port>:<host>[:<port>], where <host> may be "director". Repeatable
+  [--background]           Run in the background and write tunnel.pid to the state directory
+`))
+			})
+		})
+	})
+
+	Describe("SCP", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.SCP{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Copies files to or from the director or the jumpbox.
+Exactly one of <source> and <destination> starts with jumpbox: or director:.
+
+  bbl scp [-r] <source> <destination>
+
+  -r                       Copy directories recursively
 `))
 			})
 		})
@@ -158,12 +231,16 @@ var _ = Describe("Commands Usage", func() {
 		Entry("env-id", newStateQuery("environment id"), "Prints environment ID"),
 		Entry("ssh-key", commands.SSHKey{}, "Prints SSH private key for the jumpbox."),
 		Entry("director-ssh-key", commands.SSHKey{Director: true}, "Prints SSH private key for the director."),
-		Entry("print-env", commands.PrintEnv{}, "Prints required BOSH environment variables"),
+		Entry("print-env", commands.PrintEnv{}, `Prints required BOSH environment variables
+
+  --use-tunnel             Proxy through the tunnel started by bbl tunnel
+  --shell                  Shell to print commands for: posix, fish or powershell (Default: posix)
+  --format                 Print a file instead: dotenv or json`),
 		Entry("latest-error", commands.LatestError{}, "Prints the output from the latest call to terraform"),
 		Entry("version", commands.Version{}, "Prints version"),
 	)
 })
 
 func newStateQuery(propertyName string) commands.StateQuery {
-	return commands.NewStateQuery(nil, nil, nil, propertyName)
+	return commands.NewStateQuery(nil, nil, nil, propertyName, commands.Output{})
 }
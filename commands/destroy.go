@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
 	"github.com/cloudfoundry/bosh-bootloader/helpers"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
@@ -17,10 +18,14 @@ type Destroy struct {
 	stateValidator           stateValidator
 	terraformManager         terraformManager
 	networkDeletionValidator NetworkDeletionValidator
+	timingsLog               timingsLog
+	hooks                    hooks
+	boshClientProvider       boshClientProvider
 }
 
 type destroyConfig struct {
-	NoConfirm bool
+	NoConfirm         bool
+	DeleteDeployments bool
 }
 
 type NetworkDeletionValidator interface {
@@ -29,7 +34,8 @@ type NetworkDeletionValidator interface {
 
 func NewDestroy(plan plan, logger logger, boshManager boshManager, stateStore stateStore,
 	stateValidator stateValidator, terraformManager terraformManager,
-	networkDeletionValidator NetworkDeletionValidator) Destroy {
+	networkDeletionValidator NetworkDeletionValidator, timingsLog timingsLog, hooks hooks,
+	boshClientProvider boshClientProvider) Destroy {
 	return Destroy{
 		plan:                     plan,
 		logger:                   logger,
@@ -38,6 +44,9 @@ func NewDestroy(plan plan, logger logger, boshManager boshManager, stateStore st
 		stateValidator:           stateValidator,
 		terraformManager:         terraformManager,
 		networkDeletionValidator: networkDeletionValidator,
+		timingsLog:               timingsLog,
+		hooks:                    hooks,
+		boshClientProvider:       boshClientProvider,
 	}
 }
 
@@ -57,6 +66,37 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 		return err
 	}
 
+	config, err := d.parseArgs(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	// The deployments are deleted before the network is checked again in
+	// Execute.
+	if config.DeleteDeployments {
+		if state.NoDirector || state.BOSH.DirectorAddress == "" {
+			return fmt.Errorf("--delete-deployments needs a bosh director, but %s does not have one.", state.EnvID)
+		}
+		return nil
+	}
+
+	return d.validateSafeToDelete(state)
+}
+
+func (d Destroy) parseArgs(subcommandFlags []string) (destroyConfig, error) {
+	var config destroyConfig
+	destroyFlags := flags.New("destroy")
+	destroyFlags.Bool(&config.DeleteDeployments, "delete-deployments")
+
+	err := destroyFlags.Parse(subcommandFlags)
+	if err != nil {
+		return destroyConfig{}, err
+	}
+
+	return config, nil
+}
+
+func (d Destroy) validateSafeToDelete(state storage.State) error {
 	isPaved, _ := d.terraformManager.IsPaved()
 	if !isPaved {
 		return nil
@@ -81,16 +121,21 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 		return nil
 	}
 
-	err = d.networkDeletionValidator.ValidateSafeToDelete(networkName, state.EnvID)
+	return d.networkDeletionValidator.ValidateSafeToDelete(networkName, state.EnvID)
+}
+
+func (d Destroy) Execute(subcommandFlags []string, state storage.State) (err error) {
+	config, err := d.parseArgs(subcommandFlags)
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
+	prompt := fmt.Sprintf("Are you sure you want to delete infrastructure for %q? This operation cannot be undone!", state.EnvID)
+	if config.DeleteDeployments {
+		prompt = fmt.Sprintf("Are you sure you want to delete every deployment on the director and the infrastructure for %q? This operation cannot be undone!", state.EnvID)
+	}
 
-func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
-	proceed := d.logger.Prompt(fmt.Sprintf("Are you sure you want to delete infrastructure for %q? This operation cannot be undone!", state.EnvID))
+	proceed := d.logger.Prompt(prompt)
 	if !proceed {
 		d.logger.Step("exiting")
 		return nil
@@ -102,7 +147,6 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 			LB:   state.LB,
 		}
 
-		var err error
 		state, err = d.plan.InitializePlan(planConfig, state)
 		if err != nil {
 			return fmt.Errorf("Initialize plan during destroy: %s", err)
@@ -126,7 +170,29 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 		return err
 	}
 
-	state, err = d.deleteBOSH(state, terraformOutputs)
+	destroyedState := state
+	err = d.hooks.Run("pre-destroy", destroyedState, terraformOutputs.Map)
+	if err != nil {
+		return err
+	}
+
+	timer := newPhaseTimer("destroy", d.logger)
+	defer func() {
+		timer.Report(d.timingsLog, destroyedState, err)
+	}()
+
+	if config.DeleteDeployments && !state.NoDirector {
+		timer.Start(deleteDeploymentsPhase)
+		if err := d.deleteDeployments(state); err != nil {
+			return err
+		}
+
+		if err := d.validateSafeToDelete(state); err != nil {
+			return err
+		}
+	}
+
+	state, err = d.deleteBOSH(state, terraformOutputs, timer)
 	switch err.(type) {
 	case bosh.ManagerDeleteError:
 		mdErr := err.(bosh.ManagerDeleteError)
@@ -141,11 +207,13 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 	case error:
 		return err
 	}
+	timer.Stop()
 
 	if err := d.stateStore.Set(state); err != nil {
 		return err
 	}
 
+	timer.Start(terraformDestroyPhase)
 	if err = d.terraformManager.Setup(state); err != nil {
 		return err
 	}
@@ -158,16 +226,51 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 	if err := d.stateStore.Set(storage.State{}); err != nil {
 		return err
 	}
+	timer.Stop()
+
+	return d.hooks.Run("post-destroy", destroyedState, terraformOutputs.Map)
+}
+
+func (d Destroy) deleteDeployments(state storage.State) error {
+	boshClient, err := d.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return fmt.Errorf("Connect to the bosh director: %s", err)
+	}
+
+	deployments, err := boshClient.Deployments()
+	if err != nil {
+		return fmt.Errorf("List deployments: %s", err)
+	}
+
+	for _, deployment := range deployments {
+		d.logger.Step("deleting deployment %s", deployment)
+		if err := boshClient.DeleteDeployment(deployment); err != nil {
+			return fmt.Errorf("Delete deployment %s: %s", deployment, err)
+		}
+	}
+
+	disks, err := boshClient.OrphanedDisks()
+	if err != nil {
+		return fmt.Errorf("List orphaned disks: %s", err)
+	}
+
+	for _, disk := range disks {
+		d.logger.Step("deleting orphaned disk %s", disk)
+		if err := boshClient.DeleteOrphanedDisk(disk); err != nil {
+			return fmt.Errorf("Delete orphaned disk %s: %s", disk, err)
+		}
+	}
 
 	return nil
 }
 
-func (d Destroy) deleteBOSH(state storage.State, terraformOutputs terraform.Outputs) (storage.State, error) {
+func (d Destroy) deleteBOSH(state storage.State, terraformOutputs terraform.Outputs, timer *phaseTimer) (storage.State, error) {
 	if state.NoDirector {
 		d.logger.Println("No BOSH director, skipping...")
 		return state, nil
 	}
 
+	timer.Start(directorDeletePhase)
 	err := d.boshManager.DeleteDirector(state, terraformOutputs)
 	if err != nil {
 		return state, err
@@ -175,6 +278,7 @@ func (d Destroy) deleteBOSH(state storage.State, terraformOutputs terraform.Outp
 
 	state.BOSH = storage.BOSH{}
 
+	timer.Start(jumpboxDeletePhase)
 	err = d.boshManager.DeleteJumpbox(state, terraformOutputs)
 	if err != nil {
 		return state, err
@@ -24,6 +24,10 @@ var _ = Describe("Destroy", func() {
 		stateValidator           *fakes.StateValidator
 		terraformManager         *fakes.TerraformManager
 		networkDeletionValidator *fakes.NetworkDeletionValidator
+		timingsLog               *fakes.TimingsLog
+		hooks                    *fakes.Hooks
+		boshClientProvider       *fakes.BOSHClientProvider
+		boshClient               *fakes.BOSHClient
 	)
 
 	BeforeEach(func() {
@@ -38,13 +42,18 @@ var _ = Describe("Destroy", func() {
 		stateStore = &fakes.StateStore{}
 		stateValidator = &fakes.StateValidator{}
 		networkDeletionValidator = &fakes.NetworkDeletionValidator{}
+		timingsLog = &fakes.TimingsLog{}
+		hooks = &fakes.Hooks{}
+		boshClient = &fakes.BOSHClient{}
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		boshClientProvider.ClientCall.Returns.Client = boshClient
 
 		terraformManager = &fakes.TerraformManager{}
 		terraformManager.DestroyCall.Returns.BBLState = storage.State{ID: "some-state-id"}
 		terraformManager.IsPavedCall.Returns.IsPaved = true
 
 		destroy = commands.NewDestroy(plan, logger, boshManager, stateStore,
-			stateValidator, terraformManager, networkDeletionValidator)
+			stateValidator, terraformManager, networkDeletionValidator, timingsLog, hooks, boshClientProvider)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -213,11 +222,123 @@ var _ = Describe("Destroy", func() {
 		})
 	})
 
+	Describe("CheckFastFails with --delete-deployments", func() {
+		BeforeEach(func() {
+			terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
+				Map: map[string]interface{}{"vpc_id": "some-vpc-id"},
+			}
+			networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("vms still exist")
+		})
+
+		It("does not fail on the vms of the deployments", func() {
+			err := destroy.CheckFastFails([]string{"--delete-deployments"}, storage.State{
+				IAAS: "aws",
+				BOSH: storage.BOSH{DirectorAddress: "https://10.0.0.6:25555"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+		})
+
+		Context("when there is no director", func() {
+			It("returns an error", func() {
+				err := destroy.CheckFastFails([]string{"--delete-deployments"}, storage.State{IAAS: "aws", EnvID: "some-env-id", NoDirector: true})
+				Expect(err).To(MatchError("--delete-deployments needs a bosh director, but some-env-id does not have one."))
+			})
+		})
+
+		Context("when an unknown flag is passed", func() {
+			It("returns an error", func() {
+				err := destroy.CheckFastFails([]string{"--delete-everything"}, storage.State{IAAS: "aws"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("Execute", func() {
 		BeforeEach(func() {
 			plan.IsInitializedCall.Returns.IsInitialized = true
 		})
 
+		Context("when --delete-deployments is passed", func() {
+			var state storage.State
+
+			BeforeEach(func() {
+				state = storage.State{
+					IAAS:    "aws",
+					EnvID:   "some-env-id",
+					Jumpbox: storage.Jumpbox{URL: "some-jumpbox-url"},
+					BOSH: storage.BOSH{
+						DirectorAddress:  "https://10.0.0.6:25555",
+						DirectorUsername: "some-username",
+						DirectorPassword: "some-password",
+						DirectorSSLCA:    "some-ca",
+					},
+				}
+				terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
+					Map: map[string]interface{}{"vpc_id": "some-vpc-id"},
+				}
+				boshClient.DeploymentsCall.Returns.Deployments = []string{"cf", "concourse"}
+				boshClient.OrphanedDisksCall.Returns.CIDs = []string{"some-disk-cid"}
+			})
+
+			It("deletes the deployments and orphaned disks before the director", func() {
+				err := destroy.Execute([]string{"--delete-deployments"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PromptCall.Receives.Message).To(Equal(`Are you sure you want to delete every deployment on the director and the infrastructure for "some-env-id"? This operation cannot be undone!`))
+
+				Expect(boshClientProvider.ClientCall.Receives.Jumpbox).To(Equal(state.Jumpbox))
+				Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("https://10.0.0.6:25555"))
+				Expect(boshClientProvider.ClientCall.Receives.DirectorUsername).To(Equal("some-username"))
+				Expect(boshClientProvider.ClientCall.Receives.DirectorPassword).To(Equal("some-password"))
+				Expect(boshClientProvider.ClientCall.Receives.DirectorCACert).To(Equal("some-ca"))
+
+				Expect(boshClient.DeleteDeploymentCall.Receives).To(Equal([]string{"cf", "concourse"}))
+				Expect(boshClient.DeleteOrphanedDiskCall.Receives).To(Equal([]string{"some-disk-cid"}))
+
+				Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(1))
+				Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.NetworkName).To(Equal("some-vpc-id"))
+
+				Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(1))
+				Expect(terraformManager.DestroyCall.CallCount).To(Equal(1))
+
+				var phases []string
+				for _, phase := range timingsLog.AppendCall.Receives.Run.Phases {
+					phases = append(phases, phase.Phase)
+				}
+				Expect(phases).To(Equal([]string{"delete deployments", "director delete-env", "jumpbox delete-env", "terraform destroy"}))
+			})
+
+			Context("when a deployment fails to delete", func() {
+				It("does not delete the director", func() {
+					boshClient.DeleteDeploymentCall.Returns.Error = errors.New("task 42 error: some-result")
+
+					err := destroy.Execute([]string{"--delete-deployments"}, state)
+					Expect(err).To(MatchError("Delete deployment cf: task 42 error: some-result"))
+					Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when vms remain after the deployments were deleted", func() {
+				It("does not delete the director", func() {
+					networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("vms still exist")
+
+					err := destroy.Execute([]string{"--delete-deployments"}, state)
+					Expect(err).To(MatchError("vms still exist"))
+					Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when the director cannot be reached", func() {
+				It("returns an error", func() {
+					boshClientProvider.ClientCall.Returns.Error = errors.New("start proxy: failed")
+
+					err := destroy.Execute([]string{"--delete-deployments"}, state)
+					Expect(err).To(MatchError("Connect to the bosh director: start proxy: failed"))
+				})
+			})
+		})
+
 		It("prompts the user for confirmation", func() {
 			err := destroy.Execute([]string{}, storage.State{
 				BOSH: storage.BOSH{
@@ -298,6 +419,78 @@ var _ = Describe("Destroy", func() {
 			Expect(stateStore.SetCall.Receives[0].State.BOSH).To(Equal(storage.BOSH{}))
 		})
 
+		It("runs the pre-destroy and post-destroy hooks", func() {
+			terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{Map: map[string]interface{}{"some-output": "some-value"}}
+			state := storage.State{EnvID: "some-env-id"}
+
+			err := destroy.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(hooks.Ran()).To(Equal([]string{"pre-destroy", "post-destroy"}))
+			for _, receive := range hooks.RunCall.Receives {
+				Expect(receive.State).To(Equal(state))
+				Expect(receive.Outputs).To(Equal(map[string]interface{}{"some-output": "some-value"}))
+			}
+		})
+
+		Context("when the pre-destroy hook fails", func() {
+			It("does not delete anything", func() {
+				hooks.RunCall.Stub = func(string) error {
+					return errors.New("Run pre-destroy hook: exit status 1")
+				}
+
+				err := destroy.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("Run pre-destroy hook: exit status 1"))
+
+				Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(0))
+				Expect(terraformManager.DestroyCall.CallCount).To(Equal(0))
+			})
+		})
+
+		It("prints a summary and saves the timings of each phase", func() {
+			err := destroy.Execute([]string{}, storage.State{EnvID: "some-env-id", BBLVersion: "1.2.3"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(HaveLen(1))
+			Expect(logger.PrintfCall.Messages[0]).To(MatchRegexp(`^phase +duration\ndirector delete-env +0s\n(.|\n)*total +0s\n$`))
+
+			Expect(timingsLog.AppendCall.CallCount).To(Equal(1))
+			run := timingsLog.AppendCall.Receives.Run
+			Expect(run.Command).To(Equal("destroy"))
+			Expect(run.EnvID).To(Equal("some-env-id"))
+			Expect(run.BBLVersion).To(Equal("1.2.3"))
+
+			var phases []string
+			for _, phase := range run.Phases {
+				phases = append(phases, phase.Phase)
+			}
+			Expect(phases).To(Equal([]string{"director delete-env", "jumpbox delete-env", "terraform destroy"}))
+		})
+
+		It("saves the timings of a failed run without printing a summary", func() {
+			boshManager.DeleteJumpboxCall.Returns.Error = errors.New("pineapple")
+
+			err := destroy.Execute([]string{}, storage.State{})
+			Expect(err).To(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(BeEmpty())
+
+			run := timingsLog.AppendCall.Receives.Run
+			Expect(run.Failed).To(BeTrue())
+			Expect(run.Phases).To(HaveLen(2))
+			Expect(run.Phases[0].Phase).To(Equal("director delete-env"))
+			Expect(run.Phases[0].Failed).To(BeFalse())
+			Expect(run.Phases[1].Phase).To(Equal("jumpbox delete-env"))
+			Expect(run.Phases[1].Failed).To(BeTrue())
+		})
+
+		It("sets the phase of the log events", func() {
+			err := destroy.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.SetPhaseCall.Phases).To(Equal([]string{"director", "", "jumpbox", "", "terraform", ""}))
+		})
+
 		Context("when the plan is not initialized", func() {
 			It("initializes the plan", func() {
 				plan.IsInitializedCall.Returns.IsInitialized = false
@@ -330,6 +523,7 @@ var _ = Describe("Destroy", func() {
 				Expect(terraformManager.DestroyCall.CallCount).To(Equal(0))
 				Expect(stateStore.SetCall.CallCount).To(Equal(1))
 				Expect(stateStore.SetCall.Receives[0].State).To(Equal(storage.State{}))
+				Expect(timingsLog.AppendCall.CallCount).To(Equal(0))
 			})
 		})
 
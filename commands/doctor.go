@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var getuid = os.Getuid
+
+type Doctor struct {
+	terraformManager versionValidator
+	boshManager      boshManager
+	pathFinder       pathFinder
+	stateBootstrap   stateBootstrap
+	sshKeyGetter     sshKeyGetter
+	fs               doctorFS
+	logger           logger
+	stateDir         string
+}
+
+type versionValidator interface {
+	ValidateVersion() error
+}
+
+type pathFinder interface {
+	CommandExists(string) bool
+}
+
+type stateBootstrap interface {
+	GetState(string) (storage.State, error)
+}
+
+type doctorFS interface {
+	fileio.Stater
+	fileio.DirReader
+}
+
+// doctorCheck fails with a problem and the fix for it.
+type doctorCheck struct {
+	name string
+	run  func() (fix string, problem error)
+}
+
+func NewDoctor(terraformManager versionValidator, boshManager boshManager, pathFinder pathFinder,
+	stateBootstrap stateBootstrap, sshKeyGetter sshKeyGetter, fs doctorFS, logger logger, stateDir string) Doctor {
+	return Doctor{
+		terraformManager: terraformManager,
+		boshManager:      boshManager,
+		pathFinder:       pathFinder,
+		stateBootstrap:   stateBootstrap,
+		sshKeyGetter:     sshKeyGetter,
+		fs:               fs,
+		logger:           logger,
+		stateDir:         stateDir,
+	}
+}
+
+func (d Doctor) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return nil
+}
+
+func (d Doctor) Execute(args []string, _ storage.State) error {
+	// The state is loaded here rather than by bbl, so that a state dir bbl
+	// cannot load is reported instead of stopping the other checks.
+	state, stateErr := d.stateBootstrap.GetState(d.stateDir)
+
+	checks := []doctorCheck{
+		{"terraform", d.checkTerraform},
+		{"bosh-cli", d.checkBOSH},
+		{"nc or connect-proxy", d.checkProxyCommand},
+		{"bbl-state.json", func() (string, error) { return checkState(state, stateErr) }},
+		{"state file permissions", d.checkPermissions},
+	}
+	if stateErr == nil {
+		checks = append(checks,
+			doctorCheck{"vars stores", func() (string, error) { return d.checkVarsStores(state) }},
+			doctorCheck{"jumpbox ssh key", func() (string, error) { return d.checkJumpboxKey(state) }},
+		)
+	}
+
+	var failures int
+	for _, check := range checks {
+		fix, problem := check.run()
+		if problem == nil {
+			d.logger.Printf("ok    %s\n", check.name)
+			continue
+		}
+
+		failures++
+		d.logger.Printf("FAIL  %s: %s\n", check.name, strings.TrimSpace(problem.Error()))
+		d.logger.Printf("      fix: %s\n", fix)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("bbl doctor found %d problem(s).", failures)
+	}
+
+	d.logger.Println("No problems found.")
+	return nil
+}
+
+func (d Doctor) checkTerraform() (string, error) {
+	if err := d.terraformManager.ValidateVersion(); err != nil {
+		return "Install terraform v0.11.0 or later from https://www.terraform.io/downloads.html and put it on your PATH.", err
+	}
+	return "", nil
+}
+
+func (d Doctor) checkBOSH() (string, error) {
+	if err := fastFailBOSHVersion(d.boshManager); err != nil {
+		return "Install bosh-cli v2.0.48 or later from https://bosh.io/docs/cli-v2-install/ as bosh or bosh2 on your PATH.", err
+	}
+	return "", nil
+}
+
+func (d Doctor) checkProxyCommand() (string, error) {
+	if d.pathFinder.CommandExists("nc") || d.pathFinder.CommandExists("connect-proxy") {
+		return "", nil
+	}
+	return "Install a BSD netcat, such as the netcat-openbsd package, or connect-proxy. bosh ssh uses it to reach VMs through the jumpbox.",
+		errors.New("neither is on your PATH")
+}
+
+func checkState(state storage.State, err error) (string, error) {
+	if err == nil {
+		return "", nil
+	}
+	if state.Version > storage.STATE_SCHEMA {
+		return fmt.Sprintf("Upgrade bbl to v%s or later.", state.BBLVersion), err
+	}
+	return "Restore bbl-state.json from a backup of the state directory, or point --state-dir at the right directory.", err
+}
+
+// checkPermissions finds state files that bbl cannot both read and write,
+// which usually happens when they were created by another user. A file
+// owned by another user is reported even when its mode would let its owner
+// read and write it.
+func (d Doctor) checkPermissions() (string, error) {
+	files := []string{storage.STATE_FILE}
+	varsFiles, _ := d.fs.ReadDir(filepath.Join(d.stateDir, "vars"))
+	for _, f := range varsFiles {
+		if !f.IsDir() {
+			files = append(files, filepath.Join("vars", f.Name()))
+		}
+	}
+
+	var wrong []string
+	for _, file := range files {
+		info, err := d.fs.Stat(filepath.Join(d.stateDir, file))
+		if err != nil {
+			continue
+		}
+		if info.Mode().Perm()&0600 != 0600 || ownedByAnotherUser(info) {
+			wrong = append(wrong, file)
+		}
+	}
+
+	if len(wrong) > 0 {
+		return fmt.Sprintf("Run chmod u+rw on them in %s, and chown them to yourself if another user created them.", d.stateDir),
+			fmt.Errorf("%s cannot be read and written by the current user", strings.Join(wrong, ", "))
+	}
+	return "", nil
+}
+
+func (d Doctor) checkVarsStores(state storage.State) (string, error) {
+	var missing []string
+	for _, deployment := range deployedVMs(state) {
+		file := filepath.Join("vars", fmt.Sprintf("%s-vars-store.yml", deployment))
+		_, err := d.fs.Stat(filepath.Join(d.stateDir, file))
+		if os.IsNotExist(err) {
+			missing = append(missing, file)
+		}
+	}
+
+	if len(missing) > 0 {
+		return "Restore them from a backup of the state directory. They hold the credentials of the deployed VMs and cannot be regenerated.",
+			fmt.Errorf("%s missing", strings.Join(missing, ", "))
+	}
+	return "", nil
+}
+
+func (d Doctor) checkJumpboxKey(state storage.State) (string, error) {
+	if state.Jumpbox.URL == "" {
+		return "", nil
+	}
+
+	fix := "Restore vars/jumpbox-vars-store.yml from a backup of the state directory, or run bbl rotate to generate a new key."
+	key, err := d.sshKeyGetter.Get("jumpbox")
+	if err != nil {
+		return fix, err
+	}
+	if _, err := gossh.ParsePrivateKey([]byte(key)); err != nil {
+		return fix, fmt.Errorf("jumpbox_ssh.private_key cannot be parsed: %s", err)
+	}
+	return "", nil
+}
+
+func deployedVMs(state storage.State) []string {
+	var deployments []string
+	if state.Jumpbox.URL != "" {
+		deployments = append(deployments, "jumpbox")
+	}
+	if state.BOSH.DirectorAddress != "" {
+		deployments = append(deployments, "director")
+	}
+	return deployments
+}
+
+func (d Doctor) Usage() string {
+	return DoctorCommandUsage
+}
@@ -0,0 +1,174 @@
+package commands_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Doctor", func() {
+	var (
+		terraformManager *fakes.TerraformManager
+		boshManager      *fakes.BOSHManager
+		pathFinder       *fakes.PathFinder
+		stateBootstrap   *fakes.StateBootstrap
+		sshKeyGetter     *fakes.SSHKeyGetter
+		fs               *afero.Afero
+		logger           *fakes.Logger
+		doctor           commands.Doctor
+	)
+
+	BeforeEach(func() {
+		terraformManager = &fakes.TerraformManager{}
+		boshManager = &fakes.BOSHManager{}
+		boshManager.VersionCall.Returns.Version = "2.0.48"
+		pathFinder = &fakes.PathFinder{}
+		pathFinder.CommandExistsCall.Returns = map[string]bool{"nc": true}
+		stateBootstrap = &fakes.StateBootstrap{}
+		stateBootstrap.GetStateCall.Returns.State = storage.State{
+			Jumpbox: storage.Jumpbox{URL: "10.0.0.5:22"},
+			BOSH:    storage.BOSH{DirectorAddress: "https://10.0.0.6:25555"},
+		}
+
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+		sshKeyGetter = &fakes.SSHKeyGetter{}
+		sshKeyGetter.GetCall.Returns.PrivateKey = string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}))
+
+		fs = &afero.Afero{Fs: afero.NewMemMapFs()}
+		fs.MkdirAll("/state/vars", os.ModePerm)
+		fs.WriteFile("/state/bbl-state.json", []byte("{}"), storage.StateMode)
+		fs.WriteFile("/state/vars/jumpbox-vars-store.yml", []byte("jumpbox"), storage.StateMode)
+		fs.WriteFile("/state/vars/director-vars-store.yml", []byte("director"), storage.StateMode)
+
+		logger = &fakes.Logger{}
+
+		doctor = commands.NewDoctor(terraformManager, boshManager, pathFinder, stateBootstrap, sshKeyGetter, fs, logger, "/state")
+	})
+
+	It("reports that every check passes", func() {
+		err := doctor.Execute([]string{}, storage.State{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stateBootstrap.GetStateCall.Receives.Dir).To(Equal("/state"))
+		Expect(sshKeyGetter.GetCall.Receives.Deployment).To(Equal("jumpbox"))
+		Expect(logger.PrintfCall.Messages).To(Equal([]string{
+			"ok    terraform\n",
+			"ok    bosh-cli\n",
+			"ok    nc or connect-proxy\n",
+			"ok    bbl-state.json\n",
+			"ok    state file permissions\n",
+			"ok    vars stores\n",
+			"ok    jumpbox ssh key\n",
+		}))
+		Expect(logger.PrintlnCall.Messages).To(Equal([]string{"No problems found."}))
+	})
+
+	It("reports every failed check with its fix and returns an error", func() {
+		terraformManager.ValidateVersionCall.Returns.Error = errors.New("Terraform version must be at least v0.11.0")
+		boshManager.VersionCall.Returns.Version = "2.0.1"
+		boshManager.PathCall.Returns.Path = "/bin/bosh"
+		pathFinder.CommandExistsCall.Returns = map[string]bool{}
+
+		err := doctor.Execute([]string{}, storage.State{})
+		Expect(err).To(MatchError("bbl doctor found 3 problem(s)."))
+
+		Expect(logger.PrintfCall.Messages).To(ContainElement("FAIL  terraform: Terraform version must be at least v0.11.0\n"))
+		Expect(logger.PrintfCall.Messages).To(ContainElement("FAIL  bosh-cli: /bin/bosh: bosh-cli version must be at least v2.0.48\n"))
+		Expect(logger.PrintfCall.Messages).To(ContainElement("FAIL  nc or connect-proxy: neither is on your PATH\n"))
+		Expect(logger.PrintfCall.Messages).To(ContainElement(ContainSubstring("fix: Install terraform v0.11.0 or later")))
+		Expect(pathFinder.CommandExistsCall.Receives).To(Equal([]string{"nc", "connect-proxy"}))
+	})
+
+	It("accepts connect-proxy instead of nc", func() {
+		pathFinder.CommandExistsCall.Returns = map[string]bool{"connect-proxy": true}
+
+		err := doctor.Execute([]string{}, storage.State{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the state was created by a newer bbl", func() {
+		BeforeEach(func() {
+			stateBootstrap.GetStateCall.Returns.State = storage.State{Version: storage.STATE_SCHEMA + 1, BBLVersion: "99.0.0"}
+			stateBootstrap.GetStateCall.Returns.Error = errors.New("Existing bbl environment was created with a newer version of bbl. Please upgrade to bbl v99.0.0.\n")
+		})
+
+		It("tells the user to upgrade and skips the checks that need the state", func() {
+			err := doctor.Execute([]string{}, storage.State{})
+			Expect(err).To(MatchError("bbl doctor found 1 problem(s)."))
+
+			Expect(logger.PrintfCall.Messages).To(ContainElement("FAIL  bbl-state.json: Existing bbl environment was created with a newer version of bbl. Please upgrade to bbl v99.0.0.\n"))
+			Expect(logger.PrintfCall.Messages).To(ContainElement("      fix: Upgrade bbl to v99.0.0 or later.\n"))
+			Expect(sshKeyGetter.GetCall.CallCount).To(Equal(0))
+		})
+	})
+
+	Context("when state files cannot be read and written", func() {
+		BeforeEach(func() {
+			fs.Chmod("/state/vars/director-vars-store.yml", 0400)
+		})
+
+		It("lists them", func() {
+			err := doctor.Execute([]string{}, storage.State{})
+			Expect(err).To(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(ContainElement("FAIL  state file permissions: vars/director-vars-store.yml cannot be read and written by the current user\n"))
+			Expect(logger.PrintfCall.Messages).To(ContainElement("      fix: Run chmod u+rw on them in /state, and chown them to yourself if another user created them.\n"))
+		})
+	})
+
+	Context("when the vars store of a deployed VM is missing", func() {
+		BeforeEach(func() {
+			fs.Remove("/state/vars/director-vars-store.yml")
+		})
+
+		It("reports it", func() {
+			err := doctor.Execute([]string{}, storage.State{})
+			Expect(err).To(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(ContainElement("FAIL  vars stores: " + filepath.Join("vars", "director-vars-store.yml") + " missing\n"))
+		})
+	})
+
+	Context("when the jumpbox key cannot be parsed", func() {
+		BeforeEach(func() {
+			sshKeyGetter.GetCall.Returns.PrivateKey = "not a key"
+		})
+
+		It("reports it", func() {
+			err := doctor.Execute([]string{}, storage.State{})
+			Expect(err).To(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(ContainElement(HavePrefix("FAIL  jumpbox ssh key: jumpbox_ssh.private_key cannot be parsed")))
+		})
+	})
+
+	Context("when there is no jumpbox", func() {
+		BeforeEach(func() {
+			stateBootstrap.GetStateCall.Returns.State = storage.State{}
+			fs.Remove("/state/vars/jumpbox-vars-store.yml")
+		})
+
+		It("does not check the vars stores or the key", func() {
+			err := doctor.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sshKeyGetter.GetCall.CallCount).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,21 @@
+//go:build !windows
+// +build !windows
+
+package commands
+
+import (
+	"os"
+	"syscall"
+)
+
+// ownedByAnotherUser reports whether the file belongs to a user other than
+// the one running bbl. Root can read and write any file.
+func ownedByAnotherUser(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false
+	}
+
+	uid := getuid()
+	return uid != 0 && int(stat.Uid) != uid
+}
@@ -0,0 +1,74 @@
+//go:build !windows
+// +build !windows
+
+package commands_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Doctor state file ownership", func() {
+	var (
+		stateDir string
+		logger   *fakes.Logger
+		doctor   commands.Doctor
+	)
+
+	BeforeEach(func() {
+		var err error
+		stateDir, err = ioutil.TempDir("", "bbl-doctor")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.MkdirAll(filepath.Join(stateDir, "vars"), os.ModePerm)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(stateDir, "bbl-state.json"), []byte("{}"), storage.StateMode)).To(Succeed())
+
+		pathFinder := &fakes.PathFinder{}
+		pathFinder.CommandExistsCall.Returns = map[string]bool{"nc": true}
+		boshManager := &fakes.BOSHManager{}
+		boshManager.VersionCall.Returns.Version = "2.0.48"
+
+		logger = &fakes.Logger{}
+		fs := &afero.Afero{Fs: afero.NewOsFs()}
+		doctor = commands.NewDoctor(&fakes.TerraformManager{}, boshManager, pathFinder, &fakes.StateBootstrap{},
+			&fakes.SSHKeyGetter{}, fs, logger, stateDir)
+	})
+
+	AfterEach(func() {
+		commands.ResetGetuid()
+		os.RemoveAll(stateDir)
+	})
+
+	It("accepts state files owned by the current user", func() {
+		err := doctor.Execute([]string{}, storage.State{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(logger.PrintfCall.Messages).To(ContainElement("ok    state file permissions\n"))
+	})
+
+	It("reports state files owned by another user even when their mode is 0600", func() {
+		uid := os.Getuid()
+		commands.SetGetuid(func() int { return uid + 1 })
+
+		err := doctor.Execute([]string{}, storage.State{})
+		Expect(err).To(HaveOccurred())
+
+		Expect(logger.PrintfCall.Messages).To(ContainElement("FAIL  state file permissions: bbl-state.json cannot be read and written by the current user\n"))
+	})
+
+	It("lets root use state files owned by any user", func() {
+		commands.SetGetuid(func() int { return 0 })
+
+		err := doctor.Execute([]string{}, storage.State{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type EnvExec struct {
+	stateValidator stateValidator
+	environment    environmentGetter
+	runner         commandRunner
+	fs             envExecFS
+}
+
+type environmentGetter interface {
+	Environment(state storage.State, useTunnel bool) ([]EnvVar, string, error)
+}
+
+type commandRunner interface {
+	Run(command []string, env []string) error
+}
+
+type envExecFS interface {
+	fileio.AllRemover
+}
+
+func NewEnvExec(stateValidator stateValidator, environment environmentGetter, runner commandRunner, fs envExecFS) EnvExec {
+	return EnvExec{
+		stateValidator: stateValidator,
+		environment:    environment,
+		runner:         runner,
+		fs:             fs,
+	}
+}
+
+func (e EnvExec) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return e.stateValidator.Validate()
+}
+
+func (e EnvExec) Execute(args []string, state storage.State) error {
+	var useTunnel bool
+	envExecFlags := flags.New("env-exec")
+	envExecFlags.Bool(&useTunnel, "use-tunnel")
+	err := envExecFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	command := envExecFlags.Args()
+	if len(command) == 0 {
+		return errors.New("This command requires a command to run after --.")
+	}
+
+	envVars, privateKeyPath, err := e.environment.Environment(state, useTunnel)
+	if err != nil {
+		return err
+	}
+
+	if privateKeyPath != "" {
+		defer e.fs.RemoveAll(filepath.Dir(privateKeyPath))
+	}
+
+	var env []string
+	for _, envVar := range envVars {
+		env = append(env, fmt.Sprintf("%s=%s", envVar.Name, envVar.Value))
+	}
+
+	return e.runner.Run(command, env)
+}
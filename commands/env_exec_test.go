@@ -0,0 +1,107 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvExec", func() {
+	var (
+		envExec        commands.EnvExec
+		stateValidator *fakes.StateValidator
+		environment    *fakes.EnvironmentGetter
+		runner         *fakes.CommandRunner
+		fileIO         *fakes.FileIO
+		state          storage.State
+	)
+
+	BeforeEach(func() {
+		stateValidator = &fakes.StateValidator{}
+		environment = &fakes.EnvironmentGetter{}
+		environment.EnvironmentCall.Returns.EnvVars = []commands.EnvVar{
+			{Name: "BOSH_CLIENT", Value: "some-director-username"},
+			{Name: "BOSH_CA_CERT", Value: "some\nca cert"},
+		}
+		environment.EnvironmentCall.Returns.PrivateKeyPath = "/tmp/bosh-jumpbox123/bosh_jumpbox_private.key"
+		runner = &fakes.CommandRunner{}
+		fileIO = &fakes.FileIO{}
+
+		state = storage.State{EnvID: "some-env-id"}
+
+		envExec = commands.NewEnvExec(stateValidator, environment, runner, fileIO)
+	})
+
+	Describe("CheckFastFails", func() {
+		Context("when the state does not exist", func() {
+			It("returns an error", func() {
+				stateValidator.ValidateCall.Returns.Error = errors.New("failed to validate state")
+
+				err := envExec.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to validate state"))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		It("runs the command with the environment and removes the private key", func() {
+			err := envExec.Execute([]string{"--", "bosh", "deployments"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(environment.EnvironmentCall.Receives.State).To(Equal(state))
+			Expect(environment.EnvironmentCall.Receives.UseTunnel).To(BeFalse())
+
+			Expect(runner.RunCall.Receives.Command).To(Equal([]string{"bosh", "deployments"}))
+			Expect(runner.RunCall.Receives.Env).To(Equal([]string{
+				"BOSH_CLIENT=some-director-username",
+				"BOSH_CA_CERT=some\nca cert",
+			}))
+
+			Expect(fileIO.RemoveAllCall.Receives).To(Equal([]fakes.RemoveAllReceive{{Path: "/tmp/bosh-jumpbox123"}}))
+		})
+
+		It("passes --use-tunnel on", func() {
+			environment.EnvironmentCall.Returns.PrivateKeyPath = ""
+
+			err := envExec.Execute([]string{"--use-tunnel", "--", "bosh", "deployments"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(environment.EnvironmentCall.Receives.UseTunnel).To(BeTrue())
+			Expect(fileIO.RemoveAllCall.CallCount).To(Equal(0))
+		})
+
+		Context("when the command fails", func() {
+			It("returns its error and still removes the private key", func() {
+				runner.RunCall.Returns.Error = errors.New("exit status 3")
+
+				err := envExec.Execute([]string{"--", "false"}, state)
+				Expect(err).To(MatchError("exit status 3"))
+
+				Expect(fileIO.RemoveAllCall.CallCount).To(Equal(1))
+			})
+		})
+
+		Context("when no command is given", func() {
+			It("returns an error", func() {
+				err := envExec.Execute([]string{}, state)
+				Expect(err).To(MatchError("This command requires a command to run after --."))
+				Expect(environment.EnvironmentCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when the environment cannot be built", func() {
+			It("returns the error", func() {
+				environment.EnvironmentCall.Returns.Error = errors.New("no credhub")
+
+				err := envExec.Execute([]string{"--", "bosh", "deployments"}, state)
+				Expect(err).To(MatchError("no credhub"))
+				Expect(runner.RunCall.CallCount).To(Equal(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// envFormatter returns the lines that print-env prints.
+type envFormatter func([]EnvVar) ([]string, error)
+
+var envShells = map[string]envFormatter{
+	"posix": eachEnvVar(func(e EnvVar) string {
+		return fmt.Sprintf("export %s=%s", e.Name, posixQuote(e))
+	}),
+	"fish": eachEnvVar(func(e EnvVar) string {
+		return fmt.Sprintf("set -gx %s %s;", e.Name, quoteWith(e.Value, `\`, `\\`, `'`, `\'`))
+	}),
+	"powershell": eachEnvVar(func(e EnvVar) string {
+		return fmt.Sprintf("$env:%s = %s", e.Name, quoteWith(e.Value, `'`, `''`))
+	}),
+}
+
+var envFormats = map[string]envFormatter{
+	"dotenv": eachEnvVar(func(e EnvVar) string {
+		replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+		return fmt.Sprintf(`%s="%s"`, e.Name, replacer.Replace(e.Value))
+	}),
+	"json": func(envVars []EnvVar) ([]string, error) {
+		values := map[string]string{}
+		for _, e := range envVars {
+			values[e.Name] = e.Value
+		}
+
+		output, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return nil, err // not tested
+		}
+		return []string{string(output)}, nil
+	},
+}
+
+// safePosixValue matches values that need no quoting in a POSIX shell.
+var safePosixValue = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// posixQuote single quotes values that the shell would otherwise split or
+// expand. The CA certificates are always quoted, as bbl has always done.
+func posixQuote(e EnvVar) string {
+	if safePosixValue.MatchString(e.Value) && !strings.HasSuffix(e.Name, "_CA_CERT") {
+		return e.Value
+	}
+	return quoteWith(e.Value, `'`, `'\''`)
+}
+
+// quoteWith wraps value in single quotes, applying the escapes given as
+// old, new pairs.
+func quoteWith(value string, escapes ...string) string {
+	return "'" + strings.NewReplacer(escapes...).Replace(value) + "'"
+}
+
+func eachEnvVar(line func(EnvVar) string) envFormatter {
+	return func(envVars []EnvVar) ([]string, error) {
+		var lines []string
+		for _, e := range envVars {
+			lines = append(lines, line(e))
+		}
+		return lines, nil
+	}
+}
@@ -0,0 +1,11 @@
+package commands
+
+import "os"
+
+func SetGetuid(f func() int) {
+	getuid = f
+}
+
+func ResetGetuid() {
+	getuid = os.Getuid
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"errors"
 	"strings"
 
@@ -11,12 +10,14 @@ import (
 type GCPLBs struct {
 	terraformManager terraformManager
 	logger           logger
+	output           Output
 }
 
-func NewGCPLBs(terraformManager terraformManager, logger logger) GCPLBs {
+func NewGCPLBs(terraformManager terraformManager, logger logger, output Output) GCPLBs {
 	return GCPLBs{
 		terraformManager: terraformManager,
 		logger:           logger,
+		output:           output,
 	}
 }
 
@@ -26,10 +27,15 @@ func (l GCPLBs) Execute(subcommandFlags []string, state storage.State) error {
 		return err
 	}
 
+	output := l.output
+	if len(subcommandFlags) > 0 && subcommandFlags[0] == "--json" {
+		output = NewOutput(l.logger, OutputJSON)
+	}
+
 	switch state.LB.Type {
 	case "cf":
-		if len(subcommandFlags) > 0 && subcommandFlags[0] == "--json" {
-			lbOutput, err := json.Marshal(struct {
+		if !output.Text() {
+			return output.Print(struct {
 				RouterLBIP             string   `json:"cf_router_lb,omitempty"`
 				SSHProxyLBIP           string   `json:"cf_ssh_proxy_lb,omitempty"`
 				TCPRouterLBIP          string   `json:"cf_tcp_router_lb,omitempty"`
@@ -42,23 +48,25 @@ func (l GCPLBs) Execute(subcommandFlags []string, state storage.State) error {
 				WebSocketLBIP:          terraformOutputs.GetString("ws_lb_ip"),
 				SystemDomainDNSServers: terraformOutputs.GetStringSlice("system_domain_dns_servers"),
 			})
-			if err != nil {
-				// not tested
-				return err
-			}
+		}
 
-			l.logger.Println(string(lbOutput))
-		} else {
-			l.logger.Printf("CF Router LB: %s\n", terraformOutputs.GetString("router_lb_ip"))
-			l.logger.Printf("CF SSH Proxy LB: %s\n", terraformOutputs.GetString("ssh_proxy_lb_ip"))
-			l.logger.Printf("CF TCP Router LB: %s\n", terraformOutputs.GetString("tcp_router_lb_ip"))
-			l.logger.Printf("CF WebSocket LB: %s\n", terraformOutputs.GetString("ws_lb_ip"))
-			dnsServers := terraformOutputs.GetStringSlice("system_domain_dns_servers")
-			if len(dnsServers) > 0 {
-				l.logger.Printf("CF System Domain DNS servers: %s\n", strings.Join(dnsServers, " "))
-			}
+		l.logger.Printf("CF Router LB: %s\n", terraformOutputs.GetString("router_lb_ip"))
+		l.logger.Printf("CF SSH Proxy LB: %s\n", terraformOutputs.GetString("ssh_proxy_lb_ip"))
+		l.logger.Printf("CF TCP Router LB: %s\n", terraformOutputs.GetString("tcp_router_lb_ip"))
+		l.logger.Printf("CF WebSocket LB: %s\n", terraformOutputs.GetString("ws_lb_ip"))
+		dnsServers := terraformOutputs.GetStringSlice("system_domain_dns_servers")
+		if len(dnsServers) > 0 {
+			l.logger.Printf("CF System Domain DNS servers: %s\n", strings.Join(dnsServers, " "))
 		}
 	case "concourse":
+		if !output.Text() {
+			return output.Print(struct {
+				ConcourseLBIP string `json:"concourse_lb,omitempty"`
+			}{
+				ConcourseLBIP: terraformOutputs.GetString("concourse_lb_ip"),
+			})
+		}
+
 		l.logger.Printf("Concourse LB: %s\n", terraformOutputs.GetString("concourse_lb_ip"))
 	default:
 		return errors.New("no lbs found")
@@ -34,7 +34,7 @@ var _ = Describe("GCPLBs", func() {
 		}}
 		logger = &fakes.Logger{}
 
-		command = commands.NewGCPLBs(terraformManager, logger)
+		command = commands.NewGCPLBs(terraformManager, logger, commands.NewOutput(logger, ""))
 	})
 
 	Describe("Execute", func() {
@@ -120,6 +120,18 @@ var _ = Describe("GCPLBs", func() {
 			}))
 		})
 
+		It("prints LB ips for lb type concourse as json", func() {
+			command = commands.NewGCPLBs(terraformManager, logger, commands.NewOutput(logger, "json"))
+			incomingState.LB = storage.LB{
+				Type: "concourse",
+			}
+
+			err := command.Execute([]string{}, incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(`{"concourse_lb": "some-concourse-lb-ip"}`))
+		})
+
 		Context("failure cases", func() {
 			Context("when terraform output provider fails", func() {
 				BeforeEach(func() {
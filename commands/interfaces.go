@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/certs"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
@@ -14,6 +15,20 @@ type plan interface {
 	IsInitialized(storage.State) bool
 }
 
+type patchCompatibility interface {
+	Check(storage.State) error
+}
+
+type managedFiles interface {
+	Modified() ([]string, error)
+	Backup([]string) (string, error)
+	Record() error
+}
+
+type boshClientProvider interface {
+	Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, caCert string) (bosh.Client, error)
+}
+
 type up interface {
 	CheckFastFails([]string, storage.State) error
 	ParseArgs([]string, storage.State) (PlanConfig, error)
@@ -40,6 +55,9 @@ type boshManager interface {
 	DeleteJumpbox(bblState storage.State, terraformOutputs terraform.Outputs) error
 	GetDirectorDeploymentVars(bblState storage.State, terraformOutputs terraform.Outputs) string
 	GetJumpboxDeploymentVars(bblState storage.State, terraformOutputs terraform.Outputs) string
+	RecordDeploymentSources(bblState storage.State) storage.State
+	RenderJumpbox(bblState storage.State, redact bool) (string, error)
+	RenderDirector(bblState storage.State, redact bool) (string, error)
 	Path() string
 	Version() (string, error)
 }
@@ -90,6 +108,15 @@ type logger interface {
 	Printf(string, ...interface{})
 	Println(string)
 	Prompt(string) bool
+	SetPhase(string)
+}
+
+type hooks interface {
+	Run(hook string, state storage.State, outputs map[string]interface{}) error
+}
+
+type timingsLog interface {
+	Append(run storage.Run) error
 }
 
 type stateStore interface {
@@ -107,3 +134,7 @@ type cloudConfigManager interface {
 	IsPresentCloudConfig() bool
 	IsPresentCloudConfigVars() bool
 }
+
+type runtimeConfigManager interface {
+	Update(state storage.State) error
+}
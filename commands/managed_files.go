@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// protectManagedFiles stops bbl from overwriting bbl-managed files that were
+// edited in place, unless force is set, in which case they are backed up.
+func protectManagedFiles(managedFiles managedFiles, logger logger, force bool) error {
+	modified, err := managedFiles.Modified()
+	if err != nil {
+		return err
+	}
+	if len(modified) == 0 {
+		return nil
+	}
+
+	if !force {
+		return fmt.Errorf(`These files are generated by bbl and were modified locally: %s.
+bbl overwrites them on every plan. Move your changes into override files, such as terraform/*_override.tf,
+cloud-config/*.yml ops files or create-director-override.sh, see docs/advanced-configuration.md.
+Use --force to back up the modified files and overwrite them.`, strings.Join(modified, ", "))
+	}
+
+	backupDir, err := managedFiles.Backup(modified)
+	if err != nil {
+		return err
+	}
+	logger.Println(fmt.Sprintf("warning: backed up the modified bbl-managed files %s to %s", strings.Join(modified, ", "), backupDir))
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"encoding/json"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// Output prints the result of a query command in the format chosen with
+// the global --output flag. Commands print their usual text unless Text
+// returns false, and otherwise pass a value with json tags to Print. The
+// yaml output uses the same keys as the json output.
+type Output struct {
+	logger logger
+	format string
+}
+
+func NewOutput(logger logger, format string) Output {
+	return Output{
+		logger: logger,
+		format: format,
+	}
+}
+
+func (o Output) Text() bool {
+	return o.format != OutputJSON && o.format != OutputYAML
+}
+
+func (o Output) Print(value interface{}) error {
+	output, err := json.Marshal(value)
+	if err != nil {
+		return err // not tested
+	}
+
+	if o.format == OutputYAML {
+		var generic interface{}
+		err = yaml.Unmarshal(output, &generic)
+		if err != nil {
+			return err // not tested
+		}
+
+		output, err = yaml.Marshal(generic)
+		if err != nil {
+			return err // not tested
+		}
+		o.logger.Printf("%s", string(output))
+		return nil
+	}
+
+	o.logger.Println(string(output))
+	return nil
+}
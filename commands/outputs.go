@@ -9,13 +9,15 @@ type Outputs struct {
 	logger           logger
 	terraformManager terraformManager
 	stateValidator   stateValidator
+	output           Output
 }
 
-func NewOutputs(logger logger, terraformManager terraformManager, stateValidator stateValidator) Outputs {
+func NewOutputs(logger logger, terraformManager terraformManager, stateValidator stateValidator, output Output) Outputs {
 	return Outputs{
 		logger:           logger,
 		terraformManager: terraformManager,
 		stateValidator:   stateValidator,
+		output:           output,
 	}
 }
 
@@ -28,6 +30,12 @@ func (o Outputs) Execute(subcommandFlags []string, state storage.State) error {
 	if err != nil {
 		return err
 	}
+
+	// The text output has always been yaml.
+	if !o.output.Text() {
+		return o.output.Print(outputs.Map)
+	}
+
 	marshalled, err := yaml.Marshal(outputs.Map)
 	if err != nil {
 		return err
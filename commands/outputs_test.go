@@ -24,7 +24,7 @@ var _ = Describe("Outputs", func() {
 		stateValidator = &fakes.StateValidator{}
 		logger = &fakes.Logger{}
 		terraformManager = &fakes.TerraformManager{}
-		outputsCommand = commands.NewOutputs(logger, terraformManager, stateValidator)
+		outputsCommand = commands.NewOutputs(logger, terraformManager, stateValidator, commands.NewOutput(logger, ""))
 	})
 
 	Describe("CheckFastFails", func() {
@@ -57,6 +57,20 @@ make sure we quote multiline strings`,
 			Expect(logger.PrintfCall.Receives.Message).To(ContainSubstring("external: address\nfirewall: |-\n  cidr\n  make sure we quote multiline strings"))
 		})
 
+		It("prints the terraform outputs as json", func() {
+			outputsCommand = commands.NewOutputs(logger, terraformManager, stateValidator, commands.NewOutput(logger, "json"))
+			terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
+				Map: map[string]interface{}{
+					"external": "address",
+					"zones":    []interface{}{"z1", "z2"},
+				},
+			}
+
+			err := outputsCommand.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(`{"external": "address", "zones": ["z1", "z2"]}`))
+		})
+
 		Context("failure cases", func() {
 			Context("when getOutputs failes", func() {
 				It("returns an error", func() {
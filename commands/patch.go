@@ -0,0 +1,369 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type Patch struct {
+	logger   logger
+	fs       patchFS
+	stateDir string
+}
+
+type patchFS interface {
+	fileio.FileReader
+	fileio.FileWriter
+	fileio.DirReader
+	fileio.Stater
+	fileio.AllMkdirer
+	fileio.Remover
+}
+
+var overrideScripts = map[string]struct{}{
+	"create-jumpbox-override.sh":  {},
+	"create-director-override.sh": {},
+	"delete-jumpbox-override.sh":  {},
+	"delete-director-override.sh": {},
+}
+
+func NewPatch(logger logger, fs patchFS, stateDir string) Patch {
+	return Patch{
+		logger:   logger,
+		fs:       fs,
+		stateDir: stateDir,
+	}
+}
+
+func (p Patch) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return nil
+}
+
+func (p Patch) Execute(args []string, state storage.State) error {
+	if len(args) == 0 {
+		return errors.New("This command requires a subcommand: add, list or remove.")
+	}
+
+	var force bool
+	patchFlags := flags.New("patch")
+	patchFlags.Bool(&force, "force")
+	err := patchFlags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "add":
+		if len(patchFlags.Args()) != 1 {
+			return errors.New("bbl patch add requires the directory of a plan patch.")
+		}
+		return p.add(patchFlags.Args()[0], force)
+	case "list":
+		return p.list()
+	case "remove":
+		if len(patchFlags.Args()) != 1 {
+			return errors.New("bbl patch remove requires the name of a plan patch.")
+		}
+		return p.remove(patchFlags.Args()[0], force)
+	default:
+		return fmt.Errorf("Unknown subcommand %q. Use add, list or remove.", args[0])
+	}
+}
+
+func (p Patch) add(source string, force bool) error {
+	source, err := filepath.Abs(source)
+	if err != nil {
+		return err // not tested
+	}
+	name := filepath.Base(source)
+
+	files, err := p.patchFiles(source, "")
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("%s does not contain any plan patch files.", source)
+	}
+
+	metadata, err := storage.ReadPatchMetadata(p.fs, source)
+	if err != nil {
+		return err
+	}
+
+	patches, err := storage.ReadPatches(p.fs, p.stateDir)
+	if err != nil {
+		return err
+	}
+
+	var previous storage.Patch
+	var others []storage.Patch
+	for _, patch := range patches {
+		if patch.Name == name {
+			previous = patch
+		} else {
+			others = append(others, patch)
+		}
+	}
+
+	owners := storage.PatchOwners(others)
+	for _, file := range files {
+		if owner, ok := owners[file]; ok {
+			return fmt.Errorf("%s is already part of the %s plan patch.", file, owner)
+		}
+	}
+
+	if !force {
+		if modified := p.modifiedFiles(previous); len(modified) > 0 {
+			return fmt.Errorf("These files of the %s plan patch were modified locally: %s. Use --force to replace them.", name, strings.Join(modified, ", "))
+		}
+	}
+
+	if existing := p.existingFiles(files, previous); len(existing) > 0 {
+		if !force {
+			return fmt.Errorf("These files of the %s plan patch already exist in the state directory: %s. Use --force to back them up and replace them.", name, strings.Join(existing, ", "))
+		}
+
+		backupDir, err := storage.NewManagedFiles(p.fs, p.stateDir).Backup(existing)
+		if err != nil {
+			return err
+		}
+		p.logger.Println(fmt.Sprintf("warning: backed up %s, which the %s plan patch replaces, to %s", strings.Join(existing, ", "), name, backupDir))
+	}
+
+	patch := storage.Patch{
+		Name:   name,
+		Source: source,
+		Files:  map[string]string{},
+
+		Metadata: metadata,
+	}
+	for _, file := range files {
+		contents, err := p.fs.ReadFile(filepath.Join(source, file))
+		if err != nil {
+			return fmt.Errorf("Read %s: %s", file, err)
+		}
+
+		destination := filepath.Join(p.stateDir, file)
+		err = p.fs.MkdirAll(filepath.Dir(destination), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Create directory for %s: %s", file, err)
+		}
+
+		mode := os.FileMode(storage.StateMode)
+		if strings.HasSuffix(file, ".sh") || filepath.Dir(file) == helpers.HooksDir {
+			mode = storage.ScriptMode
+		}
+		err = p.fs.WriteFile(destination, contents, mode)
+		if err != nil {
+			return fmt.Errorf("Write %s: %s", file, err)
+		}
+
+		patch.Files[file] = storage.FileHash(contents)
+	}
+
+	for file := range previous.Files {
+		if _, ok := patch.Files[file]; !ok {
+			p.fs.Remove(filepath.Join(p.stateDir, file))
+		}
+	}
+
+	err = storage.WritePatches(p.fs, p.stateDir, append(others, patch))
+	if err != nil {
+		return err
+	}
+
+	p.logger.Printf("Added the %s plan patch with %d files.\n", name, len(patch.Files))
+	return nil
+}
+
+func (p Patch) list() error {
+	patches, err := storage.ReadPatches(p.fs, p.stateDir)
+	if err != nil {
+		return err
+	}
+
+	if len(patches) == 0 {
+		p.logger.Println("No plan patches have been added.")
+		return nil
+	}
+
+	for _, patch := range patches {
+		p.logger.Printf("%s\t%s\t%d files\n", patch.Name, patch.Source, len(patch.Files))
+		for _, file := range p.modifiedFiles(patch) {
+			p.logger.Printf("  modified: %s\n", file)
+		}
+	}
+	return nil
+}
+
+func (p Patch) remove(name string, force bool) error {
+	patches, err := storage.ReadPatches(p.fs, p.stateDir)
+	if err != nil {
+		return err
+	}
+
+	var remaining []storage.Patch
+	var patch storage.Patch
+	for _, pp := range patches {
+		if pp.Name == name {
+			patch = pp
+		} else {
+			remaining = append(remaining, pp)
+		}
+	}
+	if patch.Name == "" {
+		return fmt.Errorf("No plan patch named %s has been added.", name)
+	}
+
+	if !force {
+		if modified := p.modifiedFiles(patch); len(modified) > 0 {
+			return fmt.Errorf("These files of the %s plan patch were modified locally: %s. Use --force to remove them.", name, strings.Join(modified, ", "))
+		}
+	}
+
+	for file := range patch.Files {
+		err := p.fs.Remove(filepath.Join(p.stateDir, file))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Remove %s: %s", file, err)
+		}
+	}
+
+	err = storage.WritePatches(p.fs, p.stateDir, remaining)
+	if err != nil {
+		return err
+	}
+
+	p.logger.Printf("Removed the %s plan patch.\n", name)
+	return nil
+}
+
+// modifiedFiles returns the files of the patch that were changed or
+// deleted since it was added.
+func (p Patch) modifiedFiles(patch storage.Patch) []string {
+	var modified []string
+	for file, hash := range patch.Files {
+		contents, err := p.fs.ReadFile(filepath.Join(p.stateDir, file))
+		if err != nil || storage.FileHash(contents) != hash {
+			modified = append(modified, file)
+		}
+	}
+	sort.Strings(modified)
+	return modified
+}
+
+// existingFiles returns the files of a plan patch that are already in the
+// state dir without having been added by an earlier version of the patch.
+func (p Patch) existingFiles(files []string, previous storage.Patch) []string {
+	var existing []string
+	for _, file := range files {
+		if _, ok := previous.Files[file]; ok {
+			continue
+		}
+		if _, err := p.fs.Stat(filepath.Join(p.stateDir, file)); err == nil {
+			existing = append(existing, file)
+		}
+	}
+	sort.Strings(existing)
+	return existing
+}
+
+// patchFiles returns the files of a plan patch relative to its directory,
+// and fails for files that bbl would not pick up from the state dir.
+func (p Patch) patchFiles(source, dir string) ([]string, error) {
+	infos, err := p.fs.ReadDir(filepath.Join(source, dir))
+	if err != nil {
+		return nil, fmt.Errorf("Read plan patch: %s", err)
+	}
+
+	var files []string
+	for _, info := range infos {
+		file := filepath.Join(dir, info.Name())
+
+		if info.IsDir() {
+			if !isPatchDir(dir, info.Name()) {
+				return nil, fmt.Errorf("%s is not part of the plan patch layout. %s", file, patchLayout)
+			}
+			dirFiles, err := p.patchFiles(source, file)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, dirFiles...)
+			continue
+		}
+
+		if dir == "" && (strings.EqualFold(filepath.Ext(info.Name()), ".md") || info.Name() == storage.PATCH_METADATA_FILE) {
+			continue
+		}
+		if !isPatchFile(dir, info.Name()) {
+			return nil, fmt.Errorf("%s is not part of the plan patch layout. %s", file, patchLayout)
+		}
+		if storage.IsManagedFile(file) {
+			return nil, fmt.Errorf("%s is generated by bbl on every plan and would be overwritten. Use an ops file or a terraform override with another name instead.", file)
+		}
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+const patchLayout = "Plan patches may contain terraform/*.tf, cloud-config/*.yml, runtime-config/<name>/*.yml, vars/*.tfvars, vars/*.yml, hooks/<hook>, *-override.sh scripts and *.yml ops files."
+
+var patchDirs = map[string]bool{
+	"terraform":      true,
+	"cloud-config":   true,
+	"runtime-config": true,
+	"vars":           true,
+	helpers.HooksDir: true,
+}
+
+// isPatchDir allows the top level directories bbl reads and, in
+// runtime-config, one directory for each named runtime config.
+func isPatchDir(dir, name string) bool {
+	switch dir {
+	case "":
+		return patchDirs[name]
+	case "runtime-config":
+		return !strings.HasPrefix(name, ".")
+	}
+	return false
+}
+
+func isPatchFile(dir, name string) bool {
+	ext := filepath.Ext(name)
+	if filepath.Dir(dir) == "runtime-config" {
+		return ext == ".yml"
+	}
+
+	switch dir {
+	case "":
+		_, ok := overrideScripts[name]
+		return ok || ext == ".yml"
+	case "terraform":
+		return ext == ".tf"
+	case "cloud-config":
+		return ext == ".yml"
+	case "vars":
+		return ext == ".tfvars" || ext == ".yml"
+	case helpers.HooksDir:
+		for _, hook := range helpers.HookNames {
+			if name == hook {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p Patch) Usage() string {
+	return PatchCommandUsage
+}
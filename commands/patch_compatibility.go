@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/coreos/go-semver/semver"
+)
+
+type PatchCompatibility struct {
+	templateGenerator templateGenerator
+	fs                fileio.FileReader
+	stateDir          string
+	bblVersion        string
+}
+
+type templateGenerator interface {
+	Generate(storage.State) string
+}
+
+func NewPatchCompatibility(templateGenerator templateGenerator, fs fileio.FileReader, stateDir, bblVersion string) PatchCompatibility {
+	return PatchCompatibility{
+		templateGenerator: templateGenerator,
+		fs:                fs,
+		stateDir:          stateDir,
+		bblVersion:        bblVersion,
+	}
+}
+
+// Check fails when a patch added with bbl patch add does not support the
+// IaaS or bbl version of the environment, or when it references terraform
+// resources and outputs that bbl no longer generates.
+func (c PatchCompatibility) Check(state storage.State) error {
+	patches, err := storage.ReadPatches(c.fs, c.stateDir)
+	if err != nil {
+		return err
+	}
+
+	var template string
+	if c.templateGenerator != nil {
+		template = c.templateGenerator.Generate(state)
+	}
+
+	for _, patch := range patches {
+		if patch.Metadata == nil {
+			continue
+		}
+		metadata := *patch.Metadata
+
+		if len(metadata.IAASes) > 0 && !contains(metadata.IAASes, state.IAAS) {
+			return fmt.Errorf("The %s plan patch supports %s, not %s.", patch.Name, strings.Join(metadata.IAASes, ", "), state.IAAS)
+		}
+
+		err := c.checkVersion(patch.Name, metadata.BBLVersion)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range metadata.Terraform.Resources {
+			parts := strings.SplitN(resource, ".", 2)
+			if len(parts) != 2 {
+				return fmt.Errorf("The %s plan patch references the terraform resource %q, which is not of the form <type>.<name>.", patch.Name, resource)
+			}
+			if !declares(template, fmt.Sprintf("resource %q %q", parts[0], parts[1])) {
+				return fmt.Errorf("The %s plan patch references the terraform resource %s, which bbl no longer generates for this environment.", patch.Name, resource)
+			}
+		}
+
+		for _, output := range metadata.Terraform.Outputs {
+			if !declares(template, fmt.Sprintf("output %q", output)) {
+				return fmt.Errorf("The %s plan patch references the terraform output %s, which bbl no longer generates for this environment.", patch.Name, output)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkVersion skips development builds, whose version is not semver.
+func (c PatchCompatibility) checkVersion(name string, versions storage.PatchVersionRange) error {
+	current, err := semver.NewVersion(strings.TrimPrefix(c.bblVersion, "v"))
+	if err != nil {
+		return nil
+	}
+
+	if versions.Min != "" {
+		min, err := semver.NewVersion(versions.Min)
+		if err != nil {
+			return fmt.Errorf("The %s plan patch has an invalid minimum bbl version: %s", name, err)
+		}
+		if current.LessThan(*min) {
+			return fmt.Errorf("The %s plan patch requires bbl %s or later, but this is bbl %s.", name, versions.Min, current)
+		}
+	}
+
+	if versions.Max != "" {
+		max, err := semver.NewVersion(versions.Max)
+		if err != nil {
+			return fmt.Errorf("The %s plan patch has an invalid maximum bbl version: %s", name, err)
+		}
+		if !current.LessThan(*max) {
+			return fmt.Errorf("The %s plan patch requires a bbl version before %s, but this is bbl %s.", name, versions.Max, current)
+		}
+	}
+
+	return nil
+}
+
+func declares(template, declaration string) bool {
+	return regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(declaration) + `\s*\{`).MatchString(template)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
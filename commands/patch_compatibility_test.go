@@ -0,0 +1,210 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	awsterraform "github.com/cloudfoundry/bosh-bootloader/terraform/aws"
+	azureterraform "github.com/cloudfoundry/bosh-bootloader/terraform/azure"
+	gcpterraform "github.com/cloudfoundry/bosh-bootloader/terraform/gcp"
+	openstackterraform "github.com/cloudfoundry/bosh-bootloader/terraform/openstack"
+	vsphereterraform "github.com/cloudfoundry/bosh-bootloader/terraform/vsphere"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PatchCompatibility", func() {
+	var (
+		templateGenerator  *fakes.TemplateGenerator
+		fs                 *afero.Afero
+		metadata           storage.PatchMetadata
+		bblVersion         string
+		state              storage.State
+		patchCompatibility commands.PatchCompatibility
+	)
+
+	BeforeEach(func() {
+		templateGenerator = &fakes.TemplateGenerator{}
+		templateGenerator.GenerateCall.Returns.Template = `resource "aws_elb" "cf_router_lb" {
+  name = "${var.env_id}-cf-lb"
+}
+
+output "cf_router_lb_name" {
+  value = "${aws_elb.cf_router_lb.name}"
+}
+`
+		fs = &afero.Afero{Fs: afero.NewMemMapFs()}
+		fs.MkdirAll("/state", os.ModePerm)
+
+		metadata = storage.PatchMetadata{
+			IAASes:     []string{"aws"},
+			BBLVersion: storage.PatchVersionRange{Min: "6.0.0", Max: "7.0.0"},
+			Terraform: storage.PatchTerraform{
+				Resources: []string{"aws_elb.cf_router_lb"},
+				Outputs:   []string{"cf_router_lb_name"},
+			},
+		}
+		bblVersion = "6.9.0"
+		state = storage.State{IAAS: "aws", LB: storage.LB{Type: "cf"}}
+	})
+
+	JustBeforeEach(func() {
+		err := storage.WritePatches(fs, "/state", []storage.Patch{
+			{Name: "no-metadata"},
+			{Name: "alb-aws", Metadata: &metadata},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		patchCompatibility = commands.NewPatchCompatibility(templateGenerator, fs, "/state", bblVersion)
+	})
+
+	It("checks the patches against the template for the state", func() {
+		err := patchCompatibility.Check(state)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(templateGenerator.GenerateCall.Receives.State).To(Equal(state))
+	})
+
+	Context("when the patch does not support the iaas", func() {
+		BeforeEach(func() {
+			state.IAAS = "gcp"
+		})
+
+		It("returns an error", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).To(MatchError("The alb-aws plan patch supports aws, not gcp."))
+		})
+	})
+
+	Context("when bbl is older than the patch supports", func() {
+		BeforeEach(func() {
+			bblVersion = "5.11.0"
+		})
+
+		It("returns an error", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).To(MatchError("The alb-aws plan patch requires bbl 6.0.0 or later, but this is bbl 5.11.0."))
+		})
+	})
+
+	Context("when bbl is newer than the patch supports", func() {
+		BeforeEach(func() {
+			bblVersion = "v7.0.0"
+		})
+
+		It("returns an error", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).To(MatchError("The alb-aws plan patch requires a bbl version before 7.0.0, but this is bbl 7.0.0."))
+		})
+	})
+
+	Context("when bbl is a development build", func() {
+		BeforeEach(func() {
+			bblVersion = "dev"
+		})
+
+		It("does not check the version", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when a referenced resource is no longer generated", func() {
+		BeforeEach(func() {
+			metadata.Terraform.Resources = []string{"aws_elb.cf_ssh_lb"}
+		})
+
+		It("returns an error", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).To(MatchError("The alb-aws plan patch references the terraform resource aws_elb.cf_ssh_lb, which bbl no longer generates for this environment."))
+		})
+	})
+
+	Context("when a referenced output is no longer generated", func() {
+		BeforeEach(func() {
+			metadata.Terraform.Outputs = []string{"cf_router_lb_url"}
+		})
+
+		It("returns an error", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).To(MatchError("The alb-aws plan patch references the terraform output cf_router_lb_url, which bbl no longer generates for this environment."))
+		})
+	})
+
+	Context("when a resource is not of the form type.name", func() {
+		BeforeEach(func() {
+			metadata.Terraform.Resources = []string{"cf_router_lb"}
+		})
+
+		It("returns an error", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).To(MatchError(`The alb-aws plan patch references the terraform resource "cf_router_lb", which is not of the form <type>.<name>.`))
+		})
+	})
+
+	Context("when the patches cannot be read", func() {
+		JustBeforeEach(func() {
+			fs.WriteFile("/state/bbl-patches.json", []byte("%%%"), storage.StateMode)
+		})
+
+		It("returns an error", func() {
+			err := patchCompatibility.Check(state)
+			Expect(err).To(MatchError(ContainSubstring("Parse bbl-patches.json")))
+		})
+	})
+})
+
+var _ = Describe("the plan patches that ship with bbl", func() {
+	var templateGenerators = map[string]interface {
+		Generate(storage.State) string
+	}{
+		"aws":       awsterraform.NewTemplateGenerator(),
+		"azure":     azureterraform.NewTemplateGenerator(),
+		"gcp":       gcpterraform.NewTemplateGenerator(),
+		"openstack": openstackterraform.NewTemplateGenerator(),
+		"vsphere":   vsphereterraform.NewTemplateGenerator(),
+	}
+
+	It("declare their iaases and only reference terraform resources and outputs that bbl generates", func() {
+		planPatchesDir, err := filepath.Abs(filepath.Join("..", "plan-patches"))
+		Expect(err).NotTo(HaveOccurred())
+
+		osFS := &afero.Afero{Fs: afero.NewOsFs()}
+		infos, err := osFS.ReadDir(planPatchesDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, info := range infos {
+			if !info.IsDir() {
+				continue
+			}
+
+			metadata, err := storage.ReadPatchMetadata(osFS, filepath.Join(planPatchesDir, info.Name()))
+			Expect(err).NotTo(HaveOccurred(), info.Name())
+			Expect(metadata).NotTo(BeNil(), "%s has no bbl-patch.yml", info.Name())
+			Expect(metadata.IAASes).NotTo(BeEmpty(), info.Name())
+
+			for _, iaas := range metadata.IAASes {
+				templateGenerator, ok := templateGenerators[iaas]
+				Expect(ok).To(BeTrue(), "%s supports unknown iaas %s", info.Name(), iaas)
+
+				fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+				err = storage.WritePatches(fs, "/state", []storage.Patch{{Name: info.Name(), Metadata: metadata}})
+				Expect(err).NotTo(HaveOccurred())
+
+				state := storage.State{
+					IAAS: iaas,
+					LB:   storage.LB{Type: "cf", Domain: "example.com"},
+					GCP:  storage.GCP{Zones: []string{"z1", "z2", "z3"}},
+				}
+
+				err = commands.NewPatchCompatibility(templateGenerator, fs, "/state", "dev").Check(state)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+	})
+})
@@ -0,0 +1,354 @@
+package commands_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/runtimeconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Patch", func() {
+	var (
+		patch    commands.Patch
+		logger   *fakes.Logger
+		fs       *afero.Afero
+		stateDir string
+		patchDir string
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		fs = &afero.Afero{Fs: afero.NewMemMapFs()}
+		stateDir = "/state"
+		patchDir = "/patches/bosh-lite"
+
+		fs.MkdirAll(stateDir, os.ModePerm)
+		fs.MkdirAll(filepath.Join(patchDir, "terraform"), os.ModePerm)
+		fs.MkdirAll(filepath.Join(patchDir, "cloud-config"), os.ModePerm)
+		fs.WriteFile(filepath.Join(patchDir, "README.md"), []byte("docs"), storage.StateMode)
+		fs.WriteFile(filepath.Join(patchDir, "PATCH.md"), []byte("more docs"), storage.StateMode)
+		fs.WriteFile(filepath.Join(patchDir, "create-director-override.sh"), []byte("create"), storage.ScriptMode)
+		fs.WriteFile(filepath.Join(patchDir, "terraform", "lite_override.tf"), []byte("terraform"), storage.StateMode)
+		fs.WriteFile(filepath.Join(patchDir, "cloud-config", "lite-ops.yml"), []byte("ops"), storage.StateMode)
+
+		patch = commands.NewPatch(logger, fs, stateDir)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("returns no error", func() {
+			Expect(patch.CheckFastFails([]string{}, storage.State{})).To(Succeed())
+		})
+	})
+
+	Describe("Execute", func() {
+		It("requires a subcommand", func() {
+			err := patch.Execute([]string{}, storage.State{})
+			Expect(err).To(MatchError("This command requires a subcommand: add, list or remove."))
+		})
+
+		It("rejects unknown subcommands", func() {
+			err := patch.Execute([]string{"apply"}, storage.State{})
+			Expect(err).To(MatchError(`Unknown subcommand "apply". Use add, list or remove.`))
+		})
+
+		Describe("add", func() {
+			It("copies the patch into the state dir and records its files", func() {
+				err := patch.Execute([]string{"add", patchDir}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, err := fs.ReadFile(filepath.Join(stateDir, "terraform", "lite_override.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("terraform"))
+
+				info, err := fs.Stat(filepath.Join(stateDir, "create-director-override.sh"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode()).To(Equal(os.FileMode(storage.ScriptMode)))
+
+				_, err = fs.Stat(filepath.Join(stateDir, "README.md"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+				_, err = fs.Stat(filepath.Join(stateDir, "PATCH.md"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+
+				patches, err := storage.ReadPatches(fs, stateDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patches).To(Equal([]storage.Patch{{
+					Name:   "bosh-lite",
+					Source: patchDir,
+					Files: map[string]string{
+						"create-director-override.sh":                  storage.FileHash([]byte("create")),
+						filepath.Join("terraform", "lite_override.tf"): storage.FileHash([]byte("terraform")),
+						filepath.Join("cloud-config", "lite-ops.yml"):  storage.FileHash([]byte("ops")),
+					},
+				}}))
+				Expect(logger.PrintfCall.Messages).To(ContainElement("Added the bosh-lite plan patch with 3 files.\n"))
+			})
+
+			Context("when the patch has a bbl-patch.yml", func() {
+				BeforeEach(func() {
+					fs.WriteFile(filepath.Join(patchDir, "bbl-patch.yml"), []byte("iaases: [gcp]\n"), storage.StateMode)
+				})
+
+				It("records the metadata instead of copying the file", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = fs.Stat(filepath.Join(stateDir, "bbl-patch.yml"))
+					Expect(os.IsNotExist(err)).To(BeTrue())
+
+					patches, err := storage.ReadPatches(fs, stateDir)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(patches[0].Metadata).To(Equal(&storage.PatchMetadata{IAASes: []string{"gcp"}}))
+				})
+			})
+
+			Context("when the patch contains files outside of the layout", func() {
+				BeforeEach(func() {
+					fs.WriteFile(filepath.Join(patchDir, "terraform", "notes.txt"), []byte("notes"), storage.StateMode)
+				})
+
+				It("returns an error and copies nothing", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).To(MatchError(ContainSubstring("terraform/notes.txt is not part of the plan patch layout.")))
+
+					_, err = fs.Stat(filepath.Join(stateDir, "terraform"))
+					Expect(os.IsNotExist(err)).To(BeTrue())
+				})
+			})
+
+			Context("when the patch ships a runtime config", func() {
+				BeforeEach(func() {
+					fs.WriteFile(filepath.Join(patchDir, "runtime-config", "dns", "runtime-config.yml"), []byte("runtime config"), storage.StateMode)
+					fs.WriteFile(filepath.Join(patchDir, "runtime-config", "dns", "dns-ops.yml"), []byte("ops"), storage.StateMode)
+				})
+
+				It("copies it where the runtime config manager picks it up", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).NotTo(HaveOccurred())
+
+					stateStore := &fakes.StateStore{}
+					stateStore.GetRuntimeConfigDirCall.Returns.Directory = filepath.Join(stateDir, "runtime-config")
+					stateStore.GetVarsDirCall.Returns.Directory = filepath.Join(stateDir, "vars")
+					cli := &fakes.BOSHCLI{}
+					manager := runtimeconfig.NewManager(logger, cli, stateStore, &fakes.BOSHClientProvider{}, &fakes.TerraformManager{}, fs)
+
+					names, err := manager.Names()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(names).To(Equal([]string{"dns"}))
+
+					_, err = manager.Interpolate("dns")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, args := cli.RunArgsForCall(0)
+					Expect(args).To(ContainElement(filepath.Join(stateDir, "runtime-config", "dns", "runtime-config.yml")))
+					Expect(args).To(ContainElement(filepath.Join(stateDir, "runtime-config", "dns", "dns-ops.yml")))
+				})
+			})
+
+			Context("when the patch has runtime config files outside of a named directory", func() {
+				BeforeEach(func() {
+					fs.WriteFile(filepath.Join(patchDir, "runtime-config", "dns.yml"), []byte("runtime config"), storage.StateMode)
+				})
+
+				It("returns an error", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).To(MatchError(ContainSubstring("runtime-config/dns.yml is not part of the plan patch layout.")))
+				})
+			})
+
+			Context("when the patch contains a file that bbl generates", func() {
+				BeforeEach(func() {
+					fs.WriteFile(filepath.Join(patchDir, "cloud-config", "cloud-config.yml"), []byte("cloud-config"), storage.StateMode)
+				})
+
+				It("returns an error and copies nothing", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).To(MatchError("cloud-config/cloud-config.yml is generated by bbl on every plan and would be overwritten. Use an ops file or a terraform override with another name instead."))
+
+					_, err = fs.Stat(filepath.Join(stateDir, "cloud-config"))
+					Expect(os.IsNotExist(err)).To(BeTrue())
+				})
+			})
+
+			Context("with the plan patches that ship with bbl", func() {
+				It("adds every one of them", func() {
+					planPatchesDir, err := filepath.Abs(filepath.Join("..", "plan-patches"))
+					Expect(err).NotTo(HaveOccurred())
+
+					osFS := &afero.Afero{Fs: afero.NewOsFs()}
+					infos, err := osFS.ReadDir(planPatchesDir)
+					Expect(err).NotTo(HaveOccurred())
+
+					for _, info := range infos {
+						if !info.IsDir() {
+							continue
+						}
+
+						tempStateDir, err := osFS.TempDir("", "bbl-patch-state")
+						Expect(err).NotTo(HaveOccurred())
+						defer osFS.RemoveAll(tempStateDir)
+
+						err = commands.NewPatch(logger, osFS, tempStateDir).Execute([]string{"add", filepath.Join(planPatchesDir, info.Name())}, storage.State{})
+						Expect(err).NotTo(HaveOccurred(), info.Name())
+					}
+				})
+			})
+
+			Context("when a file of the patch already exists in the state dir", func() {
+				BeforeEach(func() {
+					fs.MkdirAll(filepath.Join(stateDir, "terraform"), os.ModePerm)
+					fs.WriteFile(filepath.Join(stateDir, "terraform", "lite_override.tf"), []byte("mine"), storage.StateMode)
+				})
+
+				It("returns an error and copies nothing", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).To(MatchError("These files of the bosh-lite plan patch already exist in the state directory: terraform/lite_override.tf. Use --force to back them up and replace them."))
+
+					contents, err := fs.ReadFile(filepath.Join(stateDir, "terraform", "lite_override.tf"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal("mine"))
+
+					_, err = fs.Stat(filepath.Join(stateDir, "create-director-override.sh"))
+					Expect(os.IsNotExist(err)).To(BeTrue())
+				})
+
+				It("backs them up and replaces them with --force", func() {
+					err := patch.Execute([]string{"add", "--force", patchDir}, storage.State{})
+					Expect(err).NotTo(HaveOccurred())
+
+					contents, err := fs.ReadFile(filepath.Join(stateDir, "terraform", "lite_override.tf"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal("terraform"))
+
+					backups, err := fs.ReadDir(filepath.Join(stateDir, storage.BACKUPS_DIR))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(backups).To(HaveLen(1))
+
+					backupDir := filepath.Join(stateDir, storage.BACKUPS_DIR, backups[0].Name())
+					contents, err = fs.ReadFile(filepath.Join(backupDir, "terraform", "lite_override.tf"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal("mine"))
+
+					Expect(logger.PrintlnCall.Messages).To(ContainElement(fmt.Sprintf("warning: backed up terraform/lite_override.tf, which the bosh-lite plan patch replaces, to %s", backupDir)))
+				})
+			})
+
+			Context("when a file belongs to another patch", func() {
+				BeforeEach(func() {
+					err := storage.WritePatches(fs, stateDir, []storage.Patch{{
+						Name:  "other",
+						Files: map[string]string{filepath.Join("cloud-config", "lite-ops.yml"): "hash"},
+					}})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an error", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).To(MatchError("cloud-config/lite-ops.yml is already part of the other plan patch."))
+				})
+			})
+
+			Context("when the patch was added before", func() {
+				BeforeEach(func() {
+					Expect(patch.Execute([]string{"add", patchDir}, storage.State{})).To(Succeed())
+					fs.Remove(filepath.Join(patchDir, "cloud-config", "lite-ops.yml"))
+					fs.WriteFile(filepath.Join(patchDir, "terraform", "lite_override.tf"), []byte("new terraform"), storage.StateMode)
+				})
+
+				It("upgrades it and removes files that are no longer part of it", func() {
+					err := patch.Execute([]string{"add", patchDir}, storage.State{})
+					Expect(err).NotTo(HaveOccurred())
+
+					contents, err := fs.ReadFile(filepath.Join(stateDir, "terraform", "lite_override.tf"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal("new terraform"))
+
+					_, err = fs.Stat(filepath.Join(stateDir, "cloud-config", "lite-ops.yml"))
+					Expect(os.IsNotExist(err)).To(BeTrue())
+				})
+
+				Context("when its files were modified locally", func() {
+					BeforeEach(func() {
+						fs.WriteFile(filepath.Join(stateDir, "terraform", "lite_override.tf"), []byte("local"), storage.StateMode)
+					})
+
+					It("returns an error", func() {
+						err := patch.Execute([]string{"add", patchDir}, storage.State{})
+						Expect(err).To(MatchError("These files of the bosh-lite plan patch were modified locally: terraform/lite_override.tf. Use --force to replace them."))
+					})
+
+					It("replaces them with --force", func() {
+						err := patch.Execute([]string{"add", "--force", patchDir}, storage.State{})
+						Expect(err).NotTo(HaveOccurred())
+
+						contents, err := fs.ReadFile(filepath.Join(stateDir, "terraform", "lite_override.tf"))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(contents)).To(Equal("new terraform"))
+					})
+				})
+			})
+		})
+
+		Describe("list", func() {
+			It("prints that there are no patches", func() {
+				err := patch.Execute([]string{"list"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"No plan patches have been added."}))
+			})
+
+			It("prints the patches and their modified files", func() {
+				Expect(patch.Execute([]string{"add", patchDir}, storage.State{})).To(Succeed())
+				fs.WriteFile(filepath.Join(stateDir, "cloud-config", "lite-ops.yml"), []byte("local"), storage.StateMode)
+
+				err := patch.Execute([]string{"list"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintfCall.Messages).To(ContainElement("bosh-lite\t/patches/bosh-lite\t3 files\n"))
+				Expect(logger.PrintfCall.Messages).To(ContainElement("  modified: cloud-config/lite-ops.yml\n"))
+			})
+		})
+
+		Describe("remove", func() {
+			BeforeEach(func() {
+				Expect(patch.Execute([]string{"add", patchDir}, storage.State{})).To(Succeed())
+			})
+
+			It("deletes the files of the patch", func() {
+				err := patch.Execute([]string{"remove", "bosh-lite"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = fs.Stat(filepath.Join(stateDir, "terraform", "lite_override.tf"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+
+				patches, err := storage.ReadPatches(fs, stateDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patches).To(BeEmpty())
+			})
+
+			It("returns an error for unknown patches", func() {
+				err := patch.Execute([]string{"remove", "other"}, storage.State{})
+				Expect(err).To(MatchError("No plan patch named other has been added."))
+			})
+
+			Context("when its files were modified locally", func() {
+				BeforeEach(func() {
+					fs.WriteFile(filepath.Join(stateDir, "cloud-config", "lite-ops.yml"), []byte("local"), storage.StateMode)
+				})
+
+				It("refuses to remove them without --force", func() {
+					err := patch.Execute([]string{"remove", "bosh-lite"}, storage.State{})
+					Expect(err).To(MatchError("These files of the bosh-lite plan patch were modified locally: cloud-config/lite-ops.yml. Use --force to remove them."))
+
+					err = patch.Execute([]string{"remove", "--force", "bosh-lite"}, storage.State{})
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+})
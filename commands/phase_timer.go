@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// phase is a phase of bbl up or bbl destroy. The name labels it in the
+// timing summary and the event is the phase of its json log events.
+type phase struct {
+	name  string
+	event string
+}
+
+var (
+	terraformApplyPhase    = phase{name: "terraform init and apply", event: "terraform"}
+	jumpboxCreatePhase     = phase{name: "jumpbox create-env", event: "jumpbox"}
+	directorCreatePhase    = phase{name: "director create-env", event: "director"}
+	cloudConfigPhase       = phase{name: "cloud-config", event: "cloud-config"}
+	runtimeConfigPhase     = phase{name: "runtime-config", event: "runtime-config"}
+	deleteDeploymentsPhase = phase{name: "delete deployments", event: "deployments"}
+	directorDeletePhase    = phase{name: "director delete-env", event: "director"}
+	jumpboxDeletePhase     = phase{name: "jumpbox delete-env", event: "jumpbox"}
+	terraformDestroyPhase  = phase{name: "terraform destroy", event: "terraform"}
+)
+
+// phaseTimer times the phases of bbl up and bbl destroy so that a summary
+// can be printed and kept in the timings log, and tells the logger which
+// phase its events belong to.
+type phaseTimer struct {
+	command string
+	logger  logger
+	started time.Time
+	phases  []storage.PhaseTiming
+
+	phase      string
+	phaseStart time.Time
+}
+
+func newPhaseTimer(command string, logger logger) *phaseTimer {
+	return &phaseTimer{
+		command: command,
+		logger:  logger,
+		started: time.Now(),
+	}
+}
+
+func (p *phaseTimer) Start(phase phase) {
+	p.Stop()
+
+	p.phase = phase.name
+	p.phaseStart = time.Now()
+	p.logger.SetPhase(phase.event)
+}
+
+func (p *phaseTimer) Stop() {
+	p.stop(false)
+}
+
+func (p *phaseTimer) stop(failed bool) {
+	if p.phase == "" {
+		return
+	}
+
+	p.phases = append(p.phases, storage.PhaseTiming{
+		Phase:   p.phase,
+		Seconds: time.Since(p.phaseStart).Seconds(),
+		Failed:  failed,
+	})
+	p.phase = ""
+	p.logger.SetPhase("")
+}
+
+// Report appends the phases that ran to the timings log. When err is not nil
+// the run and the phase it stopped in are marked as failed, and the summary
+// is only printed for runs that completed.
+func (p *phaseTimer) Report(timingsLog timingsLog, state storage.State, err error) {
+	failed := err != nil
+	p.stop(failed)
+	if len(p.phases) == 0 {
+		return
+	}
+
+	if !failed {
+		p.printSummary()
+	}
+
+	err = timingsLog.Append(storage.Run{
+		Time:                    p.started.UTC(),
+		Command:                 p.command,
+		EnvID:                   state.EnvID,
+		BBLVersion:              state.BBLVersion,
+		BOSHDeploymentCommit:    state.BOSHDeployment.Commit,
+		JumpboxDeploymentCommit: state.JumpboxDeployment.Commit,
+		Failed:                  failed,
+		Phases:                  p.phases,
+	})
+	if err != nil {
+		p.logger.Println(fmt.Sprintf("warning: the phase timings were not saved: %s", err))
+	}
+}
+
+func (p *phaseTimer) printSummary() {
+	table := &bytes.Buffer{}
+	w := tabwriter.NewWriter(table, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "phase\tduration")
+
+	var total float64
+	for _, phase := range p.phases {
+		fmt.Fprintf(w, "%s\t%s\n", phase.Phase, seconds(phase.Seconds))
+		total += phase.Seconds
+	}
+	fmt.Fprintf(w, "total\t%s\n", seconds(total))
+	w.Flush()
+
+	p.logger.Printf("%s", table.String())
+}
+
+func seconds(s float64) string {
+	return (time.Duration(s * float64(time.Second))).Round(time.Second).String()
+}
@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
 	"github.com/cloudfoundry/bosh-bootloader/flags"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
@@ -15,13 +21,30 @@ type Plan struct {
 	envIDManager       envIDManager
 	terraformManager   terraformManager
 	lbArgsHandler      lbArgsHandler
+	patchCompatibility patchCompatibility
+	permissionChecker  PermissionChecker
+	managedFiles       managedFiles
 	logger             logger
+	fs                 planFs
 	bblVersion         string
 }
 
 type PlanConfig struct {
-	Name string
-	LB   storage.LB
+	Name      string
+	LB        storage.LB
+	RenderDir string
+	Redact    bool
+	Sizing    storage.Sizing
+	Force     bool
+}
+
+type PermissionChecker interface {
+	CheckPermissions(state storage.State) ([]string, error)
+}
+
+type planFs interface {
+	fileio.FileWriter
+	fileio.AllMkdirer
 }
 
 func NewPlan(boshManager boshManager,
@@ -30,7 +53,11 @@ func NewPlan(boshManager boshManager,
 	envIDManager envIDManager,
 	terraformManager terraformManager,
 	lbArgsHandler lbArgsHandler,
+	patchCompatibility patchCompatibility,
+	permissionChecker PermissionChecker,
+	managedFiles managedFiles,
 	logger logger,
+	fs planFs,
 	bblVersion string,
 ) Plan {
 	return Plan{
@@ -40,7 +67,11 @@ func NewPlan(boshManager boshManager,
 		envIDManager:       envIDManager,
 		terraformManager:   terraformManager,
 		lbArgsHandler:      lbArgsHandler,
+		patchCompatibility: patchCompatibility,
+		permissionChecker:  permissionChecker,
+		managedFiles:       managedFiles,
 		logger:             logger,
+		fs:                 fs,
 		bblVersion:         bblVersion,
 	}
 }
@@ -63,13 +94,35 @@ func (p Plan) CheckFastFails(args []string, state storage.State) error {
 		return fmt.Errorf("The director name cannot be changed for an existing environment. Current name is %s.", state.EnvID)
 	}
 
+	if config.LB.Type != "" {
+		state.LB = config.LB
+	}
+	if err := p.patchCompatibility.Check(state); err != nil {
+		return err
+	}
+
+	// Not every IaaS can report the caller's permissions, and a failed
+	// check should not block paving with credentials that may well work.
+	// Once terraform has paved the environment, the credentials have
+	// already proven themselves.
+	if p.permissionChecker != nil && state.TFState == "" {
+		missing, err := p.permissionChecker.CheckPermissions(state)
+		if len(missing) > 0 {
+			return fmt.Errorf("The %s credentials are missing these permissions: %s. See docs/iaas-permissions.md for the minimal set bbl needs.", state.IAAS, strings.Join(missing, ", "))
+		}
+		if err != nil {
+			p.logger.Println(fmt.Sprintf("warning: could not verify IaaS permissions: %s", err))
+		}
+	}
+
 	return nil
 }
 
 func (p Plan) ParseArgs(args []string, state storage.State) (PlanConfig, error) {
 	var (
-		config PlanConfig
-		lbArgs LBArgs
+		config           PlanConfig
+		lbArgs           LBArgs
+		directorDiskSize string
 	)
 	planFlags := flags.New("up")
 	planFlags.String(&config.Name, "name", os.Getenv("BBL_ENV_NAME"))
@@ -77,6 +130,15 @@ func (p Plan) ParseArgs(args []string, state storage.State) (PlanConfig, error)
 	planFlags.String(&lbArgs.CertPath, "lb-cert", "")
 	planFlags.String(&lbArgs.KeyPath, "lb-key", "")
 	planFlags.String(&lbArgs.Domain, "lb-domain", "")
+	planFlags.String(&config.RenderDir, "render", "")
+	planFlags.Bool(&config.Redact, "redact")
+	planFlags.Bool(&config.Force, "force")
+	planFlags.String(&config.Sizing.DirectorVMType, "director-vm-type", "")
+	planFlags.String(&directorDiskSize, "director-disk-size", "")
+	planFlags.String(&config.Sizing.JumpboxVMType, "jumpbox-vm-type", "")
+	planFlags.String(&config.Sizing.StemcellLine, "stemcell-line", "")
+	planFlags.String(&config.Sizing.StemcellVersion, "stemcell-version", "")
+	planFlags.String(&config.Sizing.StemcellSHA1, "stemcell-sha1", "")
 	if state.IAAS == "aws" {
 		planFlags.String(&lbArgs.ChainPath, "lb-chain", "")
 	}
@@ -86,6 +148,16 @@ func (p Plan) ParseArgs(args []string, state storage.State) (PlanConfig, error)
 		return PlanConfig{}, err
 	}
 
+	config.Sizing.DirectorDiskSize, err = parseDiskSize(directorDiskSize)
+	if err != nil {
+		return PlanConfig{}, err
+	}
+
+	err = validateSizing(state.IAAS, config.Sizing)
+	if err != nil {
+		return PlanConfig{}, err
+	}
+
 	if (lbArgs != LBArgs{}) {
 		lbState, err := p.lbArgsHandler.GetLBState(state.IAAS, lbArgs)
 		if err != nil {
@@ -103,11 +175,75 @@ func (p Plan) Execute(args []string, state storage.State) error {
 		return err
 	}
 
-	_, err = p.InitializePlan(config, state)
-	return err
+	state, err = p.InitializePlan(config, state)
+	if err != nil {
+		return err
+	}
+
+	if config.RenderDir != "" {
+		return p.render(config, state)
+	}
+
+	return nil
+}
+
+func (p Plan) render(config PlanConfig, state storage.State) error {
+	p.logger.Step("rendering manifests to %s", config.RenderDir)
+
+	err := p.fs.MkdirAll(config.RenderDir, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("Create render dir: %s", err)
+	}
+
+	jumpbox, err := p.boshManager.RenderJumpbox(state, config.Redact)
+	if err != nil {
+		return fmt.Errorf("Render jumpbox manifest: %s", err)
+	}
+
+	director, err := p.boshManager.RenderDirector(state, config.Redact)
+	if err != nil {
+		return fmt.Errorf("Render director manifest: %s", err)
+	}
+
+	isPaved, _ := p.terraformManager.IsPaved()
+	if isPaved {
+		err = p.cloudConfigManager.GenerateVars(state)
+		if err != nil {
+			return fmt.Errorf("Render cloud config: %s", err)
+		}
+	} else {
+		p.logger.Println("warning: terraform has not been applied yet, so cloud-config.yml still contains ((variables)) for the terraform outputs.")
+	}
+
+	cloudConfig, err := p.cloudConfigManager.Interpolate()
+	if err != nil {
+		return fmt.Errorf("Render cloud config: %s", err)
+	}
+
+	rendered := []struct {
+		name     string
+		contents string
+	}{
+		{"jumpbox.yml", jumpbox},
+		{"director.yml", director},
+		{"cloud-config.yml", cloudConfig},
+	}
+
+	for _, r := range rendered {
+		err = p.fs.WriteFile(filepath.Join(config.RenderDir, r.name), []byte(r.contents), storage.StateMode)
+		if err != nil {
+			return fmt.Errorf("Write %s: %s", r.name, err)
+		}
+	}
+
+	return nil
 }
 
 func (p Plan) InitializePlan(config PlanConfig, state storage.State) (storage.State, error) {
+	if err := protectManagedFiles(p.managedFiles, p.logger, config.Force); err != nil {
+		return storage.State{}, err
+	}
+
 	state.BBLVersion = p.bblVersion
 	state.LB = config.LB
 	state.NoDirector = false
@@ -118,6 +254,9 @@ func (p Plan) InitializePlan(config PlanConfig, state storage.State) (storage.St
 		return storage.State{}, fmt.Errorf("Env id manager sync: %s", err)
 	}
 
+	state = p.boshManager.RecordDeploymentSources(state)
+	state.Sizing = state.Sizing.Merge(config.Sizing)
+
 	err = p.stateStore.Set(state)
 	if err != nil {
 		return storage.State{}, fmt.Errorf("Save state: %s", err)
@@ -139,9 +278,53 @@ func (p Plan) InitializePlan(config PlanConfig, state storage.State) (storage.St
 		return storage.State{}, fmt.Errorf("Bosh manager initialize director: %s", err)
 	}
 
+	if err := p.managedFiles.Record(); err != nil {
+		return storage.State{}, err
+	}
+
 	return state, nil
 }
 
+func parseDiskSize(size string) (int, error) {
+	if size == "" {
+		return 0, nil
+	}
+
+	gb, err := strconv.Atoi(size)
+	if err != nil || gb <= 0 {
+		return 0, fmt.Errorf("Invalid --director-disk-size %q. The disk size must be a positive number of GB.", size)
+	}
+
+	return gb, nil
+}
+
+func validateSizing(iaas string, sizing storage.Sizing) error {
+	if !bosh.SupportsVMType(iaas) {
+		if sizing.DirectorVMType != "" {
+			return fmt.Errorf("--director-vm-type is not supported on %s.", iaas)
+		}
+		if sizing.JumpboxVMType != "" {
+			return fmt.Errorf("--jumpbox-vm-type is not supported on %s.", iaas)
+		}
+	}
+
+	stemcellSet := sizing.StemcellLine != "" || sizing.StemcellVersion != "" || sizing.StemcellSHA1 != ""
+	if stemcellSet && (sizing.StemcellLine == "" || sizing.StemcellVersion == "" || sizing.StemcellSHA1 == "") {
+		return errors.New("--stemcell-line, --stemcell-version and --stemcell-sha1 must be passed together.")
+	}
+
+	if sizing.StemcellLine != "" {
+		for _, line := range bosh.StemcellLines {
+			if sizing.StemcellLine == line {
+				return nil
+			}
+		}
+		return fmt.Errorf("Invalid --stemcell-line %q. Supported stemcell lines: %s.", sizing.StemcellLine, strings.Join(bosh.StemcellLines, ", "))
+	}
+
+	return nil
+}
+
 func (p Plan) IsInitialized(state storage.State) bool {
 	// If it is older than bbl v5.4.0 with schema 13, we want to re-initialize.
 	return state.Version >= 13
@@ -3,6 +3,7 @@ package commands_test
 import (
 	"errors"
 	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/commands"
@@ -21,9 +22,13 @@ var _ = Describe("Plan", func() {
 		cloudConfigManager *fakes.CloudConfigManager
 		envIDManager       *fakes.EnvIDManager
 		lbArgsHandler      *fakes.LBArgsHandler
+		patchCompatibility *fakes.PatchCompatibility
+		permissionChecker  *fakes.PermissionChecker
+		managedFiles       *fakes.ManagedFiles
 		logger             *fakes.Logger
 		stateStore         *fakes.StateStore
 		terraformManager   *fakes.TerraformManager
+		fileIO             *fakes.FileIO
 		bblVersion         string
 	)
 
@@ -32,9 +37,13 @@ var _ = Describe("Plan", func() {
 		cloudConfigManager = &fakes.CloudConfigManager{}
 		envIDManager = &fakes.EnvIDManager{}
 		lbArgsHandler = &fakes.LBArgsHandler{}
+		patchCompatibility = &fakes.PatchCompatibility{}
+		permissionChecker = &fakes.PermissionChecker{}
+		managedFiles = &fakes.ManagedFiles{}
 		logger = &fakes.Logger{}
 		stateStore = &fakes.StateStore{}
 		terraformManager = &fakes.TerraformManager{}
+		fileIO = &fakes.FileIO{}
 		bblVersion = "42.0.0"
 
 		boshManager.VersionCall.Returns.Version = "2.0.48"
@@ -46,7 +55,11 @@ var _ = Describe("Plan", func() {
 			envIDManager,
 			terraformManager,
 			lbArgsHandler,
+			patchCompatibility,
+			permissionChecker,
+			managedFiles,
 			logger,
+			fileIO,
 			bblVersion,
 		)
 	})
@@ -63,6 +76,7 @@ var _ = Describe("Plan", func() {
 			stateWithVersion = storage.State{ID: "some-state-id", IAAS: "some-iaas", BBLVersion: "42.0.0"}
 			syncedState = storage.State{ID: "synced-state-id"}
 			envIDManager.SyncCall.Returns.State = syncedState
+			boshManager.RecordDeploymentSourcesCall.Returns.State = syncedState
 		})
 
 		It("sets up the bbl state dir", func() {
@@ -76,6 +90,9 @@ var _ = Describe("Plan", func() {
 			Expect(envIDManager.SyncCall.Receives.State).To(Equal(stateWithVersion))
 
 			Expect(stateStore.SetCall.CallCount).To(Equal(1))
+			Expect(boshManager.RecordDeploymentSourcesCall.CallCount).To(Equal(1))
+			Expect(boshManager.RecordDeploymentSourcesCall.Receives.State).To(Equal(syncedState))
+
 			Expect(stateStore.SetCall.Receives[0].State).To(Equal(syncedState))
 
 			Expect(terraformManager.SetupCall.CallCount).To(Equal(1))
@@ -89,6 +106,62 @@ var _ = Describe("Plan", func() {
 
 			Expect(cloudConfigManager.InitializeCall.CallCount).To(Equal(1))
 			Expect(cloudConfigManager.InitializeCall.Receives.State).To(Equal(syncedState))
+
+			Expect(managedFiles.RecordCall.CallCount).To(Equal(1))
+		})
+
+		Context("when bbl-managed files were modified locally", func() {
+			BeforeEach(func() {
+				managedFiles.ModifiedCall.Returns.Files = []string{"cloud-config/ops.yml", "terraform/bbl-template.tf"}
+			})
+
+			It("refuses to overwrite them and points to override files", func() {
+				err := command.Execute([]string{}, state)
+				Expect(err).To(MatchError(ContainSubstring("These files are generated by bbl and were modified locally: cloud-config/ops.yml, terraform/bbl-template.tf.")))
+				Expect(err).To(MatchError(ContainSubstring("terraform/*_override.tf")))
+
+				Expect(managedFiles.BackupCall.CallCount).To(Equal(0))
+				Expect(terraformManager.SetupCall.CallCount).To(Equal(0))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+			})
+
+			Context("with --force", func() {
+				It("backs them up before overwriting them", func() {
+					managedFiles.BackupCall.Returns.Dir = "some-state-dir/bbl-backups/some-time"
+
+					err := command.Execute([]string{"--force"}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(managedFiles.BackupCall.Receives.Files).To(Equal([]string{"cloud-config/ops.yml", "terraform/bbl-template.tf"}))
+					Expect(logger.PrintlnCall.Messages).To(ContainElement("warning: backed up the modified bbl-managed files cloud-config/ops.yml, terraform/bbl-template.tf to some-state-dir/bbl-backups/some-time"))
+					Expect(terraformManager.SetupCall.CallCount).To(Equal(1))
+				})
+
+				It("returns an error when the backup fails", func() {
+					managedFiles.BackupCall.Returns.Error = errors.New("disk full")
+
+					err := command.Execute([]string{"--force"}, state)
+					Expect(err).To(MatchError("disk full"))
+				})
+			})
+		})
+
+		Context("when the hashes of bbl-managed files cannot be read", func() {
+			It("returns an error", func() {
+				managedFiles.ModifiedCall.Returns.Error = errors.New("Parse bbl-managed-files.json: bad json")
+
+				err := command.Execute([]string{}, state)
+				Expect(err).To(MatchError("Parse bbl-managed-files.json: bad json"))
+			})
+		})
+
+		Context("when the hashes of bbl-managed files cannot be recorded", func() {
+			It("returns an error", func() {
+				managedFiles.RecordCall.Returns.Error = errors.New("Write bbl-managed-files.json: disk full")
+
+				err := command.Execute([]string{}, state)
+				Expect(err).To(MatchError("Write bbl-managed-files.json: disk full"))
+			})
 		})
 
 		Context("when lb flags are passed", func() {
@@ -127,6 +200,129 @@ var _ = Describe("Plan", func() {
 			})
 		})
 
+		Context("when sizing flags are passed", func() {
+			It("saves the sizing to the state", func() {
+				boshManager.RecordDeploymentSourcesCall.Returns.State = storage.State{
+					ID:     "synced-state-id",
+					Sizing: storage.Sizing{JumpboxVMType: "t2.small", StemcellLine: "ubuntu-trusty"},
+				}
+
+				err := command.Execute([]string{
+					"--director-vm-type", "m4.xlarge",
+					"--director-disk-size", "128",
+					"--stemcell-line", "ubuntu-xenial",
+					"--stemcell-version", "97.28",
+					"--stemcell-sha1", "some-stemcell-sha1",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedSizing := storage.Sizing{
+					DirectorVMType:   "m4.xlarge",
+					DirectorDiskSize: 128,
+					JumpboxVMType:    "t2.small",
+					StemcellLine:     "ubuntu-xenial",
+					StemcellVersion:  "97.28",
+					StemcellSHA1:     "some-stemcell-sha1",
+				}
+				Expect(stateStore.SetCall.Receives[0].State.Sizing).To(Equal(expectedSizing))
+				Expect(boshManager.InitializeJumpboxCall.Receives.State.Sizing).To(Equal(expectedSizing))
+				Expect(boshManager.InitializeDirectorCall.Receives.State.Sizing).To(Equal(expectedSizing))
+			})
+		})
+
+		Context("when --render is passed", func() {
+			BeforeEach(func() {
+				boshManager.RenderJumpboxCall.Returns.Manifest = "some-jumpbox-manifest"
+				boshManager.RenderDirectorCall.Returns.Manifest = "some-director-manifest"
+				cloudConfigManager.InterpolateCall.Returns.CloudConfig = "some-cloud-config"
+				terraformManager.IsPavedCall.Returns.IsPaved = true
+			})
+
+			It("writes the interpolated manifests to the render dir", func() {
+				err := command.Execute([]string{"--render", "some-render-dir", "--redact"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fileIO.MkdirAllCall.Receives.Dir).To(Equal("some-render-dir"))
+
+				Expect(boshManager.RenderJumpboxCall.Receives.State).To(Equal(syncedState))
+				Expect(boshManager.RenderJumpboxCall.Receives.Redact).To(BeTrue())
+				Expect(boshManager.RenderDirectorCall.Receives.State).To(Equal(syncedState))
+				Expect(boshManager.RenderDirectorCall.Receives.Redact).To(BeTrue())
+
+				Expect(fileIO.WriteFileCall.Receives).To(Equal([]fakes.WriteFileReceive{
+					{Filename: filepath.Join("some-render-dir", "jumpbox.yml"), Contents: []byte("some-jumpbox-manifest"), Mode: storage.StateMode},
+					{Filename: filepath.Join("some-render-dir", "director.yml"), Contents: []byte("some-director-manifest"), Mode: storage.StateMode},
+					{Filename: filepath.Join("some-render-dir", "cloud-config.yml"), Contents: []byte("some-cloud-config"), Mode: storage.StateMode},
+				}))
+			})
+
+			It("generates the cloud config vars from the terraform outputs", func() {
+				err := command.Execute([]string{"--render", "some-render-dir"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.GenerateVarsCall.CallCount).To(Equal(1))
+				Expect(cloudConfigManager.GenerateVarsCall.Receives.State).To(Equal(syncedState))
+			})
+
+			Context("when terraform has not been applied yet", func() {
+				BeforeEach(func() {
+					terraformManager.IsPavedCall.Returns.IsPaved = false
+				})
+
+				It("warns that the cloud config keeps its variables", func() {
+					err := command.Execute([]string{"--render", "some-render-dir"}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(cloudConfigManager.GenerateVarsCall.CallCount).To(Equal(0))
+					Expect(logger.PrintlnCall.Messages).To(ContainElement("warning: terraform has not been applied yet, so cloud-config.yml still contains ((variables)) for the terraform outputs."))
+				})
+			})
+
+			Context("failure cases", func() {
+				It("returns an error if the render dir cannot be created", func() {
+					fileIO.MkdirAllCall.Returns.Error = errors.New("kumquat")
+
+					err := command.Execute([]string{"--render", "some-render-dir"}, state)
+					Expect(err).To(MatchError("Create render dir: kumquat"))
+				})
+
+				It("returns an error if rendering the jumpbox fails", func() {
+					boshManager.RenderJumpboxCall.Returns.Error = errors.New("quince")
+
+					err := command.Execute([]string{"--render", "some-render-dir"}, state)
+					Expect(err).To(MatchError("Render jumpbox manifest: quince"))
+				})
+
+				It("returns an error if rendering the director fails", func() {
+					boshManager.RenderDirectorCall.Returns.Error = errors.New("papaya")
+
+					err := command.Execute([]string{"--render", "some-render-dir"}, state)
+					Expect(err).To(MatchError("Render director manifest: papaya"))
+				})
+
+				It("returns an error if generating the cloud config vars fails", func() {
+					cloudConfigManager.GenerateVarsCall.Returns.Error = errors.New("fig")
+
+					err := command.Execute([]string{"--render", "some-render-dir"}, state)
+					Expect(err).To(MatchError("Render cloud config: fig"))
+				})
+
+				It("returns an error if interpolating the cloud config fails", func() {
+					cloudConfigManager.InterpolateCall.Returns.Error = errors.New("guava")
+
+					err := command.Execute([]string{"--render", "some-render-dir"}, state)
+					Expect(err).To(MatchError("Render cloud config: guava"))
+				})
+
+				It("returns an error if writing a manifest fails", func() {
+					fileIO.WriteFileCall.Returns = []fakes.WriteFileReturn{{Error: errors.New("persimmon")}}
+
+					err := command.Execute([]string{"--render", "some-render-dir"}, state)
+					Expect(err).To(MatchError("Write jumpbox.yml: persimmon"))
+				})
+			})
+		})
+
 		Describe("failure cases", func() {
 			It("returns an error if state store set fails", func() {
 				stateStore.SetCall.Returns = []fakes.SetCallReturn{{Error: errors.New("peach")}}
@@ -233,6 +429,76 @@ var _ = Describe("Plan", func() {
 				})
 			})
 		})
+
+		Context("when a plan patch is not compatible with the environment", func() {
+			BeforeEach(func() {
+				patchCompatibility.CheckCall.Returns.Error = errors.New("The bosh-lite plan patch supports gcp, not aws.")
+			})
+
+			It("returns the error", func() {
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "aws", Version: 999})
+				Expect(err).To(MatchError("The bosh-lite plan patch supports gcp, not aws."))
+				Expect(patchCompatibility.CheckCall.Receives.State.IAAS).To(Equal("aws"))
+			})
+		})
+
+		It("checks the plan patches against the requested lb type", func() {
+			lbArgsHandler.GetLBStateCall.Returns.LB = storage.LB{Type: "cf"}
+
+			err := command.CheckFastFails([]string{"--lb-type", "cf"}, storage.State{IAAS: "aws", Version: 999})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(patchCompatibility.CheckCall.Receives.State.LB.Type).To(Equal("cf"))
+		})
+
+		It("checks the iaas permissions for the requested lb type", func() {
+			lbArgsHandler.GetLBStateCall.Returns.LB = storage.LB{Type: "concourse"}
+
+			err := command.CheckFastFails([]string{"--lb-type", "concourse"}, storage.State{IAAS: "gcp", Version: 999})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(permissionChecker.CheckPermissionsCall.CallCount).To(Equal(1))
+			Expect(permissionChecker.CheckPermissionsCall.Receives.State.LB.Type).To(Equal("concourse"))
+		})
+
+		Context("when the credentials are missing permissions", func() {
+			BeforeEach(func() {
+				permissionChecker.CheckPermissionsCall.Returns.Missing = []string{"ec2:CreateVpc", "ec2:RunInstances"}
+			})
+
+			It("returns an error listing them", func() {
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "aws", Version: 999})
+				Expect(err).To(MatchError("The aws credentials are missing these permissions: ec2:CreateVpc, ec2:RunInstances. See docs/iaas-permissions.md for the minimal set bbl needs."))
+			})
+		})
+
+		Context("when the permissions could not be verified", func() {
+			BeforeEach(func() {
+				permissionChecker.CheckPermissionsCall.Returns.Error = errors.New("access denied")
+			})
+
+			It("warns and continues", func() {
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "aws", Version: 999})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("warning: could not verify IaaS permissions: access denied"))
+			})
+		})
+
+		Context("when the environment has already been paved", func() {
+			It("does not check permissions", func() {
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "aws", Version: 999, TFState: "some-tf-state"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(permissionChecker.CheckPermissionsCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when the iaas has no permission checker", func() {
+			It("does not check permissions", func() {
+				command = commands.NewPlan(boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager, lbArgsHandler,
+					patchCompatibility, nil, managedFiles, logger, fileIO, bblVersion)
+
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "vsphere", Version: 999})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("ParseArgs", func() {
@@ -323,6 +589,69 @@ var _ = Describe("Plan", func() {
 			})
 		})
 
+		Context("when sizing flags are passed", func() {
+			It("sets the sizing on the config", func() {
+				config, err := command.ParseArgs([]string{
+					"--director-vm-type", "n1-standard-4",
+					"--director-disk-size", "100",
+					"--jumpbox-vm-type", "n1-standard-1",
+					"--stemcell-line", "ubuntu-xenial",
+					"--stemcell-version", "97.28",
+					"--stemcell-sha1", "some-stemcell-sha1",
+				}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Sizing).To(Equal(storage.Sizing{
+					DirectorVMType:   "n1-standard-4",
+					DirectorDiskSize: 100,
+					JumpboxVMType:    "n1-standard-1",
+					StemcellLine:     "ubuntu-xenial",
+					StemcellVersion:  "97.28",
+					StemcellSHA1:     "some-stemcell-sha1",
+				}))
+			})
+
+			Context("when the disk size is not a positive number", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--director-disk-size", "big"}, storage.State{IAAS: "gcp"})
+					Expect(err).To(MatchError(`Invalid --director-disk-size "big". The disk size must be a positive number of GB.`))
+
+					_, err = command.ParseArgs([]string{"--director-disk-size", "0"}, storage.State{IAAS: "gcp"})
+					Expect(err).To(MatchError(`Invalid --director-disk-size "0". The disk size must be a positive number of GB.`))
+				})
+			})
+
+			Context("when a vm type is passed on vsphere", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--director-vm-type", "large"}, storage.State{IAAS: "vsphere"})
+					Expect(err).To(MatchError("--director-vm-type is not supported on vsphere."))
+
+					_, err = command.ParseArgs([]string{"--jumpbox-vm-type", "small"}, storage.State{IAAS: "vsphere"})
+					Expect(err).To(MatchError("--jumpbox-vm-type is not supported on vsphere."))
+				})
+			})
+
+			Context("when the stemcell line is unknown", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{
+						"--stemcell-line", "centos-7",
+						"--stemcell-version", "97.28",
+						"--stemcell-sha1", "some-stemcell-sha1",
+					}, storage.State{IAAS: "aws"})
+					Expect(err).To(MatchError(`Invalid --stemcell-line "centos-7". Supported stemcell lines: ubuntu-trusty, ubuntu-xenial.`))
+				})
+			})
+
+			Context("when the stemcell line is passed without a version and sha1", func() {
+				It("returns an error", func() {
+					_, err := command.ParseArgs([]string{"--stemcell-line", "ubuntu-xenial"}, storage.State{IAAS: "aws"})
+					Expect(err).To(MatchError("--stemcell-line, --stemcell-version and --stemcell-sha1 must be passed together."))
+
+					_, err = command.ParseArgs([]string{"--stemcell-version", "97.28"}, storage.State{IAAS: "aws"})
+					Expect(err).To(MatchError("--stemcell-line, --stemcell-version and --stemcell-sha1 must be passed together."))
+				})
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when undefined flags are passed", func() {
 				It("returns an error", func() {
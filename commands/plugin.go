@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type pluginFinder interface {
+	Find(name string) (string, bool)
+	List() []string
+}
+
+type pluginsFS interface {
+	fileio.TempDirer
+	fileio.FileWriter
+	fileio.AllRemover
+}
+
+// Plugins runs bbl-<name> executables for commands that bbl does not know.
+type Plugins struct {
+	finder           pluginFinder
+	environment      environmentGetter
+	terraformManager terraformManager
+	runner           commandRunner
+	fs               pluginsFS
+	stateDir         string
+}
+
+type Plugin struct {
+	name    string
+	path    string
+	plugins Plugins
+}
+
+func NewPlugins(finder pluginFinder, environment environmentGetter, terraformManager terraformManager,
+	runner commandRunner, fs pluginsFS, stateDir string) Plugins {
+	return Plugins{
+		finder:           finder,
+		environment:      environment,
+		terraformManager: terraformManager,
+		runner:           runner,
+		fs:               fs,
+		stateDir:         stateDir,
+	}
+}
+
+func (p Plugins) Command(name string) (Command, bool) {
+	path, ok := p.finder.Find(name)
+	if !ok {
+		return nil, false
+	}
+
+	return Plugin{
+		name:    name,
+		path:    path,
+		plugins: p,
+	}, true
+}
+
+func (p Plugins) List() []string {
+	return p.finder.List()
+}
+
+func (p Plugin) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return nil
+}
+
+func (p Plugin) Execute(args []string, state storage.State) error {
+	env := []string{
+		fmt.Sprintf("BBL_STATE_DIR=%s", p.plugins.stateDir),
+		fmt.Sprintf("BBL_IAAS=%s", state.IAAS),
+		fmt.Sprintf("BBL_ENV_ID=%s", state.EnvID),
+	}
+
+	if state.IAAS == "" {
+		return p.plugins.runner.Run(append([]string{p.path}, args...), env)
+	}
+
+	isPaved, _ := p.plugins.terraformManager.IsPaved()
+	if isPaved {
+		outputsPath, err := p.writeTerraformOutputs()
+		if err != nil {
+			return err
+		}
+		defer p.plugins.fs.RemoveAll(filepath.Dir(outputsPath))
+
+		env = append(env, fmt.Sprintf("BBL_TERRAFORM_OUTPUTS=%s", outputsPath))
+	}
+
+	if (isPaved && state.NoDirector) || state.BOSH.DirectorAddress != "" {
+		envVars, privateKeyPath, err := p.plugins.environment.Environment(state, false)
+		if err != nil {
+			return err
+		}
+		if privateKeyPath != "" {
+			defer p.plugins.fs.RemoveAll(filepath.Dir(privateKeyPath))
+		}
+
+		for _, envVar := range envVars {
+			env = append(env, fmt.Sprintf("%s=%s", envVar.Name, envVar.Value))
+		}
+	}
+
+	return p.plugins.runner.Run(append([]string{p.path}, args...), env)
+}
+
+func (p Plugin) writeTerraformOutputs() (string, error) {
+	outputs, err := p.plugins.terraformManager.GetOutputs()
+	if err != nil {
+		return "", fmt.Errorf("Get terraform outputs: %s", err)
+	}
+
+	contents, err := json.Marshal(outputs.Map)
+	if err != nil {
+		return "", fmt.Errorf("Marshal terraform outputs: %s", err) // not tested
+	}
+
+	dir, err := p.plugins.fs.TempDir("", "bbl-plugin")
+	if err != nil {
+		return "", fmt.Errorf("Create temp dir: %s", err)
+	}
+
+	path := filepath.Join(dir, "terraform-outputs.json")
+	err = p.plugins.fs.WriteFile(path, contents, storage.StateMode)
+	if err != nil {
+		return "", fmt.Errorf("Write terraform outputs: %s", err)
+	}
+
+	return path, nil
+}
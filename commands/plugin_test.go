@@ -0,0 +1,168 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plugins", func() {
+	var (
+		finder           *fakes.PluginFinder
+		environment      *fakes.EnvironmentGetter
+		terraformManager *fakes.TerraformManager
+		runner           *fakes.CommandRunner
+		fileIO           *fakes.FileIO
+
+		plugins commands.Plugins
+		state   storage.State
+	)
+
+	BeforeEach(func() {
+		finder = &fakes.PluginFinder{}
+		finder.FindCall.Returns.Path = "/some/bin/bbl-cf-deploy"
+		finder.FindCall.Returns.OK = true
+
+		environment = &fakes.EnvironmentGetter{}
+		environment.EnvironmentCall.Returns.EnvVars = []commands.EnvVar{
+			{Name: "BOSH_CLIENT", Value: "some-director-username"},
+		}
+		environment.EnvironmentCall.Returns.PrivateKeyPath = "/tmp/bosh-jumpbox123/bosh_jumpbox_private.key"
+
+		terraformManager = &fakes.TerraformManager{}
+		terraformManager.IsPavedCall.Returns.IsPaved = true
+		terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{Map: map[string]interface{}{"jumpbox_url": "some-jumpbox-url"}}
+
+		runner = &fakes.CommandRunner{}
+		fileIO = &fakes.FileIO{}
+		fileIO.TempDirCall.Returns.Name = "/tmp/bbl-plugin123"
+
+		plugins = commands.NewPlugins(finder, environment, terraformManager, runner, fileIO, "/some/state-dir")
+
+		state = storage.State{
+			IAAS:  "gcp",
+			EnvID: "some-env-id",
+			BOSH:  storage.BOSH{DirectorAddress: "https://10.0.0.6:25555"},
+		}
+	})
+
+	Describe("Command", func() {
+		It("finds the plugin for the command", func() {
+			command, ok := plugins.Command("cf-deploy")
+			Expect(ok).To(BeTrue())
+			Expect(command.Usage()).To(ContainSubstring("Runs the plugin at /some/bin/bbl-cf-deploy"))
+
+			Expect(finder.FindCall.Receives.Name).To(Equal("cf-deploy"))
+		})
+
+		It("returns false when there is no plugin", func() {
+			finder.FindCall.Returns.OK = false
+
+			_, ok := plugins.Command("cf-deploy")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("List", func() {
+		It("lists the plugins", func() {
+			finder.ListCall.Returns.Names = []string{"cf-deploy"}
+
+			Expect(plugins.List()).To(Equal([]string{"cf-deploy"}))
+		})
+	})
+
+	Describe("Execute", func() {
+		var plugin commands.Command
+
+		BeforeEach(func() {
+			plugin, _ = plugins.Command("cf-deploy")
+		})
+
+		It("runs the plugin with the bbl environment", func() {
+			err := plugin.Execute([]string{"--some-flag", "some-value"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.WriteFileCall.Receives).To(HaveLen(1))
+			Expect(fileIO.WriteFileCall.Receives[0].Filename).To(Equal("/tmp/bbl-plugin123/terraform-outputs.json"))
+			Expect(string(fileIO.WriteFileCall.Receives[0].Contents)).To(Equal(`{"jumpbox_url":"some-jumpbox-url"}`))
+
+			Expect(environment.EnvironmentCall.Receives.State).To(Equal(state))
+
+			Expect(runner.RunCall.Receives.Command).To(Equal([]string{"/some/bin/bbl-cf-deploy", "--some-flag", "some-value"}))
+			Expect(runner.RunCall.Receives.Env).To(Equal([]string{
+				"BBL_STATE_DIR=/some/state-dir",
+				"BBL_IAAS=gcp",
+				"BBL_ENV_ID=some-env-id",
+				"BBL_TERRAFORM_OUTPUTS=/tmp/bbl-plugin123/terraform-outputs.json",
+				"BOSH_CLIENT=some-director-username",
+			}))
+
+			Expect(fileIO.RemoveAllCall.Receives).To(ConsistOf(
+				fakes.RemoveAllReceive{Path: "/tmp/bosh-jumpbox123"},
+				fakes.RemoveAllReceive{Path: "/tmp/bbl-plugin123"},
+			))
+		})
+
+		Context("when there is no environment yet", func() {
+			It("only passes the state dir, iaas and env id", func() {
+				terraformManager.IsPavedCall.Returns.IsPaved = false
+
+				err := plugin.Execute([]string{}, storage.State{NoDirector: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(terraformManager.GetOutputsCall.CallCount).To(Equal(0))
+				Expect(environment.EnvironmentCall.CallCount).To(Equal(0))
+				Expect(runner.RunCall.Receives.Env).To(Equal([]string{
+					"BBL_STATE_DIR=/some/state-dir",
+					"BBL_IAAS=",
+					"BBL_ENV_ID=",
+				}))
+			})
+
+			It("does not use the terraform manager, which is not built without an iaas", func() {
+				plugins = commands.NewPlugins(finder, environment, terraform.Manager{}, runner, fileIO, "/some/state-dir")
+				plugin, _ = plugins.Command("cf-deploy")
+
+				err := plugin.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(environment.EnvironmentCall.CallCount).To(Equal(0))
+				Expect(runner.RunCall.Receives.Env).To(Equal([]string{
+					"BBL_STATE_DIR=/some/state-dir",
+					"BBL_IAAS=",
+					"BBL_ENV_ID=",
+				}))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the terraform outputs cannot be read", func() {
+				terraformManager.GetOutputsCall.Returns.Error = errors.New("pineapple")
+
+				err := plugin.Execute([]string{}, state)
+				Expect(err).To(MatchError("Get terraform outputs: pineapple"))
+			})
+
+			It("returns an error when the print-env variables cannot be read", func() {
+				environment.EnvironmentCall.Returns.Error = errors.New("kiwi")
+
+				err := plugin.Execute([]string{}, state)
+				Expect(err).To(MatchError("kiwi"))
+				Expect(fileIO.RemoveAllCall.Receives).To(Equal([]fakes.RemoveAllReceive{{Path: "/tmp/bbl-plugin123"}}))
+			})
+
+			It("returns the error of the plugin", func() {
+				runner.RunCall.Returns.Error = errors.New("exit status 3")
+
+				err := plugin.Execute([]string{}, state)
+				Expect(err).To(MatchError("exit status 3"))
+			})
+		})
+	})
+})
@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -15,6 +18,7 @@ type PrintEnv struct {
 	terraformManager terraformManager
 	credhubGetter    credhubGetter
 	fs               fs
+	stateDir         string
 }
 
 type envSetter interface {
@@ -35,6 +39,7 @@ type allProxyGetter interface {
 type fs interface {
 	fileio.TempDirer
 	fileio.FileWriter
+	fileio.FileReader
 }
 
 func NewPrintEnv(
@@ -44,7 +49,8 @@ func NewPrintEnv(
 	allProxyGetter allProxyGetter,
 	credhubGetter credhubGetter,
 	terraformManager terraformManager,
-	fs fs) PrintEnv {
+	fs fs,
+	stateDir string) PrintEnv {
 	return PrintEnv{
 		stateValidator:   stateValidator,
 		logger:           logger,
@@ -53,6 +59,7 @@ func NewPrintEnv(
 		terraformManager: terraformManager,
 		credhubGetter:    credhubGetter,
 		fs:               fs,
+		stateDir:         stateDir,
 	}
 }
 
@@ -65,53 +72,128 @@ func (p PrintEnv) CheckFastFails(subcommandFlags []string, state storage.State)
 	return nil
 }
 
+// EnvVar is one of the variables for targeting the director.
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
 func (p PrintEnv) Execute(args []string, state storage.State) error {
+	var (
+		useTunnel bool
+		shell     string
+		format    string
+	)
+	printEnvFlags := flags.New("print-env")
+	printEnvFlags.Bool(&useTunnel, "use-tunnel")
+	printEnvFlags.String(&shell, "shell", "")
+	printEnvFlags.String(&format, "format", "")
+	err := printEnvFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if shell != "" && format != "" {
+		return errors.New("--shell and --format cannot be used together.")
+	}
+
+	var formatter envFormatter
+	switch {
+	case format != "":
+		formatter = envFormats[format]
+		if formatter == nil {
+			return fmt.Errorf("Unknown format %q. Use dotenv or json.", format)
+		}
+	case shell != "":
+		formatter = envShells[shell]
+		if formatter == nil {
+			return fmt.Errorf("Unknown shell %q. Use fish, powershell or posix.", shell)
+		}
+	default:
+		formatter = envShells["posix"]
+	}
+
+	envVars, _, err := p.Environment(state, useTunnel)
+	if err != nil {
+		return err
+	}
+
+	lines, err := formatter(envVars)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		p.logger.Println(line)
+	}
+	return nil
+}
+
+// Environment returns the variables for the bosh and credhub CLIs. Unless
+// useTunnel is set, it also returns the path of the jumpbox private key that
+// it wrote for BOSH_ALL_PROXY.
+func (p PrintEnv) Environment(state storage.State, useTunnel bool) ([]EnvVar, string, error) {
 	if state.NoDirector {
 		terraformOutputs, err := p.terraformManager.GetOutputs()
 		if err != nil {
-			return err
+			return nil, "", err
 		}
 
-		p.logger.Println(fmt.Sprintf("export BOSH_ENVIRONMENT=https://%s:25555", terraformOutputs.GetString("external_ip")))
-		return nil
+		return []EnvVar{
+			{"BOSH_ENVIRONMENT", fmt.Sprintf("https://%s:25555", terraformOutputs.GetString("external_ip"))},
+		}, "", nil
 	}
 
-	p.logger.Println(fmt.Sprintf("export BOSH_CLIENT=%s", state.BOSH.DirectorUsername))
-	p.logger.Println(fmt.Sprintf("export BOSH_CLIENT_SECRET=%s", state.BOSH.DirectorPassword))
-	p.logger.Println(fmt.Sprintf("export BOSH_ENVIRONMENT=%s", state.BOSH.DirectorAddress))
-	p.logger.Println(fmt.Sprintf("export BOSH_CA_CERT='%s'", state.BOSH.DirectorSSLCA))
-
-	p.logger.Println("export CREDHUB_CLIENT=credhub-admin")
+	envVars := []EnvVar{
+		{"BOSH_CLIENT", state.BOSH.DirectorUsername},
+		{"BOSH_CLIENT_SECRET", state.BOSH.DirectorPassword},
+		{"BOSH_ENVIRONMENT", state.BOSH.DirectorAddress},
+		{"BOSH_CA_CERT", state.BOSH.DirectorSSLCA},
+		{"CREDHUB_CLIENT", "credhub-admin"},
+	}
 
 	credhubPassword, err := p.credhubGetter.GetPassword()
 	if err == nil {
-		p.logger.Println(fmt.Sprintf("export CREDHUB_SECRET=%s", credhubPassword))
+		envVars = append(envVars, EnvVar{"CREDHUB_SECRET", credhubPassword})
 	} else {
 		p.stderrLogger.Println("No credhub password found.")
 	}
 
 	credhubServer, err := p.credhubGetter.GetServer()
 	if err == nil {
-		p.logger.Println(fmt.Sprintf("export CREDHUB_SERVER=%s", credhubServer))
+		envVars = append(envVars, EnvVar{"CREDHUB_SERVER", credhubServer})
 	} else {
 		p.stderrLogger.Println("No credhub server found.")
 	}
 
 	credhubCerts, err := p.credhubGetter.GetCerts()
 	if err == nil {
-		p.logger.Println(fmt.Sprintf("export CREDHUB_CA_CERT='%s'", credhubCerts))
+		envVars = append(envVars, EnvVar{"CREDHUB_CA_CERT", credhubCerts})
 	} else {
 		p.stderrLogger.Println("No credhub certs found.")
 	}
 
+	if useTunnel {
+		address, err := p.fs.ReadFile(filepath.Join(p.stateDir, storage.TUNNEL_ADDRESS_FILE))
+		if err != nil {
+			return nil, "", errors.New("No tunnel is running. Start one with: bbl tunnel --background")
+		}
+
+		return append(envVars,
+			EnvVar{"BOSH_ALL_PROXY", fmt.Sprintf("socks5://%s", address)},
+			EnvVar{"CREDHUB_PROXY", fmt.Sprintf("socks5://%s", address)},
+		), "", nil
+	}
+
 	privateKeyPath, err := p.allProxyGetter.GeneratePrivateKey()
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
-	p.logger.Println(fmt.Sprintf("export JUMPBOX_PRIVATE_KEY=%s", privateKeyPath))
-	p.logger.Println(fmt.Sprintf("export BOSH_ALL_PROXY=%s", p.allProxyGetter.BoshAllProxy(state.Jumpbox.URL, privateKeyPath)))
-	p.logger.Println(fmt.Sprintf("export CREDHUB_PROXY=%s", p.allProxyGetter.BoshAllProxy(state.Jumpbox.URL, privateKeyPath)))
-
-	return nil
+	allProxy := p.allProxyGetter.BoshAllProxy(state.Jumpbox.URL, privateKeyPath)
+	return append(envVars,
+		EnvVar{"JUMPBOX_PRIVATE_KEY", privateKeyPath},
+		EnvVar{"BOSH_ALL_PROXY", allProxy},
+		EnvVar{"CREDHUB_PROXY", allProxy},
+	), privateKeyPath, nil
 }
@@ -1,7 +1,9 @@
 package commands_test
 
 import (
+	"encoding/json"
 	"errors"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
@@ -51,7 +53,7 @@ var _ = Describe("PrintEnv", func() {
 			},
 		}
 
-		printEnv = commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, fileIO)
+		printEnv = commands.NewPrintEnv(logger, stderrLogger, stateValidator, allProxyGetter, credhubGetter, terraformManager, fileIO, "some-state-dir")
 	})
 	Describe("CheckFastFails", func() {
 		Context("when the state does not exist", func() {
@@ -84,10 +86,121 @@ var _ = Describe("PrintEnv", func() {
 			Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_CA_CERT='some-credhub-certs'"))
 			Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_CLIENT=credhub-admin"))
 			Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_SECRET=some-credhub-password"))
-			Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_PROXY=ipfs://some-domain-with?private_key=the-key-path"))
+			Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_PROXY='ipfs://some-domain-with?private_key=the-key-path'"))
 
 			Expect(logger.PrintlnCall.Messages).To(ContainElement(`export JUMPBOX_PRIVATE_KEY=the-key-path`))
-			Expect(logger.PrintlnCall.Messages).To(ContainElement(`export BOSH_ALL_PROXY=ipfs://some-domain-with?private_key=the-key-path`))
+			Expect(logger.PrintlnCall.Messages).To(ContainElement(`export BOSH_ALL_PROXY='ipfs://some-domain-with?private_key=the-key-path'`))
+		})
+
+		Describe("shells and formats", func() {
+			BeforeEach(func() {
+				state.BOSH.DirectorSSLCA = "-----BEGIN CERTIFICATE-----\nit's a cert\n-----END CERTIFICATE-----"
+				allProxyGetter.BoshAllProxyCall.Returns.URL = "ssh+socks5://jumpbox@1.2.3.4:22?private-key=the-key-path"
+			})
+
+			It("quotes multi-line values for posix shells", func() {
+				err := printEnv.Execute([]string{"--shell", "posix"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_CLIENT=some-director-username"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_CA_CERT='-----BEGIN CERTIFICATE-----\nit'\\''s a cert\n-----END CERTIFICATE-----'"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_ALL_PROXY='ssh+socks5://jumpbox@1.2.3.4:22?private-key=the-key-path'"))
+			})
+
+			It("prints fish commands", func() {
+				err := printEnv.Execute([]string{"--shell", "fish"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("set -gx BOSH_CLIENT 'some-director-username';"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("set -gx BOSH_CA_CERT '-----BEGIN CERTIFICATE-----\nit\\'s a cert\n-----END CERTIFICATE-----';"))
+			})
+
+			It("prints powershell commands", func() {
+				err := printEnv.Execute([]string{"--shell", "powershell"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("$env:BOSH_CLIENT = 'some-director-username'"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("$env:BOSH_CA_CERT = '-----BEGIN CERTIFICATE-----\nit''s a cert\n-----END CERTIFICATE-----'"))
+			})
+
+			It("prints a dotenv file", func() {
+				err := printEnv.Execute([]string{"--format", "dotenv"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement(`BOSH_CLIENT="some-director-username"`))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement(`BOSH_CA_CERT="-----BEGIN CERTIFICATE-----\nit's a cert\n-----END CERTIFICATE-----"`))
+			})
+
+			It("prints a json object", func() {
+				err := printEnv.Execute([]string{"--format", "json"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.CallCount).To(Equal(1))
+				var values map[string]string
+				err = json.Unmarshal([]byte(logger.PrintlnCall.Receives.Message), &values)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(values).To(HaveKeyWithValue("BOSH_CLIENT", "some-director-username"))
+				Expect(values).To(HaveKeyWithValue("BOSH_CA_CERT", state.BOSH.DirectorSSLCA))
+				Expect(values).To(HaveKeyWithValue("JUMPBOX_PRIVATE_KEY", "the-key-path"))
+			})
+
+			Context("when there is no director", func() {
+				It("formats the BOSH_ENVIRONMENT", func() {
+					terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
+						Map: map[string]interface{}{"external_ip": "some-external-ip"},
+					}
+
+					err := printEnv.Execute([]string{"--shell", "powershell"}, storage.State{NoDirector: true})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(logger.PrintlnCall.Messages).To(Equal([]string{"$env:BOSH_ENVIRONMENT = 'https://some-external-ip:25555'"}))
+				})
+			})
+
+			Context("failure cases", func() {
+				It("rejects an unknown shell", func() {
+					err := printEnv.Execute([]string{"--shell", "tcsh"}, state)
+					Expect(err).To(MatchError(`Unknown shell "tcsh". Use fish, powershell or posix.`))
+				})
+
+				It("rejects an unknown format", func() {
+					err := printEnv.Execute([]string{"--format", "yaml"}, state)
+					Expect(err).To(MatchError(`Unknown format "yaml". Use dotenv or json.`))
+				})
+
+				It("rejects a shell and a format together", func() {
+					err := printEnv.Execute([]string{"--shell", "fish", "--format", "json"}, state)
+					Expect(err).To(MatchError("--shell and --format cannot be used together."))
+				})
+			})
+		})
+
+		Context("when --use-tunnel is passed", func() {
+			BeforeEach(func() {
+				fileIO.ReadFileCall.Returns.Contents = []byte("127.0.0.1:1080")
+			})
+
+			It("points the proxy variables at the tunnel", func() {
+				err := printEnv.Execute([]string{"--use-tunnel"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal(filepath.Join("some-state-dir", "tunnel.address")))
+				Expect(allProxyGetter.GeneratePrivateKeyCall.CallCount).To(Equal(0))
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_CLIENT=some-director-username"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export BOSH_ALL_PROXY=socks5://127.0.0.1:1080"))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("export CREDHUB_PROXY=socks5://127.0.0.1:1080"))
+				Expect(logger.PrintlnCall.Messages).NotTo(ContainElement(ContainSubstring("JUMPBOX_PRIVATE_KEY")))
+			})
+
+			Context("when no tunnel is running", func() {
+				It("returns an error", func() {
+					fileIO.ReadFileCall.Returns.Error = errors.New("no such file")
+
+					err := printEnv.Execute([]string{"--use-tunnel"}, state)
+					Expect(err).To(MatchError("No tunnel is running. Start one with: bbl tunnel --background"))
+				})
+			})
 		})
 
 		Context("when there is no director", func() {
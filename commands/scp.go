@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type SCP struct {
+	client    scpClient
+	keyGetter sshKeyGetter
+}
+
+type scpClient interface {
+	Upload(hops []ssh.Host, localPath, remotePath string, recursive bool) error
+	Download(hops []ssh.Host, remotePath, localPath string, recursive bool) error
+}
+
+func NewSCP(scpClient scpClient, sshKeyGetter sshKeyGetter) SCP {
+	return SCP{
+		client:    scpClient,
+		keyGetter: sshKeyGetter,
+	}
+}
+
+func (s SCP) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	if len(state.Jumpbox.URL) == 0 {
+		return errors.New("Invalid bbl state for bbl scp.")
+	}
+
+	return nil
+}
+
+func (s SCP) Execute(args []string, state storage.State) error {
+	var recursive bool
+	scpFlags := flags.New("scp")
+	scpFlags.Bool(&recursive, "r")
+	err := scpFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	paths := scpFlags.Args()
+	if len(paths) != 2 {
+		return errors.New("This command requires a source and a destination.")
+	}
+
+	sourceHost, source := splitRemotePath(paths[0])
+	destinationHost, destination := splitRemotePath(paths[1])
+	if (sourceHost == "") == (destinationHost == "") {
+		return errors.New("Exactly one of the source and destination must start with jumpbox: or director:.")
+	}
+
+	hops, err := sshHops(s.keyGetter, state, sourceHost == "director" || destinationHost == "director")
+	if err != nil {
+		return err
+	}
+
+	if sourceHost != "" {
+		return s.client.Download(hops, source, destination, recursive)
+	}
+	return s.client.Upload(hops, source, destination, recursive)
+}
+
+// splitRemotePath splits "jumpbox:path" and "director:path" into the host and
+// the path. Other paths are local.
+func splitRemotePath(path string) (string, string) {
+	for _, host := range []string{"jumpbox", "director"} {
+		if strings.HasPrefix(path, host+":") {
+			return host, strings.TrimPrefix(path, host+":")
+		}
+	}
+	return "", path
+}
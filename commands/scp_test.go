@@ -0,0 +1,120 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SCP", func() {
+	var (
+		scpCommand   commands.SCP
+		sshClient    *fakes.SSHClient
+		sshKeyGetter *fakes.FancySSHKeyGetter
+	)
+
+	BeforeEach(func() {
+		sshClient = &fakes.SSHClient{}
+		sshKeyGetter = &fakes.FancySSHKeyGetter{}
+
+		scpCommand = commands.NewSCP(sshClient, sshKeyGetter)
+	})
+
+	Describe("CheckFastFails", func() {
+		Context("where there is no jumpbox url", func() {
+			It("returns an error", func() {
+				err := scpCommand.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("Invalid bbl state for bbl scp."))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		var (
+			state       storage.State
+			jumpboxHop  ssh.Host
+			directorHop ssh.Host
+		)
+
+		BeforeEach(func() {
+			sshKeyGetter.JumpboxGetCall.Returns.PrivateKey = "jumpbox-private-key"
+			sshKeyGetter.DirectorGetCall.Returns.PrivateKey = "director-private-key"
+
+			state = storage.State{
+				Jumpbox: storage.Jumpbox{
+					URL: "jumpboxURL:22",
+				},
+				BOSH: storage.BOSH{
+					DirectorAddress: "https://directorURL:25",
+				},
+			}
+
+			jumpboxHop = ssh.Host{Address: "jumpboxURL:22", User: "jumpbox", PrivateKey: "jumpbox-private-key"}
+			directorHop = ssh.Host{Address: "directorURL:22", User: "jumpbox", PrivateKey: "director-private-key"}
+		})
+
+		It("uploads a local file to the jumpbox", func() {
+			err := scpCommand.Execute([]string{"some-file", "jumpbox:/tmp/some-file"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sshKeyGetter.DirectorGetCall.CallCount).To(Equal(0))
+			Expect(sshClient.UploadCall.CallCount).To(Equal(1))
+			Expect(sshClient.UploadCall.Receives.Hops).To(Equal([]ssh.Host{jumpboxHop}))
+			Expect(sshClient.UploadCall.Receives.LocalPath).To(Equal("some-file"))
+			Expect(sshClient.UploadCall.Receives.RemotePath).To(Equal("/tmp/some-file"))
+			Expect(sshClient.UploadCall.Receives.Recursive).To(BeFalse())
+		})
+
+		It("downloads a directory from the director through the jumpbox", func() {
+			err := scpCommand.Execute([]string{"-r", "director:/var/vcap/sys/log", "logs"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sshClient.DownloadCall.CallCount).To(Equal(1))
+			Expect(sshClient.DownloadCall.Receives.Hops).To(Equal([]ssh.Host{jumpboxHop, directorHop}))
+			Expect(sshClient.DownloadCall.Receives.RemotePath).To(Equal("/var/vcap/sys/log"))
+			Expect(sshClient.DownloadCall.Receives.LocalPath).To(Equal("logs"))
+			Expect(sshClient.DownloadCall.Receives.Recursive).To(BeTrue())
+		})
+
+		Context("when the source or destination is missing", func() {
+			It("returns an error", func() {
+				err := scpCommand.Execute([]string{"some-file"}, state)
+				Expect(err).To(MatchError("This command requires a source and a destination."))
+			})
+		})
+
+		DescribeTable("when the paths are both local or both remote",
+			func(args []string) {
+				err := scpCommand.Execute(args, state)
+				Expect(err).To(MatchError("Exactly one of the source and destination must start with jumpbox: or director:."))
+			},
+			Entry("two local paths", []string{"some-file", "other-file"}),
+			Entry("two remote paths", []string{"jumpbox:some-file", "director:other-file"}),
+		)
+
+		Context("when the copy fails", func() {
+			It("returns the error", func() {
+				sshClient.UploadCall.Returns.Error = errors.New("kiwi")
+
+				err := scpCommand.Execute([]string{"some-file", "director:some-file"}, state)
+				Expect(err).To(MatchError("kiwi"))
+			})
+		})
+
+		Context("when ssh key getter fails to get the jumpbox key", func() {
+			It("returns the error", func() {
+				sshKeyGetter.JumpboxGetCall.Returns.Error = errors.New("fig")
+
+				err := scpCommand.Execute([]string{"some-file", "jumpbox:some-file"}, state)
+				Expect(err).To(MatchError("Get jumpbox private key: fig"))
+			})
+		})
+	})
+})
@@ -3,46 +3,28 @@ package commands
 import (
 	"errors"
 	"fmt"
-	"path/filepath"
+	"net"
 	"strings"
 
-	"github.com/cloudfoundry/bosh-bootloader/fileio"
 	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
 type SSH struct {
-	cli           sshCLI
-	keyGetter     sshKeyGetter
-	pathFinder    pathFinder
-	tempDirWriter tempDirWriter
-	randomPort    randomPort
+	client    sshClient
+	keyGetter sshKeyGetter
 }
 
-type sshCLI interface {
-	Run([]string) error
+type sshClient interface {
+	Shell([]ssh.Host) error
+	Run([]ssh.Host, string) error
 }
 
-type pathFinder interface {
-	CommandExists(string) bool
-}
-
-type randomPort interface {
-	GetPort() (string, error)
-}
-
-type tempDirWriter interface {
-	fileio.FileWriter
-	fileio.TempDirer
-}
-
-func NewSSH(sshCLI sshCLI, sshKeyGetter sshKeyGetter, pathFinder pathFinder, tempDirWriter tempDirWriter, randomPort randomPort) SSH {
+func NewSSH(sshClient sshClient, sshKeyGetter sshKeyGetter) SSH {
 	return SSH{
-		cli:           sshCLI,
-		keyGetter:     sshKeyGetter,
-		pathFinder:    pathFinder,
-		tempDirWriter: tempDirWriter,
-		randomPort:    randomPort,
+		client:    sshClient,
+		keyGetter: sshKeyGetter,
 	}
 }
 
@@ -71,74 +53,53 @@ func (s SSH) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("This command requires the --jumpbox or --director flag.")
 	}
 
-	tempDir, err := s.tempDirWriter.TempDir("", "")
+	hops, err := sshHops(s.keyGetter, state, director)
 	if err != nil {
-		return fmt.Errorf("Create temp directory: %s", err)
+		return err
 	}
 
-	jumpboxKey, err := s.keyGetter.Get("jumpbox")
-	if err != nil {
-		return fmt.Errorf("Get jumpbox private key: %s", err)
+	if command := sshFlags.Args(); len(command) > 0 {
+		return s.client.Run(hops, strings.Join(command, " "))
 	}
 
-	jumpboxKeyPath := filepath.Join(tempDir, "jumpbox-private-key")
+	return s.client.Shell(hops)
+}
 
-	err = s.tempDirWriter.WriteFile(jumpboxKeyPath, []byte(jumpboxKey), 0600)
+// sshHops returns the jumpbox, followed by the director when requested.
+func sshHops(keyGetter sshKeyGetter, state storage.State, director bool) ([]ssh.Host, error) {
+	jumpboxKey, err := keyGetter.Get("jumpbox")
 	if err != nil {
-		return fmt.Errorf("Write private key file: %s", err)
+		return nil, fmt.Errorf("Get jumpbox private key: %s", err)
 	}
 
-	jumpboxURL := strings.Split(state.Jumpbox.URL, ":")[0]
-
-	if jumpbox {
-		return s.cli.Run([]string{
-			"-o", "StrictHostKeyChecking=no",
-			"-o", "ServerAliveInterval=300",
-			fmt.Sprintf("jumpbox@%s", jumpboxURL),
-			"-i", jumpboxKeyPath,
+	hops := []ssh.Host{{
+		Address:    jumpboxAddress(state),
+		User:       "jumpbox",
+		PrivateKey: jumpboxKey,
+	}}
+
+	if director {
+		directorKey, err := keyGetter.Get("director")
+		if err != nil {
+			return nil, fmt.Errorf("Get director private key: %s", err)
+		}
+
+		hops = append(hops, ssh.Host{
+			Address:    directorAddress(state),
+			User:       "jumpbox",
+			PrivateKey: directorKey,
 		})
 	}
 
-	directorPrivateKey, err := s.keyGetter.Get("director")
-	if err != nil {
-		return fmt.Errorf("Get director private key: %s", err)
-	}
-
-	directorKeyPath := filepath.Join(tempDir, "director-private-key")
-
-	err = s.tempDirWriter.WriteFile(directorKeyPath, []byte(directorPrivateKey), 0600)
-	if err != nil {
-		return fmt.Errorf("Write private key file: %s", err)
-	}
-
-	port, err := s.randomPort.GetPort()
-	if err != nil {
-		return fmt.Errorf("Open proxy port: %s", err)
-	}
-
-	err = s.cli.Run([]string{
-		"-4",
-		"-D", port,
-		"-fNC",
-		fmt.Sprintf("jumpbox@%s", jumpboxURL),
-		"-i", jumpboxKeyPath,
-	})
-	if err != nil {
-		return fmt.Errorf("Open tunnel to jumpbox: %s", err)
-	}
-
-	proxyCommandPrefix := "nc -x"
-	if s.pathFinder.CommandExists("connect-proxy") {
-		proxyCommandPrefix = "connect-proxy -S"
-	}
+	return hops, nil
+}
 
-	ip := strings.Split(strings.TrimPrefix(state.BOSH.DirectorAddress, "https://"), ":")[0]
+func jumpboxAddress(state storage.State) string {
+	host := strings.Split(state.Jumpbox.URL, ":")[0]
+	return net.JoinHostPort(host, "22")
+}
 
-	return s.cli.Run([]string{
-		"-o", "StrictHostKeyChecking=no",
-		"-o", "ServerAliveInterval=300",
-		"-o", fmt.Sprintf("ProxyCommand=%s localhost:%s %%h %%p", proxyCommandPrefix, port),
-		"-i", directorKeyPath,
-		fmt.Sprintf("jumpbox@%s", ip),
-	})
+func directorAddress(state storage.State) string {
+	host := strings.Split(strings.TrimPrefix(state.BOSH.DirectorAddress, "https://"), ":")[0]
+	return net.JoinHostPort(host, "22")
 }
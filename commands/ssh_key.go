@@ -11,6 +11,7 @@ type SSHKey struct {
 	logger         logger
 	stateValidator stateValidator
 	sshKeyGetter   sshKeyGetter
+	output         Output
 	Director       bool
 }
 
@@ -20,19 +21,21 @@ type sshKeyGetter interface {
 
 var unmarshal = yaml.Unmarshal
 
-func NewSSHKey(logger logger, stateValidator stateValidator, sshKeyGetter sshKeyGetter) SSHKey {
+func NewSSHKey(logger logger, stateValidator stateValidator, sshKeyGetter sshKeyGetter, output Output) SSHKey {
 	return SSHKey{
 		logger:         logger,
 		stateValidator: stateValidator,
 		sshKeyGetter:   sshKeyGetter,
+		output:         output,
 	}
 }
 
-func NewDirectorSSHKey(logger logger, stateValidator stateValidator, sshKeyGetter sshKeyGetter) SSHKey {
+func NewDirectorSSHKey(logger logger, stateValidator stateValidator, sshKeyGetter sshKeyGetter, output Output) SSHKey {
 	return SSHKey{
 		logger:         logger,
 		stateValidator: stateValidator,
 		sshKeyGetter:   sshKeyGetter,
+		output:         output,
 		Director:       true,
 	}
 }
@@ -56,6 +59,12 @@ func (s SSHKey) Execute(subcommandFlags []string, state storage.State) error {
 		return errors.New("Could not retrieve the ssh key, please make sure you are targeting the proper state dir.")
 	}
 
+	if !s.output.Text() {
+		return s.output.Print(struct {
+			PrivateKey string `json:"private_key"`
+		}{privateKey})
+	}
+
 	s.logger.Println(privateKey)
 
 	return nil
@@ -29,7 +29,7 @@ var _ = Describe("SSHKey", func() {
 		sshKeyGetter = &fakes.SSHKeyGetter{}
 		sshKeyGetter.GetCall.Returns.PrivateKey = "some-private-ssh-key"
 
-		sshKeyCommand = commands.NewSSHKey(logger, stateValidator, sshKeyGetter)
+		sshKeyCommand = commands.NewSSHKey(logger, stateValidator, sshKeyGetter, commands.NewOutput(logger, ""))
 	})
 
 	Describe("CheckFastFails", func() {
@@ -55,9 +55,18 @@ var _ = Describe("SSHKey", func() {
 			Expect(logger.PrintlnCall.Messages).To(Equal([]string{"some-private-ssh-key"}))
 		})
 
+		It("prints the private ssh key as json", func() {
+			sshKeyCommand = commands.NewSSHKey(logger, stateValidator, sshKeyGetter, commands.NewOutput(logger, "json"))
+
+			err := sshKeyCommand.Execute([]string{}, incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(`{"private_key": "some-private-ssh-key"}`))
+		})
+
 		Context("director-ssh-key", func() {
 			BeforeEach(func() {
-				sshKeyCommand = commands.NewDirectorSSHKey(logger, stateValidator, sshKeyGetter)
+				sshKeyCommand = commands.NewDirectorSSHKey(logger, stateValidator, sshKeyGetter, commands.NewOutput(logger, ""))
 			})
 
 			It("uses BOSH variables to get the SSH key", func() {
@@ -23,21 +23,33 @@ const (
 	DirectorCACertPropertyName   = "director ca cert"
 )
 
+// stateQueryKeys name the value in json and yaml output.
+var stateQueryKeys = map[string]string{
+	EnvIDPropertyName:            "env_id",
+	JumpboxAddressPropertyName:   "jumpbox_address",
+	DirectorUsernamePropertyName: "director_username",
+	DirectorPasswordPropertyName: "director_password",
+	DirectorAddressPropertyName:  "director_address",
+	DirectorCACertPropertyName:   "director_ca_cert",
+}
+
 type StateQuery struct {
 	logger           logger
 	stateValidator   stateValidator
 	terraformManager terraformManager
 	propertyName     string
+	output           Output
 }
 
 type getPropertyFunc func(storage.State) string
 
-func NewStateQuery(logger logger, stateValidator stateValidator, terraformManager terraformManager, propertyName string) StateQuery {
+func NewStateQuery(logger logger, stateValidator stateValidator, terraformManager terraformManager, propertyName string, output Output) StateQuery {
 	return StateQuery{
 		logger:           logger,
 		stateValidator:   stateValidator,
 		terraformManager: terraformManager,
 		propertyName:     propertyName,
+		output:           output,
 	}
 }
 
@@ -84,6 +96,10 @@ func (s StateQuery) Execute(subcommandFlags []string, state storage.State) error
 		return fmt.Errorf("Could not retrieve %s, please make sure you are targeting the proper state dir.", s.propertyName)
 	}
 
+	if !s.output.Text() {
+		return s.output.Print(map[string]string{stateQueryKeys[s.propertyName]: propertyValue})
+	}
+
 	s.logger.Println(propertyValue)
 	return nil
 }
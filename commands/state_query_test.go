@@ -35,7 +35,7 @@ var _ = Describe("StateQuery", func() {
 			})
 
 			It("returns an error", func() {
-				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "")
+				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "", commands.NewOutput(fakeLogger, ""))
 
 				err := command.CheckFastFails([]string{}, storage.State{})
 				Expect(err).To(MatchError("state validator failed"))
@@ -54,7 +54,7 @@ var _ = Describe("StateQuery", func() {
 
 			DescribeTable("prints out the director information",
 				func(propertyName string) {
-					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, propertyName)
+					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, propertyName, commands.NewOutput(fakeLogger, ""))
 
 					err := command.CheckFastFails([]string{}, state)
 					Expect(err).To(MatchError("Error BBL does not manage this director."))
@@ -75,7 +75,7 @@ var _ = Describe("StateQuery", func() {
 			})
 
 			It("prints out the jumpbox information", func() {
-				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "jumpbox address")
+				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "jumpbox address", commands.NewOutput(fakeLogger, ""))
 
 				err := command.Execute([]string{}, storage.State{})
 				Expect(err).NotTo(HaveOccurred())
@@ -101,7 +101,7 @@ var _ = Describe("StateQuery", func() {
 
 			DescribeTable("prints out the director information",
 				func(propertyName, expectedOutput string) {
-					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, propertyName)
+					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, propertyName, commands.NewOutput(fakeLogger, ""))
 
 					err := command.Execute([]string{}, state)
 					Expect(err).NotTo(HaveOccurred())
@@ -113,6 +113,30 @@ var _ = Describe("StateQuery", func() {
 				Entry("director-password", "director password", "some-director-password"),
 				Entry("director-ssl-ca", "director ca cert", "some-director-ssl-ca"),
 			)
+
+			DescribeTable("prints out the director information as json",
+				func(propertyName, expectedOutput string) {
+					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, propertyName, commands.NewOutput(fakeLogger, "json"))
+
+					err := command.Execute([]string{}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeLogger.PrintlnCall.Receives.Message).To(MatchJSON(expectedOutput))
+				},
+				Entry("director-address", "director address", `{"director_address": "some-director-address"}`),
+				Entry("director-username", "director username", `{"director_username": "some-director-username"}`),
+				Entry("director-password", "director password", `{"director_password": "some-director-password"}`),
+				Entry("director-ssl-ca", "director ca cert", `{"director_ca_cert": "some-director-ssl-ca"}`),
+			)
+
+			It("prints out the director information as yaml", func() {
+				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "director username", commands.NewOutput(fakeLogger, "yaml"))
+
+				err := command.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLogger.PrintfCall.Messages).To(Equal([]string{"director_username: some-director-username\n"}))
+			})
 		})
 
 		Context("bbl does not manage the bosh director", func() {
@@ -126,7 +150,7 @@ var _ = Describe("StateQuery", func() {
 			})
 
 			It("prints the env id", func() {
-				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "environment id")
+				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "environment id", commands.NewOutput(fakeLogger, ""))
 
 				err := command.Execute([]string{}, state)
 				Expect(err).NotTo(HaveOccurred())
@@ -134,12 +158,21 @@ var _ = Describe("StateQuery", func() {
 				Expect(fakeLogger.PrintlnCall.Receives.Message).To(Equal("some-env-id"))
 			})
 
+			It("prints the env id as json", func() {
+				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "environment id", commands.NewOutput(fakeLogger, "json"))
+
+				err := command.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLogger.PrintlnCall.Receives.Message).To(MatchJSON(`{"env_id": "some-env-id"}`))
+			})
+
 			It("prints the eip as the director-address", func() {
 				terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{
 					Map: map[string]interface{}{"external_ip": "some-external-ip"},
 				}
 
-				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "director address")
+				command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "director address", commands.NewOutput(fakeLogger, ""))
 				err := command.Execute([]string{}, state)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeLogger.PrintlnCall.Receives.Message).To(Equal("https://some-external-ip:25555"))
@@ -153,7 +186,7 @@ var _ = Describe("StateQuery", func() {
 				})
 
 				It("director-address returns an error for no-director environment", func() {
-					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "director address")
+					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "director address", commands.NewOutput(fakeLogger, ""))
 
 					err := command.Execute([]string{}, storage.State{
 						IAAS:       "gcp",
@@ -163,7 +196,7 @@ var _ = Describe("StateQuery", func() {
 				})
 
 				It("jumpbox-address returns an error", func() {
-					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "jumpbox address")
+					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, "jumpbox address", commands.NewOutput(fakeLogger, ""))
 
 					err := command.Execute([]string{}, storage.State{})
 					Expect(err).To(MatchError("failed to get terraform output"))
@@ -173,7 +206,7 @@ var _ = Describe("StateQuery", func() {
 			Context("when the state value is empty", func() {
 				It("returns an error", func() {
 					propertyName := fmt.Sprintf("%s-%d", "some-name", rand.Int())
-					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, propertyName)
+					command := commands.NewStateQuery(fakeLogger, fakeStateValidator, terraformManager, propertyName, commands.NewOutput(fakeLogger, ""))
 					err := command.Execute([]string{}, storage.State{
 						BOSH: storage.BOSH{},
 					})
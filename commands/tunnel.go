@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+var (
+	tunnelStartTimeout  = 60 * time.Second
+	tunnelStartInterval = 200 * time.Millisecond
+)
+
+const tunnelLogTailLines = 10
+
+type Tunnel struct {
+	client     tunnelClient
+	keyGetter  sshKeyGetter
+	background background
+	logger     logger
+	fs         tunnelFS
+	stateDir   string
+}
+
+type tunnelClient interface {
+	Tunnel(hops []ssh.Host, socksAddress string, forwards []ssh.Forward, ready func(string) error, stop <-chan struct{}) error
+}
+
+type background interface {
+	Start(args []string, logPath string) (helpers.BackgroundProcess, error)
+	Running(pid int) bool
+}
+
+type tunnelFS interface {
+	fileio.FileReader
+	fileio.FileWriter
+	fileio.Remover
+}
+
+func NewTunnel(tunnelClient tunnelClient, sshKeyGetter sshKeyGetter, background background, logger logger, fs tunnelFS, stateDir string) Tunnel {
+	return Tunnel{
+		client:     tunnelClient,
+		keyGetter:  sshKeyGetter,
+		background: background,
+		logger:     logger,
+		fs:         fs,
+		stateDir:   stateDir,
+	}
+}
+
+func (t Tunnel) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	if len(state.Jumpbox.URL) == 0 {
+		return errors.New("Invalid bbl state for bbl tunnel.")
+	}
+
+	return nil
+}
+
+func (t Tunnel) Execute(args []string, state storage.State) error {
+	var (
+		port          string
+		localForwards []string
+		runBackground bool
+	)
+	tunnelFlags := flags.New("tunnel")
+	tunnelFlags.String(&port, "port", "0")
+	tunnelFlags.StringSlice(&localForwards, "L")
+	tunnelFlags.Bool(&runBackground, "background")
+	err := tunnelFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("Invalid port %q.", port)
+	}
+
+	forwards, err := parseForwards(localForwards, state)
+	if err != nil {
+		return err
+	}
+
+	addressPath := filepath.Join(t.stateDir, storage.TUNNEL_ADDRESS_FILE)
+	pidPath := filepath.Join(t.stateDir, storage.TUNNEL_PID_FILE)
+
+	if pid, ok := t.runningPid(pidPath); ok {
+		return fmt.Errorf("A tunnel is already running with pid %d. Stop it with: kill $(cat %s)", pid, pidPath)
+	}
+
+	if runBackground {
+		return t.startBackground(args, addressPath, pidPath)
+	}
+
+	hops, err := sshHops(t.keyGetter, state, false)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	// The pid and address files are only removed by the tunnel that wrote
+	// them, so a tunnel that fails to start leaves a running one alone.
+	recorded := false
+	ready := func(address string) error {
+		recorded = true
+		err := t.fs.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())), storage.StateMode)
+		if err != nil {
+			return fmt.Errorf("Write pid file: %s", err)
+		}
+
+		err = t.fs.WriteFile(addressPath, []byte(address), storage.StateMode)
+		if err != nil {
+			return fmt.Errorf("Write tunnel address: %s", err)
+		}
+
+		t.printTunnel(address, forwards)
+		return nil
+	}
+
+	err = t.client.Tunnel(hops, net.JoinHostPort("127.0.0.1", port), forwards, ready, stop)
+	if recorded {
+		t.fs.Remove(addressPath)
+		t.fs.Remove(pidPath)
+	}
+
+	return err
+}
+
+// startBackground runs "bbl tunnel" again as a detached process and waits
+// for it to record its pid and the address of its proxy.
+func (t Tunnel) startBackground(args []string, addressPath, pidPath string) error {
+	childArgs := []string{"--state-dir", t.stateDir, "tunnel"}
+	for _, arg := range args {
+		if arg != "--background" && arg != "-background" {
+			childArgs = append(childArgs, arg)
+		}
+	}
+
+	logPath := filepath.Join(t.stateDir, storage.TUNNEL_LOG_FILE)
+	process, err := t.background.Start(childArgs, logPath)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.After(tunnelStartTimeout)
+	for {
+		if pid, err := t.fs.ReadFile(pidPath); err == nil && string(pid) == strconv.Itoa(process.Pid) {
+			address, err := t.fs.ReadFile(addressPath)
+			if err == nil && len(address) > 0 {
+				t.printTunnel(string(address), nil)
+				t.logger.Printf("The tunnel is running with pid %d. Stop it with: kill $(cat %s)\n", process.Pid, pidPath)
+				return nil
+			}
+		}
+
+		select {
+		case <-process.Exited:
+			return fmt.Errorf("The tunnel exited before it started. The end of %s:\n%s", logPath, t.logTail(logPath))
+		case <-deadline:
+			return fmt.Errorf("The tunnel did not start. See %s.", logPath)
+		case <-time.After(tunnelStartInterval):
+		}
+	}
+}
+
+// runningPid returns the pid recorded by another tunnel if that process is
+// still running.
+func (t Tunnel) runningPid(pidPath string) (int, bool) {
+	contents, err := t.fs.ReadFile(pidPath)
+	if err != nil {
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil || pid == os.Getpid() {
+		return 0, false
+	}
+
+	return pid, t.background.Running(pid)
+}
+
+// logTail returns the last lines of the tunnel log.
+func (t Tunnel) logTail(logPath string) string {
+	contents, err := t.fs.ReadFile(logPath)
+	if err != nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(string(contents), "\n"), "\n")
+	if len(lines) > tunnelLogTailLines {
+		lines = lines[len(lines)-tunnelLogTailLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (t Tunnel) printTunnel(address string, forwards []ssh.Forward) {
+	t.logger.Println(fmt.Sprintf("socks5://%s", address))
+	for _, forward := range forwards {
+		t.logger.Printf("%s -> %s\n", forward.Local, forward.Remote)
+	}
+}
+
+// parseForwards reads -L forwards of the form <local-port>:<host>[:<port>].
+// The host "director" is the director's internal address, and the remote
+// port defaults to the local port.
+func parseForwards(localForwards []string, state storage.State) ([]ssh.Forward, error) {
+	var forwards []ssh.Forward
+	for _, localForward := range localForwards {
+		parts := strings.Split(localForward, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid forward %q. Use <local-port>:<host>[:<port>].", localForward)
+		}
+
+		localPort, host, remotePort := parts[0], parts[1], parts[0]
+		if len(parts) == 3 {
+			remotePort = parts[2]
+		}
+
+		for _, p := range []string{localPort, remotePort} {
+			if _, err := strconv.ParseUint(p, 10, 16); err != nil {
+				return nil, fmt.Errorf("Invalid forward %q. Use <local-port>:<host>[:<port>].", localForward)
+			}
+		}
+
+		if host == "director" {
+			host, _, _ = net.SplitHostPort(directorAddress(state))
+		}
+
+		forwards = append(forwards, ssh.Forward{
+			Local:  net.JoinHostPort("127.0.0.1", localPort),
+			Remote: net.JoinHostPort(host, remotePort),
+		})
+	}
+
+	return forwards, nil
+}
@@ -0,0 +1,239 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tunnel", func() {
+	var (
+		tunnel       commands.Tunnel
+		sshClient    *fakes.SSHClient
+		sshKeyGetter *fakes.FancySSHKeyGetter
+		background   *fakes.Background
+		logger       *fakes.Logger
+		fileIO       *fakes.FileIO
+		state        storage.State
+	)
+
+	BeforeEach(func() {
+		sshClient = &fakes.SSHClient{}
+		sshClient.TunnelCall.Returns.Address = "127.0.0.1:1080"
+		sshKeyGetter = &fakes.FancySSHKeyGetter{}
+		sshKeyGetter.JumpboxGetCall.Returns.PrivateKey = "jumpbox-private-key"
+		background = &fakes.Background{}
+		logger = &fakes.Logger{}
+		fileIO = &fakes.FileIO{}
+
+		state = storage.State{
+			Jumpbox: storage.Jumpbox{
+				URL: "jumpboxURL:22",
+			},
+			BOSH: storage.BOSH{
+				DirectorAddress: "https://10.0.0.6:25555",
+			},
+		}
+
+		tunnel = commands.NewTunnel(sshClient, sshKeyGetter, background, logger, fileIO, "some-state-dir")
+	})
+
+	Describe("CheckFastFails", func() {
+		Context("where there is no jumpbox url", func() {
+			It("returns an error", func() {
+				err := tunnel.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("Invalid bbl state for bbl tunnel."))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		It("runs a proxy through the jumpbox on a random port", func() {
+			err := tunnel.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sshClient.TunnelCall.CallCount).To(Equal(1))
+			Expect(sshClient.TunnelCall.Receives.Hops).To(Equal([]ssh.Host{
+				{Address: "jumpboxURL:22", User: "jumpbox", PrivateKey: "jumpbox-private-key"},
+			}))
+			Expect(sshClient.TunnelCall.Receives.SOCKSAddress).To(Equal("127.0.0.1:0"))
+			Expect(sshClient.TunnelCall.Receives.Forwards).To(BeEmpty())
+
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{"socks5://127.0.0.1:1080"}))
+		})
+
+		It("records its address and pid while running, and removes them when it stops", func() {
+			err := tunnel.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.WriteFileCall.Receives).To(ConsistOf(
+				fakes.WriteFileReceive{
+					Filename: filepath.Join("some-state-dir", "tunnel.pid"),
+					Contents: []byte(strconv.Itoa(os.Getpid())),
+					Mode:     storage.StateMode,
+				},
+				fakes.WriteFileReceive{
+					Filename: filepath.Join("some-state-dir", "tunnel.address"),
+					Contents: []byte("127.0.0.1:1080"),
+					Mode:     storage.StateMode,
+				},
+			))
+			Expect(fileIO.RemoveCall.Receives).To(ConsistOf(
+				fakes.RemoveReceive{Name: filepath.Join("some-state-dir", "tunnel.pid")},
+				fakes.RemoveReceive{Name: filepath.Join("some-state-dir", "tunnel.address")},
+			))
+		})
+
+		Context("when another tunnel is running", func() {
+			BeforeEach(func() {
+				fileIO.ReadFileCall.Returns.Contents = []byte("4242")
+				background.RunningCall.Returns.Running = true
+			})
+
+			It("returns an error and leaves its files alone", func() {
+				err := tunnel.Execute([]string{"--port", "1080"}, state)
+				Expect(err).To(MatchError(fmt.Sprintf("A tunnel is already running with pid 4242. Stop it with: kill $(cat %s)", filepath.Join("some-state-dir", "tunnel.pid"))))
+
+				Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal(filepath.Join("some-state-dir", "tunnel.pid")))
+				Expect(background.RunningCall.Receives.Pid).To(Equal(4242))
+				Expect(sshClient.TunnelCall.CallCount).To(Equal(0))
+				Expect(background.StartCall.CallCount).To(Equal(0))
+				Expect(fileIO.RemoveCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when the pid file belongs to a tunnel that has stopped", func() {
+			It("starts a new tunnel", func() {
+				fileIO.ReadFileCall.Returns.Contents = []byte("4242")
+
+				err := tunnel.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(background.RunningCall.Receives.Pid).To(Equal(4242))
+				Expect(sshClient.TunnelCall.CallCount).To(Equal(1))
+			})
+		})
+
+		It("uses the given port and forwards", func() {
+			err := tunnel.Execute([]string{"--port", "1080", "-L", "25555:director", "-L", "8443:10.0.16.5:443"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sshClient.TunnelCall.Receives.SOCKSAddress).To(Equal("127.0.0.1:1080"))
+			Expect(sshClient.TunnelCall.Receives.Forwards).To(Equal([]ssh.Forward{
+				{Local: "127.0.0.1:25555", Remote: "10.0.0.6:25555"},
+				{Local: "127.0.0.1:8443", Remote: "10.0.16.5:443"},
+			}))
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{
+				"127.0.0.1:25555 -> 10.0.0.6:25555\n",
+				"127.0.0.1:8443 -> 10.0.16.5:443\n",
+			}))
+		})
+
+		Context("--background", func() {
+			var exited chan struct{}
+
+			BeforeEach(func() {
+				exited = make(chan struct{})
+				background.StartCall.Returns.Process = helpers.BackgroundProcess{Pid: 4242, Exited: exited}
+				fileIO.ReadFileCall.Fake = func(filename string) ([]byte, error) {
+					switch filepath.Base(filename) {
+					case "tunnel.pid":
+						return []byte("4242"), nil
+					case "tunnel.address":
+						return []byte("127.0.0.1:1080"), nil
+					}
+					return nil, errors.New("not found")
+				}
+			})
+
+			It("starts the tunnel in a detached bbl and prints its address", func() {
+				err := tunnel.Execute([]string{"--background", "-L", "25555:director"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshClient.TunnelCall.CallCount).To(Equal(0))
+				Expect(background.StartCall.Receives.Args).To(Equal([]string{"--state-dir", "some-state-dir", "tunnel", "-L", "25555:director"}))
+				Expect(background.StartCall.Receives.LogPath).To(Equal(filepath.Join("some-state-dir", "tunnel.log")))
+				Expect(fileIO.RemoveCall.CallCount).To(Equal(0))
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"socks5://127.0.0.1:1080"}))
+				Expect(logger.PrintfCall.Receives.Arguments).To(Equal([]interface{}{4242, filepath.Join("some-state-dir", "tunnel.pid")}))
+			})
+
+			Context("when the process cannot be started", func() {
+				It("returns the error", func() {
+					background.StartCall.Returns.Error = errors.New("pineapple")
+
+					err := tunnel.Execute([]string{"--background"}, state)
+					Expect(err).To(MatchError("pineapple"))
+				})
+			})
+
+			Context("when the process exits before it records its address", func() {
+				It("returns an error with the end of the tunnel log", func() {
+					fileIO.ReadFileCall.Fake = func(filename string) ([]byte, error) {
+						if filepath.Base(filename) == "tunnel.log" {
+							return []byte("connecting\nlisten tcp 127.0.0.1:1080: bind: address already in use\n"), nil
+						}
+						return nil, errors.New("not found")
+					}
+					close(exited)
+
+					err := tunnel.Execute([]string{"--background", "--port", "1080"}, state)
+					Expect(err).To(MatchError(fmt.Sprintf("The tunnel exited before it started. The end of %s:\nconnecting\nlisten tcp 127.0.0.1:1080: bind: address already in use", filepath.Join("some-state-dir", "tunnel.log"))))
+				})
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error for an invalid port", func() {
+				err := tunnel.Execute([]string{"--port", "banana"}, state)
+				Expect(err).To(MatchError(`Invalid port "banana".`))
+			})
+
+			It("returns an error for an invalid forward", func() {
+				err := tunnel.Execute([]string{"-L", "director"}, state)
+				Expect(err).To(MatchError(`Invalid forward "director". Use <local-port>:<host>[:<port>].`))
+			})
+
+			It("returns an error when the jumpbox key cannot be read", func() {
+				sshKeyGetter.JumpboxGetCall.Returns.Error = errors.New("fig")
+
+				err := tunnel.Execute([]string{}, state)
+				Expect(err).To(MatchError("Get jumpbox private key: fig"))
+			})
+
+			It("returns an error when the tunnel fails", func() {
+				sshClient.TunnelCall.Returns.Error = errors.New("lost the jumpbox")
+
+				err := tunnel.Execute([]string{}, state)
+				Expect(err).To(MatchError("lost the jumpbox"))
+			})
+
+			It("does not remove the files of another tunnel when it fails to start", func() {
+				sshClient.TunnelCall.Returns.Error = errors.New("address already in use")
+
+				err := tunnel.Execute([]string{"--port", "1080"}, state)
+				Expect(err).To(MatchError("address already in use"))
+				Expect(fileIO.RemoveCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the address cannot be recorded", func() {
+				fileIO.WriteFileCall.Returns = []fakes.WriteFileReturn{{}, {Error: errors.New("disk full")}}
+
+				err := tunnel.Execute([]string{}, state)
+				Expect(err).To(MatchError("Write tunnel address: disk full"))
+			})
+		})
+	})
+})
@@ -1,37 +1,105 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
+	"text/tabwriter"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type Up struct {
-	plan               plan
-	boshManager        boshManager
-	cloudConfigManager cloudConfigManager
-	stateStore         stateStore
-	terraformManager   terraformManager
+	plan                 plan
+	boshManager          boshManager
+	cloudConfigManager   cloudConfigManager
+	runtimeConfigManager runtimeConfigManager
+	stateStore           stateStore
+	terraformManager     terraformManager
+	logger               logger
+	timingsLog           timingsLog
+	hooks                hooks
+	managedFiles         managedFiles
+	quotaChecker         QuotaChecker
+}
+
+type QuotaChecker interface {
+	CheckQuotas(state storage.State) ([]storage.Quota, error)
 }
 
 func NewUp(plan plan, boshManager boshManager,
 	cloudConfigManager cloudConfigManager,
-	stateStore stateStore, terraformManager terraformManager) Up {
+	runtimeConfigManager runtimeConfigManager,
+	stateStore stateStore, terraformManager terraformManager, logger logger,
+	timingsLog timingsLog, hooks hooks, managedFiles managedFiles, quotaChecker QuotaChecker) Up {
 	return Up{
-		plan:               plan,
-		boshManager:        boshManager,
-		cloudConfigManager: cloudConfigManager,
-		stateStore:         stateStore,
-		terraformManager:   terraformManager,
+		plan:                 plan,
+		boshManager:          boshManager,
+		cloudConfigManager:   cloudConfigManager,
+		runtimeConfigManager: runtimeConfigManager,
+		stateStore:           stateStore,
+		terraformManager:     terraformManager,
+		logger:               logger,
+		timingsLog:           timingsLog,
+		hooks:                hooks,
+		managedFiles:         managedFiles,
+		quotaChecker:         quotaChecker,
 	}
 }
 
 func (u Up) CheckFastFails(args []string, state storage.State) error {
-	return u.plan.CheckFastFails(args, state)
+	if err := u.plan.CheckFastFails(args, state); err != nil {
+		return err
+	}
+
+	// Once terraform has paved the environment, its resources already
+	// count towards the usage of the region.
+	if u.quotaChecker == nil || state.TFState != "" {
+		return nil
+	}
+
+	config, err := u.ParseArgs(args, state)
+	if err != nil {
+		return err
+	}
+	if config.LB.Type != "" {
+		state.LB = config.LB
+	}
+	state.Sizing = state.Sizing.Merge(config.Sizing)
+
+	quotas, err := u.quotaChecker.CheckQuotas(state)
+	if err != nil {
+		u.logger.Println(fmt.Sprintf("warning: could not check the region quotas: %s", err))
+		return nil
+	}
+
+	table := &bytes.Buffer{}
+	w := tabwriter.NewWriter(table, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "quota\trequired\tin use\tlimit")
+
+	exceeded := false
+	for _, quota := range quotas {
+		if quota.Exceeded() && quota.Assumed {
+			u.logger.Println(fmt.Sprintf("warning: %s may exceed the quota: %d required and %d in use, and the default limit is %d. The limit of this account could not be read.",
+				quota.Name, quota.Required, quota.InUse, quota.Limit))
+			continue
+		}
+		if quota.Exceeded() {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", quota.Name, quota.Required, quota.InUse, quota.Limit)
+			exceeded = true
+		}
+	}
+	w.Flush()
+
+	if exceeded {
+		return fmt.Errorf("The region does not have enough quota for this environment:\n\n%s\nRequest a quota increase or choose another region.", table.String())
+	}
+
+	return nil
 }
 
-func (u Up) Execute(args []string, state storage.State) error {
+func (u Up) Execute(args []string, state storage.State) (err error) {
 	config, err := u.ParseArgs(args, state)
 	if err != nil {
 		return err
@@ -43,12 +111,32 @@ func (u Up) Execute(args []string, state storage.State) error {
 			return err
 		}
 		state = planState
+	} else if sizing := state.Sizing.Merge(config.Sizing); sizing != state.Sizing || usesDeploymentSource(state) {
+		state, err = u.replan(state, sizing, config.Force)
+		if err != nil {
+			return err
+		}
 	}
 
+	timer := newPhaseTimer("up", u.logger)
+	defer func(state storage.State) {
+		timer.Report(u.timingsLog, state, err)
+	}(state)
+
+	if openToTheWorld(state) {
+		u.logger.Println("warning: the jumpbox and director accept connections from 0.0.0.0/0. Use --allowed-cidrs to restrict them.")
+	}
+
+	if err := u.runHook("pre-terraform", state, terraform.Outputs{}); err != nil {
+		return err
+	}
+
+	timer.Start(terraformApplyPhase)
 	state, err = u.terraformManager.Apply(state)
 	if err != nil {
 		return handleTerraformError(err, state, u.stateStore)
 	}
+	timer.Stop()
 
 	state.NoDirector = false
 
@@ -62,6 +150,15 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Parse terraform outputs: %s", err)
 	}
 
+	if err := u.runHook("post-terraform", state, terraformOutputs); err != nil {
+		return err
+	}
+
+	if err := u.runHook("pre-jumpbox", state, terraformOutputs); err != nil {
+		return err
+	}
+
+	timer.Start(jumpboxCreatePhase)
 	state, err = u.boshManager.CreateJumpbox(state, terraformOutputs)
 	switch err.(type) {
 	case bosh.ManagerCreateError:
@@ -73,12 +170,22 @@ func (u Up) Execute(args []string, state storage.State) error {
 	case error:
 		return fmt.Errorf("Create jumpbox: %s", err)
 	}
+	timer.Stop()
 
 	err = u.stateStore.Set(state)
 	if err != nil {
 		return fmt.Errorf("Save state after create jumpbox: %s", err)
 	}
 
+	if err := u.runHook("post-jumpbox", state, terraformOutputs); err != nil {
+		return err
+	}
+
+	if err := u.runHook("pre-director", state, terraformOutputs); err != nil {
+		return err
+	}
+
+	timer.Start(directorCreatePhase)
 	state, err = u.boshManager.CreateDirector(state, terraformOutputs)
 	switch err.(type) {
 	case bosh.ManagerCreateError:
@@ -90,20 +197,106 @@ func (u Up) Execute(args []string, state storage.State) error {
 	case error:
 		return fmt.Errorf("Create bosh director: %s", err)
 	}
+	timer.Stop()
 
 	err = u.stateStore.Set(state)
 	if err != nil {
 		return fmt.Errorf("Save state after create director: %s", err)
 	}
 
+	if err := u.runHook("post-director", state, terraformOutputs); err != nil {
+		return err
+	}
+
+	timer.Start(cloudConfigPhase)
 	err = u.cloudConfigManager.Update(state)
 	if err != nil {
 		return fmt.Errorf("Update cloud config: %s", err)
 	}
+	timer.Stop()
 
+	if err := u.runHook("post-cloud-config", state, terraformOutputs); err != nil {
+		return err
+	}
+
+	timer.Start(runtimeConfigPhase)
+	err = u.runtimeConfigManager.Update(state)
+	if err != nil {
+		return fmt.Errorf("Update runtime configs: %s", err)
+	}
+
+	return nil
+}
+
+// runHook saves the state when the hook fails, so that a later bbl up
+// picks up where this one stopped.
+func (u Up) runHook(hook string, state storage.State, outputs terraform.Outputs) error {
+	err := u.hooks.Run(hook, state, outputs.Map)
+	if err != nil {
+		if setErr := u.stateStore.Set(state); setErr != nil {
+			return fmt.Errorf("Save state after %s hook error: %s, %s", hook, err, setErr)
+		}
+		return err
+	}
 	return nil
 }
 
+// replan regenerates the jumpbox and director scripts of an initialized
+// environment when the sizing changed or when they are built from a
+// bosh-deployment or jumpbox-deployment checkout, whose contents and
+// commit may have moved since the last up.
+func (u Up) replan(state storage.State, sizing storage.Sizing, force bool) (storage.State, error) {
+	if err := protectManagedFiles(u.managedFiles, u.logger, force); err != nil {
+		return storage.State{}, err
+	}
+
+	state.Sizing = sizing
+	state = u.boshManager.RecordDeploymentSources(state)
+
+	err := u.stateStore.Set(state)
+	if err != nil {
+		return storage.State{}, fmt.Errorf("Save state: %s", err)
+	}
+
+	if err := u.boshManager.InitializeJumpbox(state); err != nil {
+		return storage.State{}, fmt.Errorf("Bosh manager initialize jumpbox: %s", err)
+	}
+
+	if err := u.boshManager.InitializeDirector(state); err != nil {
+		return storage.State{}, fmt.Errorf("Bosh manager initialize director: %s", err)
+	}
+
+	if err := u.managedFiles.Record(); err != nil {
+		return storage.State{}, err
+	}
+
+	return state, nil
+}
+
+func usesDeploymentSource(state storage.State) bool {
+	return state.BOSHDeployment.Path != "" || state.JumpboxDeployment.Path != ""
+}
+
+// openToTheWorld reports whether bbl manages the jumpbox and director firewall
+// rules for the IaaS and leaves them open to any source.
+func openToTheWorld(state storage.State) bool {
+	switch state.IAAS {
+	case "aws", "azure", "gcp":
+	default:
+		return false
+	}
+
+	if len(state.Network.AllowedCIDRs) == 0 {
+		return true
+	}
+	for _, cidr := range state.Network.AllowedCIDRs {
+		if cidr == "0.0.0.0/0" {
+			return true
+		}
+	}
+	return false
+}
+
 func (u Up) ParseArgs(args []string, state storage.State) (PlanConfig, error) {
 	return u.plan.ParseArgs(args, state)
 }
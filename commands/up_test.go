@@ -17,11 +17,17 @@ var _ = Describe("Up", func() {
 	var (
 		command commands.Up
 
-		plan               *fakes.Plan
-		boshManager        *fakes.BOSHManager
-		terraformManager   *fakes.TerraformManager
-		cloudConfigManager *fakes.CloudConfigManager
-		stateStore         *fakes.StateStore
+		plan                 *fakes.Plan
+		boshManager          *fakes.BOSHManager
+		terraformManager     *fakes.TerraformManager
+		cloudConfigManager   *fakes.CloudConfigManager
+		runtimeConfigManager *fakes.RuntimeConfigManager
+		stateStore           *fakes.StateStore
+		logger               *fakes.Logger
+		timingsLog           *fakes.TimingsLog
+		hooks                *fakes.Hooks
+		managedFiles         *fakes.ManagedFiles
+		quotaChecker         *fakes.QuotaChecker
 	)
 
 	BeforeEach(func() {
@@ -29,9 +35,15 @@ var _ = Describe("Up", func() {
 		boshManager = &fakes.BOSHManager{}
 		terraformManager = &fakes.TerraformManager{}
 		cloudConfigManager = &fakes.CloudConfigManager{}
+		runtimeConfigManager = &fakes.RuntimeConfigManager{}
 		stateStore = &fakes.StateStore{}
+		logger = &fakes.Logger{}
+		timingsLog = &fakes.TimingsLog{}
+		hooks = &fakes.Hooks{}
+		managedFiles = &fakes.ManagedFiles{}
+		quotaChecker = &fakes.QuotaChecker{}
 
-		command = commands.NewUp(plan, boshManager, cloudConfigManager, stateStore, terraformManager)
+		command = commands.NewUp(plan, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, terraformManager, logger, timingsLog, hooks, managedFiles, quotaChecker)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -43,6 +55,71 @@ var _ = Describe("Up", func() {
 			Expect(plan.CheckFastFailsCall.Receives.SubcommandFlags).To(Equal([]string{}))
 			Expect(plan.CheckFastFailsCall.Receives.State).To(Equal(storage.State{Version: 999}))
 		})
+
+		It("checks the region quotas for the requested lb type and sizing", func() {
+			plan.ParseArgsCall.Returns.Config = commands.PlanConfig{
+				LB:     storage.LB{Type: "cf"},
+				Sizing: storage.Sizing{DirectorVMType: "n1-standard-4"},
+			}
+
+			err := command.CheckFastFails([]string{"--lb-type", "cf"}, storage.State{IAAS: "gcp"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quotaChecker.CheckQuotasCall.CallCount).To(Equal(1))
+			Expect(quotaChecker.CheckQuotasCall.Receives.State.LB.Type).To(Equal("cf"))
+			Expect(quotaChecker.CheckQuotasCall.Receives.State.Sizing.DirectorVMType).To(Equal("n1-standard-4"))
+		})
+
+		Context("when a quota would be exceeded", func() {
+			BeforeEach(func() {
+				quotaChecker.CheckQuotasCall.Returns.Quotas = []storage.Quota{
+					{Name: "CPUs", Required: 3, InUse: 22, Limit: 24},
+					{Name: "Static addresses", Required: 4, InUse: 1, Limit: 8},
+					{Name: "Forwarding rules", Required: 4, InUse: 15, Limit: 15},
+				}
+			})
+
+			It("returns a table of the exceeded quotas", func() {
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "gcp"})
+				Expect(err).To(MatchError(`The region does not have enough quota for this environment:
+
+quota               required    in use    limit
+CPUs                3           22        24
+Forwarding rules    4           15        15
+
+Request a quota increase or choose another region.`))
+			})
+		})
+
+		Context("when a quota with an assumed limit would be exceeded", func() {
+			It("warns and continues", func() {
+				quotaChecker.CheckQuotasCall.Returns.Quotas = []storage.Quota{
+					{Name: "VPCs", Required: 1, InUse: 5, Limit: 5, Assumed: true},
+				}
+
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("warning: VPCs may exceed the quota: 1 required and 5 in use, and the default limit is 5. The limit of this account could not be read."))
+			})
+		})
+
+		Context("when the quotas cannot be checked", func() {
+			It("warns and continues", func() {
+				quotaChecker.CheckQuotasCall.Returns.Error = errors.New("access denied")
+
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("warning: could not check the region quotas: access denied"))
+			})
+		})
+
+		Context("when the environment has already been paved", func() {
+			It("does not check the quotas", func() {
+				err := command.CheckFastFails([]string{}, storage.State{IAAS: "gcp", TFState: "some-tf-state"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(quotaChecker.CheckQuotasCall.CallCount).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -120,10 +197,137 @@ var _ = Describe("Up", func() {
 				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(1))
 				Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
 
+				Expect(runtimeConfigManager.UpdateCall.CallCount).To(Equal(1))
+				Expect(runtimeConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
+
 				Expect(stateStore.SetCall.CallCount).To(Equal(3))
 			})
 		})
 
+		Context("when sizing flags change on an initialized environment", func() {
+			var resizedState storage.State
+
+			BeforeEach(func() {
+				plan.ParseArgsCall.Returns.Config = commands.PlanConfig{
+					Sizing: storage.Sizing{DirectorDiskSize: 128},
+				}
+				incomingState.Sizing = storage.Sizing{DirectorVMType: "m4.large"}
+
+				resizedState = incomingState
+				resizedState.Sizing = storage.Sizing{DirectorVMType: "m4.large", DirectorDiskSize: 128}
+				boshManager.RecordDeploymentSourcesCall.Returns.State = resizedState
+			})
+
+			It("saves the sizing and regenerates the jumpbox and director scripts", func() {
+				err := command.Execute([]string{"--director-disk-size", "128"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(plan.InitializePlanCall.CallCount).To(Equal(0))
+
+				Expect(stateStore.SetCall.Receives[0].State).To(Equal(resizedState))
+
+				Expect(boshManager.InitializeJumpboxCall.CallCount).To(Equal(1))
+				Expect(boshManager.InitializeJumpboxCall.Receives.State).To(Equal(resizedState))
+				Expect(boshManager.InitializeDirectorCall.CallCount).To(Equal(1))
+				Expect(boshManager.InitializeDirectorCall.Receives.State).To(Equal(resizedState))
+
+				Expect(terraformManager.ApplyCall.Receives.BBLState).To(Equal(resizedState))
+				Expect(managedFiles.RecordCall.CallCount).To(Equal(1))
+			})
+
+			Context("when the scripts were modified locally", func() {
+				BeforeEach(func() {
+					managedFiles.ModifiedCall.Returns.Files = []string{"create-director.sh"}
+				})
+
+				It("refuses to overwrite them", func() {
+					err := command.Execute([]string{"--director-disk-size", "128"}, incomingState)
+					Expect(err).To(MatchError(ContainSubstring("These files are generated by bbl and were modified locally: create-director.sh.")))
+
+					Expect(boshManager.InitializeDirectorCall.CallCount).To(Equal(0))
+				})
+
+				It("backs them up with --force", func() {
+					plan.ParseArgsCall.Returns.Config.Force = true
+					managedFiles.BackupCall.Returns.Dir = "some-state-dir/bbl-backups/some-time"
+
+					err := command.Execute([]string{"--director-disk-size", "128", "--force"}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(managedFiles.BackupCall.Receives.Files).To(Equal([]string{"create-director.sh"}))
+					Expect(boshManager.InitializeDirectorCall.CallCount).To(Equal(1))
+				})
+			})
+
+			Context("when the sizing is unchanged", func() {
+				BeforeEach(func() {
+					incomingState = resizedState
+				})
+
+				It("does not regenerate the scripts", func() {
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.InitializeJumpboxCall.CallCount).To(Equal(0))
+					Expect(boshManager.InitializeDirectorCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when the environment uses a bosh-deployment checkout", func() {
+				var recordedState storage.State
+
+				BeforeEach(func() {
+					incomingState = resizedState
+					incomingState.BOSHDeployment = storage.DeploymentSource{Path: "/some/bosh-deployment", Commit: "old-commit"}
+
+					recordedState = incomingState
+					recordedState.BOSHDeployment.Commit = "new-commit"
+					boshManager.RecordDeploymentSourcesCall.Returns.State = recordedState
+				})
+
+				It("records the checked out commit and regenerates the scripts even though the sizing is unchanged", func() {
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.RecordDeploymentSourcesCall.Receives.State).To(Equal(incomingState))
+					Expect(stateStore.SetCall.Receives[0].State).To(Equal(recordedState))
+
+					Expect(boshManager.InitializeJumpboxCall.CallCount).To(Equal(1))
+					Expect(boshManager.InitializeJumpboxCall.Receives.State).To(Equal(recordedState))
+					Expect(boshManager.InitializeDirectorCall.CallCount).To(Equal(1))
+					Expect(boshManager.InitializeDirectorCall.Receives.State).To(Equal(recordedState))
+					Expect(managedFiles.RecordCall.CallCount).To(Equal(1))
+				})
+			})
+
+			Context("when saving the state fails", func() {
+				It("returns an error", func() {
+					stateStore.SetCall.Returns = []fakes.SetCallReturn{{Error: errors.New("fig")}}
+
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).To(MatchError("Save state: fig"))
+				})
+			})
+
+			Context("when initializing the jumpbox fails", func() {
+				It("returns an error", func() {
+					boshManager.InitializeJumpboxCall.Returns.Error = errors.New("date")
+
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).To(MatchError("Bosh manager initialize jumpbox: date"))
+				})
+			})
+
+			Context("when initializing the director fails", func() {
+				It("returns an error", func() {
+					boshManager.InitializeDirectorCall.Returns.Error = errors.New("lime")
+
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).To(MatchError("Bosh manager initialize director: lime"))
+				})
+			})
+		})
+
 		Context("if parse args fails", func() {
 			It("returns an error if parse args fails", func() {
 				plan.ParseArgsCall.Returns.Error = errors.New("canteloupe")
@@ -153,6 +357,186 @@ var _ = Describe("Up", func() {
 			})
 		})
 
+		Context("when the jumpbox and director are open to 0.0.0.0/0", func() {
+			It("warns when no allowed cidrs are set", func() {
+				err := command.Execute([]string{}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("warning: the jumpbox and director accept connections from 0.0.0.0/0. Use --allowed-cidrs to restrict them."))
+			})
+
+			It("warns when 0.0.0.0/0 is one of the allowed cidrs", func() {
+				err := command.Execute([]string{}, storage.State{
+					IAAS:    "aws",
+					Network: storage.Network{AllowedCIDRs: []string{"203.0.113.0/24", "0.0.0.0/0"}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.CallCount).To(Equal(1))
+			})
+
+			It("does not warn when the allowed cidrs are restricted", func() {
+				err := command.Execute([]string{}, storage.State{
+					IAAS:    "azure",
+					Network: storage.Network{AllowedCIDRs: []string{"203.0.113.0/24"}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.CallCount).To(Equal(0))
+			})
+
+			It("does not warn on iaases where bbl does not manage the firewall", func() {
+				err := command.Execute([]string{}, storage.State{IAAS: "vsphere"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("running hooks", func() {
+			It("runs the hooks around each phase", func() {
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(hooks.Ran()).To(Equal([]string{
+					"pre-terraform",
+					"post-terraform",
+					"pre-jumpbox",
+					"post-jumpbox",
+					"pre-director",
+					"post-director",
+					"post-cloud-config",
+				}))
+				Expect(hooks.RunCall.Receives[0].State).To(Equal(incomingState))
+				Expect(hooks.RunCall.Receives[0].Outputs).To(BeNil())
+				Expect(hooks.RunCall.Receives[1].State).To(Equal(terraformApplyState))
+				Expect(hooks.RunCall.Receives[1].Outputs).To(Equal(terraformOutputs.Map))
+				Expect(hooks.RunCall.Receives[5].State).To(Equal(createDirectorState))
+			})
+
+			Context("when a hook fails", func() {
+				BeforeEach(func() {
+					hooks.RunCall.Stub = func(hook string) error {
+						if hook == "post-jumpbox" {
+							return errors.New("Run post-jumpbox hook: exit status 1")
+						}
+						return nil
+					}
+				})
+
+				It("saves the state and aborts", func() {
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).To(MatchError("Run post-jumpbox hook: exit status 1"))
+
+					Expect(boshManager.CreateDirectorCall.CallCount).To(Equal(0))
+					Expect(stateStore.SetCall.CallCount).To(Equal(3))
+					Expect(stateStore.SetCall.Receives[2].State).To(Equal(createJumpboxState))
+				})
+
+				Context("when saving the state fails", func() {
+					It("returns both errors", func() {
+						stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {Error: errors.New("kiwi")}}
+
+						err := command.Execute([]string{}, incomingState)
+						Expect(err).To(MatchError("Save state after post-jumpbox hook error: Run post-jumpbox hook: exit status 1, kiwi"))
+					})
+				})
+			})
+		})
+
+		Context("timing the phases", func() {
+			var phases = func() []string {
+				var names []string
+				for _, phase := range timingsLog.AppendCall.Receives.Run.Phases {
+					names = append(names, phase.Phase)
+				}
+				return names
+			}
+
+			It("prints a summary and saves the timings", func() {
+				incomingState.EnvID = "some-env-id"
+				incomingState.BBLVersion = "1.2.3"
+				incomingState.BOSHDeployment = storage.DeploymentSource{Commit: "some-commit"}
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(HaveLen(1))
+				Expect(logger.PrintfCall.Messages[0]).To(MatchRegexp(`^phase +duration\nterraform init and apply +0s\n(.|\n)*total +0s\n$`))
+
+				Expect(timingsLog.AppendCall.CallCount).To(Equal(1))
+				run := timingsLog.AppendCall.Receives.Run
+				Expect(run.Command).To(Equal("up"))
+				Expect(run.EnvID).To(Equal("some-env-id"))
+				Expect(run.BBLVersion).To(Equal("1.2.3"))
+				Expect(run.BOSHDeploymentCommit).To(Equal("some-commit"))
+				Expect(phases()).To(Equal([]string{
+					"terraform init and apply",
+					"jumpbox create-env",
+					"director create-env",
+					"cloud-config",
+					"runtime-config",
+				}))
+			})
+
+			It("sets the phase of the log events", func() {
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.SetPhaseCall.Phases).To(Equal([]string{
+					"terraform", "",
+					"jumpbox", "",
+					"director", "",
+					"cloud-config", "",
+					"runtime-config", "",
+				}))
+			})
+
+			It("saves the timings of a failed run without printing a summary", func() {
+				boshManager.CreateJumpboxCall.Returns.Error = errors.New("pineapple")
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).To(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(BeEmpty())
+
+				run := timingsLog.AppendCall.Receives.Run
+				Expect(run.Failed).To(BeTrue())
+				Expect(phases()).To(Equal([]string{
+					"terraform init and apply",
+					"jumpbox create-env",
+				}))
+				Expect(run.Phases[0].Failed).To(BeFalse())
+				Expect(run.Phases[1].Failed).To(BeTrue())
+			})
+
+			It("marks the run as failed when it fails between phases", func() {
+				hooks.RunCall.Stub = func(hook string) error {
+					if hook == "post-terraform" {
+						return errors.New("exit status 1")
+					}
+					return nil
+				}
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).To(HaveOccurred())
+
+				run := timingsLog.AppendCall.Receives.Run
+				Expect(run.Failed).To(BeTrue())
+				Expect(phases()).To(Equal([]string{"terraform init and apply"}))
+				Expect(run.Phases[0].Failed).To(BeFalse())
+			})
+
+			It("warns when the timings cannot be saved", func() {
+				timingsLog.AppendCall.Returns.Error = errors.New("disk full")
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("warning: the phase timings were not saved: disk full"))
+			})
+		})
+
 		Describe("failure cases", func() {
 			Context("when parse args fails", func() {
 				BeforeEach(func() {
@@ -253,6 +637,17 @@ var _ = Describe("Up", func() {
 				})
 			})
 
+			Context("when the runtime configs cannot be uploaded", func() {
+				BeforeEach(func() {
+					runtimeConfigManager.UpdateCall.Returns.Error = errors.New("mango")
+				})
+
+				It("returns an error", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).To(MatchError("Update runtime configs: mango"))
+				})
+			})
+
 			Context("when terraform manager apply fails", func() {
 				var partialState storage.State
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -18,6 +19,8 @@ Global Options:
   --debug      [-d]        Prints debugging output                                                       env:"BBL_DEBUG"
   --version    [-v]        Prints version
   --no-confirm [-n]        No confirm
+  --output                 Output of query commands: json, yaml or text (Default: text)                  env:"BBL_OUTPUT"
+  --log-format             Format of progress logs: json or text (Default: text)                         env:"BBL_LOG_FORMAT"
 %s
 `
 	CommandUsage = `
@@ -29,12 +32,14 @@ const GlobalUsage = `
 Basic Commands: A good place to start
   up                      Deploys BOSH director on an IAAS, creates CF/Concourse load balancers. Updates existing director.
   print-env               All environment variables needed for targeting BOSH. Use with: eval "$(bbl print-env)"
+  env-exec                Runs a command with the environment variables from print-env. Use with: bbl env-exec -- bosh deployments
 
 Maintenance Lifecycle Commands:
   destroy                 Tears down BOSH director infrastructure. Cleans up state directory
   rotate                  Rotates SSH key for the jumpbox user
   plan                    Populates a state directory with the latest config without applying it
   cleanup-leftovers       Cleans up orphaned IAAS resources
+  patch                   Adds, lists and removes plan patches
 
 Environmental Detail Commands: Useful for automation and gaining access
   jumpbox-address         Prints BOSH jumpbox address
@@ -48,19 +53,28 @@ Environmental Detail Commands: Useful for automation and gaining access
   lbs                     Prints load balancer(s) and DNS records
   outputs                 Prints the outputs from terraform
   ssh                     Opens an SSH connection to the director or jumpbox
+  scp                     Copies files to or from the director or jumpbox
+  tunnel                  Runs a SOCKS5 proxy and port forwards through the jumpbox
 
 Troubleshooting Commands:
   help                    Prints usage
   version                 Prints version
+  doctor                  Checks the workstation and state directory for common problems
   latest-error            Prints the output from the latest call to terraform`
 
+type pluginLister interface {
+	List() []string
+}
+
 type Usage struct {
-	logger logger
+	logger  logger
+	plugins pluginLister
 }
 
-func NewUsage(logger logger) Usage {
+func NewUsage(logger logger, plugins pluginLister) Usage {
 	return Usage{
-		logger: logger,
+		logger:  logger,
+		plugins: plugins,
 	}
 }
 
@@ -74,7 +88,15 @@ func (u Usage) Execute(subcommandFlags []string, state storage.State) error {
 }
 
 func (u Usage) Print() {
-	content := fmt.Sprintf(UsageHeader, "COMMAND", GlobalUsage)
+	globalUsage := GlobalUsage
+	if plugins := u.plugins.List(); len(plugins) > 0 {
+		globalUsage += "\n\nPlugins: bbl-<name> executables on PATH"
+		for _, name := range plugins {
+			globalUsage += fmt.Sprintf("\n  %-24sRuns %s%s", name, helpers.PluginPrefix, name)
+		}
+	}
+
+	content := fmt.Sprintf(UsageHeader, "COMMAND", globalUsage)
 	u.logger.Println(strings.TrimLeft(content, "\n"))
 }
 
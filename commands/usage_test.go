@@ -13,14 +13,16 @@ import (
 
 var _ = Describe("Usage", func() {
 	var (
-		usage  commands.Usage
-		logger *fakes.Logger
+		usage   commands.Usage
+		logger  *fakes.Logger
+		plugins *fakes.PluginFinder
 	)
 
 	BeforeEach(func() {
 		logger = &fakes.Logger{}
+		plugins = &fakes.PluginFinder{}
 
-		usage = commands.NewUsage(logger)
+		usage = commands.NewUsage(logger, plugins)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -44,16 +46,20 @@ Global Options:
   --debug      [-d]        Prints debugging output                                                       env:"BBL_DEBUG"
   --version    [-v]        Prints version
   --no-confirm [-n]        No confirm
+  --output                 Output of query commands: json, yaml or text (Default: text)                  env:"BBL_OUTPUT"
+  --log-format             Format of progress logs: json or text (Default: text)                         env:"BBL_LOG_FORMAT"
 
 Basic Commands: A good place to start
   up                      Deploys BOSH director on an IAAS, creates CF/Concourse load balancers. Updates existing director.
   print-env               All environment variables needed for targeting BOSH. Use with: eval "$(bbl print-env)"
+  env-exec                Runs a command with the environment variables from print-env. Use with: bbl env-exec -- bosh deployments
 
 Maintenance Lifecycle Commands:
   destroy                 Tears down BOSH director infrastructure. Cleans up state directory
   rotate                  Rotates SSH key for the jumpbox user
   plan                    Populates a state directory with the latest config without applying it
   cleanup-leftovers       Cleans up orphaned IAAS resources
+  patch                   Adds, lists and removes plan patches
 
 Environmental Detail Commands: Useful for automation and gaining access
   jumpbox-address         Prints BOSH jumpbox address
@@ -67,13 +73,30 @@ Environmental Detail Commands: Useful for automation and gaining access
   lbs                     Prints load balancer(s) and DNS records
   outputs                 Prints the outputs from terraform
   ssh                     Opens an SSH connection to the director or jumpbox
+  scp                     Copies files to or from the director or jumpbox
+  tunnel                  Runs a SOCKS5 proxy and port forwards through the jumpbox
 
 Troubleshooting Commands:
   help                    Prints usage
   version                 Prints version
+  doctor                  Checks the workstation and state directory for common problems
   latest-error            Prints the output from the latest call to terraform
 `, "\n")))
 		})
+
+		It("lists the plugins on the path", func() {
+			plugins.ListCall.Returns.Names = []string{"cf-deploy", "concourse-deploy"}
+
+			err := usage.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.PrintlnCall.Receives.Message).To(HaveSuffix(`
+  latest-error            Prints the output from the latest call to terraform
+
+Plugins: bbl-<name> executables on PATH
+  cf-deploy               Runs bbl-cf-deploy
+  concourse-deploy        Runs bbl-concourse-deploy
+`))
+		})
 	})
 
 	Describe("PrintCommandUsage", func() {
@@ -89,6 +112,8 @@ Global Options:
   --debug      [-d]        Prints debugging output                                                       env:"BBL_DEBUG"
   --version    [-v]        Prints version
   --no-confirm [-n]        No confirm
+  --output                 Output of query commands: json, yaml or text (Default: text)                  env:"BBL_OUTPUT"
+  --log-format             Format of progress logs: json or text (Default: text)                         env:"BBL_LOG_FORMAT"
 
 [my-command command options]
   some message
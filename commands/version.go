@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"fmt"
 	"runtime"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -10,17 +9,27 @@ import (
 type Version struct {
 	logger  logger
 	version string
+	output  Output
 }
 
-func NewVersion(version string, logger logger) Version {
+func NewVersion(version string, logger logger, output Output) Version {
 	return Version{
 		logger:  logger,
-		version: fmt.Sprintf("%s (%s/%s)", version, runtime.GOOS, runtime.GOARCH),
+		version: version,
+		output:  output,
 	}
 }
 
 func (v Version) Execute(subcommandFlags []string, state storage.State) error {
-	v.logger.Printf("bbl %s\n", v.version)
+	if !v.output.Text() {
+		return v.output.Print(struct {
+			Version string `json:"version"`
+			OS      string `json:"os"`
+			Arch    string `json:"arch"`
+		}{v.version, runtime.GOOS, runtime.GOARCH})
+	}
+
+	v.logger.Printf("bbl %s (%s/%s)\n", v.version, runtime.GOOS, runtime.GOARCH)
 	return nil
 }
 
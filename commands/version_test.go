@@ -24,7 +24,7 @@ var _ = Describe("Version", func() {
 
 	Describe("CheckFastFails", func() {
 		BeforeEach(func() {
-			version = commands.NewVersion("dev", logger)
+			version = commands.NewVersion("dev", logger, commands.NewOutput(logger, ""))
 		})
 
 		It("returns no error", func() {
@@ -36,7 +36,7 @@ var _ = Describe("Version", func() {
 	Describe("Execute", func() {
 		Context("when no version number was passed in", func() {
 			BeforeEach(func() {
-				version = commands.NewVersion("dev", logger)
+				version = commands.NewVersion("dev", logger, commands.NewOutput(logger, ""))
 			})
 
 			Describe("Execute", func() {
@@ -53,7 +53,7 @@ var _ = Describe("Version", func() {
 
 		Context("when a version number was passed in", func() {
 			BeforeEach(func() {
-				version = commands.NewVersion("1.2.3", logger)
+				version = commands.NewVersion("1.2.3", logger, commands.NewOutput(logger, ""))
 			})
 
 			Describe("Execute", func() {
@@ -67,5 +67,16 @@ var _ = Describe("Version", func() {
 				})
 			})
 		})
+
+		Context("when the output is json", func() {
+			It("prints the version, os and architecture", func() {
+				version = commands.NewVersion("1.2.3", logger, commands.NewOutput(logger, "json"))
+
+				err := version.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(fmt.Sprintf(`{"version": "1.2.3", "os": %q, "arch": %q}`, runtime.GOOS, runtime.GOARCH)))
+			})
+		})
 	})
 })
@@ -2,15 +2,29 @@ package config
 
 type globalFlags struct {
 	Help      bool   `short:"h" long:"help"`
-	Debug     bool   `short:"d" long:"debug"     env:"BBL_DEBUG"`
+	Debug     bool   `short:"d" long:"debug"      env:"BBL_DEBUG"`
 	Version   bool   `short:"v" long:"version"`
 	NoConfirm bool   `short:"n" long:"no-confirm"`
-	StateDir  string `short:"s" long:"state-dir" env:"BBL_STATE_DIRECTORY"`
-	IAAS      string `          long:"iaas"      env:"BBL_IAAS"`
+	StateDir  string `short:"s" long:"state-dir"  env:"BBL_STATE_DIRECTORY"`
+	IAAS      string `          long:"iaas"       env:"BBL_IAAS"`
+	Output    string `          long:"output"     env:"BBL_OUTPUT"`
+	LogFormat string `          long:"log-format" env:"BBL_LOG_FORMAT"`
 
-	AWSAccessKeyID     string `long:"aws-access-key-id"       env:"BBL_AWS_ACCESS_KEY_ID"`
-	AWSSecretAccessKey string `long:"aws-secret-access-key"   env:"BBL_AWS_SECRET_ACCESS_KEY"`
-	AWSRegion          string `long:"aws-region"              env:"BBL_AWS_REGION"`
+	BOSHDeploymentDir    string `long:"bosh-deployment-dir"    env:"BBL_BOSH_DEPLOYMENT_DIR"`
+	JumpboxDeploymentDir string `long:"jumpbox-deployment-dir" env:"BBL_JUMPBOX_DEPLOYMENT_DIR"`
+
+	NetworkCIDR      string   `long:"network-cidr"       env:"BBL_NETWORK_CIDR"`
+	InternalCIDR     string   `long:"internal-cidr"      env:"BBL_INTERNAL_CIDR"`
+	JumpboxIPOffset  int      `long:"jumpbox-ip-offset"  env:"BBL_JUMPBOX_IP_OFFSET"`
+	DirectorIPOffset int      `long:"director-ip-offset" env:"BBL_DIRECTOR_IP_OFFSET"`
+	DualStack        bool     `long:"dual-stack"         env:"BBL_DUAL_STACK"`
+	AllowedCIDRs     []string `long:"allowed-cidrs"      env:"BBL_ALLOWED_CIDRS" env-delim:","`
+
+	AWSAccessKeyID     string   `long:"aws-access-key-id"       env:"BBL_AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey string   `long:"aws-secret-access-key"   env:"BBL_AWS_SECRET_ACCESS_KEY"`
+	AWSRegion          string   `long:"aws-region"              env:"BBL_AWS_REGION"`
+	AWSSites           []string `long:"aws-site"                env:"BBL_AWS_SITES" env-delim:","`
+	AWSSiteCIDRs       []string `long:"aws-site-cidr"           env:"BBL_AWS_SITE_CIDRS" env-delim:","`
 
 	AzureClientID       string `long:"azure-client-id"        env:"BBL_AZURE_CLIENT_ID"`
 	AzureClientSecret   string `long:"azure-client-secret"    env:"BBL_AZURE_CLIENT_SECRET"`
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/fileio"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	flags "github.com/jessevdk/go-flags"
@@ -48,13 +51,32 @@ type Config struct {
 	fs             fs
 }
 
+// ParseArgs returns the global flags parsed so far together with an error, so
+// that the error can still be logged in the requested log format.
 func ParseArgs(args []string) (globalFlags, []string, error) {
 	var globals globalFlags
 	parser := flags.NewParser(&globals, flags.IgnoreUnknown)
 
-	remainingArgs, err := parser.ParseArgs(args[1:])
+	// Arguments after "--" belong to the subcommand, such as the remote
+	// command given to bbl ssh, and are not parsed as global flags.
+	args, passthroughArgs := splitAtDoubleDash(args[1:])
+
+	remainingArgs, err := parser.ParseArgs(args)
 	if err != nil {
-		return globalFlags{}, remainingArgs, err
+		return globals, remainingArgs, err
+	}
+	remainingArgs = append(remainingArgs, passthroughArgs...)
+
+	switch globals.Output {
+	case "", "text", "json", "yaml":
+	default:
+		return globals, remainingArgs, fmt.Errorf("Unknown output format %q. Use json, yaml or text.", globals.Output)
+	}
+
+	switch globals.LogFormat {
+	case "", "text", "json":
+	default:
+		return globalFlags{}, remainingArgs, fmt.Errorf("Unknown log format %q. Use json or text.", globals.LogFormat)
 	}
 
 	if !filepath.IsAbs(globals.StateDir) {
@@ -68,6 +90,15 @@ func ParseArgs(args []string) (globalFlags, []string, error) {
 	return globals, remainingArgs, nil
 }
 
+func splitAtDoubleDash(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i:]
+		}
+	}
+	return args, nil
+}
+
 func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 	if len(args) == 1 {
 		return application.Configuration{
@@ -88,6 +119,9 @@ func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 	if globalFlags.Version || command == "version" {
 		command = "version"
 		return application.Configuration{
+			Global: application.GlobalConfiguration{
+				Output: globalFlags.Output,
+			},
 			ShowCommandHelp: globalFlags.Help,
 			Command:         command,
 		}, nil
@@ -119,6 +153,19 @@ func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 		}, nil
 	}
 
+	// bbl doctor loads the state itself to report why it cannot be loaded.
+	if command == "doctor" {
+		return application.Configuration{
+			Global: application.GlobalConfiguration{
+				Debug:    globalFlags.Debug,
+				StateDir: globalFlags.StateDir,
+				Output:   globalFlags.Output,
+			},
+			Command:         command,
+			SubcommandFlags: remainingArgs[1:],
+		}, nil
+	}
+
 	state, err := c.stateBootstrap.GetState(globalFlags.StateDir)
 	if err != nil {
 		return application.Configuration{}, err
@@ -134,10 +181,26 @@ func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 		return application.Configuration{}, err
 	}
 
+	state, err = c.updateDeploymentSources(globalFlags, state)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
+	state, err = updateNetworkState(globalFlags, state)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
+	state, err = updateAWSSiteCIDRs(globalFlags, state)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
 	return application.Configuration{
 		Global: application.GlobalConfiguration{
 			Debug:    globalFlags.Debug,
 			StateDir: globalFlags.StateDir,
+			Output:   globalFlags.Output,
 		},
 		State:           state,
 		Command:         command,
@@ -183,6 +246,112 @@ func (c Config) updateIAASState(globalFlags globalFlags, state storage.State) (s
 	return state, nil
 }
 
+func (c Config) updateDeploymentSources(globalFlags globalFlags, state storage.State) (storage.State, error) {
+	sources := []struct {
+		flag string
+		dir  string
+		sink *storage.DeploymentSource
+	}{
+		{"--bosh-deployment-dir", globalFlags.BOSHDeploymentDir, &state.BOSHDeployment},
+		{"--jumpbox-deployment-dir", globalFlags.JumpboxDeploymentDir, &state.JumpboxDeployment},
+	}
+
+	for _, source := range sources {
+		if source.dir == "" {
+			continue
+		}
+
+		absDir, err := filepath.Abs(source.dir)
+		if err != nil {
+			return storage.State{}, err // not tested
+		}
+
+		info, err := c.fs.Stat(absDir)
+		if err != nil || !info.IsDir() {
+			return storage.State{}, fmt.Errorf("%s %s is not a directory.", source.flag, source.dir)
+		}
+
+		if source.sink.Path != absDir {
+			*source.sink = storage.DeploymentSource{Path: absDir}
+		}
+	}
+
+	return state, nil
+}
+
+func updateNetworkState(globalFlags globalFlags, state storage.State) (storage.State, error) {
+	if globalFlags.NetworkCIDR == "" && globalFlags.InternalCIDR == "" &&
+		globalFlags.JumpboxIPOffset == 0 && globalFlags.DirectorIPOffset == 0 &&
+		!globalFlags.DualStack && len(globalFlags.AllowedCIDRs) == 0 {
+		return state, nil
+	}
+
+	if globalFlags.DualStack {
+		if state.IAAS != "aws" && state.IAAS != "gcp" {
+			return storage.State{}, fmt.Errorf("--dual-stack is not supported on %s.", state.IAAS)
+		}
+		state.Network.DualStack = true
+	}
+
+	// On openstack and vsphere bbl deploys into an existing network, so
+	// --internal-cidr stands in for the iaas specific subnet flag.
+	switch state.IAAS {
+	case "openstack", "vsphere":
+		if globalFlags.NetworkCIDR != "" {
+			return storage.State{}, fmt.Errorf("--network-cidr is not supported on %s.", state.IAAS)
+		}
+		if len(globalFlags.AllowedCIDRs) > 0 {
+			return storage.State{}, fmt.Errorf("--allowed-cidrs is not supported on %s.", state.IAAS)
+		}
+		if state.IAAS == "openstack" {
+			copyFlagToState(globalFlags.InternalCIDR, &state.OpenStack.InternalCidr)
+		} else {
+			copyFlagToState(globalFlags.InternalCIDR, &state.VSphere.Subnet)
+		}
+	default:
+		if globalFlags.NetworkCIDR != "" {
+			if state.Network.NetworkCIDR != "" && globalFlags.NetworkCIDR != state.Network.NetworkCIDR {
+				return storage.State{}, fmt.Errorf("The network CIDR cannot be changed for an existing environment. The current network CIDR is %s.", state.Network.NetworkCIDR)
+			}
+			state.Network.NetworkCIDR = globalFlags.NetworkCIDR
+		}
+
+		if globalFlags.InternalCIDR != "" {
+			if state.Network.InternalCIDR != "" && globalFlags.InternalCIDR != state.Network.InternalCIDR {
+				return storage.State{}, fmt.Errorf("The internal CIDR cannot be changed for an existing environment. The current internal CIDR is %s.", state.Network.InternalCIDR)
+			}
+			state.Network.InternalCIDR = globalFlags.InternalCIDR
+		}
+	}
+
+	if len(globalFlags.AllowedCIDRs) > 0 {
+		state.Network.AllowedCIDRs = globalFlags.AllowedCIDRs
+	}
+	if globalFlags.JumpboxIPOffset != 0 {
+		state.Network.JumpboxIPOffset = globalFlags.JumpboxIPOffset
+	}
+	if globalFlags.DirectorIPOffset != 0 {
+		state.Network.DirectorIPOffset = globalFlags.DirectorIPOffset
+	}
+
+	err := bosh.ValidateNetwork(state.IAAS, state.Network, internalCIDR(state))
+	if err != nil {
+		return storage.State{}, err
+	}
+
+	return state, nil
+}
+
+func internalCIDR(state storage.State) string {
+	switch state.IAAS {
+	case "openstack":
+		return state.OpenStack.InternalCidr
+	case "vsphere":
+		return state.VSphere.Subnet
+	}
+	return state.Network.InternalCIDR
+}
+
 func copyFlagToState(source string, sink *string) {
 	if source != "" {
 		*sink = source
@@ -251,9 +420,148 @@ func (c Config) updateAWSState(globalFlags globalFlags, state storage.State) (st
 		state.AWS.Region = globalFlags.AWSRegion
 	}
 
+	for _, siteFlag := range globalFlags.AWSSites {
+		site, err := parseAWSSite(siteFlag)
+		if err != nil {
+			return storage.State{}, err
+		}
+
+		state, err = addAWSSite(state, site)
+		if err != nil {
+			return storage.State{}, err
+		}
+	}
+
+	return state, nil
+}
+
+var siteNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func parseAWSSite(siteFlag string) (storage.AWSSite, error) {
+	parts := strings.SplitN(siteFlag, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return storage.AWSSite{}, fmt.Errorf("Invalid --aws-site %q. Sites must be specified as name:region.", siteFlag)
+	}
+
+	if !siteNameRegexp.MatchString(parts[0]) {
+		return storage.AWSSite{}, fmt.Errorf("Invalid site name %q. Site names must start with a lowercase letter and contain only lowercase letters, digits and underscores.", parts[0])
+	}
+
+	return storage.AWSSite{Name: parts[0], Region: parts[1]}, nil
+}
+
+func addAWSSite(state storage.State, site storage.AWSSite) (storage.State, error) {
+	for _, existing := range state.AWS.Sites {
+		if existing.Name != site.Name {
+			continue
+		}
+
+		if existing.Region != site.Region {
+			return storage.State{}, fmt.Errorf("The region of site %s cannot be changed for an existing environment. The current region is %s.", site.Name, existing.Region)
+		}
+
+		return state, nil
+	}
+
+	state.AWS.Sites = append(state.AWS.Sites, site)
+
 	return state, nil
 }
 
+// updateAWSSiteCIDRs sets the VPC CIDRs of the sites, after the network CIDR
+// of the director's VPC is known. Sites without --aws-site-cidr get the next
+// 10.<n>.0.0/16 that overlaps neither the director's VPC nor another site,
+// since overlapping VPCs cannot be peered.
+func updateAWSSiteCIDRs(globalFlags globalFlags, state storage.State) (storage.State, error) {
+	for _, siteCIDRFlag := range globalFlags.AWSSiteCIDRs {
+		parts := strings.SplitN(siteCIDRFlag, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return storage.State{}, fmt.Errorf("Invalid --aws-site-cidr %q. Site CIDRs must be specified as name:cidr.", siteCIDRFlag)
+		}
+
+		index := -1
+		for i, site := range state.AWS.Sites {
+			if site.Name == parts[0] {
+				index = i
+			}
+		}
+		if index == -1 {
+			return storage.State{}, fmt.Errorf("--aws-site-cidr %s names a site that was not added with --aws-site.", siteCIDRFlag)
+		}
+
+		site := &state.AWS.Sites[index]
+		if site.VPCCIDR != "" && site.VPCCIDR != parts[1] {
+			return storage.State{}, fmt.Errorf("The VPC CIDR of site %s cannot be changed for an existing environment. The current VPC CIDR is %s.", site.Name, site.VPCCIDR)
+		}
+		site.VPCCIDR = parts[1]
+	}
+
+	networkCIDR := state.Network.NetworkCIDR
+	if networkCIDR == "" {
+		networkCIDR = defaultAWSVPCCIDR
+	}
+	taken := []string{networkCIDR}
+	for _, site := range state.AWS.Sites {
+		if site.VPCCIDR != "" {
+			taken = append(taken, site.VPCCIDR)
+		}
+	}
+
+	for i, site := range state.AWS.Sites {
+		if site.VPCCIDR != "" {
+			continue
+		}
+
+		for n := 1; n < 256; n++ {
+			cidr := fmt.Sprintf("10.%d.0.0/16", n)
+			if overlaps, _ := overlapsAny(cidr, taken); !overlaps {
+				state.AWS.Sites[i].VPCCIDR = cidr
+				taken = append(taken, cidr)
+				break
+			}
+		}
+	}
+
+	for i, site := range state.AWS.Sites {
+		others := []string{networkCIDR}
+		for j, other := range state.AWS.Sites {
+			if i != j {
+				others = append(others, other.VPCCIDR)
+			}
+		}
+
+		overlaps, err := overlapsAny(site.VPCCIDR, others)
+		if err != nil {
+			return storage.State{}, fmt.Errorf("Invalid VPC CIDR for site %s: %s", site.Name, err)
+		}
+		if overlaps {
+			return storage.State{}, fmt.Errorf("The VPC CIDR %s of site %s overlaps the VPC of the director or of another site. Pass a different CIDR with --aws-site-cidr %s:<cidr>.", site.VPCCIDR, site.Name, site.Name)
+		}
+	}
+
+	return state, nil
+}
+
+const defaultAWSVPCCIDR = "10.0.0.0/16"
+
+func overlapsAny(cidr string, others []string) (bool, error) {
+	block, err := bosh.ParseCIDRBlock(cidr)
+	if err != nil {
+		return false, err
+	}
+
+	for _, other := range others {
+		otherBlock, err := bosh.ParseCIDRBlock(other)
+		if err != nil {
+			continue
+		}
+		if block.Overlaps(otherBlock) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c Config) updateAzureState(globalFlags globalFlags, state storage.State) (storage.State, error) {
 	copyFlagToState(globalFlags.AzureClientID, &state.Azure.ClientID)
 	copyFlagToState(globalFlags.AzureClientSecret, &state.Azure.ClientSecret)
@@ -142,6 +142,18 @@ var _ = Describe("LoadState", func() {
 				})
 			})
 
+			Context("when the command is doctor", func() {
+				It("does not load the state, so that doctor can report why it cannot be loaded", func() {
+					appConfig, err := c.Bootstrap([]string{"bbl", "doctor", "--state-dir", "some-state-dir"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.Command).To(Equal("doctor"))
+					Expect(appConfig.Global.StateDir).To(Equal(fullStateDirPath))
+					Expect(appConfig.State).To(Equal(storage.State{}))
+					Expect(fakeStateBootstrap.GetStateCall.CallCount).To(Equal(0))
+				})
+			})
+
 			Context("when debug flag is passed in through environment variable", func() {
 				BeforeEach(func() {
 					os.Setenv("BBL_DEBUG", "true")
@@ -688,6 +700,59 @@ var _ = Describe("LoadState", func() {
 						Expect(state.AWS.Region).To(Equal("some-region"))
 					})
 
+					Context("when sites are passed in", func() {
+						It("adds each site to the state with its own vpc cidr", func() {
+							appConfig, err := c.Bootstrap(append(args, "--aws-site", "west:us-west-2", "--aws-site", "east:us-east-1"))
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(appConfig.State.AWS.Sites).To(Equal([]storage.AWSSite{
+								{Name: "west", Region: "us-west-2", VPCCIDR: "10.1.0.0/16"},
+								{Name: "east", Region: "us-east-1", VPCCIDR: "10.2.0.0/16"},
+							}))
+						})
+
+						It("skips the vpc cidrs that overlap the network cidr", func() {
+							appConfig, err := c.Bootstrap(append(args, "--network-cidr", "10.1.0.0/16", "--aws-site", "west:us-west-2"))
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(appConfig.State.AWS.Sites).To(Equal([]storage.AWSSite{
+								{Name: "west", Region: "us-west-2", VPCCIDR: "10.2.0.0/16"},
+							}))
+						})
+
+						It("uses the vpc cidr passed for a site", func() {
+							appConfig, err := c.Bootstrap(append(args, "--aws-site", "west:us-west-2", "--aws-site", "east:us-east-1",
+								"--aws-site-cidr", "west:172.16.0.0/16"))
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(appConfig.State.AWS.Sites).To(Equal([]storage.AWSSite{
+								{Name: "west", Region: "us-west-2", VPCCIDR: "172.16.0.0/16"},
+								{Name: "east", Region: "us-east-1", VPCCIDR: "10.1.0.0/16"},
+							}))
+						})
+
+						DescribeTable("returns an error when a site is invalid",
+							func(site, expected string) {
+								_, err := c.Bootstrap([]string{"bbl", "--iaas", "aws", "--aws-site", site, "up"})
+								Expect(err).To(MatchError(expected))
+							},
+							Entry("missing region", "west", `Invalid --aws-site "west". Sites must be specified as name:region.`),
+							Entry("invalid name", "West-2:us-west-2", `Invalid site name "West-2". Site names must start with a lowercase letter and contain only lowercase letters, digits and underscores.`),
+						)
+
+						DescribeTable("returns an error when a site cidr is invalid",
+							func(extraArgs []string, expected interface{}) {
+								_, err := c.Bootstrap(append([]string{"bbl", "--iaas", "aws", "--aws-site", "west:us-west-2"}, append(extraArgs, "up")...))
+								Expect(err).To(MatchError(expected))
+							},
+							Entry("missing cidr", []string{"--aws-site-cidr", "west"}, `Invalid --aws-site-cidr "west". Site CIDRs must be specified as name:cidr.`),
+							Entry("unknown site", []string{"--aws-site-cidr", "east:10.5.0.0/16"}, "--aws-site-cidr east:10.5.0.0/16 names a site that was not added with --aws-site."),
+							Entry("overlapping the network cidr", []string{"--network-cidr", "10.8.0.0/16", "--aws-site-cidr", "west:10.8.128.0/17"},
+								"The VPC CIDR 10.8.128.0/17 of site west overlaps the VPC of the director or of another site. Pass a different CIDR with --aws-site-cidr west:<cidr>."),
+							Entry("not a cidr", []string{"--aws-site-cidr", "west:banana"}, ContainSubstring("Invalid VPC CIDR for site west:")),
+						)
+					})
+
 					It("returns the remaining arguments", func() {
 						appConfig, err := c.Bootstrap(args)
 						Expect(err).NotTo(HaveOccurred())
@@ -709,6 +774,7 @@ var _ = Describe("LoadState", func() {
 						os.Setenv("BBL_AWS_ACCESS_KEY_ID", "some-access-key-id")
 						os.Setenv("BBL_AWS_SECRET_ACCESS_KEY", "some-secret-key")
 						os.Setenv("BBL_AWS_REGION", "some-region")
+						os.Setenv("BBL_AWS_SITES", "west:us-west-2,east:us-east-1")
 					})
 
 					It("returns a state object containing configuration flags", func() {
@@ -720,6 +786,10 @@ var _ = Describe("LoadState", func() {
 						Expect(state.AWS.AccessKeyID).To(Equal("some-access-key-id"))
 						Expect(state.AWS.SecretAccessKey).To(Equal("some-secret-key"))
 						Expect(state.AWS.Region).To(Equal("some-region"))
+						Expect(state.AWS.Sites).To(Equal([]storage.AWSSite{
+							{Name: "west", Region: "us-west-2", VPCCIDR: "10.1.0.0/16"},
+							{Name: "east", Region: "us-east-1", VPCCIDR: "10.2.0.0/16"},
+						}))
 					})
 
 					It("returns the command", func() {
@@ -739,6 +809,9 @@ var _ = Describe("LoadState", func() {
 							AccessKeyID:     "some-access-key-id",
 							SecretAccessKey: "some-secret-access-key",
 							Region:          "some-region",
+							Sites: []storage.AWSSite{
+								{Name: "west", Region: "us-west-2", VPCCIDR: "10.1.0.0/16"},
+							},
 						},
 						EnvID: "some-env-id",
 					}
@@ -759,6 +832,22 @@ var _ = Describe("LoadState", func() {
 					})
 				})
 
+				Context("when a new site is passed in", func() {
+					It("keeps existing sites and assigns the next vpc cidr", func() {
+						appConfig, err := c.Bootstrap([]string{
+							"bbl", "up",
+							"--aws-site", "west:us-west-2",
+							"--aws-site", "east:us-east-1",
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(appConfig.State.AWS.Sites).To(Equal([]storage.AWSSite{
+							{Name: "west", Region: "us-west-2", VPCCIDR: "10.1.0.0/16"},
+							{Name: "east", Region: "us-east-1", VPCCIDR: "10.2.0.0/16"},
+						}))
+					})
+				})
+
 				DescribeTable("when non-matching configuration is passed in",
 					func(args []string, expected string) {
 						_, err := c.Bootstrap(args)
@@ -769,6 +858,10 @@ var _ = Describe("LoadState", func() {
 						"The iaas type cannot be changed for an existing environment. The current iaas type is aws."),
 					Entry("returns an error for non-matching region", []string{"bbl", "up", "--aws-region", "some-other-region"},
 						"The region cannot be changed for an existing environment. The current region is some-region."),
+					Entry("returns an error for non-matching site region", []string{"bbl", "up", "--aws-site", "west:eu-west-1"},
+						"The region of site west cannot be changed for an existing environment. The current region is us-west-2."),
+					Entry("returns an error for non-matching site cidr", []string{"bbl", "up", "--aws-site-cidr", "west:10.9.0.0/16"},
+						"The VPC CIDR of site west cannot be changed for an existing environment. The current VPC CIDR is 10.1.0.0/16."),
 				)
 			})
 		})
@@ -1165,6 +1258,239 @@ var _ = Describe("LoadState", func() {
 				)
 			})
 		})
+
+		Describe("deployment sources", func() {
+			BeforeEach(func() {
+				fakeFileIO.StatCall.Returns.FileInfo = fakes.FileInfo{IsDirectory: true}
+			})
+
+			It("records the absolute paths of the bosh and jumpbox deployment checkouts", func() {
+				appConfig, err := c.Bootstrap([]string{
+					"bbl", "plan",
+					"--bosh-deployment-dir", "/some/bosh-deployment",
+					"--jumpbox-deployment-dir", "/some/jumpbox-deployment",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.BOSHDeployment).To(Equal(storage.DeploymentSource{Path: "/some/bosh-deployment"}))
+				Expect(appConfig.State.JumpboxDeployment).To(Equal(storage.DeploymentSource{Path: "/some/jumpbox-deployment"}))
+				Expect(fakeFileIO.StatCall.Receives.Name).To(Equal("/some/jumpbox-deployment"))
+			})
+
+			It("reads the checkouts from environment variables", func() {
+				os.Setenv("BBL_BOSH_DEPLOYMENT_DIR", "/some/bosh-deployment")
+
+				appConfig, err := c.Bootstrap([]string{"bbl", "plan"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.BOSHDeployment.Path).To(Equal("/some/bosh-deployment"))
+			})
+
+			It("keeps the recorded commit when the path does not change", func() {
+				fakeStateMigrator.MigrateCall.Returns.State = storage.State{
+					BOSHDeployment: storage.DeploymentSource{Path: "/some/bosh-deployment", Commit: "some-commit"},
+				}
+
+				appConfig, err := c.Bootstrap([]string{"bbl", "plan", "--bosh-deployment-dir", "/some/bosh-deployment"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.BOSHDeployment.Commit).To(Equal("some-commit"))
+			})
+
+			It("keeps the recorded checkout when no flag is passed", func() {
+				fakeStateMigrator.MigrateCall.Returns.State = storage.State{
+					JumpboxDeployment: storage.DeploymentSource{Path: "/some/jumpbox-deployment", Commit: "some-commit"},
+				}
+
+				appConfig, err := c.Bootstrap([]string{"bbl", "plan"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.JumpboxDeployment.Path).To(Equal("/some/jumpbox-deployment"))
+				Expect(fakeFileIO.StatCall.CallCount).To(Equal(0))
+			})
+
+			DescribeTable("when the checkout is not a directory",
+				func(statError error, fileInfo fakes.FileInfo) {
+					fakeFileIO.StatCall.Returns.Error = statError
+					fakeFileIO.StatCall.Returns.FileInfo = fileInfo
+
+					_, err := c.Bootstrap([]string{"bbl", "plan", "--bosh-deployment-dir", "/some/bosh-deployment"})
+					Expect(err).To(MatchError("--bosh-deployment-dir /some/bosh-deployment is not a directory."))
+				},
+				Entry("returns an error when it does not exist", errors.New("no such file"), fakes.FileInfo{}),
+				Entry("returns an error when it is a file", nil, fakes.FileInfo{IsDirectory: false}),
+			)
+		})
+
+		Describe("arguments after a double dash", func() {
+			It("passes them to the subcommand without parsing global flags", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "ssh", "--jumpbox", "--", "tail", "-n", "5", "--debug"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.Command).To(Equal("ssh"))
+				Expect(appConfig.Global.Debug).To(BeFalse())
+				Expect(appConfig.SubcommandFlags).To(Equal(application.StringSlice{"--jumpbox", "--", "tail", "-n", "5", "--debug"}))
+			})
+		})
+
+		Describe("--output", func() {
+			It("records the output format", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--output", "json", "env-id"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.Global.Output).To(Equal("json"))
+			})
+
+			It("records the output format for version", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "version", "--output", "yaml"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.Command).To(Equal("version"))
+				Expect(appConfig.Global.Output).To(Equal("yaml"))
+			})
+
+			It("rejects unknown formats", func() {
+				_, err := c.Bootstrap([]string{"bbl", "--output", "xml", "env-id"})
+				Expect(err).To(MatchError(`Unknown output format "xml". Use json, yaml or text.`))
+			})
+		})
+
+		Describe("--log-format", func() {
+			It("rejects unknown formats", func() {
+				_, err := c.Bootstrap([]string{"bbl", "--log-format", "xml", "env-id"})
+				Expect(err).To(MatchError(`Unknown log format "xml". Use json or text.`))
+			})
+
+			It("returns the log format with the errors of other flags, so they can be logged as events", func() {
+				globals, _, err := config.ParseArgs([]string{"bbl", "--log-format", "json", "--output", "xml", "env-id"})
+				Expect(err).To(MatchError(`Unknown output format "xml". Use json, yaml or text.`))
+				Expect(globals.LogFormat).To(Equal("json"))
+			})
+		})
+
+		Describe("network layout", func() {
+			It("records the cidrs and ip offsets", func() {
+				appConfig, err := c.Bootstrap([]string{
+					"bbl", "--iaas", "gcp", "plan",
+					"--network-cidr", "172.16.0.0/16",
+					"--internal-cidr", "172.16.0.0/24",
+					"--jumpbox-ip-offset", "10",
+					"--director-ip-offset", "11",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.Network).To(Equal(storage.Network{
+					NetworkCIDR:      "172.16.0.0/16",
+					InternalCIDR:     "172.16.0.0/24",
+					JumpboxIPOffset:  10,
+					DirectorIPOffset: 11,
+				}))
+			})
+
+			It("reads the network cidr from the environment", func() {
+				os.Setenv("BBL_NETWORK_CIDR", "172.16.0.0/16")
+
+				appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "aws", "plan"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.Network.NetworkCIDR).To(Equal("172.16.0.0/16"))
+			})
+
+			It("uses the internal cidr as the openstack internal cidr", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "openstack", "plan", "--internal-cidr", "192.168.10.0/24"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.OpenStack.InternalCidr).To(Equal("192.168.10.0/24"))
+				Expect(appConfig.State.Network.InternalCIDR).To(BeEmpty())
+			})
+
+			It("uses the internal cidr as the vsphere subnet", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "vsphere", "plan", "--internal-cidr", "192.168.10.0/24"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.VSphere.Subnet).To(Equal("192.168.10.0/24"))
+			})
+
+			It("records a dual stack network", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "aws", "plan", "--dual-stack"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.Network.DualStack).To(BeTrue())
+			})
+
+			It("records a dual stack network on gcp", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "gcp", "plan", "--dual-stack"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.Network.DualStack).To(BeTrue())
+			})
+
+			It("records the allowed cidrs", func() {
+				appConfig, err := c.Bootstrap([]string{
+					"bbl", "--iaas", "gcp", "up",
+					"--allowed-cidrs", "203.0.113.0/24",
+					"--allowed-cidrs", "198.51.100.7/32",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.Network.AllowedCIDRs).To(Equal([]string{"203.0.113.0/24", "198.51.100.7/32"}))
+			})
+
+			It("replaces the allowed cidrs of an existing environment", func() {
+				fakeStateMigrator.MigrateCall.Returns.State = storage.State{
+					IAAS:    "aws",
+					Network: storage.Network{AllowedCIDRs: []string{"203.0.113.0/24"}},
+				}
+				os.Setenv("BBL_ALLOWED_CIDRS", "198.51.100.0/24,192.0.2.0/24")
+
+				appConfig, err := c.Bootstrap([]string{"bbl", "plan"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.Network.AllowedCIDRs).To(Equal([]string{"198.51.100.0/24", "192.0.2.0/24"}))
+			})
+
+			Context("failure cases", func() {
+				It("rejects a dual stack network on iaases without ipv6 support", func() {
+					_, err := c.Bootstrap([]string{"bbl", "--iaas", "azure", "plan", "--dual-stack"})
+					Expect(err).To(MatchError("--dual-stack is not supported on azure."))
+				})
+
+				It("rejects allowed cidrs on iaases where bbl does not create firewall rules", func() {
+					_, err := c.Bootstrap([]string{"bbl", "--iaas", "openstack", "plan", "--allowed-cidrs", "203.0.113.0/24"})
+					Expect(err).To(MatchError("--allowed-cidrs is not supported on openstack."))
+				})
+
+				It("rejects a network cidr on iaases where bbl does not create the network", func() {
+					_, err := c.Bootstrap([]string{"bbl", "--iaas", "vsphere", "plan", "--network-cidr", "172.16.0.0/16"})
+					Expect(err).To(MatchError("--network-cidr is not supported on vsphere."))
+				})
+
+				It("rejects a change to the network cidr of an existing environment", func() {
+					fakeStateMigrator.MigrateCall.Returns.State = storage.State{
+						IAAS:    "aws",
+						Network: storage.Network{NetworkCIDR: "172.16.0.0/16"},
+					}
+
+					_, err := c.Bootstrap([]string{"bbl", "plan", "--network-cidr", "172.17.0.0/16"})
+					Expect(err).To(MatchError("The network CIDR cannot be changed for an existing environment. The current network CIDR is 172.16.0.0/16."))
+				})
+
+				It("rejects a change to the internal cidr of an existing environment", func() {
+					fakeStateMigrator.MigrateCall.Returns.State = storage.State{
+						IAAS:    "aws",
+						Network: storage.Network{InternalCIDR: "10.0.0.0/24"},
+					}
+
+					_, err := c.Bootstrap([]string{"bbl", "plan", "--internal-cidr", "10.0.1.0/24"})
+					Expect(err).To(MatchError("The internal CIDR cannot be changed for an existing environment. The current internal CIDR is 10.0.0.0/24."))
+				})
+
+				It("validates the resulting layout", func() {
+					_, err := c.Bootstrap([]string{"bbl", "--iaas", "aws", "plan", "--network-cidr", "172.16.0.0/16", "--internal-cidr", "10.0.0.0/24"})
+					Expect(err).To(MatchError("The internal CIDR 10.0.0.0/24 is not within the network CIDR 172.16.0.0/16."))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateIAAS", func() {
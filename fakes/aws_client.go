@@ -2,6 +2,7 @@ package fakes
 
 type AWSClient struct {
 	RetrieveAZsCall struct {
+		Stub     func(string) ([]string, error)
 		Receives struct {
 			Region string
 		}
@@ -25,6 +26,9 @@ type AWSClient struct {
 func (a *AWSClient) RetrieveAZs(region string) ([]string, error) {
 	a.RetrieveAZsCall.Receives.Region = region
 	a.RetrieveAZsCall.CallCount++
+	if a.RetrieveAZsCall.Stub != nil {
+		return a.RetrieveAZsCall.Stub(region)
+	}
 	return a.RetrieveAZsCall.Returns.AZs, a.RetrieveAZsCall.Returns.Error
 }
 
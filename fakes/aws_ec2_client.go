@@ -34,6 +34,61 @@ type AWSEC2Client struct {
 			Error  error
 		}
 	}
+
+	CreateVpcCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awsec2.CreateVpcInput
+		}
+		Returns struct {
+			Output *awsec2.CreateVpcOutput
+			Error  error
+		}
+	}
+
+	CreateInternetGatewayCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awsec2.CreateInternetGatewayInput
+		}
+		Returns struct {
+			Output *awsec2.CreateInternetGatewayOutput
+			Error  error
+		}
+	}
+
+	AllocateAddressCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awsec2.AllocateAddressInput
+		}
+		Returns struct {
+			Output *awsec2.AllocateAddressOutput
+			Error  error
+		}
+	}
+
+	DescribeAddressesCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awsec2.DescribeAddressesInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeAddressesOutput
+			Error  error
+		}
+	}
+
+	DescribeAccountAttributesCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awsec2.DescribeAccountAttributesInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeAccountAttributesOutput
+			Error  error
+		}
+	}
 }
 
 func (c *AWSEC2Client) DescribeAvailabilityZones(input *awsec2.DescribeAvailabilityZonesInput) (*awsec2.DescribeAvailabilityZonesOutput, error) {
@@ -53,3 +108,38 @@ func (c *AWSEC2Client) DescribeVpcs(input *awsec2.DescribeVpcsInput) (*awsec2.De
 
 	return c.DescribeVpcsCall.Returns.Output, c.DescribeVpcsCall.Returns.Error
 }
+
+func (c *AWSEC2Client) CreateVpc(input *awsec2.CreateVpcInput) (*awsec2.CreateVpcOutput, error) {
+	c.CreateVpcCall.CallCount++
+	c.CreateVpcCall.Receives.Input = input
+
+	return c.CreateVpcCall.Returns.Output, c.CreateVpcCall.Returns.Error
+}
+
+func (c *AWSEC2Client) CreateInternetGateway(input *awsec2.CreateInternetGatewayInput) (*awsec2.CreateInternetGatewayOutput, error) {
+	c.CreateInternetGatewayCall.CallCount++
+	c.CreateInternetGatewayCall.Receives.Input = input
+
+	return c.CreateInternetGatewayCall.Returns.Output, c.CreateInternetGatewayCall.Returns.Error
+}
+
+func (c *AWSEC2Client) AllocateAddress(input *awsec2.AllocateAddressInput) (*awsec2.AllocateAddressOutput, error) {
+	c.AllocateAddressCall.CallCount++
+	c.AllocateAddressCall.Receives.Input = input
+
+	return c.AllocateAddressCall.Returns.Output, c.AllocateAddressCall.Returns.Error
+}
+
+func (c *AWSEC2Client) DescribeAddresses(input *awsec2.DescribeAddressesInput) (*awsec2.DescribeAddressesOutput, error) {
+	c.DescribeAddressesCall.CallCount++
+	c.DescribeAddressesCall.Receives.Input = input
+
+	return c.DescribeAddressesCall.Returns.Output, c.DescribeAddressesCall.Returns.Error
+}
+
+func (c *AWSEC2Client) DescribeAccountAttributes(input *awsec2.DescribeAccountAttributesInput) (*awsec2.DescribeAccountAttributesOutput, error) {
+	c.DescribeAccountAttributesCall.CallCount++
+	c.DescribeAccountAttributesCall.Receives.Input = input
+
+	return c.DescribeAccountAttributesCall.Returns.Output, c.DescribeAccountAttributesCall.Returns.Error
+}
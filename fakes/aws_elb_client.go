@@ -0,0 +1,82 @@
+package fakes
+
+import (
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+type AWSELBClient struct {
+	DescribeAccountLimitsCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awselb.DescribeAccountLimitsInput
+		}
+		Returns struct {
+			Output *awselb.DescribeAccountLimitsOutput
+			Error  error
+		}
+	}
+
+	DescribeLoadBalancersCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awselb.DescribeLoadBalancersInput
+		}
+		Returns struct {
+			Output *awselb.DescribeLoadBalancersOutput
+			Error  error
+		}
+	}
+}
+
+func (c *AWSELBClient) DescribeAccountLimits(input *awselb.DescribeAccountLimitsInput) (*awselb.DescribeAccountLimitsOutput, error) {
+	c.DescribeAccountLimitsCall.CallCount++
+	c.DescribeAccountLimitsCall.Receives.Input = input
+
+	return c.DescribeAccountLimitsCall.Returns.Output, c.DescribeAccountLimitsCall.Returns.Error
+}
+
+func (c *AWSELBClient) DescribeLoadBalancers(input *awselb.DescribeLoadBalancersInput) (*awselb.DescribeLoadBalancersOutput, error) {
+	c.DescribeLoadBalancersCall.CallCount++
+	c.DescribeLoadBalancersCall.Receives.Input = input
+
+	return c.DescribeLoadBalancersCall.Returns.Output, c.DescribeLoadBalancersCall.Returns.Error
+}
+
+type AWSELBV2Client struct {
+	DescribeAccountLimitsCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awselbv2.DescribeAccountLimitsInput
+		}
+		Returns struct {
+			Output *awselbv2.DescribeAccountLimitsOutput
+			Error  error
+		}
+	}
+
+	DescribeLoadBalancersCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awselbv2.DescribeLoadBalancersInput
+		}
+		Returns struct {
+			Output *awselbv2.DescribeLoadBalancersOutput
+			Error  error
+		}
+	}
+}
+
+func (c *AWSELBV2Client) DescribeAccountLimits(input *awselbv2.DescribeAccountLimitsInput) (*awselbv2.DescribeAccountLimitsOutput, error) {
+	c.DescribeAccountLimitsCall.CallCount++
+	c.DescribeAccountLimitsCall.Receives.Input = input
+
+	return c.DescribeAccountLimitsCall.Returns.Output, c.DescribeAccountLimitsCall.Returns.Error
+}
+
+func (c *AWSELBV2Client) DescribeLoadBalancers(input *awselbv2.DescribeLoadBalancersInput) (*awselbv2.DescribeLoadBalancersOutput, error) {
+	c.DescribeLoadBalancersCall.CallCount++
+	c.DescribeLoadBalancersCall.Receives.Input = input
+
+	return c.DescribeLoadBalancersCall.Returns.Output, c.DescribeLoadBalancersCall.Returns.Error
+}
@@ -0,0 +1,23 @@
+package fakes
+
+import awsiam "github.com/aws/aws-sdk-go/service/iam"
+
+type AWSIAMClient struct {
+	SimulatePrincipalPolicyCall struct {
+		CallCount int
+		Receives  struct {
+			Input *awsiam.SimulatePrincipalPolicyInput
+		}
+		Returns struct {
+			Output *awsiam.SimulatePolicyResponse
+			Error  error
+		}
+	}
+}
+
+func (c *AWSIAMClient) SimulatePrincipalPolicy(input *awsiam.SimulatePrincipalPolicyInput) (*awsiam.SimulatePolicyResponse, error) {
+	c.SimulatePrincipalPolicyCall.CallCount++
+	c.SimulatePrincipalPolicyCall.Receives.Input = input
+
+	return c.SimulatePrincipalPolicyCall.Returns.Output, c.SimulatePrincipalPolicyCall.Returns.Error
+}
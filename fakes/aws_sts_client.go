@@ -0,0 +1,19 @@
+package fakes
+
+import awssts "github.com/aws/aws-sdk-go/service/sts"
+
+type AWSSTSClient struct {
+	GetCallerIdentityCall struct {
+		CallCount int
+		Returns   struct {
+			Output *awssts.GetCallerIdentityOutput
+			Error  error
+		}
+	}
+}
+
+func (c *AWSSTSClient) GetCallerIdentity(input *awssts.GetCallerIdentityInput) (*awssts.GetCallerIdentityOutput, error) {
+	c.GetCallerIdentityCall.CallCount++
+
+	return c.GetCallerIdentityCall.Returns.Output, c.GetCallerIdentityCall.Returns.Error
+}
@@ -0,0 +1,18 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/azure"
+
+type AzurePermissionsClient struct {
+	ListForSubscriptionCall struct {
+		CallCount int
+		Returns   struct {
+			Permissions []azure.Permission
+			Error       error
+		}
+	}
+}
+
+func (a *AzurePermissionsClient) ListForSubscription() ([]azure.Permission, error) {
+	a.ListForSubscriptionCall.CallCount++
+	return a.ListForSubscriptionCall.Returns.Permissions, a.ListForSubscriptionCall.Returns.Error
+}
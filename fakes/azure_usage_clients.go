@@ -0,0 +1,44 @@
+package fakes
+
+import (
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+)
+
+type AzureComputeUsageClient struct {
+	ListCall struct {
+		CallCount int
+		Receives  struct {
+			Location string
+		}
+		Returns struct {
+			Result compute.ListUsagesResult
+			Error  error
+		}
+	}
+}
+
+func (a *AzureComputeUsageClient) List(location string) (compute.ListUsagesResult, error) {
+	a.ListCall.CallCount++
+	a.ListCall.Receives.Location = location
+	return a.ListCall.Returns.Result, a.ListCall.Returns.Error
+}
+
+type AzureNetworkUsagesClient struct {
+	ListCall struct {
+		CallCount int
+		Receives  struct {
+			Location string
+		}
+		Returns struct {
+			Result network.UsagesListResult
+			Error  error
+		}
+	}
+}
+
+func (a *AzureNetworkUsagesClient) List(location string) (network.UsagesListResult, error) {
+	a.ListCall.CallCount++
+	a.ListCall.Receives.Location = location
+	return a.ListCall.Returns.Result, a.ListCall.Returns.Error
+}
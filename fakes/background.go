@@ -0,0 +1,41 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/helpers"
+
+type Background struct {
+	StartCall struct {
+		CallCount int
+		Receives  struct {
+			Args    []string
+			LogPath string
+		}
+		Returns struct {
+			Process helpers.BackgroundProcess
+			Error   error
+		}
+	}
+	RunningCall struct {
+		CallCount int
+		Receives  struct {
+			Pid int
+		}
+		Returns struct {
+			Running bool
+		}
+	}
+}
+
+func (b *Background) Start(args []string, logPath string) (helpers.BackgroundProcess, error) {
+	b.StartCall.CallCount++
+	b.StartCall.Receives.Args = args
+	b.StartCall.Receives.LogPath = logPath
+
+	return b.StartCall.Returns.Process, b.StartCall.Returns.Error
+}
+
+func (b *Background) Running(pid int) bool {
+	b.RunningCall.CallCount++
+	b.RunningCall.Receives.Pid = pid
+
+	return b.RunningCall.Returns.Running
+}
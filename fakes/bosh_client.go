@@ -16,6 +16,33 @@ type BOSHClient struct {
 		}
 	}
 
+	UpdateConfigCall struct {
+		CallCount int
+		Receives  []UpdateConfigReceive
+		Returns   struct {
+			Error error
+		}
+	}
+
+	DeleteConfigCall struct {
+		CallCount int
+		Receives  []DeleteConfigReceive
+		Returns   struct {
+			Error error
+		}
+	}
+
+	ConfigsCall struct {
+		CallCount int
+		Receives  struct {
+			ConfigType string
+		}
+		Returns struct {
+			Configs []bosh.Config
+			Error   error
+		}
+	}
+
 	ConfigureHTTPClientCall struct {
 		CallCount int
 		Receives  struct {
@@ -30,6 +57,38 @@ type BOSHClient struct {
 			Error error
 		}
 	}
+
+	DeploymentsCall struct {
+		CallCount int
+		Returns   struct {
+			Deployments []string
+			Error       error
+		}
+	}
+
+	DeleteDeploymentCall struct {
+		CallCount int
+		Receives  []string
+		Returns   struct {
+			Error error
+		}
+	}
+
+	OrphanedDisksCall struct {
+		CallCount int
+		Returns   struct {
+			CIDs  []string
+			Error error
+		}
+	}
+
+	DeleteOrphanedDiskCall struct {
+		CallCount int
+		Receives  []string
+		Returns   struct {
+			Error error
+		}
+	}
 }
 
 func (c *BOSHClient) UpdateCloudConfig(yaml []byte) error {
@@ -38,6 +97,42 @@ func (c *BOSHClient) UpdateCloudConfig(yaml []byte) error {
 	return c.UpdateCloudConfigCall.Returns.Error
 }
 
+type UpdateConfigReceive struct {
+	ConfigType string
+	Name       string
+	Yaml       []byte
+}
+
+type DeleteConfigReceive struct {
+	ConfigType string
+	Name       string
+}
+
+func (c *BOSHClient) UpdateConfig(configType, name string, yaml []byte) error {
+	c.UpdateConfigCall.CallCount++
+	c.UpdateConfigCall.Receives = append(c.UpdateConfigCall.Receives, UpdateConfigReceive{
+		ConfigType: configType,
+		Name:       name,
+		Yaml:       yaml,
+	})
+	return c.UpdateConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) DeleteConfig(configType, name string) error {
+	c.DeleteConfigCall.CallCount++
+	c.DeleteConfigCall.Receives = append(c.DeleteConfigCall.Receives, DeleteConfigReceive{
+		ConfigType: configType,
+		Name:       name,
+	})
+	return c.DeleteConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) Configs(configType string) ([]bosh.Config, error) {
+	c.ConfigsCall.CallCount++
+	c.ConfigsCall.Receives.ConfigType = configType
+	return c.ConfigsCall.Returns.Configs, c.ConfigsCall.Returns.Error
+}
+
 func (c *BOSHClient) ConfigureHTTPClient(socks5Client proxy.Dialer) {
 	c.ConfigureHTTPClientCall.CallCount++
 	c.ConfigureHTTPClientCall.Receives.Socks5Client = socks5Client
@@ -47,3 +142,25 @@ func (c *BOSHClient) Info() (bosh.Info, error) {
 	c.InfoCall.CallCount++
 	return c.InfoCall.Returns.Info, c.InfoCall.Returns.Error
 }
+
+func (c *BOSHClient) Deployments() ([]string, error) {
+	c.DeploymentsCall.CallCount++
+	return c.DeploymentsCall.Returns.Deployments, c.DeploymentsCall.Returns.Error
+}
+
+func (c *BOSHClient) DeleteDeployment(name string) error {
+	c.DeleteDeploymentCall.CallCount++
+	c.DeleteDeploymentCall.Receives = append(c.DeleteDeploymentCall.Receives, name)
+	return c.DeleteDeploymentCall.Returns.Error
+}
+
+func (c *BOSHClient) OrphanedDisks() ([]string, error) {
+	c.OrphanedDisksCall.CallCount++
+	return c.OrphanedDisksCall.Returns.CIDs, c.OrphanedDisksCall.Returns.Error
+}
+
+func (c *BOSHClient) DeleteOrphanedDisk(cid string) error {
+	c.DeleteOrphanedDiskCall.CallCount++
+	c.DeleteOrphanedDiskCall.Receives = append(c.DeleteOrphanedDiskCall.Receives, cid)
+	return c.DeleteOrphanedDiskCall.Returns.Error
+}
@@ -64,6 +64,27 @@ type BOSHExecutor struct {
 		}
 	}
 
+	InterpolateCall struct {
+		CallCount int
+		Receives  struct {
+			DirInput bosh.DirInput
+			State    storage.State
+			Redact   bool
+		}
+		Returns struct {
+			Manifest string
+			Error    error
+		}
+	}
+
+	SourceCommitCall struct {
+		CallCount int
+		Receives  []string
+		Returns   struct {
+			Commit string
+		}
+	}
+
 	PathCall struct {
 		CallCount int
 		Returns   struct {
@@ -122,6 +143,22 @@ func (e *BOSHExecutor) PlanDirector(input bosh.DirInput, deploymentDir, iaas str
 	return e.PlanDirectorCall.Returns.Error
 }
 
+func (e *BOSHExecutor) Interpolate(input bosh.DirInput, state storage.State, redact bool) (string, error) {
+	e.InterpolateCall.CallCount++
+	e.InterpolateCall.Receives.DirInput = input
+	e.InterpolateCall.Receives.State = state
+	e.InterpolateCall.Receives.Redact = redact
+
+	return e.InterpolateCall.Returns.Manifest, e.InterpolateCall.Returns.Error
+}
+
+func (e *BOSHExecutor) SourceCommit(dir string) string {
+	e.SourceCommitCall.CallCount++
+	e.SourceCommitCall.Receives = append(e.SourceCommitCall.Receives, dir)
+
+	return e.SourceCommitCall.Returns.Commit
+}
+
 func (e *BOSHExecutor) Path() string {
 	e.PathCall.CallCount++
 	return e.PathCall.Returns.Path
@@ -79,6 +79,37 @@ type BOSHManager struct {
 			Error error
 		}
 	}
+	RecordDeploymentSourcesCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			State storage.State
+		}
+	}
+	RenderJumpboxCall struct {
+		CallCount int
+		Receives  struct {
+			State  storage.State
+			Redact bool
+		}
+		Returns struct {
+			Manifest string
+			Error    error
+		}
+	}
+	RenderDirectorCall struct {
+		CallCount int
+		Receives  struct {
+			State  storage.State
+			Redact bool
+		}
+		Returns struct {
+			Manifest string
+			Error    error
+		}
+	}
 	GetDirectorDeploymentVarsCall struct {
 		CallCount int
 		Receives  struct {
@@ -141,6 +172,26 @@ func (b *BOSHManager) DeleteJumpbox(state storage.State, terraformOutputs terraf
 	return b.DeleteJumpboxCall.Returns.Error
 }
 
+func (b *BOSHManager) RecordDeploymentSources(state storage.State) storage.State {
+	b.RecordDeploymentSourcesCall.CallCount++
+	b.RecordDeploymentSourcesCall.Receives.State = state
+	return b.RecordDeploymentSourcesCall.Returns.State
+}
+
+func (b *BOSHManager) RenderJumpbox(state storage.State, redact bool) (string, error) {
+	b.RenderJumpboxCall.CallCount++
+	b.RenderJumpboxCall.Receives.State = state
+	b.RenderJumpboxCall.Receives.Redact = redact
+	return b.RenderJumpboxCall.Returns.Manifest, b.RenderJumpboxCall.Returns.Error
+}
+
+func (b *BOSHManager) RenderDirector(state storage.State, redact bool) (string, error) {
+	b.RenderDirectorCall.CallCount++
+	b.RenderDirectorCall.Receives.State = state
+	b.RenderDirectorCall.Receives.Redact = redact
+	return b.RenderDirectorCall.Returns.Manifest, b.RenderDirectorCall.Returns.Error
+}
+
 func (b *BOSHManager) GetDirectorDeploymentVars(state storage.State, terraformOutputs terraform.Outputs) string {
 	b.GetDirectorDeploymentVarsCall.CallCount++
 	b.GetDirectorDeploymentVarsCall.Receives.State = state
@@ -21,6 +21,15 @@ type CloudConfigOpsGenerator struct {
 			Error    error
 		}
 	}
+	GenerateCPIConfigCall struct {
+		Receives struct {
+			State storage.State
+		}
+		Returns struct {
+			CPIConfigYAML string
+			Error         error
+		}
+	}
 }
 
 func (c *CloudConfigOpsGenerator) Generate(state storage.State) (string, error) {
@@ -32,3 +41,8 @@ func (c *CloudConfigOpsGenerator) GenerateVars(state storage.State) (string, err
 	c.GenerateVarsCall.Receives.State = state
 	return c.GenerateVarsCall.Returns.VarsYAML, c.GenerateVarsCall.Returns.Error
 }
+
+func (c *CloudConfigOpsGenerator) GenerateCPIConfig(state storage.State) (string, error) {
+	c.GenerateCPIConfigCall.Receives.State = state
+	return c.GenerateCPIConfigCall.Returns.CPIConfigYAML, c.GenerateCPIConfigCall.Returns.Error
+}
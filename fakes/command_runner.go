@@ -0,0 +1,22 @@
+package fakes
+
+type CommandRunner struct {
+	RunCall struct {
+		CallCount int
+		Receives  struct {
+			Command []string
+			Env     []string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (c *CommandRunner) Run(command []string, env []string) error {
+	c.RunCall.CallCount++
+	c.RunCall.Receives.Command = command
+	c.RunCall.Receives.Env = env
+
+	return c.RunCall.Returns.Error
+}
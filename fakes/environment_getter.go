@@ -0,0 +1,29 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type EnvironmentGetter struct {
+	EnvironmentCall struct {
+		CallCount int
+		Receives  struct {
+			State     storage.State
+			UseTunnel bool
+		}
+		Returns struct {
+			EnvVars        []commands.EnvVar
+			PrivateKeyPath string
+			Error          error
+		}
+	}
+}
+
+func (e *EnvironmentGetter) Environment(state storage.State, useTunnel bool) ([]commands.EnvVar, string, error) {
+	e.EnvironmentCall.CallCount++
+	e.EnvironmentCall.Receives.State = state
+	e.EnvironmentCall.Receives.UseTunnel = useTunnel
+
+	return e.EnvironmentCall.Returns.EnvVars, e.EnvironmentCall.Returns.PrivateKeyPath, e.EnvironmentCall.Returns.Error
+}
@@ -6,7 +6,8 @@ import (
 )
 
 type FileInfo struct {
-	FileName string
+	FileName    string
+	IsDirectory bool
 }
 
 func (f FileInfo) Name() string {
@@ -22,7 +23,7 @@ func (f FileInfo) ModTime() time.Time {
 	return time.Now()
 }
 func (f FileInfo) IsDir() bool {
-	return false
+	return f.IsDirectory
 }
 func (f FileInfo) Sys() interface{} {
 	return nil
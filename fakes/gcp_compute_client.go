@@ -47,6 +47,16 @@ type GCPComputeClient struct {
 			Error  error
 		}
 	}
+	GetProjectCall struct {
+		CallCount int
+		Receives  struct {
+			ProjectID string
+		}
+		Returns struct {
+			Project *compute.Project
+			Error   error
+		}
+	}
 	GetNetworksCall struct {
 		CallCount int
 		Receives  struct {
@@ -88,6 +98,12 @@ func (g *GCPComputeClient) GetRegion(region, projectID string) (*compute.Region,
 	return g.GetRegionCall.Returns.Region, g.GetRegionCall.Returns.Error
 }
 
+func (g *GCPComputeClient) GetProject(projectID string) (*compute.Project, error) {
+	g.GetProjectCall.CallCount++
+	g.GetProjectCall.Receives.ProjectID = projectID
+	return g.GetProjectCall.Returns.Project, g.GetProjectCall.Returns.Error
+}
+
 func (g *GCPComputeClient) GetNetworks(name, projectID string) (*compute.NetworkList, error) {
 	g.GetNetworksCall.CallCount++
 	g.GetNetworksCall.Receives.Name = name
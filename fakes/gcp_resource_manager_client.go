@@ -0,0 +1,22 @@
+package fakes
+
+type GCPResourceManagerClient struct {
+	TestIamPermissionsCall struct {
+		CallCount int
+		Receives  struct {
+			ProjectID   string
+			Permissions []string
+		}
+		Returns struct {
+			Permissions []string
+			Error       error
+		}
+	}
+}
+
+func (g *GCPResourceManagerClient) TestIamPermissions(projectID string, permissions []string) ([]string, error) {
+	g.TestIamPermissionsCall.CallCount++
+	g.TestIamPermissionsCall.Receives.ProjectID = projectID
+	g.TestIamPermissionsCall.Receives.Permissions = permissions
+	return g.TestIamPermissionsCall.Returns.Permissions, g.TestIamPermissionsCall.Returns.Error
+}
@@ -0,0 +1,39 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type HooksRunReceive struct {
+	Hook    string
+	State   storage.State
+	Outputs map[string]interface{}
+}
+
+type Hooks struct {
+	RunCall struct {
+		CallCount int
+		Stub      func(hook string) error
+		Receives  []HooksRunReceive
+	}
+}
+
+func (h *Hooks) Run(hook string, state storage.State, outputs map[string]interface{}) error {
+	h.RunCall.CallCount++
+	h.RunCall.Receives = append(h.RunCall.Receives, HooksRunReceive{
+		Hook:    hook,
+		State:   state,
+		Outputs: outputs,
+	})
+
+	if h.RunCall.Stub != nil {
+		return h.RunCall.Stub(hook)
+	}
+	return nil
+}
+
+func (h *Hooks) Ran() []string {
+	var hooks []string
+	for _, receive := range h.RunCall.Receives {
+		hooks = append(hooks, receive.Hook)
+	}
+	return hooks
+}
@@ -0,0 +1,20 @@
+package fakes
+
+type KnownHosts struct {
+	ForgetCall struct {
+		CallCount int
+		Receives  struct {
+			Host string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (k *KnownHosts) Forget(host string) error {
+	k.ForgetCall.CallCount++
+	k.ForgetCall.Receives.Host = host
+
+	return k.ForgetCall.Returns.Error
+}
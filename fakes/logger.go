@@ -48,6 +48,22 @@ type Logger struct {
 			Proceed bool
 		}
 	}
+
+	ChildExitedCall struct {
+		CallCount int
+		Receives  struct {
+			Command  string
+			ExitCode int
+		}
+	}
+
+	SetPhaseCall struct {
+		CallCount int
+		Receives  struct {
+			Phase string
+		}
+		Phases []string
+	}
 }
 
 func (l *Logger) Step(message string, a ...interface{}) {
@@ -91,6 +107,19 @@ func (l *Logger) Prompt(message string) bool {
 	return l.PromptCall.Returns.Proceed
 }
 
+func (l *Logger) ChildExited(command string, exitCode int) {
+	l.ChildExitedCall.CallCount++
+	l.ChildExitedCall.Receives.Command = command
+	l.ChildExitedCall.Receives.ExitCode = exitCode
+}
+
+func (l *Logger) SetPhase(phase string) {
+	l.SetPhaseCall.CallCount++
+	l.SetPhaseCall.Receives.Phase = phase
+
+	l.SetPhaseCall.Phases = append(l.SetPhaseCall.Phases, phase)
+}
+
 func (l *Logger) PrintlnMessages() []string {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
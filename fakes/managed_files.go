@@ -0,0 +1,46 @@
+package fakes
+
+type ManagedFiles struct {
+	ModifiedCall struct {
+		CallCount int
+		Returns   struct {
+			Files []string
+			Error error
+		}
+	}
+	BackupCall struct {
+		CallCount int
+		Receives  struct {
+			Files []string
+		}
+		Returns struct {
+			Dir   string
+			Error error
+		}
+	}
+	RecordCall struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+	}
+}
+
+func (m *ManagedFiles) Modified() ([]string, error) {
+	m.ModifiedCall.CallCount++
+
+	return m.ModifiedCall.Returns.Files, m.ModifiedCall.Returns.Error
+}
+
+func (m *ManagedFiles) Backup(files []string) (string, error) {
+	m.BackupCall.CallCount++
+	m.BackupCall.Receives.Files = files
+
+	return m.BackupCall.Returns.Dir, m.BackupCall.Returns.Error
+}
+
+func (m *ManagedFiles) Record() error {
+	m.RecordCall.CallCount++
+
+	return m.RecordCall.Returns.Error
+}
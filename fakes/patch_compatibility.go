@@ -0,0 +1,22 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type PatchCompatibility struct {
+	CheckCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (p *PatchCompatibility) Check(state storage.State) error {
+	p.CheckCall.CallCount++
+	p.CheckCall.Receives.State = state
+
+	return p.CheckCall.Returns.Error
+}
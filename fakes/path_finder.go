@@ -3,17 +3,14 @@ package fakes
 type PathFinder struct {
 	CommandExistsCall struct {
 		CallCount int
-		Receives  struct {
-			Command string
-		}
-		Returns struct {
-			Exists bool
-		}
+		Receives  []string
+		Returns   map[string]bool
 	}
 }
 
 func (p *PathFinder) CommandExists(command string) bool {
 	p.CommandExistsCall.CallCount++
-	p.CommandExistsCall.Receives.Command = command
-	return p.CommandExistsCall.Returns.Exists
+	p.CommandExistsCall.Receives = append(p.CommandExistsCall.Receives, command)
+
+	return p.CommandExistsCall.Returns[command]
 }
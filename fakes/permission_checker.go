@@ -0,0 +1,23 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type PermissionChecker struct {
+	CheckPermissionsCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Missing []string
+			Error   error
+		}
+	}
+}
+
+func (p *PermissionChecker) CheckPermissions(state storage.State) ([]string, error) {
+	p.CheckPermissionsCall.CallCount++
+	p.CheckPermissionsCall.Receives.State = state
+
+	return p.CheckPermissionsCall.Returns.Missing, p.CheckPermissionsCall.Returns.Error
+}
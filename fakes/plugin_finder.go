@@ -0,0 +1,34 @@
+package fakes
+
+type PluginFinder struct {
+	FindCall struct {
+		CallCount int
+		Receives  struct {
+			Name string
+		}
+		Returns struct {
+			Path string
+			OK   bool
+		}
+	}
+
+	ListCall struct {
+		CallCount int
+		Returns   struct {
+			Names []string
+		}
+	}
+}
+
+func (p *PluginFinder) Find(name string) (string, bool) {
+	p.FindCall.CallCount++
+	p.FindCall.Receives.Name = name
+
+	return p.FindCall.Returns.Path, p.FindCall.Returns.OK
+}
+
+func (p *PluginFinder) List() []string {
+	p.ListCall.CallCount++
+
+	return p.ListCall.Returns.Names
+}
@@ -0,0 +1,36 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/commands"
+
+type Plugins struct {
+	CommandCall struct {
+		CallCount int
+		Receives  struct {
+			Name string
+		}
+		Returns struct {
+			Command commands.Command
+			OK      bool
+		}
+	}
+
+	ListCall struct {
+		CallCount int
+		Returns   struct {
+			Names []string
+		}
+	}
+}
+
+func (p *Plugins) Command(name string) (commands.Command, bool) {
+	p.CommandCall.CallCount++
+	p.CommandCall.Receives.Name = name
+
+	return p.CommandCall.Returns.Command, p.CommandCall.Returns.OK
+}
+
+func (p *Plugins) List() []string {
+	p.ListCall.CallCount++
+
+	return p.ListCall.Returns.Names
+}
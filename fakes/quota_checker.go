@@ -0,0 +1,23 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type QuotaChecker struct {
+	CheckQuotasCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Quotas []storage.Quota
+			Error  error
+		}
+	}
+}
+
+func (q *QuotaChecker) CheckQuotas(state storage.State) ([]storage.Quota, error) {
+	q.CheckQuotasCall.CallCount++
+	q.CheckQuotasCall.Receives.State = state
+
+	return q.CheckQuotasCall.Returns.Quotas, q.CheckQuotasCall.Returns.Error
+}
@@ -0,0 +1,23 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type RuntimeConfigManager struct {
+	UpdateCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (r *RuntimeConfigManager) Update(state storage.State) error {
+	r.UpdateCall.CallCount++
+	r.UpdateCall.Receives.State = state
+	return r.UpdateCall.Returns.Error
+}
@@ -0,0 +1,110 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/ssh"
+
+type SSHClient struct {
+	ShellCall struct {
+		CallCount int
+		Receives  struct {
+			Hops []ssh.Host
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	RunCall struct {
+		CallCount int
+		Receives  struct {
+			Hops    []ssh.Host
+			Command string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	UploadCall struct {
+		CallCount int
+		Receives  struct {
+			Hops       []ssh.Host
+			LocalPath  string
+			RemotePath string
+			Recursive  bool
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	TunnelCall struct {
+		CallCount int
+		Receives  struct {
+			Hops         []ssh.Host
+			SOCKSAddress string
+			Forwards     []ssh.Forward
+		}
+		Returns struct {
+			// Address is passed to the ready function unless Error is set.
+			Address string
+			Error   error
+		}
+	}
+	DownloadCall struct {
+		CallCount int
+		Receives  struct {
+			Hops       []ssh.Host
+			RemotePath string
+			LocalPath  string
+			Recursive  bool
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (s *SSHClient) Shell(hops []ssh.Host) error {
+	s.ShellCall.CallCount++
+	s.ShellCall.Receives.Hops = hops
+
+	return s.ShellCall.Returns.Error
+}
+
+func (s *SSHClient) Run(hops []ssh.Host, command string) error {
+	s.RunCall.CallCount++
+	s.RunCall.Receives.Hops = hops
+	s.RunCall.Receives.Command = command
+
+	return s.RunCall.Returns.Error
+}
+
+func (s *SSHClient) Upload(hops []ssh.Host, localPath, remotePath string, recursive bool) error {
+	s.UploadCall.CallCount++
+	s.UploadCall.Receives.Hops = hops
+	s.UploadCall.Receives.LocalPath = localPath
+	s.UploadCall.Receives.RemotePath = remotePath
+	s.UploadCall.Receives.Recursive = recursive
+
+	return s.UploadCall.Returns.Error
+}
+
+func (s *SSHClient) Download(hops []ssh.Host, remotePath, localPath string, recursive bool) error {
+	s.DownloadCall.CallCount++
+	s.DownloadCall.Receives.Hops = hops
+	s.DownloadCall.Receives.RemotePath = remotePath
+	s.DownloadCall.Receives.LocalPath = localPath
+	s.DownloadCall.Receives.Recursive = recursive
+
+	return s.DownloadCall.Returns.Error
+}
+
+func (s *SSHClient) Tunnel(hops []ssh.Host, socksAddress string, forwards []ssh.Forward, ready func(string) error, stop <-chan struct{}) error {
+	s.TunnelCall.CallCount++
+	s.TunnelCall.Receives.Hops = hops
+	s.TunnelCall.Receives.SOCKSAddress = socksAddress
+	s.TunnelCall.Receives.Forwards = forwards
+
+	if s.TunnelCall.Returns.Error != nil {
+		return s.TunnelCall.Returns.Error
+	}
+
+	return ready(s.TunnelCall.Returns.Address)
+}
@@ -28,6 +28,14 @@ type StateStore struct {
 		}
 	}
 
+	GetRuntimeConfigDirCall struct {
+		CallCount int
+		Returns   struct {
+			Directory string
+			Error     error
+		}
+	}
+
 	GetStateDirCall struct {
 		CallCount int
 		Returns   struct {
@@ -102,6 +110,12 @@ func (s *StateStore) GetCloudConfigDir() (string, error) {
 	return s.GetCloudConfigDirCall.Returns.Directory, s.GetCloudConfigDirCall.Returns.Error
 }
 
+func (s *StateStore) GetRuntimeConfigDir() (string, error) {
+	s.GetRuntimeConfigDirCall.CallCount++
+
+	return s.GetRuntimeConfigDirCall.Returns.Directory, s.GetRuntimeConfigDirCall.Returns.Error
+}
+
 func (s *StateStore) GetStateDir() string {
 	s.GetStateDirCall.CallCount++
 
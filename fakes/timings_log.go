@@ -0,0 +1,22 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type TimingsLog struct {
+	AppendCall struct {
+		CallCount int
+		Receives  struct {
+			Run storage.Run
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (t *TimingsLog) Append(run storage.Run) error {
+	t.AppendCall.CallCount++
+	t.AppendCall.Receives.Run = run
+
+	return t.AppendCall.Returns.Error
+}
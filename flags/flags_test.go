@@ -11,12 +11,15 @@ var _ = Describe("Flags", func() {
 	var (
 		f         flags.Flags
 		stringVal string
+		sliceVal  []string
 		boolVal   bool
 	)
 
 	BeforeEach(func() {
+		sliceVal = nil
 		f = flags.New("test")
 		f.String(&stringVal, "string", "")
+		f.StringSlice(&sliceVal, "slice")
 		f.Bool(&boolVal, "bool")
 	})
 
@@ -27,6 +30,12 @@ var _ = Describe("Flags", func() {
 			Expect(stringVal).To(Equal("string_value"))
 		})
 
+		It("can parse repeated string flags", func() {
+			err := f.Parse([]string{"--slice", "first", "--slice", "second"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sliceVal).To(Equal([]string{"first", "second"}))
+		})
+
 		It("can parse boolean flags", func() {
 			err := f.Parse([]string{"--bool"})
 			Expect(err).NotTo(HaveOccurred())
@@ -2,15 +2,17 @@ package gcp
 
 import (
 	"fmt"
-	"strings"
+	"strconv"
 
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
 	compute "google.golang.org/api/compute/v1"
 )
 
 type Client struct {
-	computeClient ComputeClient
-	projectID     string
-	zone          string
+	computeClient         ComputeClient
+	resourceManagerClient ResourceManagerClient
+	projectID             string
+	zone                  string
 }
 
 type ComputeClient interface {
@@ -18,6 +20,7 @@ type ComputeClient interface {
 	GetZones(region, projectID string) ([]string, error)
 	GetZone(zone, projectID string) (*compute.Zone, error)
 	GetRegion(region, projectID string) (*compute.Region, error)
+	GetProject(projectID string) (*compute.Project, error)
 	GetNetworks(name, projectID string) (*compute.NetworkList, error)
 }
 
@@ -64,38 +67,43 @@ func (c Client) ValidateSafeToDelete(networkName string, envID string) error {
 		return err
 	}
 
-	var runningInstances []*compute.Instance
+	vms := []helpers.VM{}
 	for _, instance := range instanceList.Items {
 		isInNetwork := c.isInNetwork(networkName, instance.NetworkInterfaces)
 		isBoshDirector := c.isBoshDirector(instance.Metadata)
 
 		if isInNetwork && !isBoshDirector {
-			runningInstances = append(runningInstances, instance)
+			vms = append(vms, c.vm(instance))
 		}
 	}
 
-	if len(runningInstances) == 0 {
+	if len(vms) == 0 {
 		return nil
 	}
 
-	var errorMessages []string
-	for _, instance := range runningInstances {
-		var hasDeployment bool
-		for _, item := range instance.Metadata.Items {
-			if item.Key == "deployment" {
-				errorMessages = append(errorMessages, fmt.Sprintf("%s (deployment: %s)", instance.Name, *item.Value))
-				hasDeployment = true
-				break
-			}
+	return helpers.VMsExistError{Network: fmt.Sprintf("network %s", networkName), VMs: vms}
+}
+
+func (c Client) vm(instance *compute.Instance) helpers.VM {
+	vm := helpers.VM{
+		Name: instance.Name,
+		ID:   strconv.FormatUint(instance.Id, 10),
+	}
+
+	for _, item := range instance.Metadata.Items {
+		if item.Value == nil {
+			continue
 		}
 
-		if !hasDeployment {
-			errorMessages = append(errorMessages, fmt.Sprintf("%s (not managed by bosh)", instance.Name))
+		switch item.Key {
+		case "deployment":
+			vm.Deployment = *item.Value
+		case "director":
+			vm.Director = *item.Value
 		}
 	}
 
-	return fmt.Errorf("bbl environment is not safe to delete; vms still exist in network:\n%s",
-		strings.Join(errorMessages, "\n"))
+	return vm
 }
 
 func (c Client) isInNetwork(networkName string, networkInterfaces []*compute.NetworkInterface) bool {
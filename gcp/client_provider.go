@@ -19,7 +19,7 @@ func gcpHTTPClientFunc(config *jwt.Config) *http.Client {
 var gcpHTTPClient = gcpHTTPClientFunc
 
 func NewClient(gcpConfig storage.GCP, basePath string) (Client, error) {
-	config, err := google.JWTConfigFromJSON([]byte(gcpConfig.ServiceAccountKey), compute.ComputeScope)
+	config, err := google.JWTConfigFromJSON([]byte(gcpConfig.ServiceAccountKey), compute.ComputeScope, resourceManagerScope)
 	if err != nil {
 		return Client{}, fmt.Errorf("parse service account key: %s", err)
 	}
@@ -28,19 +28,23 @@ func NewClient(gcpConfig storage.GCP, basePath string) (Client, error) {
 		config.TokenURL = basePath
 	}
 
-	service, err := compute.New(gcpHTTPClient(config))
+	httpClient := gcpHTTPClient(config)
+	service, err := compute.New(httpClient)
 	if err != nil {
 		return Client{}, fmt.Errorf("create gcp client: %s", err)
 	}
 
+	resourceManager := gcpResourceManagerClient{httpClient: httpClient, basePath: resourceManagerBasePath}
 	if basePath != "" {
 		service.BasePath = basePath
+		resourceManager.basePath = basePath
 	}
 
 	client := Client{
-		computeClient: gcpComputeClient{service: service},
-		projectID:     gcpConfig.ProjectID,
-		zone:          gcpConfig.Zone,
+		computeClient:         gcpComputeClient{service: service},
+		resourceManagerClient: resourceManager,
+		projectID:             gcpConfig.ProjectID,
+		zone:                  gcpConfig.Zone,
 	}
 
 	_, err = client.GetRegion(gcpConfig.Region)
@@ -82,6 +82,7 @@ var _ = Describe("Client", func() {
 					Items: []*compute.Instance{
 						{
 							Name: "bosh-managed-vm",
+							Id:   1234,
 							NetworkInterfaces: []*compute.NetworkInterface{
 								{
 									Network: "network-name",
@@ -102,6 +103,7 @@ var _ = Describe("Client", func() {
 						},
 						{
 							Name: "not-a-bosh-managed-vm",
+							Id:   5678,
 							NetworkInterfaces: []*compute.NetworkInterface{
 								{
 									Network: "network-name",
@@ -118,9 +120,10 @@ var _ = Describe("Client", func() {
 			It("returns a helpful error message", func() {
 				err := client.ValidateSafeToDelete("network-name", "some-env-id")
 
-				Expect(err).To(MatchError(`bbl environment is not safe to delete; vms still exist in network:
-bosh-managed-vm (deployment: some-deployment)
-not-a-bosh-managed-vm (not managed by bosh)`))
+				Expect(err).To(MatchError(`bbl environment is not safe to delete; vms still exist in network network-name:
+  bosh-managed-vm (id: 1234, deployment: some-deployment, director: some-bosh-director)
+  not-a-bosh-managed-vm (id: 5678, not managed by bosh)
+Delete them, or run bbl destroy --delete-deployments to delete every bosh deployment first.`))
 			})
 		})
 
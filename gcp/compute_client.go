@@ -50,6 +50,10 @@ func (g gcpComputeClient) GetRegion(region, projectID string) (*compute.Region,
 	return g.service.Regions.Get(projectID, region).Do()
 }
 
+func (g gcpComputeClient) GetProject(projectID string) (*compute.Project, error) {
+	return g.service.Projects.Get(projectID).Do()
+}
+
 func (g gcpComputeClient) GetNetworks(name, projectID string) (*compute.NetworkList, error) {
 	networksListCall := g.service.Networks.List(projectID)
 	return networksListCall.Filter(fmt.Sprintf("name eq %s", name)).Do()
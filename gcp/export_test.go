@@ -21,3 +21,14 @@ func NewClientWithInjectedComputeClient(computeClient ComputeClient, projectID,
 		zone:          zone,
 	}
 }
+
+func NewClientWithInjectedResourceManagerClient(resourceManagerClient ResourceManagerClient, projectID string) Client {
+	return Client{
+		resourceManagerClient: resourceManagerClient,
+		projectID:             projectID,
+	}
+}
+
+func NewResourceManagerClient(httpClient *http.Client, basePath string) ResourceManagerClient {
+	return gcpResourceManagerClient{httpClient: httpClient, basePath: basePath}
+}
@@ -0,0 +1,137 @@
+package gcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	resourceManagerBasePath = "https://cloudresourcemanager.googleapis.com"
+
+	// testIamPermissions needs a cloud-platform scope, which compute does
+	// not grant.
+	resourceManagerScope = "https://www.googleapis.com/auth/cloud-platform.read-only"
+)
+
+type ResourceManagerClient interface {
+	TestIamPermissions(projectID string, permissions []string) ([]string, error)
+}
+
+var basePermissions = []string{
+	"compute.addresses.create",
+	"compute.disks.create",
+	"compute.firewalls.create",
+	"compute.images.create",
+	"compute.instances.create",
+	"compute.networks.create",
+	"compute.subnetworks.create",
+}
+
+var lbPermissions = map[string][]string{
+	"cf": {
+		"compute.backendServices.create",
+		"compute.forwardingRules.create",
+		"compute.globalAddresses.create",
+		"compute.globalForwardingRules.create",
+		"compute.healthChecks.create",
+		"compute.httpHealthChecks.create",
+		"compute.instanceGroups.create",
+		"compute.sslCertificates.create",
+		"compute.targetHttpProxies.create",
+		"compute.targetHttpsProxies.create",
+		"compute.targetPools.create",
+		"compute.urlMaps.create",
+	},
+	"concourse": {
+		"compute.forwardingRules.create",
+		"compute.targetPools.create",
+	},
+}
+
+var dnsPermissions = []string{
+	"dns.changes.create",
+	"dns.managedZones.create",
+	"dns.resourceRecordSets.create",
+}
+
+// RequiredPermissions returns the IAM permissions bbl needs on the project
+// to pave an environment with the load balancer in the state.
+func RequiredPermissions(state storage.State) []string {
+	permissions := append([]string{}, basePermissions...)
+	permissions = append(permissions, lbPermissions[state.LB.Type]...)
+	if state.LB.Domain != "" {
+		permissions = append(permissions, dnsPermissions...)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+// CheckPermissions returns the required permissions the service account
+// does not have on the project.
+func (c Client) CheckPermissions(state storage.State) ([]string, error) {
+	required := RequiredPermissions(state)
+
+	granted, err := c.resourceManagerClient.TestIamPermissions(c.projectID, required)
+	if err != nil {
+		return nil, fmt.Errorf("Test iam permissions on project %s: %s", c.projectID, err)
+	}
+
+	grantedSet := map[string]struct{}{}
+	for _, permission := range granted {
+		grantedSet[permission] = struct{}{}
+	}
+
+	missing := []string{}
+	for _, permission := range required {
+		if _, ok := grantedSet[permission]; !ok {
+			missing = append(missing, permission)
+		}
+	}
+	return missing, nil
+}
+
+// gcpResourceManagerClient calls projects.testIamPermissions directly,
+// since the resource manager API is not vendored.
+type gcpResourceManagerClient struct {
+	httpClient *http.Client
+	basePath   string
+}
+
+func (g gcpResourceManagerClient) TestIamPermissions(projectID string, permissions []string) ([]string, error) {
+	body, err := json.Marshal(map[string][]string{"permissions": permissions})
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	url := fmt.Sprintf("%s/v1/projects/%s:testIamPermissions", g.basePath, projectID)
+	response, err := g.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	contents, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", response.Status, bytes.TrimSpace(contents))
+	}
+
+	var result struct {
+		Permissions []string `json:"permissions"`
+	}
+	err = json.Unmarshal(contents, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Permissions, nil
+}
@@ -0,0 +1,123 @@
+package gcp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Permissions", func() {
+	Describe("RequiredPermissions", func() {
+		It("adds the permissions for the load balancer and dns", func() {
+			base := gcp.RequiredPermissions(storage.State{})
+			Expect(base).To(ContainElement("compute.networks.create"))
+			Expect(base).NotTo(ContainElement("compute.urlMaps.create"))
+
+			cf := gcp.RequiredPermissions(storage.State{LB: storage.LB{Type: "cf", Domain: "example.com"}})
+			Expect(cf).To(ContainElement("compute.urlMaps.create"))
+			Expect(cf).To(ContainElement("dns.managedZones.create"))
+
+			concourse := gcp.RequiredPermissions(storage.State{LB: storage.LB{Type: "concourse"}})
+			Expect(concourse).To(ContainElement("compute.targetPools.create"))
+			Expect(concourse).NotTo(ContainElement("compute.urlMaps.create"))
+			Expect(concourse).NotTo(ContainElement("dns.managedZones.create"))
+		})
+	})
+
+	Describe("CheckPermissions", func() {
+		var (
+			resourceManagerClient *fakes.GCPResourceManagerClient
+			client                gcp.Client
+			state                 storage.State
+		)
+
+		BeforeEach(func() {
+			resourceManagerClient = &fakes.GCPResourceManagerClient{}
+			client = gcp.NewClientWithInjectedResourceManagerClient(resourceManagerClient, "some-project-id")
+			state = storage.State{LB: storage.LB{Type: "concourse"}}
+		})
+
+		It("returns the required permissions that were not granted", func() {
+			required := gcp.RequiredPermissions(state)
+			resourceManagerClient.TestIamPermissionsCall.Returns.Permissions = required[1:]
+
+			missing, err := client.CheckPermissions(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resourceManagerClient.TestIamPermissionsCall.Receives.ProjectID).To(Equal("some-project-id"))
+			Expect(resourceManagerClient.TestIamPermissionsCall.Receives.Permissions).To(Equal(required))
+			Expect(missing).To(Equal([]string{required[0]}))
+		})
+
+		Context("when every permission is granted", func() {
+			It("returns no missing permissions", func() {
+				resourceManagerClient.TestIamPermissionsCall.Returns.Permissions = gcp.RequiredPermissions(state)
+
+				missing, err := client.CheckPermissions(state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(missing).To(BeEmpty())
+			})
+		})
+
+		Context("when testing the permissions fails", func() {
+			It("returns an error", func() {
+				resourceManagerClient.TestIamPermissionsCall.Returns.Error = errors.New("failed to test")
+
+				_, err := client.CheckPermissions(state)
+				Expect(err).To(MatchError("Test iam permissions on project some-project-id: failed to test"))
+			})
+		})
+	})
+
+	Describe("ResourceManagerClient", func() {
+		var (
+			server   *httptest.Server
+			status   int
+			path     string
+			received map[string][]string
+		)
+
+		BeforeEach(func() {
+			status = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				json.NewDecoder(r.Body).Decode(&received)
+				w.WriteHeader(status)
+				w.Write([]byte(`{"permissions": ["compute.networks.create"]}`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("posts the permissions to testIamPermissions", func() {
+			resourceManager := gcp.NewResourceManagerClient(http.DefaultClient, server.URL)
+
+			granted, err := resourceManager.TestIamPermissions("some-project-id", []string{"compute.networks.create", "compute.disks.create"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(path).To(Equal("/v1/projects/some-project-id:testIamPermissions"))
+			Expect(received["permissions"]).To(Equal([]string{"compute.networks.create", "compute.disks.create"}))
+			Expect(granted).To(Equal([]string{"compute.networks.create"}))
+		})
+
+		Context("when the response is not ok", func() {
+			It("returns an error", func() {
+				status = http.StatusForbidden
+				resourceManager := gcp.NewResourceManagerClient(http.DefaultClient, server.URL)
+
+				_, err := resourceManager.TestIamPermissions("some-project-id", []string{"compute.networks.create"})
+				Expect(err).To(MatchError(ContainSubstring("403 Forbidden")))
+			})
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package gcp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	defaultDirectorCPUs = 2
+	defaultJumpboxCPUs  = 1
+)
+
+var machineTypeCPUs = regexp.MustCompile(`^(?:custom-(\d+)-\d+|[a-z0-9]+-[a-z]+-(\d+))$`)
+
+// CheckQuotas returns the CPU, static address and forwarding rule quotas of
+// the region and the network quota of the project next to what the template
+// and the jumpbox and director VMs need for the load balancer in the state.
+func (c Client) CheckQuotas(state storage.State) ([]storage.Quota, error) {
+	region, err := c.GetRegion(state.GCP.Region)
+	if err != nil {
+		return nil, fmt.Errorf("Get region %s: %s", state.GCP.Region, err)
+	}
+
+	project, err := c.computeClient.GetProject(c.projectID)
+	if err != nil {
+		return nil, fmt.Errorf("Get project %s: %s", c.projectID, err)
+	}
+
+	cpus := vmCPUs(state.Sizing.DirectorVMType, defaultDirectorCPUs) + vmCPUs(state.Sizing.JumpboxVMType, defaultJumpboxCPUs)
+	addresses, forwardingRules := 1, 0
+	switch state.LB.Type {
+	case "cf":
+		addresses, forwardingRules = addresses+3, 4
+	case "concourse":
+		addresses, forwardingRules = addresses+1, 3
+	}
+
+	quotas := []storage.Quota{}
+	for _, required := range []struct {
+		name   string
+		metric string
+		amount int
+		quotas []*compute.Quota
+	}{
+		{"CPUs", "CPUS", cpus, region.Quotas},
+		{"Static addresses", "STATIC_ADDRESSES", addresses, region.Quotas},
+		{"Forwarding rules", "FORWARDING_RULES", forwardingRules, region.Quotas},
+		{"Networks", "NETWORKS", 1, project.Quotas},
+	} {
+		if required.amount == 0 {
+			continue
+		}
+
+		q, ok := findQuota(required.quotas, required.metric)
+		if !ok {
+			continue
+		}
+
+		quotas = append(quotas, storage.Quota{
+			Name:     required.name,
+			Required: required.amount,
+			InUse:    int(q.Usage),
+			Limit:    int(q.Limit),
+		})
+	}
+
+	return quotas, nil
+}
+
+func findQuota(quotas []*compute.Quota, metric string) (*compute.Quota, bool) {
+	for _, q := range quotas {
+		if q.Metric == metric {
+			return q, true
+		}
+	}
+	return nil, false
+}
+
+// vmCPUs reads the CPU count from predefined and custom machine type names,
+// like n1-standard-4 and custom-6-23040, and falls back to the CPUs of the
+// default machine type.
+func vmCPUs(machineType string, defaultCPUs int) int {
+	matches := machineTypeCPUs.FindStringSubmatch(machineType)
+	if matches == nil {
+		return defaultCPUs
+	}
+
+	for _, match := range matches[1:] {
+		if cpus, err := strconv.Atoi(match); err == nil {
+			return cpus
+		}
+	}
+	return defaultCPUs
+}
@@ -0,0 +1,88 @@
+package gcp_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	compute "google.golang.org/api/compute/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quotas", func() {
+	var (
+		computeClient *fakes.GCPComputeClient
+		client        gcp.Client
+		state         storage.State
+	)
+
+	BeforeEach(func() {
+		computeClient = &fakes.GCPComputeClient{}
+		client = gcp.NewClientWithInjectedComputeClient(computeClient, "some-project-id", "some-zone")
+		state = storage.State{GCP: storage.GCP{Region: "some-region"}}
+
+		computeClient.GetRegionCall.Returns.Region = &compute.Region{
+			Quotas: []*compute.Quota{
+				{Metric: "CPUS", Usage: 20, Limit: 24},
+				{Metric: "STATIC_ADDRESSES", Usage: 7, Limit: 8},
+				{Metric: "FORWARDING_RULES", Usage: 3, Limit: 15},
+			},
+		}
+		computeClient.GetProjectCall.Returns.Project = &compute.Project{
+			Quotas: []*compute.Quota{
+				{Metric: "NETWORKS", Usage: 4, Limit: 5},
+			},
+		}
+	})
+
+	It("returns the quotas for the jumpbox, director and network", func() {
+		quotas, err := client.CheckQuotas(state)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(computeClient.GetRegionCall.Receives.Region).To(Equal("some-region"))
+		Expect(computeClient.GetProjectCall.Receives.ProjectID).To(Equal("some-project-id"))
+		Expect(quotas).To(Equal([]storage.Quota{
+			{Name: "CPUs", Required: 3, InUse: 20, Limit: 24},
+			{Name: "Static addresses", Required: 1, InUse: 7, Limit: 8},
+			{Name: "Networks", Required: 1, InUse: 4, Limit: 5},
+		}))
+	})
+
+	It("adds the addresses and forwarding rules of the load balancer", func() {
+		state.LB = storage.LB{Type: "cf"}
+
+		quotas, err := client.CheckQuotas(state)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quotas).To(ContainElement(storage.Quota{Name: "Static addresses", Required: 4, InUse: 7, Limit: 8}))
+		Expect(quotas).To(ContainElement(storage.Quota{Name: "Forwarding rules", Required: 4, InUse: 3, Limit: 15}))
+	})
+
+	It("counts the cpus of the requested machine types", func() {
+		state.Sizing = storage.Sizing{DirectorVMType: "n1-highmem-8", JumpboxVMType: "custom-2-4096"}
+
+		quotas, err := client.CheckQuotas(state)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quotas[0]).To(Equal(storage.Quota{Name: "CPUs", Required: 10, InUse: 20, Limit: 24}))
+	})
+
+	Context("when the region cannot be retrieved", func() {
+		It("returns an error", func() {
+			computeClient.GetRegionCall.Returns.Error = errors.New("failed to get")
+
+			_, err := client.CheckQuotas(state)
+			Expect(err).To(MatchError("Get region some-region: failed to get"))
+		})
+	})
+
+	Context("when the project cannot be retrieved", func() {
+		It("returns an error", func() {
+			computeClient.GetProjectCall.Returns.Error = errors.New("failed to get")
+
+			_, err := client.CheckQuotas(state)
+			Expect(err).To(MatchError("Get project some-project-id: failed to get"))
+		})
+	})
+})
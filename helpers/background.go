@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Background starts bbl again as a detached process, for commands that keep
+// running after the terminal that started them has gone.
+type Background struct{}
+
+// BackgroundProcess is a bbl started by Background. Exited is closed when the
+// process exits while this bbl is still running.
+type BackgroundProcess struct {
+	Pid    int
+	Exited <-chan struct{}
+}
+
+func NewBackground() Background {
+	return Background{}
+}
+
+func (Background) Start(args []string, logPath string) (BackgroundProcess, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return BackgroundProcess{}, fmt.Errorf("Find bbl executable: %s", err)
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return BackgroundProcess{}, fmt.Errorf("Open log file: %s", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(executable, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = detachedProcAttr()
+
+	err = cmd.Start()
+	if err != nil {
+		return BackgroundProcess{}, fmt.Errorf("Start bbl: %s", err)
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(exited)
+	}()
+
+	return BackgroundProcess{Pid: cmd.Process.Pid, Exited: exited}, nil
+}
+
+// Running returns true if a process with the pid exists.
+func (Background) Running(pid int) bool {
+	return pid > 0 && processRunning(pid)
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+// +build !windows
+
+package helpers
+
+import "syscall"
+
+// detachedProcAttr starts the process in a new session, so it does not get
+// the hangup signal when the terminal closes.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// processRunning sends signal 0, which only checks that the process exists.
+// EPERM means it exists but belongs to another user.
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
@@ -0,0 +1,26 @@
+package helpers
+
+import "syscall"
+
+// stillActive is the exit code GetExitCodeProcess reports for a process that
+// has not exited.
+const stillActive = 259
+
+// detachedProcAttr starts the process in a new process group, so it does not
+// get the Ctrl+C of the console that started it.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// processRunning opens the process and checks that it has no exit code yet.
+func processRunning(pid int) bool {
+	handle, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(handle)
+
+	var exitCode uint32
+	err = syscall.GetExitCodeProcess(handle, &exitCode)
+	return err == nil && exitCode == stillActive
+}
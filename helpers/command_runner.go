@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+)
+
+// CommandRunner runs a command attached to bbl's terminal with extra
+// environment variables.
+type CommandRunner struct{}
+
+func NewCommandRunner() CommandRunner {
+	return CommandRunner{}
+}
+
+// Run returns an *exec.ExitError when the command exits with a non-zero
+// status. Interrupts are left to the command while it runs, so that bbl is
+// still around to clean up after it.
+func (CommandRunner) Run(command []string, env []string) error {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	return cmd.Run()
+}
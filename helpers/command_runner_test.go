@@ -0,0 +1,30 @@
+package helpers_test
+
+import (
+	"os/exec"
+
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CommandRunner", func() {
+	var runner helpers.CommandRunner
+
+	BeforeEach(func() {
+		runner = helpers.NewCommandRunner()
+	})
+
+	It("runs the command with the extra environment variables", func() {
+		err := runner.Run([]string{"sh", "-c", `test "$SOME_VAR" = "some value"`}, []string{"SOME_VAR=some value"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when the command fails", func() {
+		It("returns an exit error with its status", func() {
+			err := runner.Run([]string{"sh", "-c", "exit 3"}, nil)
+			Expect(err).To(BeAssignableToTypeOf(&exec.ExitError{}))
+			Expect(err.(*exec.ExitError).ExitCode()).To(Equal(3))
+		})
+	})
+})
@@ -0,0 +1,100 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const HooksDir = "hooks"
+
+// HookNames are the hooks that bbl up and bbl destroy run.
+var HookNames = []string{
+	"pre-terraform", "post-terraform",
+	"pre-jumpbox", "post-jumpbox",
+	"pre-director", "post-director",
+	"post-cloud-config",
+	"pre-destroy", "post-destroy",
+}
+
+type hooksFS interface {
+	fileio.Stater
+}
+
+// Hooks runs the executables in the hooks directory of the state dir
+// before and after the phases of bbl up and bbl destroy. The output of the
+// hooks goes to output, which keeps it out of the stdout of commands such as
+// bbl print-env.
+type Hooks struct {
+	stateDir string
+	fs       hooksFS
+	output   io.Writer
+}
+
+func NewHooks(stateDir string, fs hooksFS, output io.Writer) Hooks {
+	return Hooks{
+		stateDir: stateDir,
+		fs:       fs,
+		output:   output,
+	}
+}
+
+// Run does nothing when the hook does not exist. The hook gets the state
+// dir as its argument and the terraform outputs as json on stdin.
+func (h Hooks) Run(hook string, state storage.State, outputs map[string]interface{}) error {
+	path := filepath.Join(h.stateDir, HooksDir, hook)
+
+	info, err := h.fs.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Find %s hook: %s", hook, err)
+	}
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		return fmt.Errorf("Hook %s is not executable.", path)
+	}
+
+	if outputs == nil {
+		outputs = map[string]interface{}{}
+	}
+	input, err := json.Marshal(outputs)
+	if err != nil {
+		return fmt.Errorf("Marshal terraform outputs for %s hook: %s", hook, err) // not tested
+	}
+
+	cmd := exec.Command(path, h.stateDir)
+	cmd.Env = append(os.Environ(), hookEnv(hook, h.stateDir, state)...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = h.output
+	cmd.Stderr = h.output
+
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("Run %s hook: %s", hook, err)
+	}
+
+	return nil
+}
+
+func hookEnv(hook, stateDir string, state storage.State) []string {
+	env := []string{
+		fmt.Sprintf("BBL_HOOK=%s", hook),
+		fmt.Sprintf("BBL_STATE_DIR=%s", stateDir),
+		fmt.Sprintf("BBL_ENV_ID=%s", state.EnvID),
+		fmt.Sprintf("BBL_IAAS=%s", state.IAAS),
+	}
+
+	for name, value := range storage.CredentialEnv(state) {
+		env = append(env, fmt.Sprintf("%s=%s", name, value))
+	}
+
+	return env
+}
@@ -0,0 +1,103 @@
+package helpers_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hooks", func() {
+	var (
+		stateDir string
+		output   *bytes.Buffer
+		hooks    helpers.Hooks
+		state    storage.State
+	)
+
+	BeforeEach(func() {
+		var err error
+		stateDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = os.Mkdir(filepath.Join(stateDir, "hooks"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+
+		output = &bytes.Buffer{}
+		hooks = helpers.NewHooks(stateDir, &afero.Afero{Fs: afero.NewOsFs()}, output)
+
+		state = storage.State{
+			EnvID: "some-env-id",
+			IAAS:  "aws",
+			AWS:   storage.AWS{AccessKeyID: "some-access-key-id"},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(stateDir)
+	})
+
+	writeHook := func(name, contents string, mode os.FileMode) {
+		err := ioutil.WriteFile(filepath.Join(stateDir, "hooks", name), []byte("#!/bin/sh\n"+contents), mode)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("runs the hook with the state dir, the environment and the terraform outputs", func() {
+		writeHook("post-terraform", `echo "$1 $BBL_HOOK $BBL_STATE_DIR $BBL_ENV_ID $BBL_IAAS $BBL_AWS_ACCESS_KEY_ID" > "$1/out"; cat >> "$1/out"`, 0755)
+
+		err := hooks.Run("post-terraform", state, map[string]interface{}{"jumpbox_url": "some-jumpbox-url"})
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := ioutil.ReadFile(filepath.Join(stateDir, "out"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal(stateDir + " post-terraform " + stateDir + " some-env-id aws some-access-key-id\n" + `{"jumpbox_url":"some-jumpbox-url"}`))
+	})
+
+	It("passes an empty object when there are no terraform outputs", func() {
+		writeHook("pre-terraform", `cat > "$1/out"`, 0755)
+
+		err := hooks.Run("pre-terraform", state, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := ioutil.ReadFile(filepath.Join(stateDir, "out"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(Equal("{}"))
+	})
+
+	It("writes the stdout and stderr of the hook to the output", func() {
+		writeHook("post-director", `echo some-stdout; echo some-stderr >&2`, 0755)
+
+		err := hooks.Run("post-director", state, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(output.String()).To(Equal("some-stdout\nsome-stderr\n"))
+	})
+
+	It("does nothing when the hook does not exist", func() {
+		err := hooks.Run("pre-director", state, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the hook exits with a non-zero status", func() {
+			writeHook("pre-jumpbox", "exit 3", 0755)
+
+			err := hooks.Run("pre-jumpbox", state, nil)
+			Expect(err).To(MatchError("Run pre-jumpbox hook: exit status 3"))
+		})
+
+		It("returns an error when the hook is not executable", func() {
+			writeHook("pre-jumpbox", "exit 0", 0644)
+
+			err := hooks.Run("pre-jumpbox", state, nil)
+			Expect(err).To(MatchError("Hook " + filepath.Join(stateDir, "hooks", "pre-jumpbox") + " is not executable."))
+		})
+	})
+})
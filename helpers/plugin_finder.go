@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const PluginPrefix = "bbl-"
+
+// PluginFinder looks for bbl-<name> executables in the directories of a
+// PATH list.
+type PluginFinder struct {
+	path string
+}
+
+func NewPluginFinder(path string) PluginFinder {
+	return PluginFinder{
+		path: path,
+	}
+}
+
+func (p PluginFinder) Find(name string) (string, bool) {
+	if name == "" || strings.ContainsRune(name, filepath.Separator) {
+		return "", false
+	}
+
+	for _, dir := range filepath.SplitList(p.path) {
+		path := filepath.Join(dir, PluginPrefix+name)
+		if isExecutable(path) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// List returns the names of the plugins, without the bbl- prefix.
+func (p PluginFinder) List() []string {
+	seen := map[string]struct{}{}
+	var names []string
+
+	for _, dir := range filepath.SplitList(p.path) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			name := strings.TrimPrefix(file.Name(), PluginPrefix)
+			if name == file.Name() || name == "" {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			if !isExecutable(filepath.Join(dir, file.Name())) {
+				continue
+			}
+
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func isExecutable(path string) bool {
+	_, err := exec.LookPath(path)
+	return err == nil
+}
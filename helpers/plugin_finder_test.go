@@ -0,0 +1,76 @@
+package helpers_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PluginFinder", func() {
+	var (
+		firstDir  string
+		secondDir string
+		finder    helpers.PluginFinder
+	)
+
+	BeforeEach(func() {
+		var err error
+		firstDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+		secondDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		for path, mode := range map[string]os.FileMode{
+			filepath.Join(firstDir, "bbl-cf-deploy"):         0755,
+			filepath.Join(firstDir, "bbl-not-executable"):    0644,
+			filepath.Join(firstDir, "some-other-executable"): 0755,
+			filepath.Join(secondDir, "bbl-cf-deploy"):        0755,
+			filepath.Join(secondDir, "bbl-concourse-deploy"): 0755,
+		} {
+			err = ioutil.WriteFile(path, []byte("#!/bin/sh\n"), mode)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		finder = helpers.NewPluginFinder(strings.Join([]string{firstDir, "/does/not/exist", secondDir}, string(os.PathListSeparator)))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(firstDir)
+		os.RemoveAll(secondDir)
+	})
+
+	Describe("Find", func() {
+		It("returns the first plugin with the name on the path", func() {
+			path, ok := finder.Find("cf-deploy")
+			Expect(ok).To(BeTrue())
+			Expect(path).To(Equal(filepath.Join(firstDir, "bbl-cf-deploy")))
+
+			path, ok = finder.Find("concourse-deploy")
+			Expect(ok).To(BeTrue())
+			Expect(path).To(Equal(filepath.Join(secondDir, "bbl-concourse-deploy")))
+		})
+
+		It("does not find missing or non-executable plugins", func() {
+			_, ok := finder.Find("missing")
+			Expect(ok).To(BeFalse())
+
+			_, ok = finder.Find("not-executable")
+			Expect(ok).To(BeFalse())
+
+			_, ok = finder.Find("../" + filepath.Base(firstDir) + "/bbl-cf-deploy")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("List", func() {
+		It("returns the names of the executable plugins", func() {
+			Expect(finder.List()).To(Equal([]string{"cf-deploy", "concourse-deploy"}))
+		})
+	})
+})
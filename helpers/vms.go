@@ -0,0 +1,46 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VM is a VM that keeps the network of an environment from being deleted.
+type VM struct {
+	Name       string
+	ID         string
+	Deployment string
+	Director   string
+}
+
+func (v VM) String() string {
+	details := []string{}
+	if v.ID != "" {
+		details = append(details, fmt.Sprintf("id: %s", v.ID))
+	}
+	if v.Deployment == "" {
+		details = append(details, "not managed by bosh")
+	} else {
+		details = append(details, fmt.Sprintf("deployment: %s", v.Deployment))
+	}
+	if v.Director != "" {
+		details = append(details, fmt.Sprintf("director: %s", v.Director))
+	}
+
+	return fmt.Sprintf("%s (%s)", v.Name, strings.Join(details, ", "))
+}
+
+type VMsExistError struct {
+	Network string
+	VMs     []VM
+}
+
+func (e VMsExistError) Error() string {
+	lines := []string{}
+	for _, vm := range e.VMs {
+		lines = append(lines, fmt.Sprintf("  %s", vm))
+	}
+
+	return fmt.Sprintf("bbl environment is not safe to delete; vms still exist in %s:\n%s\nDelete them, or run bbl destroy --delete-deployments to delete every bosh deployment first.",
+		e.Network, strings.Join(lines, "\n"))
+}
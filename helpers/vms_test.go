@@ -0,0 +1,24 @@
+package helpers_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMsExistError", func() {
+	It("lists the vms with their ids and bosh tags", func() {
+		err := helpers.VMsExistError{
+			Network: "vpc some-vpc-id",
+			VMs: []helpers.VM{
+				{Name: "router/0", ID: "i-123", Deployment: "cf", Director: "some-director"},
+				{Name: "unnamed", ID: "i-456"},
+			},
+		}
+
+		Expect(err.Error()).To(Equal(`bbl environment is not safe to delete; vms still exist in vpc some-vpc-id:
+  router/0 (id: i-123, deployment: cf, director: some-director)
+  unnamed (id: i-456, not managed by bosh)
+Delete them, or run bbl destroy --delete-deployments to delete every bosh deployment first.`))
+	})
+})
@@ -0,0 +1,305 @@
+package runtimeconfig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+)
+
+const (
+	BaseFile = "runtime-config.yml"
+	VarsFile = "runtime-config-vars.yml"
+
+	// AppliedFile lists the runtime configs bbl uploaded to the director, so
+	// that the ones whose directory is removed can be deleted again without
+	// touching runtime configs uploaded by other means.
+	AppliedFile = "runtime-configs.yml"
+)
+
+type fs interface {
+	fileio.FileReader
+	fileio.FileWriter
+	fileio.DirReader
+	fileio.Stater
+}
+
+type Manager struct {
+	logger             logger
+	command            command
+	stateStore         stateStore
+	boshClientProvider boshClientProvider
+	terraformManager   terraformManager
+	fs                 fs
+}
+
+type logger interface {
+	Step(string, ...interface{})
+}
+
+type command interface {
+	Run(stdout io.Writer, runtimeConfigDirectory string, args []string) error
+}
+
+type boshClientProvider interface {
+	Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, caCert string) (bosh.Client, error)
+}
+
+type terraformManager interface {
+	GetOutputs() (terraform.Outputs, error)
+}
+
+type stateStore interface {
+	GetRuntimeConfigDir() (string, error)
+	GetVarsDir() (string, error)
+}
+
+var marshal func(interface{}) ([]byte, error) = yaml.Marshal
+
+func NewManager(logger logger, cmd command, stateStore stateStore, boshClientProvider boshClientProvider,
+	terraformManager terraformManager, fs fs) Manager {
+	return Manager{
+		logger:             logger,
+		command:            cmd,
+		stateStore:         stateStore,
+		boshClientProvider: boshClientProvider,
+		terraformManager:   terraformManager,
+		fs:                 fs,
+	}
+}
+
+// Names returns the name of every runtime config in the runtime-config
+// directory. Each subdirectory containing a runtime-config.yml is uploaded
+// to the director as a runtime config of the same name.
+func (m Manager) Names() ([]string, error) {
+	runtimeConfigDir, err := m.stateStore.GetRuntimeConfigDir()
+	if err != nil {
+		return []string{}, err
+	}
+
+	files, err := m.fs.ReadDir(runtimeConfigDir)
+	if err != nil {
+		return []string{}, fmt.Errorf("Read runtime config dir: %s", err)
+	}
+
+	names := []string{}
+	for _, file := range files {
+		if !file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		_, err := m.fs.Stat(filepath.Join(runtimeConfigDir, file.Name(), BaseFile))
+		if err != nil {
+			return []string{}, fmt.Errorf("Runtime config %s is missing %s", file.Name(), BaseFile)
+		}
+
+		names = append(names, file.Name())
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (m Manager) GenerateVars() error {
+	varsDir, err := m.stateStore.GetVarsDir()
+	if err != nil {
+		return err
+	}
+
+	terraformOutputs, err := m.terraformManager.GetOutputs()
+	if err != nil {
+		return fmt.Errorf("Get terraform outputs: %s", err)
+	}
+
+	vars, err := marshal(terraformOutputs.Map)
+	if err != nil {
+		return fmt.Errorf("Generate runtime config vars: %s", err) // not tested
+	}
+
+	err = m.fs.WriteFile(filepath.Join(varsDir, VarsFile), vars, storage.StateMode)
+	if err != nil {
+		return fmt.Errorf("Write runtime config vars: %s", err)
+	}
+
+	return nil
+}
+
+func (m Manager) Interpolate(name string) (string, error) {
+	runtimeConfigDir, err := m.stateStore.GetRuntimeConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	varsDir, err := m.stateStore.GetVarsDir()
+	if err != nil {
+		return "", err
+	}
+
+	configDir := filepath.Join(runtimeConfigDir, name)
+	args := []string{
+		"interpolate", filepath.Join(configDir, BaseFile),
+		"--vars-file", filepath.Join(varsDir, VarsFile),
+	}
+
+	files, err := m.fs.ReadDir(configDir)
+	if err != nil {
+		return "", fmt.Errorf("Read runtime config dir: %s", err)
+	}
+
+	for _, file := range files {
+		fileName := file.Name()
+		if fileName != BaseFile && filepath.Ext(fileName) == ".yml" {
+			args = append(args, "-o", filepath.Join(configDir, fileName))
+		}
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	err = m.command.Run(buf, configDir, args)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (m Manager) Update(state storage.State) error {
+	names, err := m.Names()
+	if err != nil {
+		return err
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 && len(applied) == 0 {
+		return nil
+	}
+
+	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return err // not tested
+	}
+
+	if len(names) > 0 {
+		m.logger.Step("generating runtime configs")
+
+		err = m.GenerateVars()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range names {
+		runtimeConfig, err := m.Interpolate(name)
+		if err != nil {
+			return fmt.Errorf("Interpolate runtime config %s: %s", name, err)
+		}
+
+		m.logger.Step("applying runtime config %s", name)
+		err = boshClient.UpdateConfig(bosh.RuntimeConfigType, name, []byte(runtimeConfig))
+		if err != nil {
+			return fmt.Errorf("Update runtime config %s: %s", name, err)
+		}
+	}
+
+	err = m.deleteRemoved(boshClient, names, applied)
+	if err != nil {
+		return err
+	}
+
+	return m.recordApplied(names)
+}
+
+// deleteRemoved deletes the runtime configs on the director that bbl
+// applied before but whose directory no longer exists.
+func (m Manager) deleteRemoved(boshClient bosh.Client, names, applied []string) error {
+	current := map[string]bool{}
+	for _, name := range names {
+		current[name] = true
+	}
+
+	removed := map[string]bool{}
+	for _, name := range applied {
+		if !current[name] {
+			removed[name] = true
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	configs, err := boshClient.Configs(bosh.RuntimeConfigType)
+	if err != nil {
+		return fmt.Errorf("List runtime configs: %s", err)
+	}
+
+	for _, config := range configs {
+		if !removed[config.Name] {
+			continue
+		}
+
+		m.logger.Step("deleting runtime config %s", config.Name)
+		err = boshClient.DeleteConfig(bosh.RuntimeConfigType, config.Name)
+		if err != nil {
+			return fmt.Errorf("Delete runtime config %s: %s", config.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m Manager) applied() ([]string, error) {
+	varsDir, err := m.stateStore.GetVarsDir()
+	if err != nil {
+		return []string{}, err
+	}
+
+	appliedFile := filepath.Join(varsDir, AppliedFile)
+	if _, err := m.fs.Stat(appliedFile); err != nil {
+		return []string{}, nil
+	}
+
+	contents, err := m.fs.ReadFile(appliedFile)
+	if err != nil {
+		return []string{}, fmt.Errorf("Read applied runtime configs: %s", err)
+	}
+
+	var names []string
+	err = yaml.Unmarshal(contents, &names)
+	if err != nil {
+		return []string{}, fmt.Errorf("Read applied runtime configs: %s", err)
+	}
+
+	return names, nil
+}
+
+func (m Manager) recordApplied(names []string) error {
+	varsDir, err := m.stateStore.GetVarsDir()
+	if err != nil {
+		return err
+	}
+
+	contents, err := marshal(names)
+	if err != nil {
+		return fmt.Errorf("Record applied runtime configs: %s", err) // not tested
+	}
+
+	err = m.fs.WriteFile(filepath.Join(varsDir, AppliedFile), contents, storage.StateMode)
+	if err != nil {
+		return fmt.Errorf("Record applied runtime configs: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package runtimeconfig_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/runtimeconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		logger             *fakes.Logger
+		cli                *fakes.BOSHCLI
+		stateStore         *fakes.StateStore
+		boshClientProvider *fakes.BOSHClientProvider
+		boshClient         *fakes.BOSHClient
+		terraformManager   *fakes.TerraformManager
+		fs                 *afero.Afero
+		manager            runtimeconfig.Manager
+
+		runtimeConfigDir string
+		varsDir          string
+		incomingState    storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		cli = &fakes.BOSHCLI{}
+		stateStore = &fakes.StateStore{}
+		boshClient = &fakes.BOSHClient{}
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		terraformManager = &fakes.TerraformManager{}
+		fs = &afero.Afero{Fs: afero.NewMemMapFs()}
+
+		boshClientProvider.ClientCall.Returns.Client = boshClient
+
+		runtimeConfigDir = "/some-state-dir/runtime-config"
+		stateStore.GetRuntimeConfigDirCall.Returns.Directory = runtimeConfigDir
+		Expect(fs.MkdirAll(runtimeConfigDir, os.ModePerm)).To(Succeed())
+
+		varsDir = "/some-state-dir/vars"
+		stateStore.GetVarsDirCall.Returns.Directory = varsDir
+		Expect(fs.MkdirAll(varsDir, os.ModePerm)).To(Succeed())
+
+		for _, name := range []string{"dns", "syslog"} {
+			Expect(fs.MkdirAll(filepath.Join(runtimeConfigDir, name), os.ModePerm)).To(Succeed())
+			Expect(fs.WriteFile(filepath.Join(runtimeConfigDir, name, "runtime-config.yml"), []byte("some-base"), os.ModePerm)).To(Succeed())
+		}
+		Expect(fs.WriteFile(filepath.Join(runtimeConfigDir, "dns", "ops.yml"), []byte("some-ops"), os.ModePerm)).To(Succeed())
+		Expect(fs.WriteFile(filepath.Join(runtimeConfigDir, "dns", "README.md"), []byte("some-readme"), os.ModePerm)).To(Succeed())
+
+		terraformManager.GetOutputsCall.Returns.Outputs = terraform.Outputs{Map: map[string]interface{}{
+			"network_name": "some-network",
+		}}
+
+		cli.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
+			stdout.Write([]byte("some-runtime-config"))
+			return nil
+		}
+
+		incomingState = storage.State{
+			BOSH: storage.BOSH{
+				DirectorAddress:  "some-director-address",
+				DirectorUsername: "some-director-username",
+				DirectorPassword: "some-director-password",
+				DirectorSSLCA:    "some-director-ca",
+			},
+		}
+
+		manager = runtimeconfig.NewManager(logger, cli, stateStore, boshClientProvider, terraformManager, fs)
+	})
+
+	Describe("Names", func() {
+		It("returns the sorted names of runtime config directories", func() {
+			Expect(fs.WriteFile(filepath.Join(runtimeConfigDir, "some-file.yml"), []byte(""), os.ModePerm)).To(Succeed())
+			Expect(fs.MkdirAll(filepath.Join(runtimeConfigDir, ".hidden"), os.ModePerm)).To(Succeed())
+
+			names, err := manager.Names()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{"dns", "syslog"}))
+		})
+
+		Context("when a runtime config directory has no base file", func() {
+			It("returns an error", func() {
+				Expect(fs.MkdirAll(filepath.Join(runtimeConfigDir, "os-conf"), os.ModePerm)).To(Succeed())
+
+				_, err := manager.Names()
+				Expect(err).To(MatchError("Runtime config os-conf is missing runtime-config.yml"))
+			})
+		})
+
+		Context("when getting the runtime config dir fails", func() {
+			It("returns an error", func() {
+				stateStore.GetRuntimeConfigDirCall.Returns.Error = errors.New("carrot")
+
+				_, err := manager.Names()
+				Expect(err).To(MatchError("carrot"))
+			})
+		})
+	})
+
+	Describe("GenerateVars", func() {
+		It("writes the terraform outputs to the runtime config vars file", func() {
+			err := manager.GenerateVars()
+			Expect(err).NotTo(HaveOccurred())
+
+			vars, err := fs.ReadFile(filepath.Join(varsDir, "runtime-config-vars.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vars).To(MatchYAML("network_name: some-network"))
+		})
+
+		Context("when getting terraform outputs fails", func() {
+			It("returns an error", func() {
+				terraformManager.GetOutputsCall.Returns.Error = errors.New("pineapple")
+
+				err := manager.GenerateVars()
+				Expect(err).To(MatchError("Get terraform outputs: pineapple"))
+			})
+		})
+	})
+
+	Describe("Interpolate", func() {
+		It("interpolates the base file with the ops files in the runtime config directory", func() {
+			runtimeConfig, err := manager.Interpolate("dns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runtimeConfig).To(Equal("some-runtime-config"))
+
+			Expect(cli.RunCallCount()).To(Equal(1))
+			_, workingDirectory, args := cli.RunArgsForCall(0)
+			Expect(workingDirectory).To(Equal(filepath.Join(runtimeConfigDir, "dns")))
+			Expect(args).To(Equal([]string{
+				"interpolate", filepath.Join(runtimeConfigDir, "dns", "runtime-config.yml"),
+				"--vars-file", filepath.Join(varsDir, "runtime-config-vars.yml"),
+				"-o", filepath.Join(runtimeConfigDir, "dns", "ops.yml"),
+			}))
+		})
+
+		Context("when the command fails to run", func() {
+			It("returns an error", func() {
+				cli.RunReturns(errors.New("failed to run"))
+
+				_, err := manager.Interpolate("dns")
+				Expect(err).To(MatchError("failed to run"))
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		It("uploads each runtime config to the director", func() {
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("some-director-address"))
+			Expect(boshClientProvider.ClientCall.Receives.DirectorCACert).To(Equal("some-director-ca"))
+
+			Expect(boshClient.UpdateConfigCall.Receives).To(Equal([]fakes.UpdateConfigReceive{
+				{ConfigType: bosh.RuntimeConfigType, Name: "dns", Yaml: []byte("some-runtime-config")},
+				{ConfigType: bosh.RuntimeConfigType, Name: "syslog", Yaml: []byte("some-runtime-config")},
+			}))
+
+			Expect(logger.StepCall.Messages).To(Equal([]string{
+				"generating runtime configs",
+				"applying runtime config dns",
+				"applying runtime config syslog",
+			}))
+		})
+
+		Context("when there are no runtime configs", func() {
+			It("does not connect to the director", func() {
+				Expect(fs.RemoveAll(runtimeConfigDir)).To(Succeed())
+				Expect(fs.MkdirAll(runtimeConfigDir, os.ModePerm)).To(Succeed())
+
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClientProvider.ClientCall.CallCount).To(Equal(0))
+			})
+		})
+
+		It("records the applied runtime configs", func() {
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			applied, err := fs.ReadFile(filepath.Join(varsDir, "runtime-configs.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(applied)).To(Equal("- dns\n- syslog\n"))
+		})
+
+		Context("when a runtime config directory was removed since the last update", func() {
+			BeforeEach(func() {
+				Expect(fs.WriteFile(filepath.Join(varsDir, "runtime-configs.yml"), []byte("- dns\n- old\n- syslog\n"), os.ModePerm)).To(Succeed())
+				boshClient.ConfigsCall.Returns.Configs = []bosh.Config{
+					{Name: "default", Type: "runtime"},
+					{Name: "dns", Type: "runtime"},
+					{Name: "old", Type: "runtime"},
+					{Name: "syslog", Type: "runtime"},
+				}
+			})
+
+			It("deletes it from the director and leaves runtime configs bbl did not apply alone", func() {
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClient.ConfigsCall.Receives.ConfigType).To(Equal(bosh.RuntimeConfigType))
+				Expect(boshClient.DeleteConfigCall.Receives).To(Equal([]fakes.DeleteConfigReceive{
+					{ConfigType: bosh.RuntimeConfigType, Name: "old"},
+				}))
+				Expect(logger.StepCall.Messages).To(ContainElement("deleting runtime config old"))
+
+				applied, err := fs.ReadFile(filepath.Join(varsDir, "runtime-configs.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(applied)).To(Equal("- dns\n- syslog\n"))
+			})
+
+			Context("when every runtime config directory was removed", func() {
+				It("deletes them all from the director", func() {
+					Expect(fs.RemoveAll(runtimeConfigDir)).To(Succeed())
+					Expect(fs.MkdirAll(runtimeConfigDir, os.ModePerm)).To(Succeed())
+
+					err := manager.Update(incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+					Expect(boshClient.DeleteConfigCall.Receives).To(Equal([]fakes.DeleteConfigReceive{
+						{ConfigType: bosh.RuntimeConfigType, Name: "dns"},
+						{ConfigType: bosh.RuntimeConfigType, Name: "old"},
+						{ConfigType: bosh.RuntimeConfigType, Name: "syslog"},
+					}))
+				})
+			})
+
+			Context("when listing the runtime configs fails", func() {
+				It("returns an error", func() {
+					boshClient.ConfigsCall.Returns.Error = errors.New("failed to list")
+
+					err := manager.Update(incomingState)
+					Expect(err).To(MatchError("List runtime configs: failed to list"))
+				})
+			})
+
+			Context("when deleting a runtime config fails", func() {
+				It("returns an error", func() {
+					boshClient.DeleteConfigCall.Returns.Error = errors.New("failed to delete")
+
+					err := manager.Update(incomingState)
+					Expect(err).To(MatchError("Delete runtime config old: failed to delete"))
+				})
+			})
+		})
+
+		Context("when the bosh client fails to update a runtime config", func() {
+			It("returns an error", func() {
+				boshClient.UpdateConfigCall.Returns.Error = errors.New("failed to update")
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("Update runtime config dns: failed to update"))
+			})
+		})
+
+		Context("when interpolating a runtime config fails", func() {
+			It("returns an error", func() {
+				cli.RunReturns(errors.New("failed to run"))
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("Interpolate runtime config dns: failed to run"))
+			})
+		})
+	})
+})
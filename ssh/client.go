@@ -0,0 +1,257 @@
+package ssh
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Host is one hop of an ssh connection. Address is host:port.
+type Host struct {
+	Address    string
+	User       string
+	PrivateKey string
+}
+
+type Client struct {
+	hostKeyCallback ssh.HostKeyCallback
+	scp             scp
+	in              io.Reader
+	out             io.Writer
+	err             io.Writer
+}
+
+// ExitError carries the exit status of a remote command or shell so that bbl
+// can exit with it.
+type ExitError struct {
+	Status int
+}
+
+func (e ExitError) Error() string {
+	return fmt.Sprintf("Remote command exited with status %d", e.Status)
+}
+
+func (e ExitError) ExitCode() int {
+	return e.Status
+}
+
+const (
+	dialTimeout       = 30 * time.Second
+	keepAliveInterval = 300 * time.Second
+)
+
+func NewClient(knownHosts KnownHosts, fs scpFS, in io.Reader, out, err io.Writer) Client {
+	return Client{
+		hostKeyCallback: knownHosts.HostKeyCallback,
+		scp:             scp{fs: fs},
+		in:              in,
+		out:             out,
+		err:             err,
+	}
+}
+
+// Shell opens an interactive shell on the last host, connecting to each host
+// through the one before it.
+func (c Client) Shell(hops []Host) error {
+	client, closeAll, err := c.connect(hops)
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("Open session: %s", err)
+	}
+	defer session.Close()
+
+	session.Stdin = c.in
+	session.Stdout = c.out
+	session.Stderr = c.err
+
+	if stdin, ok := c.in.(*os.File); ok && isTerminal(int(stdin.Fd())) {
+		fd := int(stdin.Fd())
+
+		restore, err := makeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("Set terminal to raw mode: %s", err)
+		}
+		defer restore()
+
+		width, height := terminalSize(fd)
+		term := os.Getenv("TERM")
+		if term == "" {
+			term = "xterm"
+		}
+
+		err = session.RequestPty(term, height, width, ssh.TerminalModes{ssh.ECHO: 1})
+		if err != nil {
+			return fmt.Errorf("Request pty: %s", err)
+		}
+	}
+
+	err = session.Shell()
+	if err != nil {
+		return fmt.Errorf("Start shell: %s", err)
+	}
+
+	return exitError(session.Wait())
+}
+
+// Run runs command on the last host and returns an ExitError when it fails.
+func (c Client) Run(hops []Host, command string) error {
+	client, closeAll, err := c.connect(hops)
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("Open session: %s", err)
+	}
+	defer session.Close()
+
+	session.Stdin = c.in
+	session.Stdout = c.out
+	session.Stderr = c.err
+
+	return exitError(session.Run(command))
+}
+
+// Upload copies a local file, or a directory when recursive, to remotePath on
+// the last host.
+func (c Client) Upload(hops []Host, localPath, remotePath string, recursive bool) error {
+	return c.copy(hops, "-t", remotePath, recursive, func(w io.Writer, r *bufio.Reader) error {
+		return c.scp.send(w, r, localPath, recursive)
+	})
+}
+
+// Download copies remotePath on the last host, a directory when recursive, to
+// localPath.
+func (c Client) Download(hops []Host, remotePath, localPath string, recursive bool) error {
+	return c.copy(hops, "-f", remotePath, recursive, func(w io.Writer, r *bufio.Reader) error {
+		return c.scp.receive(w, r, localPath)
+	})
+}
+
+func (c Client) copy(hops []Host, mode, remotePath string, recursive bool, transfer func(io.Writer, *bufio.Reader) error) error {
+	client, closeAll, err := c.connect(hops)
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("Open session: %s", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("Open session: %s", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("Open session: %s", err)
+	}
+	session.Stderr = c.err
+
+	args := []string{"scp", mode}
+	if recursive {
+		args = append(args, "-r")
+	}
+	args = append(args, shellQuote(remotePath))
+
+	err = session.Start(strings.Join(args, " "))
+	if err != nil {
+		return fmt.Errorf("Start scp: %s", err)
+	}
+
+	err = transfer(stdin, bufio.NewReader(stdout))
+	stdin.Close()
+	if err != nil {
+		return err
+	}
+
+	return exitError(session.Wait())
+}
+
+func exitError(err error) error {
+	if exitErr, ok := err.(*ssh.ExitError); ok {
+		return ExitError{Status: exitErr.ExitStatus()}
+	}
+	return err
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// connect returns a client for the last hop and a function that closes the
+// connections to every hop.
+func (c Client) connect(hops []Host) (*ssh.Client, func(), error) {
+	var clients []*ssh.Client
+	closeAll := func() {
+		for i := len(clients) - 1; i >= 0; i-- {
+			clients[i].Close()
+		}
+	}
+
+	for _, hop := range hops {
+		signer, err := ssh.ParsePrivateKey([]byte(hop.PrivateKey))
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("Parse private key for %s: %s", hop.Address, err)
+		}
+
+		config := &ssh.ClientConfig{
+			User:            hop.User,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: c.hostKeyCallback,
+		}
+
+		var conn net.Conn
+		if len(clients) == 0 {
+			conn, err = net.DialTimeout("tcp", hop.Address, dialTimeout)
+		} else {
+			conn, err = clients[len(clients)-1].Dial("tcp", hop.Address)
+		}
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("Connect to %s: %s", hop.Address, err)
+		}
+
+		sshConn, chans, reqs, err := ssh.NewClientConn(conn, hop.Address, config)
+		if err != nil {
+			conn.Close()
+			closeAll()
+			return nil, nil, fmt.Errorf("Connect to %s: %s", hop.Address, err)
+		}
+
+		client := ssh.NewClient(sshConn, chans, reqs)
+		go keepAlive(client)
+		clients = append(clients, client)
+	}
+
+	return clients[len(clients)-1], closeAll, nil
+}
+
+// keepAlive stands in for OpenSSH's ServerAliveInterval so idle sessions are
+// not dropped by load balancers and NAT gateways.
+func keepAlive(client *ssh.Client) {
+	for {
+		time.Sleep(keepAliveInterval)
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		if err != nil {
+			return
+		}
+	}
+}
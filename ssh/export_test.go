@@ -0,0 +1,14 @@
+package ssh
+
+import (
+	"bufio"
+	"io"
+)
+
+func SCPSend(fs scpFS, w io.Writer, r io.Reader, path string, recursive bool) error {
+	return scp{fs: fs}.send(w, bufio.NewReader(r), path, recursive)
+}
+
+func SCPReceive(fs scpFS, w io.Writer, r io.Reader, target string) error {
+	return scp{fs: fs}.receive(w, bufio.NewReader(r), target)
+}
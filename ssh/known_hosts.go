@@ -0,0 +1,127 @@
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"golang.org/x/crypto/ssh"
+)
+
+type knownHostsFS interface {
+	fileio.FileReader
+	fileio.FileWriter
+}
+
+// KnownHosts pins the host keys of the jumpbox and director. The first key
+// presented by a host is recorded, and later connections must present the
+// same key. The file uses the OpenSSH known_hosts format.
+type KnownHosts struct {
+	path string
+	fs   knownHostsFS
+}
+
+func NewKnownHosts(path string, fs knownHostsFS) KnownHosts {
+	return KnownHosts{
+		path: path,
+		fs:   fs,
+	}
+}
+
+func (k KnownHosts) HostKeyCallback(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	host := normalizeHost(hostname)
+
+	contents, err := k.fs.ReadFile(k.path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Read known hosts: %s", err)
+	}
+
+	var known bool
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), " ", 2)
+		if len(fields) != 2 || fields[0] != host {
+			continue
+		}
+
+		knownKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(fields[1]))
+		if err != nil {
+			return fmt.Errorf("Parse known host key for %s: %s", host, err)
+		}
+
+		if bytes.Equal(knownKey.Marshal(), key.Marshal()) {
+			return nil
+		}
+		known = true
+	}
+
+	if known {
+		return fmt.Errorf("The host key for %s does not match the key recorded in %s. If the host was recreated, remove its entry from that file.", host, k.path)
+	}
+
+	line := fmt.Sprintf("%s %s", host, ssh.MarshalAuthorizedKey(key))
+	if len(contents) > 0 && !bytes.HasSuffix(contents, []byte("\n")) {
+		contents = append(contents, '\n')
+	}
+
+	err = k.fs.WriteFile(k.path, append(contents, line...), 0600)
+	if err != nil {
+		return fmt.Errorf("Write known hosts: %s", err)
+	}
+
+	return nil
+}
+
+// Forget removes the recorded keys of host, so that the key presented by a
+// recreated VM is pinned on the next connection.
+func (k KnownHosts) Forget(host string) error {
+	host = normalizeHost(host)
+
+	contents, err := k.fs.ReadFile(k.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("Read known hosts: %s", err)
+	}
+
+	var kept []byte
+	var forgotten bool
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(fields) == 2 && fields[0] == host {
+			forgotten = true
+			continue
+		}
+		kept = append(kept, line+"\n"...)
+	}
+
+	if !forgotten {
+		return nil
+	}
+
+	err = k.fs.WriteFile(k.path, kept, 0600)
+	if err != nil {
+		return fmt.Errorf("Write known hosts: %s", err)
+	}
+
+	return nil
+}
+
+// normalizeHost drops the default port, matching how OpenSSH names hosts.
+func normalizeHost(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if port == "22" {
+		return host
+	}
+	return fmt.Sprintf("[%s]:%s", host, port)
+}
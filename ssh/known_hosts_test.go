@@ -0,0 +1,143 @@
+package ssh_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/spf13/afero"
+	gossh "golang.org/x/crypto/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KnownHosts", func() {
+	var (
+		fs         *afero.Afero
+		knownHosts ssh.KnownHosts
+		hostKey    gossh.PublicKey
+	)
+
+	BeforeEach(func() {
+		fs = &afero.Afero{Fs: afero.NewMemMapFs()}
+		knownHosts = ssh.NewKnownHosts("/state/known_hosts", fs)
+		hostKey = newHostKey()
+	})
+
+	It("records the key of a host on first use", func() {
+		err := knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := fs.ReadFile("/state/known_hosts")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal(fmt.Sprintf("10.0.0.5 %s", gossh.MarshalAuthorizedKey(hostKey))))
+	})
+
+	It("names hosts on other ports the way openssh does", func() {
+		err := knownHosts.HostKeyCallback("10.0.0.5:2222", nil, hostKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := fs.ReadFile("/state/known_hosts")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(HavePrefix("[10.0.0.5]:2222 "))
+	})
+
+	It("accepts the recorded key and appends new hosts", func() {
+		Expect(knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)).To(Succeed())
+		Expect(knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)).To(Succeed())
+
+		otherKey := newHostKey()
+		Expect(knownHosts.HostKeyCallback("10.0.0.6:22", nil, otherKey)).To(Succeed())
+
+		contents, err := fs.ReadFile("/state/known_hosts")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal(fmt.Sprintf("10.0.0.5 %s10.0.0.6 %s",
+			gossh.MarshalAuthorizedKey(hostKey),
+			gossh.MarshalAuthorizedKey(otherKey),
+		)))
+	})
+
+	It("refuses a key that does not match the recorded key", func() {
+		Expect(knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)).To(Succeed())
+
+		err := knownHosts.HostKeyCallback("10.0.0.5:22", nil, newHostKey())
+		Expect(err).To(MatchError("The host key for 10.0.0.5 does not match the key recorded in /state/known_hosts. If the host was recreated, remove its entry from that file."))
+	})
+
+	Describe("Forget", func() {
+		It("removes the recorded key of a host and keeps the others", func() {
+			otherKey := newHostKey()
+			Expect(knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)).To(Succeed())
+			Expect(knownHosts.HostKeyCallback("10.0.0.6:22", nil, otherKey)).To(Succeed())
+
+			err := knownHosts.Forget("10.0.0.5")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := fs.ReadFile("/state/known_hosts")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal(fmt.Sprintf("10.0.0.6 %s", gossh.MarshalAuthorizedKey(otherKey))))
+
+			Expect(knownHosts.HostKeyCallback("10.0.0.5:22", nil, newHostKey())).To(Succeed())
+		})
+
+		It("does nothing when there is no known hosts file", func() {
+			err := knownHosts.Forget("10.0.0.5")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fs.Stat("/state/known_hosts")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns an error when the file cannot be written", func() {
+			fileIO := &fakes.FileIO{}
+			fileIO.ReadFileCall.Returns.Contents = []byte(fmt.Sprintf("10.0.0.5 %s", gossh.MarshalAuthorizedKey(hostKey)))
+			fileIO.WriteFileCall.Returns = []fakes.WriteFileReturn{{Error: errors.New("guava")}}
+			knownHosts = ssh.NewKnownHosts("/state/known_hosts", fileIO)
+
+			err := knownHosts.Forget("10.0.0.5")
+			Expect(err).To(MatchError("Write known hosts: guava"))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the file cannot be read", func() {
+			fileIO := &fakes.FileIO{}
+			fileIO.ReadFileCall.Returns.Error = errors.New("kiwi")
+			knownHosts = ssh.NewKnownHosts("/state/known_hosts", fileIO)
+
+			err := knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)
+			Expect(err).To(MatchError("Read known hosts: kiwi"))
+		})
+
+		It("returns an error when the file cannot be written", func() {
+			fileIO := &fakes.FileIO{}
+			fileIO.WriteFileCall.Returns = []fakes.WriteFileReturn{{Error: errors.New("papaya")}}
+			knownHosts = ssh.NewKnownHosts("/state/known_hosts", fileIO)
+
+			err := knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)
+			Expect(err).To(MatchError("Write known hosts: papaya"))
+		})
+
+		It("returns an error when a recorded key cannot be parsed", func() {
+			Expect(fs.WriteFile("/state/known_hosts", []byte("10.0.0.5 ssh-rsa not-a-key\n"), 0600)).To(Succeed())
+
+			err := knownHosts.HostKeyCallback("10.0.0.5:22", nil, hostKey)
+			Expect(err).To(MatchError(ContainSubstring("Parse known host key for 10.0.0.5")))
+		})
+	})
+})
+
+func newHostKey() gossh.PublicKey {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	publicKey, err := gossh.NewPublicKey(&privateKey.PublicKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	return publicKey
+}
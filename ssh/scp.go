@@ -0,0 +1,242 @@
+package ssh
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	"github.com/spf13/afero"
+)
+
+type scpFS interface {
+	fileio.Stater
+	fileio.DirReader
+	fileio.AllMkdirer
+	Open(name string) (afero.File, error)
+	OpenFile(name string, flag int, perm os.FileMode) (afero.File, error)
+}
+
+// scp speaks the protocol used between scp and a remote "scp -t" (sink) or
+// "scp -f" (source), which every stemcell provides.
+type scp struct {
+	fs scpFS
+}
+
+func (s scp) send(w io.Writer, r *bufio.Reader, path string, recursive bool) error {
+	err := readAck(r)
+	if err != nil {
+		return err
+	}
+
+	info, err := s.fs.Stat(path)
+	if err != nil {
+		return fmt.Errorf("Stat %s: %s", path, err)
+	}
+
+	if info.IsDir() {
+		if !recursive {
+			return fmt.Errorf("%s is a directory. Use -r to copy directories.", path)
+		}
+		return s.sendDir(w, r, path, info)
+	}
+
+	return s.sendFile(w, r, path, info)
+}
+
+func (s scp) sendFile(w io.Writer, r *bufio.Reader, path string, info os.FileInfo) error {
+	file, err := s.fs.Open(path)
+	if err != nil {
+		return fmt.Errorf("Open %s: %s", path, err)
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(w, "C%04o %d %s\n", info.Mode().Perm(), info.Size(), info.Name())
+	if err != nil {
+		return err
+	}
+	if err := readAck(r); err != nil {
+		return err
+	}
+
+	_, err = io.CopyN(w, file, info.Size())
+	if err != nil {
+		return fmt.Errorf("Copy %s: %s", path, err)
+	}
+
+	_, err = w.Write([]byte{0})
+	if err != nil {
+		return err
+	}
+
+	return readAck(r)
+}
+
+func (s scp) sendDir(w io.Writer, r *bufio.Reader, path string, info os.FileInfo) error {
+	_, err := fmt.Fprintf(w, "D%04o 0 %s\n", info.Mode().Perm(), info.Name())
+	if err != nil {
+		return err
+	}
+	if err := readAck(r); err != nil {
+		return err
+	}
+
+	entries, err := s.fs.ReadDir(path)
+	if err != nil {
+		return fmt.Errorf("Read directory %s: %s", path, err)
+	}
+
+	for _, entry := range entries {
+		entryPath := filepath.Join(path, entry.Name())
+		if entry.IsDir() {
+			err = s.sendDir(w, r, entryPath, entry)
+		} else if entry.Mode().IsRegular() {
+			err = s.sendFile(w, r, entryPath, entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprint(w, "E\n")
+	if err != nil {
+		return err
+	}
+
+	return readAck(r)
+}
+
+// receive writes what a remote "scp -f" sends to target. As with scp, the
+// top level file or directory is placed inside target when target is an
+// existing directory, and is written to target itself otherwise.
+func (s scp) receive(w io.Writer, r *bufio.Reader, target string) error {
+	var dirs []string
+	destination := func(name string) string {
+		if len(dirs) > 0 {
+			return filepath.Join(dirs[len(dirs)-1], name)
+		}
+		if info, err := s.fs.Stat(target); err == nil && info.IsDir() {
+			return filepath.Join(target, name)
+		}
+		return target
+	}
+
+	if err := ack(w); err != nil {
+		return err
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF && line == "" {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch line[0] {
+		case 1, 2:
+			return errors.New(strings.TrimSpace(line[1:]))
+		case 'T':
+		case 'E':
+			if len(dirs) == 0 {
+				return errors.New("scp: unexpected end of directory")
+			}
+			dirs = dirs[:len(dirs)-1]
+		case 'C', 'D':
+			mode, size, name, err := parseHeader(line)
+			if err != nil {
+				return err
+			}
+			path := destination(name)
+
+			if line[0] == 'D' {
+				err = s.fs.MkdirAll(path, mode)
+				if err != nil {
+					return fmt.Errorf("Create directory %s: %s", path, err)
+				}
+				dirs = append(dirs, path)
+				break
+			}
+
+			if err := ack(w); err != nil {
+				return err
+			}
+			err = s.receiveFile(r, path, mode, size)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("scp: unexpected message %q", strings.TrimSpace(line))
+		}
+
+		if err := ack(w); err != nil {
+			return err
+		}
+	}
+}
+
+func (s scp) receiveFile(r *bufio.Reader, path string, mode os.FileMode, size int64) error {
+	file, err := s.fs.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("Create %s: %s", path, err)
+	}
+	defer file.Close()
+
+	_, err = io.CopyN(file, r, size)
+	if err != nil {
+		return fmt.Errorf("Copy %s: %s", path, err)
+	}
+
+	return readAck(r)
+}
+
+// parseHeader parses a "C0644 12 name" or "D0755 0 name" line. Names that
+// would escape the target directory are refused.
+func parseHeader(line string) (os.FileMode, int64, string, error) {
+	fields := strings.SplitN(strings.TrimSuffix(line[1:], "\n"), " ", 3)
+	if len(fields) != 3 {
+		return 0, 0, "", fmt.Errorf("scp: invalid header %q", strings.TrimSpace(line))
+	}
+
+	mode, err := strconv.ParseUint(fields[0], 8, 32)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("scp: invalid mode %q", fields[0])
+	}
+
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || size < 0 {
+		return 0, 0, "", fmt.Errorf("scp: invalid size %q", fields[1])
+	}
+
+	name := fields[2]
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return 0, 0, "", fmt.Errorf("scp: invalid file name %q", name)
+	}
+
+	return os.FileMode(mode), size, name, nil
+}
+
+func ack(w io.Writer) error {
+	_, err := w.Write([]byte{0})
+	return err
+}
+
+func readAck(r *bufio.Reader) error {
+	status, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if status == 0 {
+		return nil
+	}
+
+	// The remote scp reports its errors as "scp: <message>".
+	message, _ := r.ReadString('\n')
+	return errors.New(strings.TrimSpace(message))
+}
@@ -0,0 +1,108 @@
+package ssh_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scp", func() {
+	var (
+		fs     *afero.Afero
+		remote *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fs = &afero.Afero{Fs: afero.NewMemMapFs()}
+		remote = &bytes.Buffer{}
+	})
+
+	Describe("send", func() {
+		It("sends a file", func() {
+			err := fs.WriteFile("/some/file", []byte("hello"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ssh.SCPSend(fs, remote, strings.NewReader("\x00\x00\x00"), "/some/file", false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(remote.String()).To(Equal("C0644 5 file\nhello\x00"))
+		})
+
+		It("sends a directory when recursive", func() {
+			err := fs.MkdirAll("/some/dir", 0755)
+			Expect(err).NotTo(HaveOccurred())
+			err = fs.WriteFile("/some/dir/file", []byte("hi"), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ssh.SCPSend(fs, remote, strings.NewReader("\x00\x00\x00\x00\x00"), "/some/dir", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(remote.String()).To(Equal("D0755 0 dir\nC0600 2 file\nhi\x00E\n"))
+		})
+
+		Context("when the path is a directory and the copy is not recursive", func() {
+			It("returns an error", func() {
+				err := fs.MkdirAll("/some/dir", 0755)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ssh.SCPSend(fs, remote, strings.NewReader("\x00"), "/some/dir", false)
+				Expect(err).To(MatchError("/some/dir is a directory. Use -r to copy directories."))
+			})
+		})
+
+		Context("when the remote scp reports an error", func() {
+			It("returns the error", func() {
+				err := ssh.SCPSend(fs, remote, strings.NewReader("\x01scp: /nope: Permission denied\n"), "/some/file", false)
+				Expect(err).To(MatchError("scp: /nope: Permission denied"))
+			})
+		})
+	})
+
+	Describe("receive", func() {
+		It("writes a file into an existing directory", func() {
+			err := fs.MkdirAll("/target", 0755)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ssh.SCPReceive(fs, remote, strings.NewReader("C0640 5 file\nhello\x00"), "/target")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := fs.ReadFile("/target/file")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("hello"))
+
+			info, err := fs.Stat("/target/file")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(BeEquivalentTo(0640))
+
+			Expect(remote.String()).To(Equal("\x00\x00\x00"))
+		})
+
+		It("writes a directory to a new path", func() {
+			err := ssh.SCPReceive(fs, remote, strings.NewReader("D0755 0 logs\nC0644 2 a\nhi\x00E\n"), "/copy")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := fs.ReadFile("/copy/a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("hi"))
+		})
+
+		Context("when the remote scp reports an error", func() {
+			It("returns the error", func() {
+				err := ssh.SCPReceive(fs, remote, strings.NewReader("\x01scp: /nope: No such file or directory\n"), "/target")
+				Expect(err).To(MatchError("scp: /nope: No such file or directory"))
+			})
+		})
+
+		Context("when a file name would escape the target", func() {
+			It("returns an error", func() {
+				err := ssh.SCPReceive(fs, remote, strings.NewReader("C0644 2 ../evil\nhi\x00"), "/target")
+				Expect(err).To(MatchError(`scp: invalid file name "../evil"`))
+			})
+		})
+	})
+})
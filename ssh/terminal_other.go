@@ -0,0 +1,20 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package ssh
+
+import "errors"
+
+// On other platforms stdin is never treated as a terminal, so interactive
+// sessions run without a pty.
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("not a terminal")
+}
+
+func terminalSize(fd int) (int, int) {
+	return 80, 24
+}
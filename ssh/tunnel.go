@@ -0,0 +1,104 @@
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+
+	socks5 "github.com/genevieve/go-socks5"
+	"golang.org/x/net/context"
+)
+
+// Forward sends connections to Local through the tunnel to Remote, like
+// ssh -L. Both are host:port.
+type Forward struct {
+	Local  string
+	Remote string
+}
+
+// Tunnel serves a SOCKS5 proxy on socksAddress, and each forward, through the
+// last host until stop is closed or the connection is lost. ready is called
+// with the address of the proxy once every listener is open.
+func (c Client) Tunnel(hops []Host, socksAddress string, forwards []Forward, ready func(string) error, stop <-chan struct{}) error {
+	client, closeAll, err := c.connect(hops)
+	if err != nil {
+		return err
+	}
+	defer closeAll()
+
+	server, err := socks5.New(&socks5.Config{
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return client.Dial(network, address)
+		},
+		Logger: log.New(ioutil.Discard, "", 0),
+	})
+	if err != nil {
+		return fmt.Errorf("Create socks5 proxy: %s", err)
+	}
+
+	socksListener, err := net.Listen("tcp", socksAddress)
+	if err != nil {
+		return fmt.Errorf("Listen on %s: %s", socksAddress, err)
+	}
+	defer socksListener.Close()
+	go server.Serve(socksListener)
+
+	for _, forward := range forwards {
+		listener, err := net.Listen("tcp", forward.Local)
+		if err != nil {
+			return fmt.Errorf("Listen on %s: %s", forward.Local, err)
+		}
+		defer listener.Close()
+		go c.serveForward(listener, client.Dial, forward.Remote)
+	}
+
+	err = ready(socksListener.Addr().String())
+	if err != nil {
+		return err
+	}
+
+	lost := make(chan error, 1)
+	go func() {
+		lost <- client.Wait()
+	}()
+
+	select {
+	case <-stop:
+		return nil
+	case err := <-lost:
+		return fmt.Errorf("Lost the connection to %s: %v", hops[len(hops)-1].Address, err)
+	}
+}
+
+func (c Client) serveForward(listener net.Listener, dial func(string, string) (net.Conn, error), remote string) {
+	for {
+		local, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer local.Close()
+
+			conn, err := dial("tcp", remote)
+			if err != nil {
+				fmt.Fprintf(c.err, "Connect to %s: %s\n", remote, err)
+				return
+			}
+			defer conn.Close()
+
+			done := make(chan struct{}, 2)
+			go func() {
+				io.Copy(conn, local)
+				done <- struct{}{}
+			}()
+			go func() {
+				io.Copy(local, conn)
+				done <- struct{}{}
+			}()
+			<-done
+		}()
+	}
+}
@@ -0,0 +1,111 @@
+package ssh_test
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/ssh"
+	proxy "github.com/cloudfoundry/socks5-proxy"
+	"github.com/spf13/afero"
+	netproxy "golang.org/x/net/proxy"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tunnel", func() {
+	var (
+		client      ssh.Client
+		httpServer  *httptest.Server
+		httpAddress string
+		hops        []ssh.Host
+		stop        chan struct{}
+		done        chan error
+	)
+
+	BeforeEach(func() {
+		httpServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		httpAddress = strings.TrimPrefix(httpServer.URL, "http://")
+
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		privateKeyPEM := string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+		}))
+
+		sshAddress := proxy.StartTestSSHServer(httpAddress, privateKeyPEM, "")
+		hops = []ssh.Host{{Address: sshAddress, User: "jumpbox", PrivateKey: privateKeyPEM}}
+
+		fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+		client = ssh.NewClient(ssh.NewKnownHosts("/known_hosts", fs), fs, nil, GinkgoWriter, GinkgoWriter)
+
+		stop = make(chan struct{})
+		done = make(chan error, 1)
+	})
+
+	AfterEach(func() {
+		close(stop)
+		Eventually(done).Should(Receive(BeNil()))
+		httpServer.Close()
+	})
+
+	start := func(forwards []ssh.Forward) string {
+		addresses := make(chan string, 1)
+		go func() {
+			done <- client.Tunnel(hops, "127.0.0.1:0", forwards, func(address string) error {
+				addresses <- address
+				return nil
+			}, stop)
+		}()
+
+		var address string
+		Eventually(addresses, "5s").Should(Receive(&address))
+		return address
+	}
+
+	request := func(conn net.Conn) string {
+		defer conn.Close()
+
+		_, err := fmt.Fprint(conn, "GET / HTTP/1.0\n")
+		Expect(err).NotTo(HaveOccurred())
+
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		return strings.TrimSpace(line)
+	}
+
+	It("proxies connections through the host with socks5", func() {
+		socksAddress := start(nil)
+
+		dialer, err := netproxy.SOCKS5("tcp", socksAddress, nil, netproxy.Direct)
+		Expect(err).NotTo(HaveOccurred())
+
+		conn, err := dialer.Dial("tcp", httpAddress)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(request(conn)).To(Equal("HTTP/1.0 200 OK"))
+	})
+
+	It("forwards a local port through the host", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		localAddress := listener.Addr().String()
+		listener.Close()
+
+		start([]ssh.Forward{{Local: localAddress, Remote: httpAddress}})
+
+		conn, err := net.Dial("tcp", localAddress)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(request(conn)).To(Equal("HTTP/1.0 200 OK"))
+	})
+})
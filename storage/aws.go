@@ -1,7 +1,14 @@
 package storage
 
 type AWS struct {
-	AccessKeyID     string `json:"-"`
-	SecretAccessKey string `json:"-"`
-	Region          string `json:"region,omitempty"`
+	AccessKeyID     string    `json:"-"`
+	SecretAccessKey string    `json:"-"`
+	Region          string    `json:"region,omitempty"`
+	Sites           []AWSSite `json:"sites,omitempty"`
+}
+
+type AWSSite struct {
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	VPCCIDR string `json:"vpcCIDR"`
 }
@@ -0,0 +1,39 @@
+package storage
+
+// CredentialEnv returns the BBL_* variables that carry the IaaS credentials
+// of state to the create-env and delete-env scripts and to the hooks.
+func CredentialEnv(state State) map[string]string {
+	switch state.IAAS {
+	case "aws":
+		return map[string]string{
+			"BBL_AWS_ACCESS_KEY_ID":     state.AWS.AccessKeyID,
+			"BBL_AWS_SECRET_ACCESS_KEY": state.AWS.SecretAccessKey,
+			"BBL_AWS_REGION":            state.AWS.Region,
+		}
+	case "azure":
+		return map[string]string{
+			"BBL_AZURE_CLIENT_ID":       state.Azure.ClientID,
+			"BBL_AZURE_CLIENT_SECRET":   state.Azure.ClientSecret,
+			"BBL_AZURE_SUBSCRIPTION_ID": state.Azure.SubscriptionID,
+			"BBL_AZURE_TENANT_ID":       state.Azure.TenantID,
+		}
+	case "gcp":
+		return map[string]string{
+			"BBL_GCP_SERVICE_ACCOUNT_KEY_PATH": state.GCP.ServiceAccountKeyPath,
+			"BBL_GCP_ZONE":                     state.GCP.Zone,
+			"BBL_GCP_PROJECT_ID":               state.GCP.ProjectID,
+		}
+	case "vsphere":
+		return map[string]string{
+			"BBL_VSPHERE_VCENTER_USER":     state.VSphere.VCenterUser,
+			"BBL_VSPHERE_VCENTER_PASSWORD": state.VSphere.VCenterPassword,
+		}
+	case "openstack":
+		return map[string]string{
+			"BBL_OPENSTACK_USERNAME": state.OpenStack.Username,
+			"BBL_OPENSTACK_PASSWORD": state.OpenStack.Password,
+		}
+	}
+
+	return map[string]string{}
+}
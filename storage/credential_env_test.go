@@ -0,0 +1,34 @@
+package storage_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredentialEnv", func() {
+	It("returns the credentials of the iaas in the state", func() {
+		env := storage.CredentialEnv(storage.State{
+			IAAS: "aws",
+			AWS: storage.AWS{
+				AccessKeyID:     "some-access-key-id",
+				SecretAccessKey: "some-secret-access-key",
+				Region:          "some-region",
+			},
+			Azure: storage.Azure{ClientID: "some-client-id"},
+		})
+
+		Expect(env).To(Equal(map[string]string{
+			"BBL_AWS_ACCESS_KEY_ID":     "some-access-key-id",
+			"BBL_AWS_SECRET_ACCESS_KEY": "some-secret-access-key",
+			"BBL_AWS_REGION":            "some-region",
+		}))
+	})
+
+	Context("when the iaas is not set", func() {
+		It("returns no variables", func() {
+			Expect(storage.CredentialEnv(storage.State{})).To(BeEmpty())
+		})
+	})
+})
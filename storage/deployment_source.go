@@ -0,0 +1,8 @@
+package storage
+
+// DeploymentSource records a local checkout of bosh-deployment or
+// jumpbox-deployment that is used instead of the copy embedded in bbl.
+type DeploymentSource struct {
+	Path   string `json:"path,omitempty"`
+	Commit string `json:"commit,omitempty"`
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"encoding/json"
+	"time"
 
 	uuid "github.com/nu7hatch/gouuid"
 )
@@ -21,3 +22,7 @@ func SetUUIDNewV4(f func() (uuid *uuid.UUID, err error)) {
 func ResetUUIDNewV4() {
 	uuidNewV4 = uuid.NewV4
 }
+
+func SetManagedFilesNow(m *ManagedFiles, now func() time.Time) {
+	m.now = now
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
 )
 
 var bblManaged = map[string]struct{}{
@@ -15,15 +17,22 @@ var bblManaged = map[string]struct{}{
 	"jumpbox-state.json":       struct{}{},
 	"jumpbox-vars-file.yml":    struct{}{},
 	"jumpbox-vars-store.yml":   struct{}{},
+	"runtime-config-vars.yml":  struct{}{},
+	"runtime-configs.yml":      struct{}{},
 	"terraform.tfstate":        struct{}{},
 	"terraform.tfstate.backup": struct{}{},
 }
 
+type gcFS interface {
+	fs
+	fileio.FileReader
+}
+
 type GarbageCollector struct {
-	fs fs
+	fs gcFS
 }
 
-func NewGarbageCollector(fs fs) GarbageCollector {
+func NewGarbageCollector(fs gcFS) GarbageCollector {
 	return GarbageCollector{
 		fs: fs,
 	}
@@ -36,34 +45,54 @@ func (g GarbageCollector) Remove(dir string) error {
 		return fmt.Errorf("Removing %s: %s", bblStateJson, err)
 	}
 
+	// Files that came from a plan patch are left for bbl patch remove.
+	patches, err := ReadPatches(g.fs, dir)
+	if err != nil {
+		return err
+	}
+	owners := PatchOwners(patches)
+	remove := func(file string) {
+		if _, ok := owners[file]; ok {
+			return
+		}
+		g.fs.Remove(filepath.Join(dir, file))
+	}
+
 	g.fs.RemoveAll(filepath.Join(dir, "bosh-deployment"))
 	g.fs.RemoveAll(filepath.Join(dir, "jumpbox-deployment"))
 	g.fs.RemoveAll(filepath.Join(dir, "bbl-ops-files"))
 
 	tfDir := filepath.Join(dir, "terraform")
-	g.fs.Remove(filepath.Join(tfDir, "bbl-template.tf"))
+	remove(filepath.Join("terraform", "bbl-template.tf"))
 	g.fs.RemoveAll(filepath.Join(tfDir, ".terraform"))
 	g.fs.Remove(tfDir)
 
 	ccDir := filepath.Join(dir, "cloud-config")
-	g.fs.Remove(filepath.Join(ccDir, "cloud-config.yml"))
-	g.fs.Remove(filepath.Join(ccDir, "ops.yml"))
+	remove(filepath.Join("cloud-config", "cloud-config.yml"))
+	remove(filepath.Join("cloud-config", "ops.yml"))
 	g.fs.Remove(ccDir)
 
+	g.fs.Remove(filepath.Join(dir, "runtime-config"))
+
 	vDir := filepath.Join(dir, "vars")
 	vFiles, _ := g.fs.ReadDir(vDir)
 	for _, f := range vFiles {
 		if _, ok := bblManaged[f.Name()]; ok {
-			_ = g.fs.Remove(filepath.Join(vDir, f.Name()))
+			remove(filepath.Join("vars", f.Name()))
 		}
 	}
 	g.fs.Remove(filepath.Join(dir, "vars"))
 
 	g.fs.RemoveAll(filepath.Join(dir, ".terraform"))
-	g.fs.Remove(filepath.Join(dir, "create-jumpbox.sh"))
-	g.fs.Remove(filepath.Join(dir, "create-director.sh"))
-	g.fs.Remove(filepath.Join(dir, "delete-jumpbox.sh"))
-	g.fs.Remove(filepath.Join(dir, "delete-director.sh"))
+	remove("create-jumpbox.sh")
+	remove("create-director.sh")
+	remove("delete-jumpbox.sh")
+	remove("delete-director.sh")
+	g.fs.Remove(filepath.Join(dir, KNOWN_HOSTS_FILE))
+	g.fs.Remove(filepath.Join(dir, TUNNEL_ADDRESS_FILE))
+	g.fs.Remove(filepath.Join(dir, TUNNEL_PID_FILE))
+	g.fs.Remove(filepath.Join(dir, TUNNEL_LOG_FILE))
+	g.fs.Remove(filepath.Join(dir, MANAGED_FILES_FILE))
 
 	return nil
 }
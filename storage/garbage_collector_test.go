@@ -46,6 +46,29 @@ var _ = Describe("garbage collector", func() {
 			Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{Name: createJumpbox}))
 		})
 
+		It("removes the pinned host keys", func() {
+			err := gc.Remove("some-dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{Name: filepath.Join("some-dir", "known_hosts")}))
+		})
+
+		It("removes the files left by bbl tunnel", func() {
+			err := gc.Remove("some-dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{Name: filepath.Join("some-dir", "tunnel.address")}))
+			Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{Name: filepath.Join("some-dir", "tunnel.pid")}))
+			Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{Name: filepath.Join("some-dir", "tunnel.log")}))
+		})
+
+		It("removes the hashes of bbl-managed files", func() {
+			err := gc.Remove("some-dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{Name: filepath.Join("some-dir", "bbl-managed-files.json")}))
+		})
+
 		DescribeTable("removing bbl-created directories",
 			func(directory string, expectToBeDeleted bool) {
 				err := gc.Remove("some-dir")
@@ -90,6 +113,20 @@ var _ = Describe("garbage collector", func() {
 			})
 		})
 
+		Describe("runtime-config", func() {
+			It("removes the directory only if it is empty", func() {
+				err := gc.Remove("some-dir")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{
+					Name: filepath.Join("some-dir", "runtime-config"),
+				}))
+				Expect(fileIO.RemoveAllCall.Receives).NotTo(ContainElement(fakes.RemoveAllReceive{
+					Path: filepath.Join("some-dir", "runtime-config"),
+				}))
+			})
+		})
+
 		Describe("vars", func() {
 			Context("when the vars directory contains only bbl files", func() {
 				BeforeEach(func() {
@@ -102,6 +139,7 @@ var _ = Describe("garbage collector", func() {
 						fakes.FileInfo{FileName: "jumpbox-state.json"},
 						fakes.FileInfo{FileName: "jumpbox-vars-file.yml"},
 						fakes.FileInfo{FileName: "jumpbox-vars-store.yml"},
+						fakes.FileInfo{FileName: "runtime-config-vars.yml"},
 						fakes.FileInfo{FileName: "terraform.tfstate"},
 						fakes.FileInfo{FileName: "terraform.tfstate.backup"},
 					}
@@ -153,6 +191,33 @@ var _ = Describe("garbage collector", func() {
 			})
 		})
 
+		Context("when plan patches have been added", func() {
+			BeforeEach(func() {
+				fileIO.ReadFileCall.Fake = func(filename string) ([]byte, error) {
+					Expect(filename).To(Equal(filepath.Join("some-dir", "bbl-patches.json")))
+					return []byte(`{"patches": [{"name": "some-patch", "files": {
+						"cloud-config/cloud-config.yml": "some-hash",
+						"create-director.sh": "some-hash"
+					}}]}`), nil
+				}
+			})
+
+			It("spares the files that came from the patches", func() {
+				err := gc.Remove("some-dir")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fileIO.RemoveCall.Receives).NotTo(ContainElement(fakes.RemoveReceive{
+					Name: filepath.Join("some-dir", "cloud-config", "cloud-config.yml"),
+				}))
+				Expect(fileIO.RemoveCall.Receives).NotTo(ContainElement(fakes.RemoveReceive{
+					Name: filepath.Join("some-dir", "create-director.sh"),
+				}))
+				Expect(fileIO.RemoveCall.Receives).To(ContainElement(fakes.RemoveReceive{
+					Name: filepath.Join("some-dir", "cloud-config", "ops.yml"),
+				}))
+			})
+		})
+
 		Context("when the bbl-state.json file does not exist", func() {
 			It("does nothing", func() {
 				err := gc.Remove("some-dir")
@@ -163,6 +228,15 @@ var _ = Describe("garbage collector", func() {
 		})
 
 		Context("failure cases", func() {
+			Context("when the plan patches cannot be read", func() {
+				It("returns an error", func() {
+					fileIO.ReadFileCall.Returns.Contents = []byte("%%%")
+
+					err := gc.Remove("some-dir")
+					Expect(err).To(MatchError(ContainSubstring("Parse bbl-patches.json")))
+				})
+			})
+
 			Context("when the bbl-state.json file cannot be removed", func() {
 				BeforeEach(func() {
 					fileIO.RemoveCall.Returns = []fakes.RemoveReturn{{Error: errors.New("permission denied")}}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+)
+
+// BACKUPS_DIR holds copies of bbl-managed files that were modified locally
+// and overwritten with --force.
+const BACKUPS_DIR = "bbl-backups"
+
+var managedFiles = []string{
+	filepath.Join("terraform", "bbl-template.tf"),
+	filepath.Join("cloud-config", "cloud-config.yml"),
+	filepath.Join("cloud-config", "ops.yml"),
+	"create-jumpbox.sh",
+	"create-director.sh",
+	"delete-jumpbox.sh",
+	"delete-director.sh",
+}
+
+var managedDirs = []string{
+	"bosh-deployment",
+	"jumpbox-deployment",
+	"bbl-ops-files",
+}
+
+// IsManagedFile returns true for the files, relative to the state dir, that
+// bbl generates on every plan.
+func IsManagedFile(file string) bool {
+	for _, managedFile := range managedFiles {
+		if file == managedFile {
+			return true
+		}
+	}
+	return false
+}
+
+type managedFilesFS interface {
+	fileio.FileReader
+	fileio.FileWriter
+	fileio.DirReader
+	fileio.AllMkdirer
+}
+
+// ManagedFiles records the hashes of the files bbl generates on every plan,
+// so that local edits to them can be found before they are overwritten.
+type ManagedFiles struct {
+	fs  managedFilesFS
+	dir string
+	now func() time.Time
+}
+
+func NewManagedFiles(fs managedFilesFS, dir string) ManagedFiles {
+	return ManagedFiles{
+		fs:  fs,
+		dir: dir,
+		now: time.Now,
+	}
+}
+
+// Modified returns the managed files whose contents differ from what bbl
+// last wrote. Deleted files are not reported, since bbl writes them again.
+func (m ManagedFiles) Modified() ([]string, error) {
+	hashes, err := m.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []string
+	for file, hash := range hashes {
+		contents, err := m.fs.ReadFile(filepath.Join(m.dir, file))
+		if err != nil {
+			continue
+		}
+		if FileHash(contents) != hash {
+			modified = append(modified, file)
+		}
+	}
+	sort.Strings(modified)
+
+	return modified, nil
+}
+
+// Backup copies the files to a new directory under bbl-backups and returns
+// its path.
+func (m ManagedFiles) Backup(files []string) (string, error) {
+	backupDir := filepath.Join(m.dir, BACKUPS_DIR, m.now().UTC().Format("20060102T150405Z"))
+
+	for _, file := range files {
+		contents, err := m.fs.ReadFile(filepath.Join(m.dir, file))
+		if err != nil {
+			return "", fmt.Errorf("Back up %s: %s", file, err)
+		}
+
+		destination := filepath.Join(backupDir, file)
+		err = m.fs.MkdirAll(filepath.Dir(destination), os.ModePerm)
+		if err != nil {
+			return "", fmt.Errorf("Back up %s: %s", file, err)
+		}
+
+		err = m.fs.WriteFile(destination, contents, StateMode)
+		if err != nil {
+			return "", fmt.Errorf("Back up %s: %s", file, err)
+		}
+	}
+
+	return backupDir, nil
+}
+
+// Record hashes the managed files as they are now. Files that came from a
+// plan patch are left to bbl patch.
+func (m ManagedFiles) Record() error {
+	patches, err := ReadPatches(m.fs, m.dir)
+	if err != nil {
+		return err
+	}
+	owners := PatchOwners(patches)
+
+	files := append([]string{}, managedFiles...)
+	for _, dir := range managedDirs {
+		files = append(files, m.walk(dir)...)
+	}
+
+	hashes := map[string]string{}
+	for _, file := range files {
+		if _, ok := owners[file]; ok {
+			continue
+		}
+
+		contents, err := m.fs.ReadFile(filepath.Join(m.dir, file))
+		if err != nil {
+			continue
+		}
+		hashes[file] = FileHash(contents)
+	}
+
+	contents, err := json.MarshalIndent(hashes, "", "\t")
+	if err != nil {
+		return fmt.Errorf("Marshal %s: %s", MANAGED_FILES_FILE, err) // not tested
+	}
+
+	err = m.fs.WriteFile(filepath.Join(m.dir, MANAGED_FILES_FILE), contents, StateMode)
+	if err != nil {
+		return fmt.Errorf("Write %s: %s", MANAGED_FILES_FILE, err)
+	}
+
+	return nil
+}
+
+func (m ManagedFiles) read() (map[string]string, error) {
+	contents, err := m.fs.ReadFile(filepath.Join(m.dir, MANAGED_FILES_FILE))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Read %s: %s", MANAGED_FILES_FILE, err)
+	}
+
+	var hashes map[string]string
+	err = json.Unmarshal(contents, &hashes)
+	if err != nil {
+		return nil, fmt.Errorf("Parse %s: %s", MANAGED_FILES_FILE, err)
+	}
+
+	return hashes, nil
+}
+
+func (m ManagedFiles) walk(dir string) []string {
+	infos, err := m.fs.ReadDir(filepath.Join(m.dir, dir))
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, info := range infos {
+		path := filepath.Join(dir, info.Name())
+		if info.IsDir() {
+			files = append(files, m.walk(path)...)
+		} else {
+			files = append(files, path)
+		}
+	}
+	return files
+}
@@ -0,0 +1,122 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/spf13/afero"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ManagedFiles", func() {
+	var (
+		fs           *afero.Afero
+		managedFiles storage.ManagedFiles
+	)
+
+	BeforeEach(func() {
+		fs = &afero.Afero{Fs: afero.NewMemMapFs()}
+		fs.MkdirAll("/state/terraform", os.ModePerm)
+		fs.MkdirAll("/state/bosh-deployment/aws", os.ModePerm)
+		fs.WriteFile("/state/terraform/bbl-template.tf", []byte("template"), storage.StateMode)
+		fs.WriteFile("/state/create-director.sh", []byte("create"), storage.ScriptMode)
+		fs.WriteFile("/state/bosh-deployment/aws/cpi.yml", []byte("cpi"), storage.StateMode)
+
+		managedFiles = storage.NewManagedFiles(fs, "/state")
+		storage.SetManagedFilesNow(&managedFiles, func() time.Time {
+			return time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+		})
+	})
+
+	It("reports no modified files before any are recorded", func() {
+		modified, err := managedFiles.Modified()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(modified).To(BeEmpty())
+	})
+
+	Context("when the files have been recorded", func() {
+		BeforeEach(func() {
+			Expect(managedFiles.Record()).To(Succeed())
+		})
+
+		It("reports no modified files", func() {
+			modified, err := managedFiles.Modified()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(modified).To(BeEmpty())
+		})
+
+		It("reports files that were edited, including those in the deployment dirs", func() {
+			fs.WriteFile("/state/terraform/bbl-template.tf", []byte("edited"), storage.StateMode)
+			fs.WriteFile("/state/bosh-deployment/aws/cpi.yml", []byte("edited"), storage.StateMode)
+
+			modified, err := managedFiles.Modified()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(modified).To(Equal([]string{
+				filepath.Join("bosh-deployment", "aws", "cpi.yml"),
+				filepath.Join("terraform", "bbl-template.tf"),
+			}))
+		})
+
+		It("does not report deleted files", func() {
+			fs.Remove("/state/create-director.sh")
+
+			modified, err := managedFiles.Modified()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(modified).To(BeEmpty())
+		})
+	})
+
+	Context("when a plan patch owns a managed file", func() {
+		BeforeEach(func() {
+			err := storage.WritePatches(fs, "/state", []storage.Patch{{
+				Name:  "some-patch",
+				Files: map[string]string{"create-director.sh": "some-hash"},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(managedFiles.Record()).To(Succeed())
+		})
+
+		It("leaves it to bbl patch", func() {
+			fs.WriteFile("/state/create-director.sh", []byte("edited"), storage.ScriptMode)
+
+			modified, err := managedFiles.Modified()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(modified).To(BeEmpty())
+		})
+	})
+
+	Describe("Backup", func() {
+		It("copies the files to a timestamped directory under bbl-backups", func() {
+			dir, err := managedFiles.Backup([]string{filepath.Join("terraform", "bbl-template.tf")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dir).To(Equal("/state/bbl-backups/20261018T093000Z"))
+
+			contents, err := fs.ReadFile("/state/bbl-backups/20261018T093000Z/terraform/bbl-template.tf")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("template"))
+		})
+
+		It("returns an error when a file cannot be read", func() {
+			_, err := managedFiles.Backup([]string{"missing.sh"})
+			Expect(err).To(MatchError(ContainSubstring("Back up missing.sh")))
+		})
+	})
+
+	Describe("IsManagedFile", func() {
+		It("returns true for the files bbl generates on every plan", func() {
+			Expect(storage.IsManagedFile(filepath.Join("cloud-config", "ops.yml"))).To(BeTrue())
+			Expect(storage.IsManagedFile(filepath.Join("cloud-config", "lb-ops.yml"))).To(BeFalse())
+		})
+	})
+
+	It("returns an error when the recorded hashes cannot be parsed", func() {
+		fs.WriteFile("/state/bbl-managed-files.json", []byte("%%%"), storage.StateMode)
+
+		_, err := managedFiles.Modified()
+		Expect(err).To(MatchError(ContainSubstring("Parse bbl-managed-files.json")))
+	})
+})
@@ -0,0 +1,35 @@
+package storage
+
+const (
+	DefaultJumpboxIPOffset  = 5
+	DefaultDirectorIPOffset = 6
+)
+
+// Network records the address ranges requested with --network-cidr and
+// --internal-cidr and the offsets of the jumpbox and director within the
+// internal CIDR. Empty values fall back to the bbl defaults. DualStack
+// additionally assigns IPv6 CIDRs to the network on IaaSes that support it.
+// AllowedCIDRs limits the sources that may reach the jumpbox and director;
+// when empty they are open to 0.0.0.0/0.
+type Network struct {
+	NetworkCIDR      string   `json:"networkCIDR,omitempty"`
+	InternalCIDR     string   `json:"internalCIDR,omitempty"`
+	JumpboxIPOffset  int      `json:"jumpboxIPOffset,omitempty"`
+	DirectorIPOffset int      `json:"directorIPOffset,omitempty"`
+	DualStack        bool     `json:"dualStack,omitempty"`
+	AllowedCIDRs     []string `json:"allowedCIDRs,omitempty"`
+}
+
+func (n Network) JumpboxOffset() int {
+	if n.JumpboxIPOffset == 0 {
+		return DefaultJumpboxIPOffset
+	}
+	return n.JumpboxIPOffset
+}
+
+func (n Network) DirectorOffset() int {
+	if n.DirectorIPOffset == 0 {
+		return DefaultDirectorIPOffset
+	}
+	return n.DirectorIPOffset
+}
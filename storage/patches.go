@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// PATCH_METADATA_FILE declares what a plan patch is compatible with. It
+// lives in the patch directory and is recorded in bbl-patches.json
+// instead of being copied to the state dir.
+const PATCH_METADATA_FILE = "bbl-patch.yml"
+
+// Patch records a plan patch applied with bbl patch add. Files maps the
+// path of each file relative to the state dir to the sha256 of the
+// contents that were copied.
+type Patch struct {
+	Name   string            `json:"name"`
+	Source string            `json:"source"`
+	Files  map[string]string `json:"files"`
+
+	Metadata *PatchMetadata `json:"metadata,omitempty"`
+}
+
+// PatchMetadata lists the IaaSes and bbl versions a plan patch supports,
+// and the bbl-generated terraform resources and outputs it overrides or
+// depends on. BBLVersion.Max is exclusive.
+type PatchMetadata struct {
+	IAASes     []string          `yaml:"iaases"      json:"iaases,omitempty"`
+	BBLVersion PatchVersionRange `yaml:"bbl_version" json:"bbl_version"`
+	Terraform  PatchTerraform    `yaml:"terraform"   json:"terraform"`
+}
+
+type PatchVersionRange struct {
+	Min string `yaml:"min" json:"min,omitempty"`
+	Max string `yaml:"max" json:"max,omitempty"`
+}
+
+type PatchTerraform struct {
+	Resources []string `yaml:"resources" json:"resources,omitempty"`
+	Outputs   []string `yaml:"outputs"   json:"outputs,omitempty"`
+}
+
+type patchManifest struct {
+	Patches []Patch `json:"patches"`
+}
+
+func ReadPatches(fs fileio.FileReader, dir string) ([]Patch, error) {
+	contents, err := fs.ReadFile(filepath.Join(dir, PATCHES_FILE))
+	if os.IsNotExist(err) || (err == nil && len(contents) == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Read %s: %s", PATCHES_FILE, err)
+	}
+
+	var manifest patchManifest
+	err = json.Unmarshal(contents, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Parse %s: %s", PATCHES_FILE, err)
+	}
+
+	return manifest.Patches, nil
+}
+
+func WritePatches(fs fileio.FileWriter, dir string, patches []Patch) error {
+	if patches == nil {
+		patches = []Patch{}
+	}
+
+	contents, err := json.MarshalIndent(patchManifest{Patches: patches}, "", "\t")
+	if err != nil {
+		return fmt.Errorf("Marshal %s: %s", PATCHES_FILE, err) // not tested
+	}
+
+	err = fs.WriteFile(filepath.Join(dir, PATCHES_FILE), contents, StateMode)
+	if err != nil {
+		return fmt.Errorf("Write %s: %s", PATCHES_FILE, err)
+	}
+
+	return nil
+}
+
+// ReadPatchMetadata returns nil when the patch in dir has no metadata file.
+func ReadPatchMetadata(fs fileio.FileReader, dir string) (*PatchMetadata, error) {
+	contents, err := fs.ReadFile(filepath.Join(dir, PATCH_METADATA_FILE))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Read %s: %s", PATCH_METADATA_FILE, err)
+	}
+
+	var metadata PatchMetadata
+	err = yaml.UnmarshalStrict(contents, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("Parse %s: %s", PATCH_METADATA_FILE, err)
+	}
+
+	return &metadata, nil
+}
+
+// PatchOwners maps the files of the patches to the names of the patches
+// they came from.
+func PatchOwners(patches []Patch) map[string]string {
+	owners := map[string]string{}
+	for _, patch := range patches {
+		for file := range patch.Files {
+			owners[file] = patch.Name
+		}
+	}
+	return owners
+}
+
+func FileHash(contents []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(contents))
+}
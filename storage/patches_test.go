@@ -0,0 +1,121 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Patches", func() {
+	var fileIO *fakes.FileIO
+
+	BeforeEach(func() {
+		fileIO = &fakes.FileIO{}
+	})
+
+	Describe("ReadPatches", func() {
+		It("reads the patches from bbl-patches.json", func() {
+			fileIO.ReadFileCall.Returns.Contents = []byte(`{"patches": [{"name": "some-patch", "source": "/some/source", "files": {"terraform/some.tf": "some-hash"}}]}`)
+
+			patches, err := storage.ReadPatches(fileIO, "some-dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal("some-dir/bbl-patches.json"))
+			Expect(patches).To(Equal([]storage.Patch{{
+				Name:   "some-patch",
+				Source: "/some/source",
+				Files:  map[string]string{"terraform/some.tf": "some-hash"},
+			}}))
+		})
+
+		It("returns no patches when the file does not exist", func() {
+			fileIO.ReadFileCall.Returns.Error = os.ErrNotExist
+
+			patches, err := storage.ReadPatches(fileIO, "some-dir")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(patches).To(BeEmpty())
+		})
+
+		It("returns an error when the file cannot be read", func() {
+			fileIO.ReadFileCall.Returns.Error = errors.New("permission denied")
+
+			_, err := storage.ReadPatches(fileIO, "some-dir")
+			Expect(err).To(MatchError("Read bbl-patches.json: permission denied"))
+		})
+	})
+
+	Describe("WritePatches", func() {
+		It("writes the patches to bbl-patches.json", func() {
+			err := storage.WritePatches(fileIO, "some-dir", []storage.Patch{{Name: "some-patch"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.WriteFileCall.Receives[0].Filename).To(Equal("some-dir/bbl-patches.json"))
+			Expect(string(fileIO.WriteFileCall.Receives[0].Contents)).To(ContainSubstring(`"name": "some-patch"`))
+		})
+
+		It("returns an error when the file cannot be written", func() {
+			fileIO.WriteFileCall.Returns = []fakes.WriteFileReturn{{Error: errors.New("disk full")}}
+
+			err := storage.WritePatches(fileIO, "some-dir", nil)
+			Expect(err).To(MatchError("Write bbl-patches.json: disk full"))
+		})
+	})
+
+	Describe("ReadPatchMetadata", func() {
+		It("reads bbl-patch.yml from the patch directory", func() {
+			fileIO.ReadFileCall.Returns.Contents = []byte(`iaases: [aws]
+bbl_version:
+  min: 6.0.0
+  max: 7.0.0
+terraform:
+  resources: [aws_elb.cf_router_lb]
+  outputs: [cf_router_lb_name]
+`)
+
+			metadata, err := storage.ReadPatchMetadata(fileIO, "some-patch")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal("some-patch/bbl-patch.yml"))
+			Expect(metadata).To(Equal(&storage.PatchMetadata{
+				IAASes:     []string{"aws"},
+				BBLVersion: storage.PatchVersionRange{Min: "6.0.0", Max: "7.0.0"},
+				Terraform: storage.PatchTerraform{
+					Resources: []string{"aws_elb.cf_router_lb"},
+					Outputs:   []string{"cf_router_lb_name"},
+				},
+			}))
+		})
+
+		It("returns no metadata when the file does not exist", func() {
+			fileIO.ReadFileCall.Returns.Error = os.ErrNotExist
+
+			metadata, err := storage.ReadPatchMetadata(fileIO, "some-patch")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metadata).To(BeNil())
+		})
+
+		It("returns an error for unknown keys", func() {
+			fileIO.ReadFileCall.Returns.Contents = []byte("iaas: aws\n")
+
+			_, err := storage.ReadPatchMetadata(fileIO, "some-patch")
+			Expect(err).To(MatchError(ContainSubstring("Parse bbl-patch.yml")))
+		})
+	})
+
+	Describe("PatchOwners", func() {
+		It("maps files to the patches they came from", func() {
+			Expect(storage.PatchOwners([]storage.Patch{
+				{Name: "first", Files: map[string]string{"terraform/a.tf": "a"}},
+				{Name: "second", Files: map[string]string{"vars/b.tfvars": "b"}},
+			})).To(Equal(map[string]string{
+				"terraform/a.tf": "first",
+				"vars/b.tfvars":  "second",
+			}))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package storage
+
+// Quota compares how much of a resource bbl is about to create in a region
+// with how much is already in use there and the limit of the region.
+// Assumed is set when the IaaS does not report the limit of the account, so
+// Limit is only the default limit of the IaaS.
+type Quota struct {
+	Name     string
+	Required int
+	InUse    int
+	Limit    int
+	Assumed  bool
+}
+
+func (q Quota) Exceeded() bool {
+	return q.InUse+q.Required > q.Limit
+}
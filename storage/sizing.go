@@ -0,0 +1,34 @@
+package storage
+
+type Sizing struct {
+	DirectorVMType   string `json:"directorVMType,omitempty"`
+	DirectorDiskSize int    `json:"directorDiskSize,omitempty"`
+	JumpboxVMType    string `json:"jumpboxVMType,omitempty"`
+	StemcellLine     string `json:"stemcellLine,omitempty"`
+	StemcellVersion  string `json:"stemcellVersion,omitempty"`
+	StemcellSHA1     string `json:"stemcellSHA1,omitempty"`
+}
+
+// Merge returns the sizing with every field that is set in other
+// overriding the corresponding field of s.
+func (s Sizing) Merge(other Sizing) Sizing {
+	if other.DirectorVMType != "" {
+		s.DirectorVMType = other.DirectorVMType
+	}
+	if other.DirectorDiskSize != 0 {
+		s.DirectorDiskSize = other.DirectorDiskSize
+	}
+	if other.JumpboxVMType != "" {
+		s.JumpboxVMType = other.JumpboxVMType
+	}
+	if other.StemcellLine != "" {
+		s.StemcellLine = other.StemcellLine
+	}
+	if other.StemcellVersion != "" {
+		s.StemcellVersion = other.StemcellVersion
+	}
+	if other.StemcellSHA1 != "" {
+		s.StemcellSHA1 = other.StemcellSHA1
+	}
+	return s
+}
@@ -17,8 +17,17 @@ var (
 )
 
 const (
-	STATE_SCHEMA = 14
-	STATE_FILE   = "bbl-state.json"
+	STATE_SCHEMA     = 14
+	STATE_FILE       = "bbl-state.json"
+	KNOWN_HOSTS_FILE = "known_hosts"
+
+	TUNNEL_ADDRESS_FILE = "tunnel.address"
+	TUNNEL_PID_FILE     = "tunnel.pid"
+	TUNNEL_LOG_FILE     = "tunnel.log"
+
+	TIMINGS_LOG_FILE   = "timings.log"
+	PATCHES_FILE       = "bbl-patches.json"
+	MANAGED_FILES_FILE = "bbl-managed-files.json"
 )
 
 type Store struct {
@@ -96,6 +105,10 @@ func (s Store) GetCloudConfigDir() (string, error) {
 	return s.getDir("cloud-config")
 }
 
+func (s Store) GetRuntimeConfigDir() (string, error) {
+	return s.getDir("runtime-config")
+}
+
 func (s Store) GetTerraformDir() (string, error) {
 	return s.getDir("terraform")
 }
@@ -125,6 +125,10 @@ var _ = Describe("Store", func() {
 						Variables: "some-vars",
 						Manifest:  "name: bosh",
 					},
+					BOSHDeployment: storage.DeploymentSource{
+						Path:   "some-bosh-deployment-path",
+						Commit: "some-bosh-deployment-commit",
+					},
 					EnvID:   "some-env-id",
 					TFState: "some-tf-state",
 				})
@@ -181,6 +185,13 @@ var _ = Describe("Store", func() {
 						"key": "value"
 					}
 				},
+				"boshDeployment": {
+					"path": "some-bosh-deployment-path",
+					"commit": "some-bosh-deployment-commit"
+				},
+				"jumpboxDeployment": {},
+				"sizing": {},
+				"network": {},
 				"tfState": "some-tf-state",
 				"latestTFOutput": ""
 		    	}`))
@@ -273,6 +284,7 @@ var _ = Describe("Store", func() {
 			}
 		},
 		Entry("cloud-config", "cloud-config", func() (string, error) { return store.GetCloudConfigDir() }),
+		Entry("runtime-config", "runtime-config", func() (string, error) { return store.GetRuntimeConfigDir() }),
 		Entry("state", "", func() (string, error) { return store.GetStateDir(), nil }),
 		Entry("vars", "vars", func() (string, error) { return store.GetVarsDir() }),
 		Entry("terraform", "terraform", func() (string, error) { return store.GetTerraformDir() }),
@@ -290,6 +302,7 @@ var _ = Describe("Store", func() {
 			Expect(fileIO.MkdirAllCall.Receives.Dir).To(Equal(expectedDir))
 		},
 		Entry("cloud-config", "cloud-config", func() (string, error) { return store.GetCloudConfigDir() }),
+		Entry("runtime-config", "runtime-config", func() (string, error) { return store.GetRuntimeConfigDir() }),
 		Entry("vars", "vars", func() (string, error) { return store.GetVarsDir() }),
 		Entry("terraform", "terraform", func() (string, error) { return store.GetTerraformDir() }),
 		Entry("bosh-deployment", "bosh-deployment", func() (string, error) { return store.GetDirectorDeploymentDir() }),
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/fileio"
+)
+
+type PhaseTiming struct {
+	Phase   string  `json:"phase"`
+	Seconds float64 `json:"seconds"`
+	Failed  bool    `json:"failed,omitempty"`
+}
+
+// Run is the record of one bbl up or bbl destroy in the timings log. Failed
+// runs are kept too, with the phase they stopped in marked as failed.
+type Run struct {
+	Time                    time.Time     `json:"time"`
+	Command                 string        `json:"command"`
+	EnvID                   string        `json:"envID"`
+	BBLVersion              string        `json:"bblVersion"`
+	BOSHDeploymentCommit    string        `json:"boshDeploymentCommit,omitempty"`
+	JumpboxDeploymentCommit string        `json:"jumpboxDeploymentCommit,omitempty"`
+	Failed                  bool          `json:"failed,omitempty"`
+	Phases                  []PhaseTiming `json:"phases"`
+}
+
+type timingsLogFS interface {
+	fileio.FileReader
+	fileio.FileWriter
+}
+
+// TimingsLog appends one line of JSON per run to timings.log in the state
+// directory. It is kept across bbl destroy.
+type TimingsLog struct {
+	dir string
+	fs  timingsLogFS
+}
+
+func NewTimingsLog(dir string, fs timingsLogFS) TimingsLog {
+	return TimingsLog{
+		dir: dir,
+		fs:  fs,
+	}
+}
+
+func (t TimingsLog) Append(run Run) error {
+	path := filepath.Join(t.dir, TIMINGS_LOG_FILE)
+
+	contents, err := t.fs.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Read timings log: %s", err)
+	}
+
+	line, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("Marshal timings: %s", err) // not tested
+	}
+	contents = append(contents, line...)
+	contents = append(contents, '\n')
+
+	err = t.fs.WriteFile(path, contents, StateMode)
+	if err != nil {
+		return fmt.Errorf("Write timings log: %s", err)
+	}
+
+	return nil
+}
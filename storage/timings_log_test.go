@@ -0,0 +1,80 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TimingsLog", func() {
+	var (
+		fileIO     *fakes.FileIO
+		timingsLog storage.TimingsLog
+		run        storage.Run
+	)
+
+	BeforeEach(func() {
+		fileIO = &fakes.FileIO{}
+		fileIO.ReadFileCall.Returns.Error = os.ErrNotExist
+
+		timingsLog = storage.NewTimingsLog("some-state-dir", fileIO)
+
+		run = storage.Run{
+			Time:                 time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Command:              "up",
+			EnvID:                "some-env-id",
+			BBLVersion:           "1.2.3",
+			BOSHDeploymentCommit: "some-commit",
+			Phases: []storage.PhaseTiming{
+				{Phase: "terraform init and apply", Seconds: 336},
+			},
+		}
+	})
+
+	Describe("Append", func() {
+		It("creates the log with the run", func() {
+			err := timingsLog.Append(run)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fileIO.ReadFileCall.Receives.Filename).To(Equal("some-state-dir/timings.log"))
+			Expect(fileIO.WriteFileCall.Receives).To(HaveLen(1))
+			Expect(fileIO.WriteFileCall.Receives[0].Filename).To(Equal("some-state-dir/timings.log"))
+			Expect(string(fileIO.WriteFileCall.Receives[0].Contents)).To(Equal(
+				`{"time":"2026-01-02T03:04:05Z","command":"up","envID":"some-env-id","bblVersion":"1.2.3","boshDeploymentCommit":"some-commit","phases":[{"phase":"terraform init and apply","seconds":336}]}` + "\n",
+			))
+			Expect(fileIO.WriteFileCall.Receives[0].Mode).To(Equal(os.FileMode(storage.StateMode)))
+		})
+
+		It("appends the run to an existing log", func() {
+			fileIO.ReadFileCall.Returns.Contents = []byte("some-earlier-run\n")
+			fileIO.ReadFileCall.Returns.Error = nil
+
+			err := timingsLog.Append(run)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(fileIO.WriteFileCall.Receives[0].Contents)).To(HavePrefix("some-earlier-run\n{"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the log cannot be read", func() {
+				fileIO.ReadFileCall.Returns.Error = errors.New("permission denied")
+
+				err := timingsLog.Append(run)
+				Expect(err).To(MatchError("Read timings log: permission denied"))
+			})
+
+			It("returns an error when the log cannot be written", func() {
+				fileIO.WriteFileCall.Returns = []fakes.WriteFileReturn{{Error: errors.New("disk full")}}
+
+				err := timingsLog.Append(run)
+				Expect(err).To(MatchError("Write timings log: disk full"))
+			})
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cloudfoundry/bosh-bootloader/aws"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type InputGenerator struct {
@@ -44,11 +45,28 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 		"availability_zones": azs,
 	}
 
+	terraform.AddNetworkInputs(inputs, state.Network, "vpc_cidr")
+
+	for _, site := range state.AWS.Sites {
+		siteAZs, err := i.awsClient.RetrieveAZs(site.Region)
+		if err != nil {
+			return map[string]interface{}{}, fmt.Errorf("Retrieve availability zones for site %s: %s", site.Name, err)
+		}
+
+		inputs[fmt.Sprintf("%s_site_region", site.Name)] = site.Region
+		inputs[fmt.Sprintf("%s_site_vpc_cidr", site.Name)] = site.VPCCIDR
+		inputs[fmt.Sprintf("%s_site_availability_zones", site.Name)] = siteAZs
+	}
+
 	if state.LB.Type == "cf" {
 		inputs["ssl_certificate"] = state.LB.Cert
 		inputs["ssl_certificate_private_key"] = state.LB.Key
 		inputs["ssl_certificate_chain"] = state.LB.Chain
 
+		if state.Network.DualStack {
+			inputs["ipv6_cidrs"] = []string{"::/0"}
+		}
+
 		if state.LB.Domain != "" {
 			inputs["system_domain"] = state.LB.Domain
 
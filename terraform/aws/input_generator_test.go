@@ -67,6 +67,29 @@ var _ = Describe("InputGenerator", func() {
 			}))
 		})
 
+		Context("when a network layout is requested", func() {
+			It("passes the cidrs, ip offsets and allowed cidrs to terraform", func() {
+				inputs, err := inputGenerator.Generate(storage.State{
+					EnvID: "some-env-id",
+					AWS:   storage.AWS{Region: "some-region"},
+					Network: storage.Network{
+						NetworkCIDR:      "172.16.0.0/16",
+						InternalCIDR:     "172.16.0.0/24",
+						JumpboxIPOffset:  10,
+						DirectorIPOffset: 11,
+						AllowedCIDRs:     []string{"203.0.113.0/24"},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs["vpc_cidr"]).To(Equal("172.16.0.0/16"))
+				Expect(inputs["internal_cidr"]).To(Equal("172.16.0.0/24"))
+				Expect(inputs["jumpbox_ip_offset"]).To(Equal(10))
+				Expect(inputs["director_ip_offset"]).To(Equal(11))
+				Expect(inputs["allowed_cidrs"]).To(Equal([]string{"203.0.113.0/24"}))
+			})
+		})
+
 		Context("when a cf lb exists", func() {
 			var state storage.State
 
@@ -105,6 +128,20 @@ var _ = Describe("InputGenerator", func() {
 				}))
 			})
 
+			Context("when the network is dual stack", func() {
+				BeforeEach(func() {
+					state.Network.DualStack = true
+				})
+
+				It("allows ipv6 traffic to the load balancers", func() {
+					inputs, err := inputGenerator.Generate(state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(inputs["dual_stack"]).To(BeTrue())
+					Expect(inputs["ipv6_cidrs"]).To(Equal([]string{"::/0"}))
+				})
+			})
+
 			Context("when a domain name is supplied", func() {
 				BeforeEach(func() {
 					state.LB.Domain = "some-domain"
@@ -133,6 +170,61 @@ var _ = Describe("InputGenerator", func() {
 			})
 		})
 
+		Context("when sites are configured", func() {
+			BeforeEach(func() {
+				awsClient.RetrieveAZsCall.Stub = func(region string) ([]string, error) {
+					if region == "some-other-region" {
+						return []string{"other-z1", "other-z2"}, nil
+					}
+					return []string{"z1", "z2", "z3"}, nil
+				}
+			})
+
+			It("returns the region, vpc cidr and availability zones of each site", func() {
+				inputs, err := inputGenerator.Generate(storage.State{
+					EnvID: "some-env-id",
+					AWS: storage.AWS{
+						Region: "some-region",
+						Sites: []storage.AWSSite{{
+							Name:    "west",
+							Region:  "some-other-region",
+							VPCCIDR: "10.1.0.0/16",
+						}},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs).To(Equal(map[string]interface{}{
+					"env_id":                       "some-env-id",
+					"short_env_id":                 "some-env-id",
+					"region":                       "some-region",
+					"availability_zones":           []string{"z1", "z2", "z3"},
+					"west_site_region":             "some-other-region",
+					"west_site_vpc_cidr":           "10.1.0.0/16",
+					"west_site_availability_zones": []string{"other-z1", "other-z2"},
+				}))
+			})
+
+			Context("when retrieving the availability zones of a site fails", func() {
+				It("returns an error", func() {
+					awsClient.RetrieveAZsCall.Stub = func(region string) ([]string, error) {
+						if region == "some-other-region" {
+							return []string{}, errors.New("failed to get zones")
+						}
+						return []string{"z1"}, nil
+					}
+
+					_, err := inputGenerator.Generate(storage.State{
+						AWS: storage.AWS{
+							Region: "some-region",
+							Sites:  []storage.AWSSite{{Name: "west", Region: "some-other-region"}},
+						},
+					})
+					Expect(err).To(MatchError("Retrieve availability zones for site west: failed to get zones"))
+				})
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when the availability zone retriever fails", func() {
 				It("returns an error", func() {
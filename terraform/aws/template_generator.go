@@ -18,6 +18,8 @@ type templates struct {
 	sslCertificate string
 	isoSeg         string
 	vpc            string
+	site           string
+	dualStack      string
 }
 
 func NewTemplateGenerator() TemplateGenerator {
@@ -28,6 +30,10 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
 	template := strings.Join([]string{tmpls.base, tmpls.iam, tmpls.vpc}, "\n")
 
+	if state.Network.DualStack {
+		template = strings.Join([]string{template, tmpls.dualStack}, "\n")
+	}
+
 	switch state.LB.Type {
 	case "concourse":
 		template = strings.Join([]string{template, tmpls.lbSubnet, tmpls.concourseLB}, "\n")
@@ -39,6 +45,10 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 		}
 	}
 
+	for _, site := range state.AWS.Sites {
+		template = strings.Join([]string{template, strings.Replace(tmpls.site, "SITE_NAME", site.Name, -1)}, "\n")
+	}
+
 	return template
 }
 
@@ -53,6 +63,8 @@ func readTemplates() templates {
 	tmpls.cfDNS = string(MustAsset("templates/cf_dns.tf"))
 	tmpls.isoSeg = string(MustAsset("templates/iso_segments.tf"))
 	tmpls.vpc = string(MustAsset("templates/vpc.tf"))
+	tmpls.site = string(MustAsset("templates/site.tf"))
+	tmpls.dualStack = string(MustAsset("templates/dual_stack.tf"))
 
 	return tmpls
 }
@@ -74,6 +74,39 @@ var _ = Describe("TemplateGenerator", func() {
 				checkTemplate(template, expectedTemplate)
 			})
 		})
+
+		Context("when the network is dual stack", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("base", "iam", "vpc", "dual_stack", "lb_subnet", "cf_lb", "ssl_certificate", "iso_segments")
+				lb = storage.LB{
+					Type: "cf",
+				}
+			})
+			It("adds the ipv6 routes and outputs to the base template", func() {
+				template := templateGenerator.Generate(storage.State{
+					LB:      lb,
+					Network: storage.Network{DualStack: true},
+				})
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
+		Context("when sites are configured", func() {
+			It("adds the site template once per site with the site name substituted", func() {
+				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						Sites: []storage.AWSSite{{Name: "west"}, {Name: "east"}},
+					},
+				})
+
+				siteTemplate := expectTemplate("site")
+				checkTemplate(template, strings.Join([]string{
+					expectTemplate("base", "iam", "vpc"),
+					strings.Replace(siteTemplate, "SITE_NAME", "west", -1),
+					strings.Replace(siteTemplate, "SITE_NAME", "east", -1),
+				}, "\n"))
+			})
+		})
 	})
 })
 
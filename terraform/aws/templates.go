@@ -4,9 +4,11 @@
 // templates/cf_dns.tf
 // templates/cf_lb.tf
 // templates/concourse_lb.tf
+// templates/dual_stack.tf
 // templates/iam.tf
 // templates/iso_segments.tf
 // templates/lb_subnet.tf
+// templates/site.tf
 // templates/ssl_certificate.tf
 // templates/vpc.tf
 // DO NOT EDIT!
@@ -76,7 +78,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x5b\x5f\x6f\xe3\xb8\x11\x7f\x3e\x7f\x0a\xc1\xd8\x87\xdd\x36\xf6\x5a\x8e\xff\xe5\x80\x2d\x70\xed\x15\xe8\xf5\xe1\x5a\xf4\xee\xad\x58\x08\x14\x45\xcb\x6c\x24\x51\x20\x29\x67\xb3\x81\xbf\x7b\xc1\x7f\x92\x28\x89\x96\x94\x8d\xd7\xc9\xdd\x3d\x6c\x22\xce\x0c\x67\x7e\x33\x9c\x19\x4a\x93\x23\xa0\x18\x84\x09\xf2\xa6\x19\xe0\x01\x48\x71\x90\x82\x7c\xea\x3d\x4d\x3c\x8f\x3f\xe6\xc8\xfb\xe4\x4d\xc5\x83\xc9\xc4\xf3\x22\xb4\x07\x45\xc2\xbd\x4f\x72\xd5\xf3\x40\x3e\xcb\x08\xe5\x07\x04\x18\x9f\xf9\x82\x12\xa4\x78\xe6\x2f\xa2\x3d\xdc\x6d\xb7\xd3\x36\xcd\xb2\xa4\x01\x7e\x08\x57\xdb\x55\x49\xc3\x48\xc1\x0f\x33\x5f\xfc\x66\x68\xb6\x2b\xe8\xef\x36\x7e\x68\xd3\xd8\x7b\xdd\x6e\xc0\x7e\xb9\x58\xaf\x3b\x68\xaa\xbd\xd0\x9d\xbf\xf3\xb7\x91\xa2\x81\x60\x06\x51\xc6\x29\x48\xe4\x6e\x86\x66\x19\xdd\x6e\xc0\x76\xa3\x68\x50\xd1\x45\x73\x87\x42\xe4\xef\xf6\x7e\x49\xf3\x80\xa4\x2a\x75\x9d\x6f\xc1\x6e\x75\xb7\x5f\x43\x9b\x66\x69\xd1\x2c\x7d\x7f\xb9\x58\xad\xb4\xce\x05\x9b\x69\x93\xea\x34\xd1\x0a\xae\xd1\x1e\x2e\x6d\x1a\x5b\xce\x7e\xb9\x0d\xd7\xe0\x6e\x5b\xd2\xc4\xe4\x58\xea\xa4\x69\xe0\xed\xdd\xc6\x5f\x80\x4a\x4e\x87\xce\xe1\x6e\xbb\x5f\xdf\x46\x3b\x9b\xc6\xde\x6b\x17\xee\x21\xda\xed\xa5\x9c\xd3\xe4\x34\x99\x54\x51\x03\x20\x44\x8c\x05\xf7\xe8\xd1\x0e\x1a\xc6\x29\xce\xe2\xa9\x4d\xcc\x10\xa4\x88\x0f\x24\xa6\x28\xc6\x24\x1b\x40\x18\x12\x76\x08\x70\x16\x92\x22\x8b\x02\x88\x23\xaa\x78\xaa\x70\x9d\x2e\xe6\xf2\xff\x8f\x8b\x06\x27\x38\x02\x9c\x80\x10\x27\x98\x3f\x06\x5f\x49\x86\x98\xbd\x5d\x82\x19\x6f\xb0\xa0\xec\x18\xe0\x68\x88\xad\x07\x42\x79\x30\x98\xfc\x98\xc3\x9a\xee\x92\x54\xe1\x6f\xa8\x2d\x83\x7c\x63\x91\xbf\x91\x72\x28\x62\xa4\xa0\x50\x98\xf4\xc0\x02\x84\xf3\xa9\x37\xfd\x5f\x91\xe6\x21\xf9\xa2\x7e\x53\x80\xe4\x28\x8b\x58\x40\x32\xef\x93\xf7\x5f\x49\x89\x33\x8e\x68\x86\x78\x10\x03\x8e\x1e\xc0\xe3\x1c\xc7\xd3\xcf\x13\xcf\x3b\xe6\xd0\xf3\x4c\x08\x70\x5a\x20\x7b\x13\x9e\xb0\x20\xa7\xf8\x08\x38\x52\xce\x54\x3e\x38\xa6\x1a\x3f\x90\xc4\x84\x62\x7e\x48\x85\xae\xff\xf9\xed\x27\xa1\x3d\x65\x20\x08\x31\x67\x42\xe2\x6a\x71\xb7\x69\xab\x7d\x8f\x1e\x83\x1c\x60\xda\x12\x27\x16\x32\x90\x22\x05\xc8\xbb\xa7\x23\xa0\x73\x05\xec\x29\x28\x29\x27\x9e\x97\x17\x61\x82\xa1\x90\xa3\xe8\x1a\x6a\xce\x0d\xed\xbc\x22\x0c\x48\x8e\x32\xc6\x0e\xa7\x0e\x18\x19\x82\x05\x15\x91\x11\x53\x52\x08\x44\x45\x86\x6c\x3e\x14\xfa\x69\xdd\xcc\x89\xb1\x14\x9c\x65\x80\xcf\x0c\xd3\x4c\x31\x49\x5f\x30\x48\x71\xce\xb1\x74\xc6\xf4\xd7\x9f\x7e\x9f\x2a\xd8\x03\x1c\xd5\x04\x25\x04\x82\x64\xae\x1e\x9f\x64\x12\xe6\x20\x66\x3a\xff\xfe\x2a\xb6\x1d\xb8\xdf\x49\xf0\x26\x78\x8f\xe0\x23\x4c\x90\x16\x80\xe3\x8c\x50\x14\xc0\x03\xc8\x62\xc4\x64\x50\x08\x53\x64\x04\x9c\xfa\xf0\x08\x68\x91\x20\x0d\x0a\x27\x55\x24\xa9\xc7\x62\x83\x06\x3d\x8e\x94\xb2\x6d\x51\xf3\x36\xb0\xf3\xd2\x5e\x7d\x12\x34\x24\x28\xa6\x88\x49\x67\xef\x29\x49\x83\x9c\x50\x2e\x17\x16\x82\x94\x98\xdf\xcd\x93\x9c\x12\x4e\x20\x49\x34\xf3\x4c\x26\x6f\x71\xca\x82\x30\x21\xf0\x5e\x99\x5c\x25\x87\xcf\x63\x6c\xc6\x30\xcd\x2f\x6c\x2c\xce\x4a\x6b\x1b\x96\x88\xcd\xdb\x20\xcc\xfc\x16\x0a\xf2\xd1\x0b\x59\xcc\xe1\x45\x0d\xb6\xfe\x73\x5b\xdf\x24\xe3\xb0\x85\x44\x83\xa4\x19\x1b\x8d\xe5\xcd\x7a\x7d\xbb\x16\x06\x49\x10\x9a\xf6\x9f\xb1\x4b\x85\x3c\x48\x3a\x8d\x1b\x81\x6b\x11\xbd\x46\x5c\x8b\xe8\x6d\xe0\x8a\x33\xc6\x41\x06\x35\x98\x0a\x43\x93\xf4\x71\xde\xb4\xea\xdd\x93\x38\x0c\x07\xc2\xf8\x7b\xb9\x73\x11\x66\x88\xab\xc2\xa0\x7f\xae\x0e\xcb\x8d\xb7\xfd\x70\x12\x18\x98\x2d\x02\x1b\x56\x11\x7c\xcb\x79\x8a\x22\x5c\xa4\x82\x4c\x09\x28\x13\xb8\xb5\xab\x63\x33\x69\x52\x09\x51\x84\x18\x0f\xe0\x01\xc1\x7b\xc3\xb9\x07\x09\x43\xa2\xa0\xa6\xd8\xe1\x4d\x51\x23\xc8\x7d\x91\xbf\x17\x35\xa0\xd6\xc2\xdf\x78\xe2\x81\xea\xa1\x94\x15\xa2\x8a\xb4\x9c\xa0\x12\xc2\x98\xf0\xfa\xdc\x55\x85\x3a\xcb\x90\xea\x28\xff\x9e\x1d\x7f\xf9\xb9\xb5\x3e\xed\x2e\x31\xaa\x73\x11\x3b\x3f\xa7\x6b\x31\x7e\xaa\x83\x6e\x9e\x09\x73\x0c\xdc\x9d\xdd\x4d\x4e\xc9\x11\x47\x88\x4a\x45\x74\x1b\x53\xf6\xb6\x95\xfe\xd5\x33\xe5\xb9\xb2\xa3\xad\x48\xaa\x67\x92\x44\xf9\xa0\xf2\x57\xe5\x17\x75\x56\x8f\x88\x32\xdd\x06\xfc\xe5\x93\xe7\xcf\xfd\xed\x7c\xd1\x11\xe7\xba\xfb\x6b\x77\x25\xae\x85\xa7\xaa\xa1\xe8\xea\x25\xfa\xdb\x1d\xc7\x39\x1c\xd0\xf3\x18\xce\xfe\xc6\xe7\x17\x4d\xf9\x52\xdd\xcf\x99\x9d\x2f\xd7\x02\x39\x80\x92\xcb\xa2\x6a\x8e\x4d\xec\x67\x13\x60\x57\x72\xef\xcb\xea\xe7\xca\xa4\x2b\x8f\xd7\x12\x38\x4a\xf6\xcd\xfd\xda\x77\x82\x67\xc2\x23\xca\xcc\x2b\x80\xc7\x59\xed\xae\x0c\x8f\x6c\xf4\x5e\x01\x3e\x5d\x0d\xa7\x59\x6c\xb5\x9d\xd6\x42\xbd\xf9\x34\x0b\xcf\x6a\x41\xcf\xe2\x04\x92\x84\x3c\x94\x85\xe1\x7b\x20\x86\xce\x03\xa6\xee\x1a\x63\xe2\x69\xf1\xdd\xc0\x62\xec\xe0\x42\xa8\x32\xe0\x65\x80\x1a\x18\x61\x15\xd9\xef\x7f\xfb\x77\x4f\xdb\xb9\x5c\x9e\xef\x3b\xe5\xfa\xe8\xa6\x53\xbf\x2d\x19\xd4\xbc\x9b\x17\x14\xa3\xeb\xa2\xe0\xea\xaf\x89\x7f\xfd\xd7\x6f\xff\xf0\x7e\xc6\x14\x41\x4e\xe8\x4b\x15\x46\xc7\xd6\xa3\x8a\xe2\x8d\x68\x36\x4a\x55\xc7\xd5\xc8\x0e\xc0\xca\xfa\x78\x2e\x20\x5d\xfe\xea\x90\x77\xa9\xfa\xe8\x08\x38\xbd\xd0\x7d\x64\x15\xf8\xad\x37\x93\xa7\xe1\x27\xf8\x2c\x60\x72\x11\xc4\x28\x73\xa6\xba\x9e\x83\x3c\x0a\xbe\xd1\x87\xb8\xff\x4e\xbe\xd9\x6d\x76\x3d\xd7\x47\x45\x71\xd1\x83\xdc\x8b\x75\x01\xc0\x1b\x05\x78\xb7\x5a\xdd\x9e\x07\x58\x53\x5c\x17\x60\x48\x51\x74\x28\xc2\xb7\x0a\xf2\x6e\xb5\xea\x01\x59\x51\x5c\x17\x64\x91\x31\x22\x5d\x4f\x02\x90\xe3\x37\x8a\xf6\x72\xbd\x5e\xaf\x7b\x6a\xbf\x26\xb9\x3a\xde\x6f\x14\xe2\xd7\xf1\x4a\x6f\x6c\x92\x76\x5f\x21\xaf\x0a\xf7\x5b\x79\x83\x3a\x12\xee\x6f\xbb\x6a\x8d\xed\xdb\x5e\xe7\x35\xab\xfa\xbc\x3a\xa0\xeb\xd7\x94\xfd\x8d\xff\x3f\xb5\xc8\x17\x6a\xf9\xdd\xfb\x7e\xb7\xae\xdf\x7c\x83\x7e\x46\x83\x6f\x65\xe4\x3f\x4a\x53\x6f\xf0\xa0\xe3\xdf\x7a\x5d\x18\x8f\xdb\xdb\xdd\x9d\x03\x11\xbd\x74\x69\x4c\xce\x5e\x67\xae\x84\x8a\xf3\x9a\x52\x2e\x5d\x1a\x15\xd3\xb7\xbd\x32\x60\xdc\xbd\x58\xb5\x76\x69\x68\x74\x69\xb8\x00\x30\xaf\xb3\xe8\x58\x95\xb9\x5d\xe2\xbf\xb1\xf5\xbc\xfc\xab\xbd\xeb\xb5\x9f\xce\xa6\xe3\x05\x10\x7f\x7e\xf7\x79\x79\xc4\xaf\xd7\x81\x8e\x40\x5c\x7e\x09\x2f\x1b\x4e\xfd\xdb\x93\xdd\x03\x75\xb5\x40\xf5\x13\x55\x7d\xda\x57\x02\xe4\xd7\x70\x33\x52\x77\xe3\xed\x6e\xbc\xc5\x87\x51\x2f\x4a\x95\x1a\xdd\xad\x0e\x25\x05\x47\x01\x07\x61\x15\x1b\xd6\xa3\xb1\x1f\x5e\x25\xb3\x53\x52\x84\x18\xc7\x19\x10\xbd\x57\x60\x1b\x5c\x9b\x6a\xf4\x3c\xfd\x29\xbc\x39\x7d\x50\xfb\x0e\xde\xfa\x66\x6e\x02\xad\xb6\x65\x9d\xbd\x64\xad\xad\xcf\x9b\x3a\x3a\x9c\x5a\x17\x09\x18\x23\x10\x4b\x03\xa6\xde\x54\xad\xd4\x7c\x6d\x12\xb8\x3d\x3c\x31\x60\x68\xa2\xa1\xf6\xb7\xa8\x5b\xc6\x60\x75\x24\xeb\xba\x41\x52\x64\xbc\x0d\x6b\x82\xb2\x98\x1f\x64\xa8\xb5\x07\x49\xab\x99\x0b\xdb\x23\xbd\x91\x5c\xa7\x73\x06\xf4\xea\x46\x29\x35\xc7\x59\x84\xbe\xfc\xd9\x57\xbb\xb5\xb4\x50\x52\x50\x82\x52\x94\x71\x87\xa2\x96\xa4\xa1\x87\xa4\xfa\xcc\x2e\xb5\x7b\xf7\x54\x93\x71\x1a\x73\xc3\xa8\x0c\x17\xf7\x8c\x96\x76\xae\xdb\x86\x7d\x04\x4b\xaf\xbd\xc8\x31\x74\x4b\x1b\x78\x14\xcb\x71\xa1\x0e\xcf\xbb\x46\x52\xc6\x9c\xc1\x2e\x05\x9f\x79\x0e\x07\xc5\xfb\xd0\x60\xef\x3a\xc2\x26\xf6\x6a\x47\xb9\xb9\xe7\xfc\x4f\x73\x1c\xb5\xa2\x70\xd8\xf9\x1e\x01\x45\x33\x01\x0a\x4f\xc7\x43\xa2\x44\x3e\x51\xc7\xa1\x7c\x89\xda\xb8\xef\xcb\x9a\xd1\x9a\x6f\x2a\x95\x13\xb1\xd2\xf2\xe8\xd9\xd1\x33\x9b\x3f\x7e\x68\x38\xa3\x9c\x61\x53\xda\x5a\x1b\xdd\x78\x3a\x1b\x98\x36\xb9\x5c\xc5\xf9\x20\xf6\xb5\x62\x2f\x6d\xad\xf3\x0f\x60\xdf\x7c\xe8\x42\xff\x3e\xd5\x7f\x23\x30\x2d\x7f\x12\x80\xa2\x4c\xba\xf7\x1e\x3d\x06\x94\x70\xa0\x5f\x84\x98\xe9\x09\x52\xf0\xbc\xe0\xd5\x84\x93\x99\x03\xd7\x5e\x03\x49\x81\x2a\x3c\xcd\xf4\x78\x35\xe5\x6d\xc8\x95\x3e\x46\x98\x35\xb7\x5e\xc9\x29\xb1\x75\x0f\x8d\x57\x0f\x83\x1c\xa5\x7a\xfc\x2b\x63\x98\xe3\x23\xea\xd0\x1a\x7d\x29\x71\xeb\x54\x18\xe1\xf2\x32\x22\x7f\xd6\x43\xe9\x38\xb7\xf5\x35\x24\x05\x4d\x46\x8a\xf9\x71\xb9\xb4\x24\x55\x9f\x00\xa2\xa8\xba\x39\x95\xe2\x0e\x9c\xe7\xec\xc7\x8f\x1f\xfb\xc5\x8a\xfb\x9d\x25\xd9\x1a\xd8\xeb\xd0\x4f\xaf\xbb\x4c\x3c\x3b\x68\xd6\x10\x37\xf2\x4d\xa7\xd9\xc2\xf9\xbd\xfe\xbc\xf8\x73\x6d\x6c\xd3\x41\xe3\xa5\x37\x3f\x3a\x34\x25\x3a\x66\xfe\x1a\x8e\x73\x8c\x6f\xd6\x85\x7f\xee\x0c\x83\x6f\x12\xef\x42\xc6\xda\xaa\xac\x08\xdd\x68\x38\x5a\xbb\x9a\x00\xf0\x75\x28\x67\xab\x28\xd9\x82\x54\x42\x6f\x09\x6b\x67\x7b\xc3\x50\xff\xeb\xa3\x1a\x83\x35\xbc\x59\x23\xd7\x59\x2d\x00\xb4\xcd\x53\xcb\x7f\x73\xf3\x2f\xa0\x99\xe3\x0c\x80\xaf\x41\x09\x5b\x90\x82\x3c\xc7\x59\xdc\x12\x39\xf9\xc1\xf3\xbe\xe2\x3c\x05\xf9\x7b\x1b\x92\x8e\xe2\xda\x81\xcc\x8d\xd7\xcb\x25\xf0\xf8\x30\xf9\xa1\x57\x49\x59\xb3\xae\xa7\x66\xbd\x64\xb6\xd4\xb5\xca\xb5\xc3\xf7\x16\x8d\xc3\xda\xea\xef\xb2\x5a\xec\x16\x8d\x83\x3d\x7e\xe8\x63\x8e\x1f\x1c\x09\xa0\x56\x7b\x1d\x32\x3a\xaa\xbc\x03\x84\x01\xc2\xba\x6a\xbe\x94\xf6\xff\x00\x00\x00\xff\xff\x39\xf1\x7e\xd7\x26\x3a\x00\x00")
+var _templatesBaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdd\x5b\x5b\x8f\xdb\x36\x16\x7e\xee\xfc\x0a\xc2\x9b\x87\x4c\xd7\x76\x2c\x8f\x6f\x13\x20\x29\xba\xed\x02\xdb\x7d\x68\x8b\xa6\x6f\x41\x20\xd0\x12\x6d\x33\x23\x4b\x82\x48\xd9\x99\x0c\xfc\xdf\x97\x57\x49\x94\x48\x5b\x9a\x19\xcf\x65\x27\x0f\xb1\xc9\x73\xe3\xc7\xc3\x73\x0e\xa5\xe3\x1d\xcc\x30\x5c\x46\x08\xf4\x62\x48\x7d\xb8\xc5\xfe\x16\xa6\x3d\x70\x77\x01\x00\xbd\x4d\x11\xf8\x00\x7a\x7c\xe0\x82\x7d\x0f\xd1\x0a\xe6\x11\x65\x43\x7c\x16\x00\x98\x0e\xe2\x24\xa3\x1b\x04\x09\x1d\x78\x9c\x92\xb1\x0f\xbc\x51\xb8\x0a\x16\xf3\x79\xaf\x49\x33\x2e\x68\xa0\xb7\x0c\x26\xf3\x49\x41\x43\x92\x9c\x6e\x98\x0c\xfe\xa7\x68\xe6\x93\xc0\x5b\xcc\xbc\xa5\x49\x63\xea\xba\x9a\xc1\xd5\x78\x34\x9d\x5a\x68\x4a\x5d\xe8\xda\x5b\x78\xf3\x50\xd2\x04\x70\x10\xa0\x98\x66\x30\x12\xda\x34\xcd\x38\x64\xa2\xe6\x33\x49\x83\x72\x1b\xcd\x35\x5a\x22\x6f\xb1\xf2\x0a\x9a\x3d\x12\xa6\x54\x6d\xbe\x82\x8b\xc9\xf5\x6a\x1a\x98\x34\x63\x83\x66\xec\x79\xe3\xd1\x64\xa2\x6c\xce\xc9\x40\x2d\xa9\x4a\x13\x4e\x82\x29\x5a\x05\x63\x93\xc6\x94\xb3\x1a\xcf\x97\x53\x78\x3d\x2f\x68\xd6\xc9\xae\xb0\x49\xd1\x04\x57\xd7\x33\x6f\x04\x4b\x39\x16\x9b\x97\x8b\xf9\x6a\x7a\x15\x2e\x4c\x1a\x53\xd7\x62\xb9\x0a\xd0\x62\x25\xe4\x1c\x2e\x0e\x17\x17\xbb\xc2\x6b\x60\x10\x20\x42\xfc\x1b\x74\x6b\x3a\x0d\xa1\x19\x8e\xd7\x3d\x93\x98\xa0\x20\x43\xb4\x25\x71\x86\xd6\x38\x89\x5b\x10\x2e\x13\xb2\xf1\x71\xbc\x4c\xf2\x38\xf4\x03\x1c\x66\x92\xa7\x74\xd7\xde\x68\x28\xfe\xbd\x1b\xd5\x38\x61\x14\x25\x7b\x24\x99\x48\x45\x13\x28\x56\x1f\x61\x42\x7b\x15\x61\x72\xf8\xf3\x17\x31\x44\x82\x0c\xa7\x94\x19\xc9\x29\x3f\x25\x79\xc6\xa0\x00\x4a\x26\xa0\x09\xc8\x10\x0c\x36\x80\x39\x24\xf8\x9a\x6f\xd3\x65\xf2\x0d\xc0\x38\x04\x21\xce\x50\x40\x93\xac\x0f\x92\x1d\xca\x32\xcc\xe4\x80\xe6\x12\xb8\xa1\x51\x12\xc0\x88\x08\xb3\xfe\x01\x7e\x49\xe2\x10\x73\x65\x7c\x28\x80\x71\x9c\x50\xa6\x80\xe6\x59\x0c\xb8\x91\xa4\x0f\x48\x02\x82\x4d\x92\x10\x04\x96\x88\xee\x11\x8a\x85\x6a\xba\x4f\x00\x24\x40\x22\x47\x86\x4c\x56\x43\x19\xe1\xf6\xbf\xb9\x23\x69\x84\xe9\xdb\x5e\xbf\xd7\x07\x11\x8a\xd7\x74\xf3\x96\x21\x35\x34\x30\xba\x04\x1f\xc1\x08\xfc\x04\xbe\x26\x38\x96\x94\x16\x92\xf7\x62\xb0\xa1\xe5\xf2\x50\x47\x7f\x07\x71\x04\x97\x98\x69\xbd\xf5\xbf\x27\x31\x22\xe6\x66\x4b\xec\x0d\x16\x14\xef\x7c\x1c\xb6\xf1\xb4\x0d\x8b\x3a\x7e\x6b\xf2\x5d\x1a\x54\x3c\x47\xfb\x40\x85\xda\x70\x27\x4f\xfb\x93\x37\xab\xc9\xc1\x31\x45\x19\xdb\xa1\x0e\xc2\x6a\x12\x94\xa7\xf8\x38\xf5\x93\xd5\x8a\x20\x5a\x77\xe6\xa9\x49\xaf\xbd\xc9\xcd\x30\xe3\x0c\x19\x22\xc2\x3f\x19\xea\x7b\xe2\x23\xcc\x42\x7c\xa1\x4a\x7c\x93\x3c\x29\x8a\x43\xe2\x0b\x87\xfe\x2c\x28\xe5\x82\xd8\x99\x5d\x43\x8a\xf6\xf0\x76\x88\xd7\x3d\xee\xfc\x0c\xb0\xf2\x94\xd0\x2c\x47\xa6\x12\x1a\x11\x3f\xcd\xf0\x8e\x31\xc9\xd3\x2e\x0f\xe9\x6e\xab\xb6\x18\x46\xeb\x24\xc3\x74\xb3\xe5\x08\xfc\xf5\xe9\x67\x8e\x49\x46\xa0\xbf\xc4\x94\x70\x89\x93\xd1\xb5\xc5\x6c\x26\xc9\x4f\x21\xce\x1a\xe2\xf8\x44\x0c\xb7\x48\xc2\xfc\xe6\x8e\xfb\x9f\xdc\xfb\x83\x5f\x50\x32\xba\x34\x5f\x46\x38\xe0\x72\x24\x5d\xcd\xcc\xa1\xa6\x1d\x96\x84\x7e\xc2\x30\x21\x64\x23\x9d\xd7\xb4\x87\x85\xb3\x3c\xe3\xce\xbb\xce\x92\x9c\x23\xca\x53\x68\x7d\x90\xdb\xa7\x6c\xd3\x41\xc5\x30\x70\xc0\x98\x06\x9a\x69\x20\x99\x9a\xd1\xe5\xf7\x9f\xff\xee\x49\xd8\x19\x4f\x45\x90\x08\x11\x43\x39\x7c\x10\x59\x9a\xc2\x35\x51\x09\xfa\x77\xae\xb6\xa5\xbe\x03\xe7\x8d\xf0\x0a\x05\xb7\x01\xf3\x2b\x29\x00\xaf\x59\xf2\x46\x7e\xb0\x81\xf1\x1a\x11\xe1\x14\x7c\x29\xc2\x03\x0e\xa7\xf0\xf0\xb3\x3c\x42\x0a\x14\x9a\x94\x9e\x24\x87\xb9\x82\x1a\x3d\x5b\x98\x30\xb6\x29\x6a\xd8\x04\x76\x58\xac\xd7\x0c\xd8\x68\xcd\x6c\x12\x9b\xbd\xca\x92\xad\x9f\xb2\x30\x20\x26\x46\x9c\x34\xd1\xdf\xf5\x48\x9a\x25\x34\x09\x92\x48\x31\x0f\x44\x76\xe7\x67\xd7\x5f\x32\x64\x6f\xe4\x92\xcb\xec\xf1\xa5\xcb\x9a\x71\xb0\x4d\xcf\xbc\x58\x16\x4d\xf4\x6a\x6b\x2b\xe1\xca\x9b\x20\x0c\xbc\x06\x0a\x62\xe8\x91\x56\x4c\x83\xb3\x2e\xd8\xf8\x73\xaf\xbe\x4e\xc6\xac\xaa\x23\x51\x23\xa9\xfb\x46\x6d\x7a\x36\x9d\x5e\x4d\xf9\x82\x04\x08\x7e\xfb\x75\x15\xd9\xc0\xb6\xb8\x0e\xb8\xe6\xe1\x4b\xc4\x95\x59\xf5\x2a\x70\xc5\x31\xa1\x30\x0e\x14\x98\x12\x43\x1d\xf4\x71\x5a\x5f\xd5\x9b\x3b\x7e\x18\x36\x09\xa1\x6f\x85\xe6\x7c\xc9\x62\x96\x4c\x0c\xea\x73\x79\x58\xfa\x60\xce\x8b\x1a\x16\x27\x95\x0a\xdf\x84\x95\x3b\xdf\x78\xb8\x45\x21\xce\xb7\x9c\x4c\x0a\x28\x02\xb8\xa1\xd5\xa1\x4c\x2c\xa9\x80\x88\x25\x05\xca\xa2\x31\x0a\x6e\x34\xe7\x8a\x55\x83\x88\x27\xd4\x2d\x76\xec\x26\xcf\x11\xc9\x4d\x9e\x8a\x62\xae\x72\xc7\x93\xa5\x9b\x2c\xb2\xe5\x2a\x78\x16\x69\x6c\x82\x0c\x08\x5d\xdc\xeb\x8b\x2d\x0b\x59\xd3\x90\xbc\x72\xfc\x3b\xde\xfd\xf6\x6b\x63\xbe\x67\x4f\x31\xb2\x72\xe1\x9a\xef\x53\xb5\xe8\x7d\xaa\x82\xae\xc7\xf8\x72\x34\xdc\xd6\xea\x86\x9d\x85\x1d\x2b\xd6\x33\x61\x88\x2a\x63\x8a\xcb\x4f\x69\x7f\x39\x26\x77\xae\xb8\xf2\x94\x24\xe5\x98\x20\x91\x7b\x60\x16\x07\x72\x4c\x9e\x55\x76\x4b\x20\xaa\x0c\xf8\xf8\x01\x78\x43\x6f\x3e\x1c\x59\xfc\x5c\x55\x7c\xcd\xaa\xc4\x35\x71\x57\x16\x14\xb6\x5a\xe2\x74\xb9\xe3\x38\x87\x2d\x6a\x1e\xcd\x79\xba\xf0\xf9\x4d\x51\x3e\x56\xf5\x73\x44\xf3\xf9\x4a\x20\x07\x50\x62\x9a\x67\xcd\xae\x81\xfd\x68\x00\xb4\x05\xf7\x53\x51\xfd\x58\x9a\x74\xc5\xf1\x4a\x00\x47\xd1\xaa\xae\xaf\x79\x27\xb8\x27\x3c\x3c\xcd\xbc\x00\x78\x9c\xd9\xee\x99\xe1\x11\x85\xde\x0b\xc0\xc7\x56\x70\x82\x6a\x8d\x69\x43\xa8\x51\x7c\xea\x89\x7b\x95\xa0\x47\x71\x12\xcf\x28\x8a\xc4\xf0\x14\x88\xa1\xe3\x80\xc9\xbb\x46\x17\x7f\x1a\x3d\x19\x58\xec\xbe\xeb\x42\xa8\x51\xb3\x3c\x10\xa8\xce\x65\xe7\xdf\xbf\xfc\x79\xa2\xec\x1c\x8f\x8f\xd7\x9d\x62\xbe\x73\xd1\xa9\x9e\x96\x0c\x5b\xe6\x46\x59\xc4\x75\xcd\x8b\x9c\xeb\x74\x4e\xfc\xd7\x1f\x9f\xfe\x03\x7e\x55\x0f\x7e\x1e\x2b\x31\x3a\x54\x77\x4a\x8a\x7d\x5e\x6c\x14\xa6\x76\xcb\x91\x16\xc0\x8a\xfc\x78\xcc\x21\x5d\xfb\x65\x91\x77\xae\xfc\xe8\x70\x38\x35\x61\x3f\xb2\x7a\x8f\x9a\x0f\x63\x0f\xed\x0f\xf1\x51\xcc\xc4\x24\x5c\xa3\x98\xde\xf3\x2c\x77\x42\xf0\x0c\xd7\xf2\xd9\x62\xb6\x38\x71\x83\x94\x14\x67\x3d\xcb\x27\xb1\xce\x21\x7c\xa5\x00\x2f\x26\x93\xab\xe3\x00\x2b\x8a\xe7\x05\x98\x5d\x96\xc2\x4d\xbe\x7c\xad\x20\x33\x0c\x4f\x80\x2c\x29\x9e\x17\x64\x1e\x31\x8a\x77\x09\x30\xc5\xaf\x14\xed\xf1\x94\xfd\x9d\x48\xff\x8a\xe4\xd9\xf1\x7e\xa5\x10\xbf\xe4\xa7\xa5\xee\x20\x1d\xbe\x4c\xb8\x5f\xcb\x43\xd4\x8e\x70\x3f\xec\xb6\xd5\xb5\x74\x7b\x99\x37\xad\xf2\x0d\x6b\x8b\xc2\x5f\x51\x9e\xae\xfd\xff\xab\x44\x3e\x52\xd5\xef\xd6\xfb\x64\x85\xbf\x7e\x0d\x7d\x8f\x1a\xdf\x88\xc8\xff\x47\x75\xbd\x86\x24\xeb\xfe\xec\xeb\xcc\x90\x5c\x5d\x2d\xae\x1d\xa0\xa8\xa9\x27\x80\xe5\xe8\xa5\xe6\x99\x80\x71\x5e\x56\x8a\xa9\x27\x00\x46\x17\x70\x2f\xed\x1c\x39\x8b\xb2\x72\xee\x09\xd0\x51\x69\xe2\x0c\xd8\xbc\xec\x47\x7d\x0a\xbc\x7a\xba\x7f\x60\x19\x7a\xfe\x27\x7d\xcf\x57\x8a\x3a\x0b\x90\x47\x40\xfc\xfe\x95\xe8\xf9\x11\x7f\xbe\x6a\xb4\x03\xe2\xe2\xc5\x78\x51\x7c\xaa\x6f\x77\x66\x3d\x64\x2b\x87\xaa\x27\xaa\x2c\x84\x8c\x8e\x3d\xf0\x81\x37\xe4\x81\x9f\x04\xa5\x14\x2d\x5e\x9b\xeb\xf6\xc0\x3e\x58\xf4\xc1\x48\x77\x38\x1a\xac\x9d\x9e\xb2\x4a\xa3\xed\x45\x12\x5b\x3a\x65\xe7\x92\x77\xf7\xe9\x45\x1a\x43\x5d\xdf\xda\x0a\x66\xa7\x24\xde\x51\x80\x63\xc8\xab\x36\xdf\x84\xa7\xd2\x33\x0b\x80\x7a\x8f\x5e\x6f\x5d\xa8\xbc\x44\x6f\xbc\x70\xd7\x6e\x59\x51\x59\x65\x2f\x58\x2b\xf3\xc3\xba\x8d\xc3\x23\x4b\x52\x22\x21\x21\x49\x80\xc5\x02\xd8\x22\xe5\x4c\xc5\x33\x74\xb8\x37\x3b\x2f\x5a\x74\x5c\xd4\xcc\x7e\x88\xb9\x85\xc7\x96\x07\xb8\x6a\x5b\xc0\x92\x1a\xb5\x74\x6d\x54\x5a\x70\x1b\x8d\xb2\x65\xc3\x86\xb9\x23\x27\xfd\xbe\xde\xe8\x62\x75\xf2\x49\x5f\x1a\xc5\x3c\x3c\x44\xdf\xfe\xe9\x49\x6d\x0d\x2b\xa4\x14\x14\xa1\x2d\x2b\xc7\x1c\x86\x1a\x92\x2e\xe5\x21\xc1\xe9\x6e\xe6\x37\x6d\x02\x8d\xcb\x51\x98\x73\xac\x28\x64\x34\xc6\x91\xac\x2c\x50\x8b\x12\x27\xd3\x34\x9a\x9d\xd2\x5e\x4f\x5a\x4e\x08\xbb\xb8\x48\x62\x18\x86\x3c\x12\xfa\xdc\xdf\x33\x04\xf5\xb5\xaa\xae\xb0\x73\x37\x81\xb0\x8c\x61\x5a\x9a\x70\xe8\x72\x8b\x2a\xe1\xe0\x77\xa9\x06\x8e\xae\x1b\x95\x19\x2c\x0a\xff\x7a\x94\x80\xe1\x96\xd6\x32\x68\x14\x5d\x51\x16\x1f\x75\x75\xde\x74\x89\x16\x36\x03\xef\x19\x31\x5a\x9d\xcc\xb6\xc7\xd2\x16\x6c\xf4\x29\xa9\x04\x9d\xba\xce\xe1\x8f\xcc\xf8\xc6\x79\x69\x17\x89\x3a\x40\x51\x0f\xd5\x7c\xa7\xd7\x6d\xbc\xa4\xf2\x6b\x84\xe2\x41\x71\xed\x99\x86\xc8\x6e\x8d\x36\x2e\x33\xc5\xb6\x68\x7a\x2b\x7d\xca\xe4\x5f\xef\x5d\xad\x7a\xd2\x5a\x43\x51\x1f\xa8\xb8\x55\x74\xd4\xeb\x59\xde\xf4\xd7\x82\x9d\xaf\xa2\xd1\x8d\x2f\x45\x96\x4d\xf7\x15\x99\x2d\x45\x36\x1b\xf6\x2f\x6d\xdb\x74\xb3\x55\x3f\xaa\xe9\x15\x9f\x38\xf2\x28\x16\x7e\xc0\x1b\xd3\x59\x1d\xa7\xc3\x97\xee\x26\x61\x9b\x9f\xe6\xb4\xec\xf8\xd2\x7d\xf1\x6a\x7b\x61\x94\xa3\x12\x78\xdd\x4d\x5f\x76\xbd\x6b\x72\x69\x8f\x16\x66\xf4\xf1\x97\x72\x8a\x4d\x70\x37\xd1\x97\x83\x7e\x8a\xb6\xaa\x1d\x2e\x26\x98\xe2\x1d\xb2\x58\x8d\xbe\x15\x60\x5a\x0d\x46\xb8\xb8\x8d\x89\xcf\xaa\x49\x1f\xa7\xa6\xbd\x9a\x24\xcf\xa2\x8e\x62\xde\x8f\xc7\x86\xa4\xf2\x7d\x88\x4c\x1a\x35\x71\x1b\x4a\x53\xf2\xfe\xdd\xbb\xd3\x62\xf9\x1d\xd7\x90\x6c\x34\x30\x5a\xec\x53\xf3\xae\x25\x1e\x6d\xbc\xab\x89\xeb\xf8\xd8\x57\xab\x70\xf6\x2f\x1c\x17\x7f\xac\x8e\xaf\x6f\x50\x77\xe9\xf5\x37\x30\x75\x89\x8e\x1e\xc8\xda\xc6\x7d\x3e\x2d\xfc\x8b\xd5\x0d\x1e\x24\xde\x85\x8c\xa1\xaa\x48\x1d\x76\x34\x1c\xd5\x6a\x45\x00\xfc\xde\x96\xb3\x91\xbd\x4c\x41\x32\xf2\x37\x84\x35\xd3\x82\x66\xa8\xfe\x5c\xaf\xc2\x60\x34\xb3\x56\xc8\x55\x54\xf3\x61\x16\xdb\xa3\x93\x9c\x1f\xea\xff\x19\x9d\xe3\x0c\xc0\xef\x7e\x01\x1b\x6f\x6c\x4e\xf9\x6f\xac\xea\x22\x2f\x7e\x00\xe0\x3b\x4e\xd9\xf4\x5b\x13\x12\x4b\x16\xb6\x20\xd3\x07\x27\xb9\x38\x1e\x97\x17\x3f\x9c\x34\x52\x24\xb7\xe7\x33\xb3\x9a\x5b\x1b\xe6\x1a\x79\xdd\xb1\xf7\x06\x8d\x63\xb5\xe5\xaf\xdf\x1a\xec\xf5\x4b\xb3\x8d\x7d\xbd\x3f\xc5\xbc\xde\x3b\x02\x40\x25\x21\x3b\x64\x58\xca\x01\x07\x08\x2d\x84\xd9\x0a\x01\x21\xed\x7f\x7e\xaf\x2c\x1d\x57\x3d\x00\x00")
 
 func templatesBaseTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -91,7 +93,7 @@ func templatesBaseTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/base.tf", size: 14886, mode: os.FileMode(480), modTime: time.Unix(1525715599, 0)}
+	info := bindataFileInfo{name: "templates/base.tf", size: 15703, mode: os.FileMode(480), modTime: time.Unix(1792361466, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -116,7 +118,7 @@ func templatesCf_dnsTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\xdc\x5b\x8f\x9b\x46\x14\x07\xf0\x77\x7f\x0a\x64\xf5\xa9\xd2\xba\x1e\xee\x54\xda\xa7\x95\xaa\xf6\xa5\x8a\x9a\xbc\x55\x15\xc2\x78\xd6\x46\x61\xc1\x9a\x19\x6f\x95\x46\xfe\xee\x15\x37\x5f\x62\x0c\xf6\xdf\xff\x24\x9b\xcd\x53\x80\x33\x73\x18\x0e\x3f\x8e\xad\x65\x95\xd4\xe5\x56\xa5\xd2\x9a\x26\xff\xea\x58\xcb\x74\xab\x32\xf3\x29\x5e\xa9\x72\xbb\x99\x5a\xd3\xf4\x39\xd6\x7a\x1d\xe7\x8b\xb3\x5d\x9f\x27\x96\x55\x24\x2f\xd2\x6a\x7f\x1e\xad\xe9\x4f\x9f\x5f\x13\x35\x93\xc5\x6b\x9c\x2d\x77\x0f\xe9\xf3\x83\xd6\xeb\x87\x7c\xf1\xd0\x85\x3e\x34\xa1\x13\xcb\x5a\x4a\x9d\xaa\x6c\x63\xb2\xb2\xa8\x02\x9f\x7e\xb3\xde\xbf\xff\xbd\xda\xf1\xba\x49\xe3\x6c\x79\x34\x62\x5e\xa6\x49\x3e\x6b\x36\xef\xa6\x93\x89\x65\x65\xc5\x4a\x49\xad\xeb\x04\x2c\x2b\xcd\x96\x2a\x5e\xe4\x65\xfa\x51\x5b\x8f\xd6\xdf\xd3\xf9\xac\xfe\xf7\xcb\x7c\xfa\x4f\xbd\x7f\xa3\x4a\x53\xa6\x65\xde\x0e\x68\xd2\x7a\x7e\xcb\x7a\x56\xe5\x4b\xbc\x29\x95\xa9\xb7\xdb\xb6\x6d\xd7\x9b\x4d\xd9\x6d\x3c\xda\xbc\xab\xa6\x95\xc7\xb3\x9e\x46\xcf\x7b\x42\xe7\x7d\xb3\x3f\x88\xe9\x15\x49\xd7\xd3\x99\x64\xd5\x4d\xf6\x67\xb5\xca\x37\x2d\x6f\x3d\x42\x9e\x3d\xcb\xf4\x53\x9a\xcb\x76\x98\x6c\x55\x94\x4a\xc6\xe9\x3a\x29\x56\xb2\x99\xb7\xba\x7e\xed\x94\xbb\xc9\xa4\xdc\x9a\xcd\xd6\x8c\x5d\xf3\xd7\x24\xdf\xb6\xe9\x9c\x57\xcc\xec\x52\xec\xac\xbe\x7a\xbb\xc9\xe4\xea\x7a\xcb\x0a\x23\x55\x91\xe4\xf7\x14\x5e\x37\xc6\xb5\x15\x68\xfd\xd1\x06\x40\xa5\x78\x9a\x68\xb3\xc2\xb7\x2f\xd2\x79\xd9\x0e\x95\xae\x75\xb9\x7c\x7f\xa4\x12\x1e\xb8\x50\xac\x5a\x1e\xac\xa7\x6b\x8b\xfa\xc2\x20\x17\xaa\x5b\xe6\x8b\xe3\x92\x3e\x2f\xdd\xd3\x9f\xfd\xfa\xe8\x75\xa9\x4c\x7c\xb6\x4a\xd5\x6a\xa4\xaa\xd4\x3a\xfe\xaf\x2c\x64\x9c\x97\xc9\x32\x5e\x24\x79\x52\xa4\x59\xb1\xb2\x1e\x2d\xa3\xb6\xb2\x5a\xac\xb5\x4c\x72\xb3\x8e\xd3\xb5\x4c\x3f\xb6\xeb\xd5\x6c\xfa\x14\x9b\xb5\x92\x7a\x5d\xe6\xcb\x7a\x3a\xaf\xde\xb7\x2d\xce\xf7\x3e\x5a\x4d\x3d\xd5\xe7\xfb\x9a\xe4\xa7\x69\xfa\x4d\xb1\x24\x6a\x25\xcd\xd9\x29\x7c\x78\x7a\xf7\x6b\x55\x74\x4d\x99\x98\xec\x45\x96\x5b\xf3\xc5\x41\xf6\xe1\xba\x6a\x23\x0b\xa9\xba\xcb\x5a\x68\x93\x14\xa9\xec\x31\xf8\x78\x67\x57\x91\xc7\x37\x45\xbe\x38\xad\xfc\x93\xd0\x6a\xe7\xe9\x0d\x75\x08\xad\xf3\xe0\xdd\xba\x7a\xbb\x28\xa4\xd1\x47\x59\xec\x47\xaa\xf7\xcc\xaa\xd0\xe6\x98\xd9\xcf\x6d\x54\x6f\xbd\xd6\xf5\xdc\x57\x9c\x32\x5f\x1c\xd2\x98\x55\x87\x35\xb5\x77\x3e\xc4\x56\xe5\x57\x8c\xb0\x2c\x74\x7c\x18\x65\xdc\x67\x55\x6e\x8d\x54\x68\x4b\xd0\x44\x5f\xdb\x15\xfc\x55\x1f\xfd\x1d\x1b\x83\xb0\x0f\xc6\x7a\xe3\xee\x6b\x4d\xe9\xba\x4e\xcf\x9c\xcd\xd6\xaf\x38\xe9\x85\x59\x0f\xd3\xbe\xc1\xa7\xc7\x50\x31\xdd\xfd\xdc\x18\xae\xf3\xd1\x27\xc6\xa5\xf0\x1b\x3a\xa1\xc3\x10\x77\x35\x43\x87\x55\xba\xa9\x1f\x6a\xee\xbd\x6f\xd7\x12\x0d\x2e\x18\xd2\x15\xf5\xdc\xbb\x5f\xde\xbf\x6f\xba\xa6\xbf\x66\x53\x74\x65\x69\xdd\x50\xe5\x60\x6b\xb4\x1f\x00\xef\x8e\xf6\x2b\xf6\x66\x1a\x24\x61\x8f\x75\x48\xe1\x9c\xd5\x1f\xb5\x55\xde\xdb\x1d\xad\x8d\x19\x68\x8f\xda\xc8\xde\xe6\xa8\x8b\xbc\x2e\x8b\xa1\x34\xc6\xf2\x38\x7a\xe2\x9d\x67\xd2\x05\xeb\x26\x5a\xeb\x3c\x4e\xa5\x32\xd9\x73\x96\x26\x46\x56\x16\xed\x6b\x33\x4b\x5e\x62\x2d\xd5\xab\x54\xc7\x87\x54\xed\x56\xf5\xdf\x59\xa2\x8a\x1d\xef\x84\x06\xda\xce\xe3\x87\x69\xff\x09\x69\x9d\x73\x4f\x87\xaa\xec\xfd\x0d\xec\x61\x8a\xb1\x1e\x76\x7f\x64\x7f\x1b\x7b\x18\x68\xa4\x93\x3d\x8c\x73\x6b\x33\x6b\xd2\x0d\xda\xc9\x9a\x74\x73\x6d\x1b\xfb\xe1\xe9\xdd\x77\xec\x61\xc5\xdc\x76\x7b\x1e\x66\x42\xd8\x6f\xb9\xb7\xbb\xb8\xbc\x77\x3f\xfb\x06\xae\xf9\xe8\xf3\xae\x37\xf6\x86\x96\xae\x8d\xbf\xab\x9f\x6b\x57\xe6\xa6\x66\xee\xc3\xd3\xbb\x6f\xd7\xc9\x5d\x5e\x24\xa4\x8d\xeb\x2d\xdf\xf3\x12\x7e\x2b\xe9\xfe\x98\x5d\xe7\x78\x4d\xb1\x6e\xbb\xfb\xfa\xcd\xe1\x41\xc6\x9a\xcd\x26\x1a\xef\x34\x9b\x55\xa2\xb7\x99\xfe\x40\x9b\xe9\x0c\xb4\x99\xde\x7d\x5d\xa6\x73\x43\x97\xb9\xbf\x09\x6f\xff\x16\x6e\x1f\x3a\xfa\x2d\xdc\x75\x79\x78\x78\x1e\x1e\x33\x0f\x1f\xcf\xc3\x67\xe6\x11\xe0\x79\x04\xcc\x3c\x42\x3c\x8f\x90\x99\x47\x84\xe7\x11\x11\xf3\x70\x06\x3e\x97\x8d\xe4\xe1\x0c\x7c\x30\xbb\x3d\x0f\x81\xe7\x21\x98\x79\xa0\xdf\xe2\xef\x43\x49\x79\x38\x78\x1e\x97\x3e\xd5\x41\x79\xe0\x9e\x3a\x4c\x4f\x1d\xdc\x53\x87\xe9\xa9\x83\x7b\xea\x30\x3d\x75\x70\x4f\x1d\xa6\xa7\x0e\xee\xa9\xc3\xf4\xd4\xc1\x3d\x75\x98\x9e\xba\xb8\xa7\x2e\xd3\x53\x17\xf7\xd4\x65\x7a\xea\xe2\x9e\xba\x4c\x4f\x5d\xdc\xd3\x8b\xdf\x92\x41\x79\xe0\x9e\xba\x4c\x4f\x5d\xdc\x53\x97\xe9\xa9\x8b\x7b\xea\x32\x3d\x75\x71\x4f\x5d\xa6\xa7\x2e\xee\xa9\xcb\xf4\xd4\xc5\x3d\x75\x99\x9e\x7a\xb8\xa7\x1e\xd3\x53\x0f\xf7\xd4\x63\x7a\xea\xe1\x9e\x7a\x4c\x4f\x3d\xdc\x53\x8f\xe9\xa9\x87\x7b\xea\x31\x3d\xf5\x70\x4f\x3d\xa6\xa7\x1e\xee\xa9\xc7\xf4\xd4\xc3\x3d\xf5\x98\x9e\x7a\xb8\xa7\x1e\xd3\x53\x0f\xf7\xd4\x63\x7a\xea\xe3\x9e\xfa\x4c\x4f\x7d\xdc\x53\x9f\xe9\xa9\x8f\x7b\xea\x33\x3d\xf5\x71\x4f\x7d\xa6\xa7\x3e\xee\xa9\xcf\xf4\xd4\xc7\x3d\xf5\x99\x9e\xfa\xb8\xa7\x3e\xd3\x53\x1f\xf7\xd4\x67\x7a\xea\xe3\x9e\xfa\x4c\x4f\x7d\xdc\x53\x9f\xe9\x69\x80\x7b\x1a\x30\x3d\x0d\x70\x4f\x03\xa6\xa7\x01\xee\x69\xc0\xf4\x34\xc0\x3d\x0d\x98\x9e\x06\xb8\xa7\x01\xd3\xd3\x00\xf7\x34\x60\x7a\x1a\xe0\x9e\x06\x4c\x4f\x03\xdc\xd3\x80\xe9\x69\x80\x7b\x1a\x30\x3d\x0d\x70\x4f\x03\xa6\xa7\x43\xbf\x27\x38\x92\xc7\xd0\x2f\x0a\xde\x9e\x07\xee\x69\xc8\xf4\x34\xc4\x3d\x0d\x99\x9e\x86\xb8\xa7\x21\xd3\xd3\x10\xf7\x34\x64\x7a\x1a\xe2\x9e\x86\x4c\x4f\x43\xdc\xd3\x90\xe9\x69\x88\x7b\x1a\x32\x3d\x0d\x71\x4f\x43\xa6\xa7\x21\xee\x69\xc8\xf4\x34\xc2\x3d\x8d\x98\x9e\x46\xb8\xa7\x11\xd3\xd3\x08\xf7\x34\x62\x7a\x1a\xe1\x9e\x46\x4c\x4f\x23\xdc\xd3\x88\xe9\x69\x84\x7b\x1a\x31\x3d\x8d\x70\x4f\x23\xa6\xa7\x11\xee\x69\xc4\xf4\x34\xc2\x3d\x8d\x98\x9e\x46\xb8\xa7\x11\xd1\x53\x31\x87\x3d\xed\x42\x49\x79\xc0\x9e\x76\xa1\xa4\x3c\x60\x4f\xbb\x50\x52\x1e\xb0\xa7\x5d\x28\x29\x0f\xd8\xd3\x2e\x94\x94\x07\xec\x69\x17\x4a\xca\x03\xf6\xb4\x0b\x25\xe5\x01\x7b\xda\x85\x92\xf2\x80\x3d\xed\x42\x49\x79\xc0\x9e\x76\xa1\x9c\x3c\x04\xee\xa9\x60\x7a\x2a\x70\x4f\x05\xd3\x53\x81\x7b\x2a\x98\x9e\x0a\xdc\x53\xc1\xf4\x54\xe0\x9e\x0a\xa6\xa7\x02\xf7\x54\x30\x3d\x15\xb8\xa7\x82\xe9\xa9\xc0\x3d\x15\x4c\x4f\x05\xee\xa9\x60\x7a\x2a\x70\x4f\x05\xd3\x53\x1b\xf7\xd4\x66\x7a\x6a\xe3\x9e\xda\x4c\x4f\xe1\xbf\xca\xb3\x0f\x25\xe5\x81\x7b\x6a\x5f\xe9\x29\xef\x65\xc2\xfb\x5f\xae\x6e\xc7\x1f\x7b\xb3\xba\x39\xac\xff\xb5\xea\x76\x88\x91\x77\xaa\xdb\x11\x4e\x5e\xa8\xfe\x3f\x00\x00\xff\xff\x59\xb1\x38\x54\x2d\x50\x00\x00")
+var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x9c\x5d\x6f\xa3\x46\x18\x85\xef\xf7\x57\x20\xab\x57\x95\xe2\x7a\x80\x19\x86\x4a\x7b\xb5\x52\xd5\xde\x54\x55\xb7\x77\x55\x85\x08\x99\xc4\x68\x09\x58\x80\x53\xa5\xab\xfc\xf7\x0e\x1f\xb6\x71\x6d\x83\x7d\x7c\xba\x72\x9c\x9b\xac\x67\xde\x97\x33\x33\x87\x87\xa3\x48\x6c\x69\xaa\x62\x5d\x26\xc6\x99\xc5\x7f\x57\x51\x65\x92\x75\x99\xd6\xaf\xd1\x53\x59\xac\x57\x33\x67\x96\x3c\x46\x55\xb5\x8c\xb2\xfb\x83\xa1\xaf\x1f\x1c\x27\x8f\x9f\x8d\xd3\x7f\x3e\x3a\xb3\xef\xbe\xbe\xc4\xe5\xdc\xe4\x2f\x51\xfa\xf0\x76\x97\x3c\xde\xd9\xd2\xbb\xec\xfe\x6e\x53\x7a\xd7\x95\xda\xc2\x07\x53\x25\x65\xba\xaa\xd3\x22\x6f\x0a\x3f\xfd\xe4\x7c\xfe\xfc\x73\x33\xf0\xb2\x4a\x6c\xf1\xa0\x63\x56\x24\x71\x36\xef\xbe\x7e\x9b\x7d\xb0\x53\xd2\xfc\xa9\x34\x55\xd5\x0a\x70\x9c\x24\x7d\x28\xa3\x7b\x3b\xeb\x4b\xb5\xa9\xfa\x73\xb6\x98\xb7\x3f\x3f\x2c\x66\x7f\xb5\x93\xd2\xd5\x8b\x8a\x86\x33\x9b\x49\x9d\xda\xed\x50\xf5\xd6\x4f\x5e\x95\x45\x5d\x24\x45\xe6\x0c\x56\x56\x27\xad\x6c\xc7\x79\x2c\x8b\xe7\x68\x55\x94\xf5\x6e\xd0\xb5\x9f\x76\xac\x2e\xf6\x46\x06\x63\x6f\x8d\x6e\x33\x94\x3d\xec\xf3\xd1\x59\x1c\xd4\x6f\xbe\x1b\x8a\xb1\x3a\xee\xc4\xec\x60\xd5\x87\x0b\x6e\x2f\x57\xc7\x4f\x9b\x8b\xfd\xda\x1c\xd3\x45\xe7\xd3\x76\xc8\xd2\x47\x93\xbc\x26\x99\xe9\xdb\xa4\x4f\x79\x51\x9a\x28\x59\xc6\xf9\x93\xe9\xae\xdb\x18\xa0\xbf\xa4\x2d\x29\xd6\xf5\x6a\x5d\x4f\x99\xe6\x25\xce\xd6\xbd\x9c\x43\xcb\xcd\x4f\xd5\xce\xdb\xe3\xb7\x17\x29\xcf\x35\x6c\x9a\xd7\xa6\xcc\xe3\xec\x1a\xe7\x6e\x7a\x9c\x6b\x61\xe7\x97\xbe\x00\xf2\xf2\xbe\xd0\x8d\x4b\x2f\xdd\xa4\xe3\x2e\x1e\x33\xf1\x88\x87\xdf\x8b\x85\x47\x0e\x8a\xe5\xe5\x51\x3f\x9d\x6b\xea\x13\x4d\x4e\xb8\xdb\x64\xf7\x43\x4b\x1f\x5a\x77\xff\xb3\xdd\x9f\x6a\x69\x0f\x21\x3a\xd8\xa5\x66\x37\x92\xb2\xa8\xaa\xe8\x9f\x22\x37\x51\x56\xc4\x0f\xd1\x7d\x9c\xc5\x79\x62\x7d\x68\xab\xeb\x72\x6d\x9a\xcd\x5a\x9a\x38\xab\x97\x76\x73\x4c\xf2\xa5\xdf\xaf\xee\xab\xd7\xa8\x5e\x5a\x85\xcb\x22\x7b\x68\x2f\x27\xdb\xb1\x75\x7e\x38\x6a\x7d\xd3\xed\x73\xb3\x5e\xbb\x39\xfb\x32\x55\x67\x96\xb8\x7c\x32\xf5\xc1\x12\xfe\xf8\xf4\xdb\x8f\x8d\xe9\x3a\x9b\xd4\xe9\xb3\xb1\x67\xf1\x9f\x49\xee\xee\x5c\xab\xda\xe4\xa6\xdc\x1c\x6b\x5e\xd5\x76\x39\x66\xe8\xc2\xad\xb7\x77\x83\x1b\x47\x0e\x6f\x0a\x7b\x38\xfb\xf4\x1e\x96\x36\x83\xfb\x37\xd4\xae\xb4\xd5\xc1\xbb\x75\xab\xf5\x7d\x6e\xea\x6a\xa0\x62\xdb\xa9\x1d\x99\x37\xa5\xdd\x9c\xf9\xf7\x7d\xd5\x51\xbf\xb6\x7e\x3e\x66\x4e\xeb\xaa\x9d\x8c\x79\x33\xad\xf3\xde\x61\x8b\x75\x99\x9d\xd1\xe1\x21\xaf\xa2\x5d\x97\x69\x3e\xdb\xdf\xac\x29\xd0\x4c\xd1\x55\x9f\x1b\x2b\x7e\x6f\x67\xbf\xd3\x64\xa1\x17\xa7\x72\x45\x3b\xf2\x76\x8b\x9a\x7d\xdf\x3b\x25\xba\x1b\xba\x51\xd5\x63\xb2\x77\xba\x6f\xf0\x01\x38\x76\x3f\x5c\xfd\xe8\x1b\xbf\x55\x27\x1f\x7a\xa7\xca\x2f\x08\x73\xbb\x16\x57\xe5\xb9\xdd\x2e\x5d\x14\xe9\x3a\x7c\x7c\xbb\x54\x37\xba\x61\x48\xb0\x3b\x81\x90\x21\x41\x6e\xda\xd3\xff\x67\xae\x3b\xd3\x5a\x17\xb8\x1c\x4c\x77\xdb\x06\x78\xc0\xdb\xee\xd8\xcd\x64\x3c\xe1\x4e\x85\x3c\xbd\x60\x45\xbc\xde\xe5\x47\x03\xde\xb2\xae\x47\x12\x5e\x5f\x79\x34\xdf\x6d\x2a\xcf\x53\x31\x26\x63\x4a\xc7\xe0\xb9\x79\xa8\x64\x53\x5c\x75\xd5\x55\x95\x45\x89\x29\xeb\xf4\x31\x4d\xe2\xda\x34\x2c\xda\x7a\x33\x8d\x9f\xad\xf5\xca\x17\x6b\xa6\xc1\x94\x26\x31\x36\xff\x9c\xc7\x65\xfe\xc6\x5b\xd0\x48\x72\x1e\x3e\x51\x8f\x2f\xc8\xae\x82\xbb\x1c\x2a\x65\xaf\xcf\xe0\xbb\x4b\x4c\xc5\xf0\xed\xcc\xe3\x49\x7c\xd7\x68\x22\x8c\xef\xfa\x5c\x9a\xc7\xed\x41\xa2\x61\xdc\x96\x9e\x9b\xc4\xed\x1d\xff\x4e\x63\xb8\x58\xb8\xfe\xa9\x70\x28\x84\x7b\xcb\xe1\xf0\xe4\xf9\x5c\xfd\xf0\x1c\x31\xcd\xe4\x03\xf3\x68\xed\x05\x99\xb0\xaf\xbf\x2a\x10\xf6\x3b\x73\x51\x1a\xb4\x16\xfe\x76\x51\xf0\xf4\x26\x21\x39\xf0\xa4\x87\xf7\x2d\x7c\x2b\x72\xdf\x67\x6c\x9d\xf6\x14\xeb\xb6\xbb\x2e\xb0\x8e\x37\x99\x4a\xab\x5d\x35\x1e\x55\xbb\x5d\xa2\xe7\x54\x35\x92\x53\xbd\x91\x9c\x2a\xaf\x8b\xa9\xde\x05\x31\x75\x7b\x13\x5e\xfe\x97\xc8\x6d\xe9\xe4\x5f\x22\xcf\xd3\x21\x71\x1d\x92\xa9\x43\xe1\x3a\x14\x53\x47\x80\xeb\x08\x98\x3a\x34\xae\x43\x33\x75\x84\xb8\x8e\x90\xa8\xc3\x5b\xc0\x3a\xbc\x05\x53\x87\xc0\x75\x08\xa6\x0e\x17\xd7\xe1\x32\x75\x78\xb8\x0e\x8f\xa9\x03\xe7\xa9\xc7\xe4\xa9\x87\xf3\xd4\x63\xf2\xd4\xc3\x79\xea\x31\x79\xea\xe1\x3c\xf5\x98\x3c\xf5\x70\x9e\x7a\x4c\x9e\x7a\x38\x4f\x3d\x26\x4f\x7d\x9c\xa7\x3e\x93\xa7\x3e\xce\x53\x9f\xc9\x53\x1f\xe7\xa9\xcf\xe4\xa9\x8f\xf3\xd4\x67\xf2\xd4\xc7\x79\xea\x33\x79\xea\xe3\x3c\xf5\x99\x3c\xf5\x71\x9e\xfa\x4c\x9e\xfa\x38\x4f\x7d\x26\x4f\x7d\x9c\xa7\x3e\x93\xa7\x3e\xce\x53\x9f\xc9\x53\x89\xf3\x54\x32\x79\x2a\x71\x9e\x4a\x26\x4f\x25\xce\x53\xc9\xe4\xa9\xc4\x79\x2a\x99\x3c\x95\x38\x4f\x25\x93\xa7\x12\xe7\xa9\x64\xf2\x54\xe2\x3c\x95\x4c\x9e\x4a\x9c\xa7\x92\xc9\x53\x89\xf3\x54\x32\x79\x2a\x71\x9e\x4a\x26\x4f\x15\xce\x53\xc5\xe4\xa9\xc2\x79\xaa\x98\x3c\x55\x38\x4f\x15\x93\xa7\x0a\xe7\xa9\x62\xf2\x54\xe1\x3c\x55\x4c\x9e\x2a\x9c\xa7\x8a\xc9\x53\x85\xf3\x54\x31\x79\xaa\x70\x9e\x2a\x26\x4f\x15\xce\x53\xc5\xe4\xa9\xc2\x79\xaa\x98\x3c\x0d\x70\x9e\x06\x4c\x9e\x06\x38\x4f\x03\x26\x4f\x03\x9c\xa7\x01\x93\xa7\x01\xce\xd3\x80\xc9\xd3\x00\xe7\x69\xc0\xe4\x69\x80\xf3\x34\x60\xf2\x34\xc0\x79\x1a\x30\x79\x1a\xe0\x3c\x0d\x98\x3c\x0d\x70\x9e\x06\x4c\x9e\x06\x38\x4f\x03\x26\x4f\x35\xce\x53\xcd\xe4\xa9\xc6\x79\xaa\x99\x3c\xd5\x38\x4f\x35\x93\xa7\x1a\xe7\xa9\x66\xf2\x54\xe3\x3c\xd5\x4c\x9e\x6a\x9c\xa7\x9a\xc9\x53\x8d\xf3\x54\x33\x79\xaa\x71\x9e\x6a\x26\x4f\x35\xce\x53\xcd\xe4\xa9\xc6\x79\xaa\x99\x3c\x0d\x71\x9e\x86\x4c\x9e\x86\x38\x4f\x43\x26\x4f\x43\x9c\xa7\x21\x93\xa7\x21\xce\xd3\x90\xc9\xd3\x10\xe7\x69\xc8\xe4\x69\x88\xf3\x34\x64\xf2\x34\xc4\x79\x1a\x32\x79\x1a\xe2\x3c\x0d\x99\x3c\x0d\x71\x9e\x86\x4c\x9e\x86\x38\x4f\x43\x22\x4f\xc5\x02\xe6\xe9\xa6\x94\xa4\x43\xe0\x3a\x04\x53\x87\x8b\xeb\x70\x99\x3a\x3c\x5c\x87\xc7\xd4\xe1\xe3\x3a\x7c\xa6\x0e\x89\xeb\x90\x4c\x1d\x0a\xd7\xa1\x98\x3a\x02\x5c\x47\xc0\xd4\xa1\x71\x1d\x9a\xa9\x23\xc4\x75\x30\x79\x2a\x70\x9e\x0a\x26\x4f\x05\xce\x53\xc1\xe4\xa9\xc0\x79\x2a\x98\x3c\x15\x38\x4f\x05\x93\xa7\x02\xe7\xa9\x60\xf2\x54\xe0\x3c\x15\x4c\x9e\x0a\x9c\xa7\x82\xc9\x53\x81\xf3\x54\x30\x79\x2a\x70\x9e\x0a\x26\x4f\x05\xce\x53\xc1\xe4\xa9\x8b\xf3\xd4\x65\xf2\xd4\xc5\x79\xea\x32\x79\xea\xe2\x3c\x75\x99\x3c\x75\x71\x9e\xba\x1e\xff\x7f\x48\x1a\x7f\x99\xf0\xfa\xb7\xb3\xfb\xfe\x53\xaf\x66\x77\xd3\x8e\xbf\x97\xdd\xb7\x98\x78\x29\xbb\xef\xb0\xf7\x46\xf6\xbf\x0c\x2a\x60\xbf\x72\x51\x00\x00")
 
 func templatesCf_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -131,7 +133,7 @@ func templatesCf_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 20525, mode: os.FileMode(480), modTime: time.Unix(1524679797, 0)}
+	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 20850, mode: os.FileMode(480), modTime: time.Unix(1792361171, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -156,6 +158,26 @@ func templatesConcourse_lbTf() (*asset, error) {
 	return a, nil
 }
 
+var _templatesDual_stackTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x52\xcb\x6e\xc3\x20\x10\x3c\xc7\x5f\x81\x50\x0f\x6d\xd5\xd2\xf4\xd2\x43\xa4\x7c\x49\x55\x21\xec\x6c\x2d\x54\x02\x88\x87\xa3\x24\xf2\xbf\x97\x87\x49\x4d\x9c\x2a\xdc\xd8\xd9\x1d\x66\x86\x35\x60\x95\x37\x1d\x20\xcc\x0e\x96\x42\x6f\xc0\x5a\xaa\xa4\x38\x52\x2e\x1d\x18\x09\x8e\xf6\xcc\xc1\x81\x1d\x31\xc2\x15\xdc\x63\x74\x6e\x10\x1a\x74\x47\xf9\x0e\x6d\x11\x7e\x38\x0b\xd5\x31\x41\x72\x65\xc4\xcd\xd8\x34\xa6\xa2\x37\xca\x3b\x08\x3c\x99\x9a\x89\x5c\xa0\x8e\xb5\x02\x28\xd7\xc3\x47\xa6\xdc\x81\x75\x5c\x32\xc7\x95\x4c\x55\xda\xf1\x9d\xa1\x6d\x60\xff\x89\xef\x6c\x36\x6f\x6b\x1c\xda\xe6\x6a\x26\x8d\x51\x49\x3a\x49\xce\x3d\x47\xa4\xf6\x43\x92\x68\x84\x2a\x51\x13\x5f\x39\x17\xde\x59\x13\xb9\x65\x87\xdc\x4e\xc0\x42\xe7\x0d\x77\x41\x70\xe8\xd5\xd4\x78\x51\xe5\x71\x03\xce\x09\x64\xa5\x39\x9e\xab\xa6\x12\xfe\x92\x9e\xfc\xc3\x5b\x8c\xba\xa3\x86\x85\xbd\xe9\xa5\x00\x6b\xa3\x9c\xea\x94\xa8\xe0\xd7\xf7\x08\x7d\x1b\xb5\xa7\x5a\x19\x37\x83\xd6\x91\x51\xd5\xd5\x52\xbf\xfa\x45\x1b\xeb\x9f\xf9\x23\xbf\x62\x48\x21\x37\xed\x1d\xc2\x69\x75\x4a\xef\xb4\x5f\x4c\x78\x58\xac\x57\xe9\xc9\x19\x97\xf1\x8b\x5f\x76\xa2\xd6\xb7\xf1\xab\xff\x5e\xde\x33\xad\xb9\xec\x17\xac\xcd\x0a\xa1\x13\xd7\x01\x7e\x2c\x29\xa6\xd1\x59\x7a\xe9\x6e\xc9\x33\x61\x03\xe3\x82\xb5\x5c\xc4\x2c\x4f\x4a\xc2\x88\x5f\xd0\xdd\xa9\x2b\xf7\x23\x7e\x6a\x56\x49\xf8\x2f\xb0\x27\x0f\x9d\x7d\x03\x00\x00")
+
+func templatesDual_stackTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDual_stackTf,
+		"templates/dual_stack.tf",
+	)
+}
+
+func templatesDual_stackTf() (*asset, error) {
+	bytes, err := templatesDual_stackTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/dual_stack.tf", size: 893, mode: os.FileMode(480), modTime: time.Unix(1792361171, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _templatesIamTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x57\x51\x6f\xe3\x36\x0c\x7e\xae\x7f\x05\x61\xec\x61\x2b\x9a\xac\xed\xcb\x80\xe0\x8a\x43\xd1\x66\xc5\xb6\x1b\x56\x24\xc5\x3d\xac\x28\x0c\x46\xa6\x1d\x6d\xb2\xe4\x49\x72\xba\xac\xc8\x7f\x1f\x24\xd9\x4e\xd2\xd8\x4e\xbb\xe1\xee\xa5\x40\xfd\x7d\x24\x3f\x92\x0e\x49\xaf\x50\x73\x5c\x08\x82\x78\xa1\xcc\x32\xe1\x58\x24\x5c\x1a\x8b\x92\x51\x52\x6a\x95\x71\x41\x31\xbc\x44\x00\x29\x65\x58\x09\x0b\x57\x10\xc7\xd1\x26\x8a\x84\x62\x28\x8c\x87\x38\x16\xf7\x81\x7a\xaf\xd5\x8a\xa7\x94\x3a\xd6\x37\x2f\x2b\xd4\xe3\x5e\xaf\x70\xe5\x3c\xc1\x47\x38\x87\x09\x5c\xc0\xc6\x3b\x4d\xd1\x22\xc4\xf8\x6c\x7a\x84\x78\x91\x41\x8f\xc4\x82\xde\x10\x66\x13\x47\x11\x00\x53\x95\xb4\x81\xed\x75\x8f\x0f\x25\x07\x01\x9a\x8c\xaa\x34\xa3\xad\x08\xad\x06\x03\x93\x5c\x25\x3c\xdd\x24\x5e\x80\xe7\x46\x00\x25\xda\xa5\xa3\x7c\xff\x3a\xf8\x05\x8c\x60\x40\x40\x04\x20\x78\x46\x6c\xcd\x04\xf9\x58\x00\x4c\x13\x5a\x4a\x16\x94\x29\x4d\x49\x4a\xc6\x6a\xb5\x86\x2b\xb0\xba\xa2\x08\x60\xe3\x6c\xd0\x98\xaa\x20\x1f\x3d\x29\x95\xe0\xcc\x11\x3e\x7c\x98\xfe\xf6\x63\xe4\x9c\xc4\x9f\x49\x1b\xae\x64\x3c\x81\xf8\xf2\xfc\xe2\x72\x74\x71\x3e\xba\xf8\x21\x3e\x73\xd0\xdc\xa2\xa5\x82\xa4\x8d\x27\xf0\xe8\x03\x86\xb0\x00\xf1\x35\xb3\xb5\x91\xb1\x66\x72\xed\x63\xcc\x5c\x82\x67\x0d\xe3\x5e\x73\xc9\x78\x89\x22\x9e\xb4\x66\xce\x27\xe9\x15\x67\xe4\x2c\x89\x5d\x8e\xb1\xc0\x7f\x94\xc4\x67\x33\x66\xaa\x88\x6b\xda\xa6\x75\x32\xcd\x32\x62\x2e\x7c\x7c\x2d\x84\x7a\xde\x7a\x9f\xf3\xd4\x3d\x0d\x16\x9b\x08\xe0\x29\xda\x44\x2e\xa7\xce\x36\x85\xbc\xdf\xda\xa8\x9a\xfd\xff\x5a\xf5\x05\x4a\xfd\xb8\xad\x22\xb1\x4b\x57\x74\xc5\x38\x5a\xba\x4e\x53\x4d\xc6\xb4\xc5\x69\x70\x6b\x91\x2d\x3f\x2b\x51\x15\xf4\x1a\xbb\x51\xe5\xfa\xa7\x02\xf3\x43\xc0\xbf\x51\xdd\x46\xb7\x24\xc8\xd2\x5c\x62\x69\x96\xca\x76\xa3\x7d\x96\x86\x69\xbe\x68\x94\xd2\x81\xd6\x96\xb0\x42\x2e\x70\xc1\x05\xb7\xeb\xdf\x95\xec\x27\x7a\xf1\xfd\x68\xfd\x3b\xef\x25\xcc\x28\xe7\x4a\xf6\xc2\x73\x62\x95\xe6\x76\x7d\xa7\x55\x55\xf6\xb3\xea\x4a\xf4\x13\xaa\x85\xa4\x7e\x38\xd4\xaa\x03\x1e\xe8\x9b\x6f\x4f\x5f\x0b\x02\xfa\x80\xf9\x81\xcf\x5f\x55\xca\xb3\x75\x53\x96\x6b\x6b\x35\x5f\x54\xf6\xc0\xfd\xac\x92\xbd\xa5\x7b\x20\x5d\x70\x89\xb6\xbf\xb8\xae\xa8\xc6\x92\xee\x7c\xb1\x6e\x49\x0f\xc1\x37\xce\xa3\x98\x97\xca\x36\xee\x67\xf4\x57\x45\x66\xa0\xb8\x6f\xe0\xd6\xcf\x77\xa9\x07\x9c\x50\xb4\x99\xea\x28\x47\xfb\xb6\x38\xf0\xc1\x2d\xc2\x8e\x08\xa5\x40\x56\x9b\x47\x27\x00\x4f\x67\xee\x6f\xc7\xe0\x72\x4f\x67\xf5\x64\x72\xcf\x4f\xeb\xd9\x75\x16\x9d\xbc\x78\x70\xe7\x77\x7e\xe2\xfd\x73\x2c\x26\xf7\x68\x8c\x9f\xab\xef\xf5\x7d\x32\xe0\x98\x04\x1a\xcb\x99\x50\x98\x2e\x50\xa0\x64\x5c\xe6\x93\xd3\xff\x14\xa2\x29\xc6\x76\xc2\xc3\xd0\xdc\xae\xe1\x8e\x91\xd6\x62\x7f\x16\x66\x32\xa3\xa9\x64\x7a\x5d\xda\xd3\x57\x96\x2d\xe3\x8e\x24\x69\xb4\x74\x8b\x16\x7f\xa1\x75\x2f\x2f\x74\xf7\x4e\xa3\xb4\x7d\x94\xa6\xcb\xde\xcd\x1e\xe5\xe9\x95\xec\x9d\xfc\x3b\x84\xbf\x36\x6e\xff\x3b\xba\x9e\x76\x76\x73\x82\x7e\x6a\xfb\x4d\xb0\xbb\xae\x1c\xa5\x76\x77\xe4\xba\xa8\xdd\x68\x19\x88\xfb\x2b\xd0\x9f\x42\x63\xd4\xf2\xe0\xf2\x39\xb2\xd1\x3a\x75\xbf\xe3\x04\xab\xb5\x8e\x3c\xde\xe4\xb3\x27\xd0\x3d\x09\xf2\x9c\xe5\x7b\xf5\x75\x1c\x47\x3c\x97\xee\x2a\x62\x4b\x94\x39\x19\xb8\x82\xc7\xd8\x79\x8e\x9f\xfc\x65\x74\x90\x50\x26\xd4\x73\x22\x54\xee\x92\x58\x88\x90\x83\x50\x79\x92\xbb\x1d\x90\x6c\xb3\x71\x5c\x26\x54\x95\x3e\xa3\x65\xcb\xa4\xa5\x8c\x17\x0b\xd1\x48\xf7\x57\x6f\x68\xab\x6b\x04\x74\x64\xda\x84\x33\x75\x37\x00\x56\x25\x4b\x78\xda\xbe\x3f\x3b\xf7\x68\x40\x3c\xc9\x6a\xcc\x32\xce\x12\xbb\x2e\x29\x90\x66\xd3\x9f\xa7\x37\x0f\x1d\x1d\xea\x12\xb9\x9b\x9c\xd3\x9a\x94\x9a\x32\xfe\xf7\xb6\x4f\x66\xa9\xb4\x4d\x9a\x6e\x09\x95\x8f\x82\xdd\xe0\xf9\xdb\xe6\x32\xd4\x79\x47\x72\x0e\xcd\x28\xbc\xaa\x5f\xec\x34\x6d\x4e\xc3\xe3\x47\xe4\xf1\x13\x75\x55\xb2\xad\xf0\x63\xc7\x6a\xef\x4d\xfc\xb6\x23\x75\xa7\x0c\xef\xaf\xe9\xf6\x66\xed\xf9\x65\x6d\xdf\x37\xfe\x55\x2e\x54\x17\xaa\x9e\xbe\x9f\x54\xee\x0f\xa9\xdd\xdd\xb9\x0f\xcf\xad\x26\x2c\x0e\xf0\xfb\xca\x7e\x52\xf9\x74\x45\x72\x7f\xb5\x7b\xb0\x19\xdb\x8d\xf7\x41\x46\x08\x60\x9a\x9e\x3d\x1d\x7f\x37\xba\x56\xf5\x7e\x07\x55\x65\xcb\xca\xfa\x35\xdd\xf3\x55\xbc\x42\x51\xd1\xf0\x87\x25\x7c\x84\x3f\x14\x97\xdf\xc6\xf1\x19\xb8\xef\xdb\x71\xdf\x6c\x0d\xa3\xf1\xd4\x4f\x98\xef\x60\xb2\xb5\x7a\x93\x81\x9f\xe0\xff\x06\x00\x00\xff\xff\x6f\x9b\x07\x6e\xce\x0f\x00\x00")
 
 func templatesIamTfBytes() ([]byte, error) {
@@ -216,6 +238,26 @@ func templatesLb_subnetTf() (*asset, error) {
 	return a, nil
 }
 
+var _templatesSiteTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdd\x59\x4b\x6f\xdc\x36\x10\x3e\x67\x7f\x85\x20\xf4\x10\x37\x5e\x76\x0d\x14\x68\x2f\x2e\xd0\x43\x0e\x39\x34\x97\xf6\x66\x04\x04\x57\xa2\xb5\x44\xb4\xa4\x40\x52\x72\xd7\xc1\xfe\xf7\x0e\x49\xbd\x45\xc9\x94\x1f\xc1\x36\x36\xe0\x20\xe4\x70\x38\xdf\xc7\x79\x70\xa8\x8a\x48\x46\xf6\x39\x8d\xe2\xbf\x3f\xfd\xf3\x11\x7f\xfe\xf3\xaf\x8f\x58\x31\x4d\xb1\xa4\x19\x13\x3c\x8e\xbe\x6d\xa2\x48\x9f\x0a\x1a\xdd\x46\xb1\xd2\x92\xf1\x2c\xde\x9c\x37\x9b\x6a\x6e\x5d\x55\x24\x38\x61\xa9\x5c\xbf\x92\x54\x84\xe5\x64\xcf\x72\xa6\x4f\xf8\x51\x70\xaa\x86\x3a\x72\xa6\xb4\xd5\x50\x48\x51\xb1\x94\xca\x28\x26\x0f\xb5\x0c\xc9\x19\x51\x91\xfd\xb9\xed\x29\x8e\xcd\x54\x92\x50\xa5\xf0\x57\x7a\x32\x53\x3f\x7d\x83\xfd\x51\x37\x76\x36\x22\x8a\x26\x92\xea\xa1\x48\x37\x66\x45\x1c\x1d\xcd\x06\x4e\xc4\xcb\x18\x48\x83\x78\x45\xa5\x32\xf2\x20\xfb\xc7\x6d\x74\x83\x6e\x7e\x43\x3b\x6b\xbb\xa4\x4a\x94\x32\xa1\xd6\x76\x43\x56\xec\x63\xd0\x81\x6a\x71\xf6\x7f\x6e\xed\x4a\x34\x80\x68\xf8\xc6\xfb\x5c\x24\x5f\x87\x82\x5e\x33\x9b\x03\xb2\xb0\x18\x57\x9a\xf0\x84\x62\x4d\x39\xfc\x7b\x6a\x56\xa6\xf4\x9e\x94\xb9\x36\x22\x30\x01\xa7\x85\x53\xae\xf0\x41\x28\xcd\xc9\x91\x2a\x10\xd1\xb2\xa4\x06\xa9\x26\x99\xb2\xd6\x46\xd1\x67\x98\xea\xb6\xa5\xbc\xc2\x2c\x3d\x6f\xdb\xed\xb7\x06\x18\x08\x9e\xa7\x3c\x30\xae\xa9\xe4\x40\x77\x46\x34\x7d\x20\xa7\x29\x29\x2c\x1b\x71\xe2\xe3\xc1\x40\x63\x69\x83\xbd\x26\xd8\x83\x1f\x81\x5d\x9e\xd3\x80\xb3\xc6\x05\x61\x72\xba\xfb\x5e\xa8\x03\xae\x8e\x6a\x72\x2e\x3e\x2b\x8c\x1a\xc3\x52\xff\x0c\x6a\x32\x70\xa7\xb7\x55\x69\x14\x96\xfb\x9c\x25\x9d\xff\xe9\x5c\xe1\x42\xb2\x0a\xc8\x30\x83\xa8\x91\x45\x9d\x20\x16\x05\xe5\x4a\x1d\x7c\x38\x54\xb9\x07\x2e\xa7\x28\x38\xd1\xed\x5c\x00\x8e\x96\xcd\x40\x3e\x07\x7e\x68\x57\x98\xff\xbb\x0d\xdf\x2f\x38\xe2\x75\xf4\xfb\x75\xb4\xbb\x72\x81\xb3\xc2\x9d\x00\xce\xb6\x86\xe3\xf7\x2a\x29\x4a\xd8\x46\x1b\xf7\xf5\x93\x31\x10\x78\x6b\xef\xb2\x9b\xad\xb5\x23\x7a\x32\xf6\x53\xaa\x34\x03\x25\x90\x6d\xf0\x90\xff\x1d\xb2\xbf\xbf\xec\x8c\x58\x1d\x58\xcd\x81\x0e\x92\x84\x2f\x02\xd1\x24\xfe\x9a\x43\xee\x19\xdb\xd7\xd6\x6a\xea\xcd\xa3\x65\xac\x4b\x44\xd5\x1b\x10\xa5\x44\xc2\x2c\xba\x29\x75\x4e\x70\xc1\xab\xe7\x38\x73\xe2\xad\xf5\xad\xe9\x6e\x1c\xcd\x86\xcd\x0c\x05\xaf\x07\x9d\xb2\xc2\xef\x21\x76\x22\x20\x66\x53\x0a\x69\x21\x55\xd8\x96\x9e\xbb\x38\xec\x64\xe3\x2f\xce\xbb\xbb\xa3\xb4\xc9\x7d\x62\x9d\x31\x64\x36\x41\xc3\xa4\x8f\x7f\x9f\x91\x24\x07\x17\x75\x1e\xdb\x67\x0f\x30\x22\x3f\xf4\x86\xf7\xe1\xb9\xad\x3c\xb6\xe0\x34\xe9\x08\x23\x79\x2d\xa0\xe6\x42\xd2\x5b\x89\x45\xc9\xf5\x34\xc2\x72\xca\x33\x7d\xf0\x25\xc1\xe9\xa5\xe7\xea\x3c\x4a\xbe\xe3\x00\x0b\xce\xc1\xfd\x85\xa1\xa9\xf8\xd7\x6b\x87\x01\x31\x9e\xd2\x7f\x3f\xdc\x38\x6b\x26\x56\x3a\xa5\x34\xa7\x47\xca\x75\x18\xae\x81\xe2\xf5\xd9\xbe\x39\x95\x3a\xe5\x03\xa2\x4e\xdb\xd9\x15\x00\xf8\x93\xb3\x7b\x9a\x9c\x12\xb8\x5d\x3a\xad\x2c\xe3\x42\x52\x9c\x1c\x08\xcf\xec\xb5\xe5\x2e\xee\x08\x8a\xaf\xe1\x04\xc7\x76\xda\x60\x58\x5b\x4c\x5a\x97\xb9\x84\x8a\x12\x60\xcc\xab\x95\x95\x5e\x4a\xf0\x16\x83\xde\xbc\x27\x3e\x5f\xa1\xa4\xf8\xc0\xbe\x4e\x5d\x09\x4a\x03\x01\x39\xe0\x99\x09\xc0\x57\xa3\x9a\x78\x9b\x4f\x7a\x63\xa3\xd1\xcf\x40\xc6\x24\xf2\x9e\x55\xc1\x56\x30\x6d\xfc\xba\xa0\xd4\xb4\x7c\x38\x11\x9c\xd3\xc4\x4f\x74\x2d\xe3\x98\xed\x67\x3c\xc7\x19\x14\x89\x1c\xb9\x61\x6b\xb4\x11\xc7\xb5\x58\x60\x36\xb4\x4b\xea\xde\x2d\xa4\x6f\x5b\x91\x90\x1a\xe3\xfd\xe9\xc2\x4f\x01\x36\x8d\x67\x01\x3c\x3e\xc1\xc5\x4c\xa0\x2e\x5c\xd4\x3d\x7b\x8d\x58\xf2\x88\x20\xbf\x0d\x0d\x79\xa4\xd4\xa2\xb6\x78\x62\x85\xff\x86\x30\x93\x91\x40\x8d\x69\x61\x9e\x83\x6d\x26\x0f\xf5\x7b\xab\x41\x43\xfb\x56\x5c\xcc\xa4\xa8\x37\xca\x52\x40\xa1\xed\xf9\x80\xb8\xa1\x26\x47\x61\x00\x29\x4b\x4d\xff\x85\x70\x64\x11\x86\x13\xd2\x72\xf8\x43\x93\xb2\xc2\x53\x14\x4d\x4a\x69\xaa\x46\x06\xb2\xc5\xd2\x3d\x76\x24\x38\x8e\x42\x5f\xdc\xd5\x0f\x18\xc3\x97\xa4\xa5\x5b\x59\xbd\xc7\xd6\xed\xe1\xce\x23\x91\xac\xd0\x75\xea\xfd\x54\x4b\xc6\xbe\x54\xff\x54\x22\x7f\xee\x55\x71\x62\x54\xe8\x25\xd1\xc0\x9f\xbb\x09\x0e\xe9\xc4\xb2\x5c\xbc\x11\x7a\xa4\x61\x2c\xbf\x5f\xd3\x55\x8c\x74\xf4\x7d\x72\x38\x85\xc2\xac\x68\x7c\xd3\xbe\xac\x8e\x53\x30\xf8\x2e\xe0\x75\xef\x52\x52\x68\x91\x88\x7c\x30\xbf\xbd\x31\x53\xf7\x52\x1c\x71\x21\xa4\xee\x4d\xed\x8c\x4a\x31\x1c\x6d\xc6\x0d\xe4\xb0\x1a\xf2\x72\x7a\x97\x6a\xcd\x0f\x4a\x6f\x97\xf0\x54\x3b\x7e\x37\xa9\x8f\x5f\xde\x82\x6d\xd3\xcd\x3f\xb4\xaf\x0c\x17\xcb\x3b\xfd\x7e\xb4\x77\x1d\x52\x30\xe1\xb6\x16\xfa\xd8\xf5\x55\xba\x70\xde\x3c\x6a\x2f\xcc\x39\x67\x4b\x72\x30\x75\x8b\xce\xf9\x32\xfa\xfe\x1f\xf1\xbd\x4c\x21\x5c\x23\x8a\x52\x2f\x7e\xde\xab\x48\x5e\xd2\xa7\xdb\xa3\x79\x65\xf5\xd7\x22\xdc\x7c\xf9\x98\xa8\xed\x7f\x5d\x41\x33\xdf\x56\x50\xb3\x7a\x71\xab\xc5\x4b\xcd\x68\xc7\x17\x24\x91\x79\x03\xc8\x23\x6e\x5b\x73\x7c\x24\x45\xd1\x35\xb0\xdd\xee\x9b\x77\x51\xf4\xc8\x0a\x98\x7e\xbf\xf8\x46\xe9\x69\xd7\x27\xef\x01\x67\xf3\x4c\xb5\x52\x89\xc1\x70\xb5\x79\x17\x08\xc4\x3a\xd7\xa5\x42\xe9\x3c\xbf\x83\xf4\x1f\x5b\xbd\xe5\x2e\xb5\x1e\x00\x00")
+
+func templatesSiteTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesSiteTf,
+		"templates/site.tf",
+	)
+}
+
+func templatesSiteTf() (*asset, error) {
+	bytes, err := templatesSiteTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/site.tf", size: 7861, mode: os.FileMode(480), modTime: time.Unix(1792359607, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _templatesSsl_certificateTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\x41\x6e\xc3\x20\x14\x44\xf7\x9c\x62\x84\xba\xee\x0d\x72\x16\x84\xf1\xb8\xf9\x2a\x31\xd1\x87\xd0\xa2\x88\xbb\x57\xc6\x1b\xa7\x92\x37\x61\x83\x04\xf3\x46\x6f\xaa\x57\xf1\x53\x24\x6c\xce\xd1\x05\x6a\x91\x45\x82\x2f\xb4\x78\x1a\xa0\xb4\x3b\x71\x81\xcd\x45\x65\xfd\xb2\xa6\x1b\x73\x4a\xb8\x70\xf5\xb2\xbe\xc1\xdd\x55\xea\x76\x7f\xb3\x9d\xd2\xca\x9c\x1e\x1a\x08\xeb\x7f\xb2\x13\x7f\x73\x99\x5a\xa9\xaf\xca\x36\x4e\xe3\x61\xaf\x59\xfd\x6d\x2b\xe7\x22\xbf\x5b\xdb\xc7\xb3\x7a\xfd\xcc\xd7\xa4\xc5\x71\xad\x4e\xe6\x6e\x8d\x01\x8e\x2a\x53\x9a\x1b\x0e\xe1\x57\xd3\x6e\xff\xc5\xc7\xe2\xd3\xf8\xfe\x3d\xa0\xc3\x44\xec\xe7\x14\x3a\x44\x77\xbf\x28\x0b\x43\x0b\x91\x63\x14\x10\x94\x43\x95\x4b\x52\xba\x99\xb9\x68\x6a\xb8\xa0\xe8\x83\x06\xe8\xa6\x9b\xbf\x00\x00\x00\xff\xff\x4f\x95\x65\x5c\xd6\x01\x00\x00")
 
 func templatesSsl_certificateTfBytes() ([]byte, error) {
@@ -236,7 +278,7 @@ func templatesSsl_certificateTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesVpcTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xd1\x6a\xe3\x30\x10\x45\xdf\xf5\x15\x17\xb1\x0f\xc9\xb2\x6b\xb2\xaf\x81\x6c\xff\xa0\xfd\x04\xa1\x48\x53\x67\x5a\x65\x64\x24\xd9\x6d\x08\xfe\xf7\x22\xd9\x69\x21\xf4\xa1\x03\x36\x66\xe6\x0e\xf7\x8c\xef\x64\x13\xdb\x63\x20\x68\x7a\xe7\x5c\x58\x7a\x33\x0d\xce\xb0\xd7\xb8\x2a\xa0\x5c\x06\xc2\x5a\x07\xe8\x5c\x12\x4b\xaf\x15\xe0\xe9\xd9\x8e\xa1\xdc\x06\x4b\x2b\xbb\xc4\x43\xe1\x28\xb5\xf5\xd4\xbe\x6c\x08\x17\x8c\x99\x60\x05\x37\x07\x4c\x83\xd3\x6a\x56\x2a\x44\x67\x43\x6e\x46\xd5\xd4\xc5\x51\x4a\x5d\xfd\x75\x0d\x24\x7d\x39\x6d\x26\x9b\xba\x3b\xae\x2d\xfe\x63\x87\x07\xec\xb0\xc7\xbf\x59\xaf\xab\xec\x57\x90\x9f\xac\x7e\x33\xc2\x1e\x2f\x91\x65\xa3\xa1\xff\xc0\xbe\xe5\xda\xee\xea\xf3\xbb\x63\xbf\x9d\x1b\x6d\xa2\x1c\xc7\xe4\x08\x7a\x15\x68\xe8\xf6\xae\xfc\x0b\xfb\x5d\x2d\x3c\xf5\xc8\xee\xf3\xbe\x86\xec\xd8\x27\x73\x0c\xd1\xbd\xde\xab\x2b\x5b\xd3\xb2\x4f\x4d\xca\x92\x8b\x15\x47\xa6\x90\x58\x71\x97\x9b\x74\x0d\xa0\x4a\x48\x6a\x82\xc6\x4b\x36\xa7\x98\x8b\xd8\x33\x65\x1c\x50\xd2\x48\xaa\x66\x68\xfb\xe5\x1f\x03\x8f\xf6\x4c\x5f\x3e\x24\x93\x61\x3f\xff\x6d\x71\x00\xb3\x9a\xd5\x47\x00\x00\x00\xff\xff\xe1\xdc\x0f\xba\x0f\x02\x00\x00")
+var _templatesVpcTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x53\x4d\x8f\xda\x30\x14\xbc\xf3\x2b\x46\xd9\x1e\xd8\x8a\x66\xe9\xa1\x1c\x90\xb6\xab\x7e\x5c\xf6\xd2\x56\xbd\x56\x55\xf4\xb0\x1f\x89\x8b\x6b\x23\xdb\x09\x45\x2b\xfe\x7b\x9f\x13\x02\x5b\xd8\x43\x83\x92\x20\x7b\xfc\x66\xe6\xcd\x4b\x47\xc1\xd0\xca\x32\x0a\xfe\x63\x62\x32\xae\xae\xba\xad\xaa\x8c\x2e\xf0\x34\x01\xd2\x7e\xcb\x38\x5e\xf7\x28\x62\x0a\x82\x28\x64\x43\xf3\x9a\x5a\x9b\xc6\x8d\x61\x29\xaa\x60\xb6\xc9\x78\x97\x97\xbe\xf6\xff\xc8\xda\x3d\xda\xc8\x20\x87\x91\x01\xc2\x50\x4c\x0e\x93\x49\x77\x22\xd7\x2d\xd9\x2a\x26\x52\x9b\x81\xf7\xdf\xf2\x6b\xb2\x91\xaf\x19\x3e\xc4\x68\x6a\x87\xc7\x6f\xdd\x02\x9f\x1e\x3f\x7f\x8f\x48\x1e\xa9\xe1\x5c\x5f\xf8\x34\x8c\x4b\x1c\x44\x02\x62\xbb\x72\x9c\xe2\x05\xa9\xd9\x76\x8b\x4a\x19\x1d\xe2\x8b\x66\xad\xa8\xbd\xb6\xfa\xe3\xe7\xb5\x90\x67\x0a\xc4\xaf\xdf\xb1\x96\xb7\x77\x75\x34\x9a\x31\x2f\xfb\xdf\xdd\x1c\x02\xce\xea\xac\x27\x8d\x15\x59\x72\x8a\xc3\xa0\xc9\x7a\x25\x16\x7b\x11\xb9\xfb\xca\xb7\x2e\xe5\xc2\xaf\x9e\x2c\xbb\x3a\x35\x53\x11\x5d\x5e\x04\x74\x8b\xf7\x98\xe3\x41\xee\x25\xde\x1e\x8a\xe3\x51\xa3\x8f\xea\xff\xe7\xe8\x0b\x5b\x52\xec\x97\x37\x6e\x5a\xa0\x98\x81\x76\x31\x2f\x97\xf9\x7e\x5d\xca\x39\xa1\x11\x9e\x1b\x7c\xf4\xa9\x41\x76\x17\xe1\xd7\x20\x28\xef\xb4\x19\xe2\x06\x05\x06\x77\x64\x5b\x4a\xac\x67\x88\x3e\xc7\x67\xc5\xb0\xda\xe4\x7c\x08\x5b\x4b\x8a\x1b\x6f\x35\x87\xbe\xd8\x4a\xdc\x6f\xb0\x6b\xd8\x9d\xc2\x6b\x28\xc2\xf9\x73\xb2\xe5\xe8\x6e\x4c\x6c\x70\xa8\x3c\x59\x49\x82\xa7\x83\xe4\x6b\xc5\x23\xbc\xea\x39\x6e\x67\x28\x96\xcb\xbb\x77\x8b\x22\x1b\x91\xb6\x07\x8e\xbe\x0d\x4a\x46\xe1\x78\x4e\x5c\xf7\xcf\x1c\xc4\x10\xc2\xc5\x35\x34\x36\xa7\x55\x9e\x82\xea\x7b\x7f\x66\xb9\x44\xe7\x26\xf7\x58\x41\xf4\x50\xe3\x64\xd0\x25\xfb\x2a\xb1\x93\xf7\x7e\x84\x1e\xe7\x2c\x43\x64\x43\x26\xb4\xd2\x2e\x56\x8d\x8f\xc9\xd1\x6f\xe9\xf4\x3d\x52\x68\x39\x07\x40\xfd\xe0\x57\x35\x3b\x0e\xb9\xcb\xd5\x85\xd1\x33\xf1\xf9\xbb\x1a\xa2\x4b\x54\x0f\x63\x06\x7c\x91\xa2\x67\x20\xbb\x4e\xd2\x3f\xbc\xe9\x3f\x4d\xe0\x20\xed\xf9\x0b\xee\xf3\xcf\xc9\x1b\x04\x00\x00")
 
 func templatesVpcTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -251,7 +293,7 @@ func templatesVpcTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vpc.tf", size: 527, mode: os.FileMode(480), modTime: time.Unix(1524679797, 0)}
+	info := bindataFileInfo{name: "templates/vpc.tf", size: 1051, mode: os.FileMode(480), modTime: time.Unix(1792361171, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -312,9 +354,11 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/cf_dns.tf": templatesCf_dnsTf,
 	"templates/cf_lb.tf": templatesCf_lbTf,
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
+	"templates/dual_stack.tf": templatesDual_stackTf,
 	"templates/iam.tf": templatesIamTf,
 	"templates/iso_segments.tf": templatesIso_segmentsTf,
 	"templates/lb_subnet.tf": templatesLb_subnetTf,
+	"templates/site.tf": templatesSiteTf,
 	"templates/ssl_certificate.tf": templatesSsl_certificateTf,
 	"templates/vpc.tf": templatesVpcTf,
 }
@@ -364,9 +408,11 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
 		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"dual_stack.tf": &bintree{templatesDual_stackTf, map[string]*bintree{}},
 		"iam.tf": &bintree{templatesIamTf, map[string]*bintree{}},
 		"iso_segments.tf": &bintree{templatesIso_segmentsTf, map[string]*bintree{}},
 		"lb_subnet.tf": &bintree{templatesLb_subnetTf, map[string]*bintree{}},
+		"site.tf": &bintree{templatesSiteTf, map[string]*bintree{}},
 		"ssl_certificate.tf": &bintree{templatesSsl_certificateTf, map[string]*bintree{}},
 		"vpc.tf": &bintree{templatesVpcTf, map[string]*bintree{}},
 	}},
@@ -35,6 +35,17 @@ variable "bosh_inbound_cidr" {
   default = "0.0.0.0/0"
 }
 
+variable "allowed_cidrs" {
+  type        = "list"
+  default     = []
+  description = "Sources allowed to reach the jumpbox and director, overrides bosh_inbound_cidr"
+}
+
+locals {
+  # Conditionals cannot return lists, so choose between the two as strings.
+  bosh_inbound_cidrs = "${split(",", length(var.allowed_cidrs) > 0 ? join(",", var.allowed_cidrs) : var.bosh_inbound_cidr)}"
+}
+
 variable "availability_zones" {
   type = "list"
 }
@@ -52,6 +63,19 @@ variable "vpc_cidr" {
   default = "10.0.0.0/16"
 }
 
+variable "internal_cidr" {
+  type    = "string"
+  default = ""
+}
+
+variable "jumpbox_ip_offset" {
+  default = 5
+}
+
+variable "director_ip_offset" {
+  default = 6
+}
+
 resource "aws_eip" "jumpbox_eip" {
   depends_on = ["aws_internet_gateway.ig"]
   vpc        = true
@@ -232,7 +256,7 @@ resource "aws_security_group_rule" "bosh_security_group_rule_tcp_ssh" {
   protocol          = "tcp"
   from_port         = 22
   to_port           = 22
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
+  cidr_blocks       = ["${local.bosh_inbound_cidrs}"]
 }
 
 resource "aws_security_group_rule" "bosh_security_group_rule_tcp_bosh_agent" {
@@ -318,7 +342,7 @@ resource "aws_security_group_rule" "jumpbox_ssh" {
   protocol          = "tcp"
   from_port         = 22
   to_port           = 22
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
+  cidr_blocks       = ["${local.bosh_inbound_cidrs}"]
 }
 
 resource "aws_security_group_rule" "jumpbox_rdp" {
@@ -327,7 +351,7 @@ resource "aws_security_group_rule" "jumpbox_rdp" {
   protocol          = "tcp"
   from_port         = 3389
   to_port           = 3389
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
+  cidr_blocks       = ["${local.bosh_inbound_cidrs}"]
 }
 
 resource "aws_security_group_rule" "jumpbox_agent" {
@@ -336,7 +360,7 @@ resource "aws_security_group_rule" "jumpbox_agent" {
   protocol          = "tcp"
   from_port         = 6868
   to_port           = 6868
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
+  cidr_blocks       = ["${local.bosh_inbound_cidrs}"]
 }
 
 resource "aws_security_group_rule" "jumpbox_director" {
@@ -345,7 +369,7 @@ resource "aws_security_group_rule" "jumpbox_director" {
   protocol          = "tcp"
   from_port         = 25555
   to_port           = 25555
-  cidr_blocks       = ["${var.bosh_inbound_cidr}"]
+  cidr_blocks       = ["${local.bosh_inbound_cidrs}"]
 }
 
 resource "aws_security_group_rule" "jumpbox_egress" {
@@ -377,7 +401,7 @@ resource "aws_security_group_rule" "bosh_internal_security_rule_udp" {
 
 resource "aws_subnet" "bosh_subnet" {
   vpc_id     = "${local.vpc_id}"
-  cidr_block = "${cidrsubnet(var.vpc_cidr, 8, 0)}"
+  cidr_block = "${var.internal_cidr == "" ? cidrsubnet(var.vpc_cidr, 8, 0) : var.internal_cidr}"
 
   tags {
     Name = "${var.env_id}-bosh-subnet"
@@ -405,6 +429,9 @@ resource "aws_subnet" "internal_subnets" {
   cidr_block        = "${cidrsubnet(var.vpc_cidr, 4, count.index+1)}"
   availability_zone = "${element(var.availability_zones, count.index)}"
 
+  ipv6_cidr_block                 = "${var.dual_stack ? cidrsubnet(local.vpc_ipv6_cidr, 8, count.index+1) : ""}"
+  assign_ipv6_address_on_creation = "${var.dual_stack}"
+
   tags {
     Name = "${var.env_id}-internal-subnet${count.index}"
   }
@@ -438,8 +465,8 @@ locals {
   director_name        = "bosh-${var.env_id}"
   internal_cidr        = "${aws_subnet.bosh_subnet.cidr_block}"
   internal_gw          = "${cidrhost(local.internal_cidr, 1)}"
-  jumpbox_internal_ip  = "${cidrhost(local.internal_cidr, 5)}"
-  director_internal_ip = "${cidrhost(local.internal_cidr, 6)}"
+  jumpbox_internal_ip  = "${cidrhost(local.internal_cidr, var.jumpbox_ip_offset)}"
+  director_internal_ip = "${cidrhost(local.internal_cidr, var.director_ip_offset)}"
 }
 
 resource "aws_kms_key" "kms_key" {
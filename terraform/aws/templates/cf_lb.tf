@@ -4,10 +4,11 @@ resource "aws_security_group" "cf_ssh_lb_security_group" {
   vpc_id      = "${local.vpc_id}"
 
   ingress {
-    cidr_blocks = ["0.0.0.0/0"]
-    protocol    = "tcp"
-    from_port   = 2222
-    to_port     = 2222
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = ["${var.ipv6_cidrs}"]
+    protocol         = "tcp"
+    from_port        = 2222
+    to_port          = 2222
   }
 
   egress {
@@ -99,24 +100,27 @@ resource "aws_security_group" "cf_router_lb_security_group" {
   vpc_id      = "${local.vpc_id}"
 
   ingress {
-    cidr_blocks = ["0.0.0.0/0"]
-    protocol    = "tcp"
-    from_port   = 80
-    to_port     = 80
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = ["${var.ipv6_cidrs}"]
+    protocol         = "tcp"
+    from_port        = 80
+    to_port          = 80
   }
 
   ingress {
-    cidr_blocks = ["0.0.0.0/0"]
-    protocol    = "tcp"
-    from_port   = 443
-    to_port     = 443
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = ["${var.ipv6_cidrs}"]
+    protocol         = "tcp"
+    from_port        = 443
+    to_port          = 443
   }
 
   ingress {
-    cidr_blocks = ["0.0.0.0/0"]
-    protocol    = "tcp"
-    from_port   = 4443
-    to_port     = 4443
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = ["${var.ipv6_cidrs}"]
+    protocol         = "tcp"
+    from_port        = 4443
+    to_port          = 4443
   }
 
   egress {
@@ -224,10 +228,11 @@ resource "aws_security_group" "cf_tcp_lb_security_group" {
   vpc_id      = "${local.vpc_id}"
 
   ingress {
-    cidr_blocks = ["0.0.0.0/0"]
-    protocol    = "tcp"
-    from_port   = 1024
-    to_port     = 1123
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = ["${var.ipv6_cidrs}"]
+    protocol         = "tcp"
+    from_port        = 1024
+    to_port          = 1123
   }
 
   egress {
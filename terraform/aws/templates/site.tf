@@ -0,0 +1,239 @@
+variable "SITE_NAME_site_region" {
+  type = "string"
+}
+
+variable "SITE_NAME_site_vpc_cidr" {
+  type = "string"
+}
+
+variable "SITE_NAME_site_availability_zones" {
+  type = "list"
+}
+
+provider "aws" {
+  alias      = "SITE_NAME"
+  access_key = "${var.access_key}"
+  secret_key = "${var.secret_key}"
+  region     = "${var.SITE_NAME_site_region}"
+
+  version = ">= 1.17.0"
+}
+
+resource "aws_vpc" "SITE_NAME_site_vpc" {
+  provider             = "aws.SITE_NAME"
+  cidr_block           = "${var.SITE_NAME_site_vpc_cidr}"
+  instance_tenancy     = "default"
+  enable_dns_hostnames = true
+
+  tags {
+    Name = "${var.env_id}-SITE_NAME-vpc"
+  }
+}
+
+resource "aws_internet_gateway" "SITE_NAME_site_ig" {
+  provider = "aws.SITE_NAME"
+  vpc_id   = "${aws_vpc.SITE_NAME_site_vpc.id}"
+}
+
+resource "aws_key_pair" "SITE_NAME_site_bosh_vms" {
+  provider   = "aws.SITE_NAME"
+  key_name   = "${var.env_id}_SITE_NAME_bosh_vms"
+  public_key = "${tls_private_key.bosh_vms.public_key_openssh}"
+}
+
+resource "aws_subnet" "SITE_NAME_site_nat_subnet" {
+  provider   = "aws.SITE_NAME"
+  vpc_id     = "${aws_vpc.SITE_NAME_site_vpc.id}"
+  cidr_block = "${cidrsubnet(var.SITE_NAME_site_vpc_cidr, 8, 0)}"
+
+  tags {
+    Name = "${var.env_id}-SITE_NAME-nat-subnet"
+  }
+}
+
+resource "aws_route_table" "SITE_NAME_site_nat_route_table" {
+  provider = "aws.SITE_NAME"
+  vpc_id   = "${aws_vpc.SITE_NAME_site_vpc.id}"
+}
+
+resource "aws_route" "SITE_NAME_site_nat_route_table" {
+  provider               = "aws.SITE_NAME"
+  destination_cidr_block = "0.0.0.0/0"
+  gateway_id             = "${aws_internet_gateway.SITE_NAME_site_ig.id}"
+  route_table_id         = "${aws_route_table.SITE_NAME_site_nat_route_table.id}"
+}
+
+resource "aws_route_table_association" "SITE_NAME_site_route_nat_subnet" {
+  provider       = "aws.SITE_NAME"
+  subnet_id      = "${aws_subnet.SITE_NAME_site_nat_subnet.id}"
+  route_table_id = "${aws_route_table.SITE_NAME_site_nat_route_table.id}"
+}
+
+resource "aws_eip" "SITE_NAME_site_nat_eip" {
+  provider   = "aws.SITE_NAME"
+  depends_on = ["aws_internet_gateway.SITE_NAME_site_ig"]
+  vpc        = true
+}
+
+resource "aws_nat_gateway" "SITE_NAME_site_nat" {
+  provider      = "aws.SITE_NAME"
+  allocation_id = "${aws_eip.SITE_NAME_site_nat_eip.id}"
+  subnet_id     = "${aws_subnet.SITE_NAME_site_nat_subnet.id}"
+}
+
+resource "aws_subnet" "SITE_NAME_site_internal_subnets" {
+  provider          = "aws.SITE_NAME"
+  count             = "${length(var.SITE_NAME_site_availability_zones)}"
+  vpc_id            = "${aws_vpc.SITE_NAME_site_vpc.id}"
+  cidr_block        = "${cidrsubnet(var.SITE_NAME_site_vpc_cidr, 4, count.index+1)}"
+  availability_zone = "${element(var.SITE_NAME_site_availability_zones, count.index)}"
+
+  tags {
+    Name = "${var.env_id}-SITE_NAME-internal-subnet${count.index}"
+  }
+
+  lifecycle {
+    ignore_changes = ["cidr_block", "availability_zone"]
+  }
+}
+
+resource "aws_route_table" "SITE_NAME_site_internal_route_table" {
+  provider = "aws.SITE_NAME"
+  vpc_id   = "${aws_vpc.SITE_NAME_site_vpc.id}"
+}
+
+resource "aws_route" "SITE_NAME_site_internal_route_table" {
+  provider               = "aws.SITE_NAME"
+  destination_cidr_block = "0.0.0.0/0"
+  nat_gateway_id         = "${aws_nat_gateway.SITE_NAME_site_nat.id}"
+  route_table_id         = "${aws_route_table.SITE_NAME_site_internal_route_table.id}"
+}
+
+resource "aws_route_table_association" "SITE_NAME_site_route_internal_subnets" {
+  provider       = "aws.SITE_NAME"
+  count          = "${length(var.SITE_NAME_site_availability_zones)}"
+  subnet_id      = "${element(aws_subnet.SITE_NAME_site_internal_subnets.*.id, count.index)}"
+  route_table_id = "${aws_route_table.SITE_NAME_site_internal_route_table.id}"
+}
+
+resource "aws_vpc_peering_connection" "SITE_NAME_site_peering" {
+  vpc_id      = "${local.vpc_id}"
+  peer_vpc_id = "${aws_vpc.SITE_NAME_site_vpc.id}"
+  peer_region = "${var.SITE_NAME_site_region}"
+
+  tags {
+    Name = "${var.env_id}-SITE_NAME-peering"
+  }
+}
+
+resource "aws_vpc_peering_connection_accepter" "SITE_NAME_site_peering" {
+  provider                  = "aws.SITE_NAME"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.SITE_NAME_site_peering.id}"
+  auto_accept               = true
+}
+
+resource "aws_route" "SITE_NAME_site_to_bosh" {
+  provider                  = "aws.SITE_NAME"
+  destination_cidr_block    = "${var.vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.SITE_NAME_site_peering.id}"
+  route_table_id            = "${aws_route_table.SITE_NAME_site_internal_route_table.id}"
+}
+
+resource "aws_route" "bosh_to_SITE_NAME_site" {
+  destination_cidr_block    = "${var.SITE_NAME_site_vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.SITE_NAME_site_peering.id}"
+  route_table_id            = "${aws_route_table.bosh_route_table.id}"
+}
+
+resource "aws_route" "internal_to_SITE_NAME_site" {
+  destination_cidr_block    = "${var.SITE_NAME_site_vpc_cidr}"
+  vpc_peering_connection_id = "${aws_vpc_peering_connection.SITE_NAME_site_peering.id}"
+  route_table_id            = "${aws_route_table.internal_route_table.id}"
+}
+
+resource "aws_security_group" "SITE_NAME_site_internal_security_group" {
+  provider    = "aws.SITE_NAME"
+  name        = "${var.env_id}-SITE_NAME-internal-security-group"
+  description = "Internal"
+  vpc_id      = "${aws_vpc.SITE_NAME_site_vpc.id}"
+
+  tags {
+    Name = "${var.env_id}-SITE_NAME-internal-security-group"
+  }
+
+  lifecycle {
+    ignore_changes = ["name"]
+  }
+}
+
+resource "aws_security_group_rule" "SITE_NAME_site_internal_security_group_rule_self" {
+  provider          = "aws.SITE_NAME"
+  security_group_id = "${aws_security_group.SITE_NAME_site_internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  self              = true
+}
+
+resource "aws_security_group_rule" "SITE_NAME_site_internal_security_group_rule_bosh" {
+  provider          = "aws.SITE_NAME"
+  security_group_id = "${aws_security_group.SITE_NAME_site_internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.vpc_cidr}"]
+}
+
+resource "aws_security_group_rule" "SITE_NAME_site_internal_security_group_rule_allow_internet" {
+  provider          = "aws.SITE_NAME"
+  security_group_id = "${aws_security_group.SITE_NAME_site_internal_security_group.id}"
+  type              = "egress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "bosh_security_group_rule_SITE_NAME_site" {
+  security_group_id = "${aws_security_group.bosh_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.SITE_NAME_site_vpc_cidr}"]
+}
+
+resource "aws_security_group_rule" "internal_security_group_rule_SITE_NAME_site" {
+  security_group_id = "${aws_security_group.internal_security_group.id}"
+  type              = "ingress"
+  protocol          = "-1"
+  from_port         = 0
+  to_port           = 0
+  cidr_blocks       = ["${var.SITE_NAME_site_vpc_cidr}"]
+}
+
+output "SITE_NAME_site_region" {
+  value = "${var.SITE_NAME_site_region}"
+}
+
+output "SITE_NAME_site_default_key_name" {
+  value = "${aws_key_pair.SITE_NAME_site_bosh_vms.key_name}"
+}
+
+output "SITE_NAME_site_internal_security_group" {
+  value = "${aws_security_group.SITE_NAME_site_internal_security_group.id}"
+}
+
+output "SITE_NAME_site_az_subnet_id_mapping" {
+  value = "${
+	  zipmap("${aws_subnet.SITE_NAME_site_internal_subnets.*.availability_zone}", "${aws_subnet.SITE_NAME_site_internal_subnets.*.id}")
+	}"
+}
+
+output "SITE_NAME_site_az_subnet_cidr_mapping" {
+  value = "${
+	  zipmap("${aws_subnet.SITE_NAME_site_internal_subnets.*.availability_zone}", "${aws_subnet.SITE_NAME_site_internal_subnets.*.cidr_block}")
+	}"
+}
@@ -4,9 +4,24 @@ variable "existing_vpc_id" {
   description = "Optionally use an existing vpc"
 }
 
+variable "dual_stack" {
+  default     = false
+  description = "Assign IPv6 CIDRs to the vpc and internal subnets"
+}
+
+variable "ipv6_cidrs" {
+  type        = "list"
+  default     = []
+  description = "IPv6 CIDRs allowed alongside 0.0.0.0/0 on the load balancers"
+}
+
 locals {
   vpc_count = "${length(var.existing_vpc_id) > 0 ? 0 : 1}"
   vpc_id    = "${length(var.existing_vpc_id) > 0 ? var.existing_vpc_id : join(" ", aws_vpc.vpc.*.id)}"
+
+  # Both sides of a conditional are evaluated, so fall back to a placeholder
+  # block when the vpc has no IPv6 CIDR.
+  vpc_ipv6_cidr = "${coalesce(join("", aws_vpc.vpc.*.ipv6_cidr_block), "::/56")}"
 }
 
 resource "aws_vpc" "vpc" {
@@ -15,6 +30,8 @@ resource "aws_vpc" "vpc" {
   instance_tenancy     = "default"
   enable_dns_hostnames = true
 
+  assign_generated_ipv6_cidr_block = "${var.dual_stack}"
+
   tags {
     Name = "${var.env_id}-vpc"
   }
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type InputGenerator struct {
@@ -24,6 +25,8 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 		"region":        state.Azure.Region,
 	}
 
+	terraform.AddNetworkInputs(input, state.Network, "network_cidr")
+
 	if state.LB.Cert != "" && state.LB.Key != "" {
 		input["pfx_cert_base64"] = state.LB.Cert
 		input["pfx_password"] = state.LB.Key
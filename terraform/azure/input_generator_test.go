@@ -42,6 +42,37 @@ var _ = Describe("InputGenerator", func() {
 			}))
 		})
 
+		Context("given a network layout", func() {
+			It("passes the cidrs and ip offsets to terraform", func() {
+				state.Network = storage.Network{
+					NetworkCIDR:      "172.16.0.0/16",
+					InternalCIDR:     "172.16.4.0/24",
+					DirectorIPOffset: 20,
+				}
+				inputs, err := inputGenerator.Generate(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs).To(Equal(map[string]interface{}{
+					"simple_env_id":      "envid",
+					"env_id":             state.EnvID,
+					"region":             state.Azure.Region,
+					"network_cidr":       "172.16.0.0/16",
+					"internal_cidr":      "172.16.4.0/24",
+					"director_ip_offset": 20,
+				}))
+			})
+		})
+
+		Context("given allowed cidrs", func() {
+			It("restricts the jumpbox and director rules to them", func() {
+				state.Network.AllowedCIDRs = []string{"203.0.113.0/24"}
+				inputs, err := inputGenerator.Generate(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs["allowed_cidrs"]).To(Equal([]string{"203.0.113.0/24"}))
+			})
+		})
+
 		Context("given a long environment id", func() {
 			It("shortens the id for simple_env_id", func() {
 				state.EnvID = "super-long-environment-id-with-999"
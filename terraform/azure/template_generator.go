@@ -12,6 +12,8 @@ type templates struct {
 	network              string
 	storage              string
 	networkSecurityGroup string
+	securityRules        string
+	allowedCIDRRules     string
 	output               string
 	tls                  string
 	cfLB                 string
@@ -28,7 +30,12 @@ func NewTemplateGenerator() TemplateGenerator {
 func (t TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
 
-	template := strings.Join([]string{tmpls.vars, tmpls.resourceGroup, tmpls.network, tmpls.storage, tmpls.networkSecurityGroup, tmpls.output, tmpls.tls}, "\n")
+	securityRules := tmpls.securityRules
+	if len(state.Network.AllowedCIDRs) > 0 {
+		securityRules = tmpls.allowedCIDRRules
+	}
+
+	template := strings.Join([]string{tmpls.vars, tmpls.resourceGroup, tmpls.network, tmpls.storage, tmpls.networkSecurityGroup, securityRules, tmpls.output, tmpls.tls}, "\n")
 
 	switch state.LB.Type {
 	case "cf":
@@ -51,6 +58,8 @@ func readTemplates() templates {
 	tmpls.network = string(MustAsset("templates/network.tf"))
 	tmpls.storage = string(MustAsset("templates/storage.tf"))
 	tmpls.networkSecurityGroup = string(MustAsset("templates/network_security_group.tf"))
+	tmpls.securityRules = string(MustAsset("templates/network_security_rules.tf"))
+	tmpls.allowedCIDRRules = string(MustAsset("templates/network_security_rules_allowed_cidrs.tf"))
 	tmpls.output = string(MustAsset("templates/output.tf"))
 	tmpls.tls = string(MustAsset("templates/tls.tf"))
 	tmpls.cfLB = string(MustAsset("templates/cf_lb.tf"))
@@ -26,7 +26,7 @@ var _ = Describe("TemplateGenerator", func() {
 	Describe("Generate", func() {
 		Context("when no lb type is provided", func() {
 			BeforeEach(func() {
-				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "output", "tls")
+				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "network_security_rules", "output", "tls")
 			})
 			It("uses the base template", func() {
 				template := templateGenerator.Generate(storage.State{})
@@ -34,9 +34,22 @@ var _ = Describe("TemplateGenerator", func() {
 			})
 		})
 
+		Context("when allowed cidrs are provided", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "network_security_rules_allowed_cidrs", "output", "tls")
+			})
+
+			It("restricts the jumpbox and director rules to the allowed cidrs", func() {
+				template := templateGenerator.Generate(storage.State{
+					Network: storage.Network{AllowedCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+				})
+				checkTemplate(template, expectedTemplate)
+			})
+		})
+
 		Context("when a CF lb type is provided with no system domain", func() {
 			BeforeEach(func() {
-				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "output", "tls", "cf_lb")
+				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "network_security_rules", "output", "tls", "cf_lb")
 				lb = storage.LB{
 					Type: "cf",
 				}
@@ -49,7 +62,7 @@ var _ = Describe("TemplateGenerator", func() {
 
 		Context("when a concourse lb type is provided", func() {
 			BeforeEach(func() {
-				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "output", "tls", "concourse_lb")
+				expectedTemplate = expectTemplate("vars", "resource_group", "network", "storage", "network_security_group", "network_security_rules", "output", "tls", "concourse_lb")
 				lb = storage.LB{
 					Type: "concourse",
 				}
@@ -5,6 +5,8 @@
 // templates/concourse_lb.tf
 // templates/network.tf
 // templates/network_security_group.tf
+// templates/network_security_rules.tf
+// templates/network_security_rules_allowed_cidrs.tf
 // templates/output.tf
 // templates/resource_group.tf
 // templates/storage.tf
@@ -137,7 +139,7 @@ func templatesConcourse_lbTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesNetworkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xcf\x4a\xc4\x30\x10\xc6\xef\x79\x8a\x61\xf0\xa0\xb0\x5b\x3c\x7a\xf1\x49\x44\x42\x36\x19\xd7\xe0\x36\x29\x93\x3f\x8a\x25\xef\x2e\xa9\xad\xd8\xd8\xe2\xe6\xfc\x9b\x99\xef\xf7\x85\x29\xf8\xc4\x9a\x00\xd5\x67\x62\xe2\x5e\x66\xcb\x31\xa9\x8b\x74\x14\xdf\x3d\xbf\x21\xe0\xc9\x87\x57\x84\x51\x00\x38\xd5\x13\x34\xef\x11\xf0\x66\xcc\x8a\x3b\x72\x59\x5a\x53\x8e\x15\x3f\x66\x87\x02\x40\x19\xc3\x14\x82\x0c\x83\xd2\xf4\xc3\x3f\xcd\x03\xf3\x05\xa9\xad\xe1\x82\xcf\x02\xe0\xe2\xb5\x8a\xd6\xbb\xcd\xfd\x4c\x67\xeb\x5d\xa9\x7b\x97\xd4\xf2\xcc\x3e\x0d\x72\x8a\x35\x71\x8b\xc4\x1a\xe8\x6a\xa4\xae\x52\x05\x45\x11\xe2\xaf\x74\x48\x27\x47\xf1\x5f\xd7\x1d\xd9\xb0\x92\x1d\x98\x5e\xec\xc7\xef\x81\x2a\xf8\x7d\xe1\xb6\xf5\x3e\xc0\xc3\x01\xee\xef\x76\xad\xae\xd6\x02\x68\x3e\x6e\xa3\x95\x86\x68\x6a\xf9\x0a\x00\x00\xff\xff\xdb\x9a\xf5\x1a\x0b\x02\x00\x00")
+var _templatesNetworkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x91\x51\x0e\x82\x30\x0c\x86\xdf\x39\x45\xb3\xf8\x0a\x37\xf0\x24\xc6\x2c\x83\x55\x5c\x84\x8d\x74\x6c\x1a\xc9\xee\xee\x86\x68\x04\x21\xba\xd7\x7d\x6d\xff\xaf\x25\xb4\xc6\x51\x85\xc0\xc4\xdd\x11\x52\xcb\xbd\xa2\xde\x89\x86\x6b\xec\xaf\x86\x2e\x0c\x58\x69\xec\x99\xc1\x90\x01\x68\xd1\x22\x2c\xde\x1e\xd8\x6e\xf0\x82\x0a\xd4\x9e\x2b\x19\xf2\x84\xe7\x5e\xb3\xc8\x0b\x29\x09\xad\xe5\xb6\x13\x15\xbe\xf9\xc3\x54\x30\x4d\xe0\x95\x92\x14\xd8\x31\xf2\x8d\xa9\x44\xaf\x8c\x5e\xed\x4f\x58\xc7\xaf\x90\xfa\xd2\x94\x9a\xd7\x64\x5c\xc7\xc7\x58\x23\xf7\x92\x98\x03\x45\x8a\x54\x24\x2a\x56\x87\x2c\xa3\x2f\x69\xeb\xca\x98\xe6\xa7\xeb\x86\xac\x9d\xc9\x76\x84\x27\x75\xfb\x2c\x48\x5a\x4d\xf1\x9c\x31\xd9\x6e\x48\xfc\x6d\x01\xb0\xb8\xd3\xca\x12\x16\xc4\x62\x0b\x0f\xeb\x7a\xe3\x74\xfa\x01\x00\x00")
 
 func templatesNetworkTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -152,12 +154,12 @@ func templatesNetworkTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/network.tf", size: 523, mode: os.FileMode(480), modTime: time.Unix(1519854618, 0)}
+	info := bindataFileInfo{name: "templates/network.tf", size: 506, mode: os.FileMode(480), modTime: time.Unix(1792360850, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesNetwork_security_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x94\xcf\x8e\x9b\x30\x10\x87\xef\x7e\x8a\x91\xd5\xd3\x4a\x1b\x6d\xd9\xb0\xe2\x92\x43\x8f\xbd\xf7\x8e\x1c\x7b\x4a\xac\x12\x0f\x1a\x9b\xa4\x6d\xc4\xbb\x57\x86\x92\x86\x84\x44\x14\x55\xaa\xb2\x0a\xe7\x6f\x7e\xf3\xc7\x9f\x60\xf4\x54\xb3\x46\x90\xea\x67\xcd\xc8\xdb\xdc\x61\xd8\x13\x7f\xcb\x3d\xea\x9a\x6d\xf8\x91\x17\x4c\x75\x25\x41\xae\xc9\x6f\x24\x1c\x04\x80\x53\x5b\x84\xb3\x6f\x05\xf2\xc3\x61\xa7\x78\x81\x6e\x97\x5b\xd3\x3c\xb7\xb8\x00\x28\x49\xab\x60\xc9\x8d\xc2\x8c\x85\x25\xd7\x44\xae\x9f\xa4\xeb\x97\xb7\x3d\x5a\xae\x1f\x6c\x08\x2c\x62\xfe\x22\x52\x8d\x14\x02\x20\xa8\xc2\xb7\xc3\x01\xa0\xdb\x59\x26\xb7\x45\x17\x2e\xc6\x8a\x9d\x1a\xd1\x08\x31\x61\x71\xae\x4b\x94\x20\xfd\xad\xb5\xaf\xae\xef\xbb\xed\x2b\xb6\x14\xc3\xc6\x6b\x92\x97\x17\x01\x60\x2c\xa3\x3e\x3f\xd1\x9f\xdc\xcf\x6e\x4d\xb5\x33\x31\x4d\x69\x8d\xde\x5f\x9d\xe0\x53\x59\xd2\xbe\xeb\x4a\x81\x34\x95\x57\xb8\x2f\xba\x8a\xd4\xef\x73\x56\xc4\x21\x67\xe5\x0a\x1c\x52\x4f\x91\x31\xe8\x83\x75\xed\x03\x5e\x80\x2b\x90\x49\x72\x12\xa4\x8c\x61\xf4\x3e\xaf\x18\xbf\xda\xef\x37\x82\xce\xc1\x9e\x19\x53\x60\x70\xe0\x09\x2a\x00\x8c\x0b\x3c\x22\xd4\x38\x38\x48\xfb\x2b\x51\x62\xe1\xb3\x2a\xd0\x85\x19\xbe\x9c\x14\x4f\xd0\xe6\xe3\x7d\x6b\xf3\x96\xbd\x65\x0f\x71\x86\xe2\x74\xcf\x49\x3c\xd7\x9d\x63\xfd\x04\x7d\x92\xfb\xd6\x27\x49\xd3\x34\x7d\xf8\x73\xf4\xc7\x38\x3f\xc3\x9a\x58\x35\xc1\x95\xd7\xff\xe1\xca\xd3\x3f\x32\x25\x7d\x7d\x68\x72\xd4\x44\x33\x9a\x4d\xbd\x9e\xa1\x4a\x5f\x39\x41\x97\xe5\x7d\xff\x5a\xb2\x6c\xb9\x7c\xef\xca\xfc\x0a\x00\x00\xff\xff\xea\xda\x59\x3f\xf4\x0b\x00\x00")
+var _templatesNetwork_security_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x92\x4d\x4e\xc3\x30\x10\x85\xf7\x39\x85\x15\xb1\x42\x22\xaa\xa8\x58\xb2\x60\xc9\x29\x2c\xd7\x1e\x82\x45\x32\x13\x8d\xed\x16\xa8\x72\x77\xc6\x69\x2b\x48\xea\x00\xde\xfa\x7b\x6f\xde\xfc\x30\x04\x4a\x6c\x41\xd5\xe6\x33\x31\x70\xaf\x11\xe2\x81\xf8\x4d\x07\xb0\x89\x7d\xfc\xd0\x2d\x53\x1a\x6a\x55\xef\x28\xbc\xd6\xea\x58\x29\x85\xa6\x07\xb5\x78\x8f\xaa\xbe\x39\xee\x0d\x37\x80\x7b\xed\xdd\x78\x37\xe1\x02\x77\x64\x4d\xf4\x84\x45\x98\xa1\x95\xaf\x31\x73\x7c\x4e\x72\xaa\xa7\xa7\x1a\x13\x77\x09\x36\x07\x9a\xec\xdf\x64\x4a\xd4\x22\x8f\xa6\x0d\x53\x38\xa5\x24\x81\x67\xc2\x1e\x30\x5e\xc5\xca\x95\xc6\x6a\xac\x2a\xfe\xbb\x71\x4e\x1d\x48\xdf\x0e\xc3\x7a\xdb\xab\xed\x67\x95\x68\x06\xf6\x94\xcd\xca\x9a\xfb\xcd\x56\x18\xe7\x19\xec\x72\x44\xdf\xbe\xcf\xb8\xa3\x84\x2e\xbb\x19\x6b\x21\x84\xd5\x04\x4f\x5d\x47\x87\x53\x55\x8a\x64\xa9\x5b\xe1\x6e\x33\x73\x1e\xe6\x40\x1c\x35\x1b\x6c\xa1\xc0\x38\x08\xd1\xe3\xb4\xbe\x2b\x50\x98\x87\xed\x0f\x23\xe3\x9c\xcc\x34\xe8\x81\xe1\xc5\xbf\xff\x62\xb4\x04\x2f\x4c\xe9\x00\x66\xe3\xfd\xc7\x21\xc8\x92\x8a\xe7\x5b\x38\xa7\x32\x38\x73\x1b\xab\x2f\x3d\x38\x15\xc6\x1f\x03\x00\x00")
 
 func templatesNetwork_security_groupTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -172,12 +174,52 @@ func templatesNetwork_security_groupTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/network_security_group.tf", size: 3060, mode: os.FileMode(480), modTime: time.Unix(1519854618, 0)}
+	info := bindataFileInfo{name: "templates/network_security_group.tf", size: 799, mode: os.FileMode(480), modTime: time.Unix(1792370640, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesOutputTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\xd1\x6e\xdb\x30\x0c\x45\xdf\xfd\x15\x82\xb1\x87\x15\xc8\xdc\x2d\x80\x87\x21\xc0\xbe\x85\x90\x6d\x36\xd6\x2a\x4b\x02\x45\xa9\xed\x8a\xfc\xfb\xe0\x3a\x2e\x2c\xd7\x4a\xd6\xe8\x51\xba\x3c\xf7\x82\x14\x6d\x60\x17\x58\x94\xd1\x20\x83\x91\x03\x96\xe2\xb5\x10\x22\x4a\x1d\x50\xfc\x16\xe5\x97\x57\xf9\x37\x10\xd2\x00\x51\x11\x07\xa9\xc1\x20\x3f\x59\x7a\xac\x1a\xeb\xfb\x6a\xac\x38\x95\xc5\xa9\x28\x66\x90\x0f\xcd\x55\xd4\xa4\xc9\x11\x08\xbd\x0d\xd4\x22\x1c\xc9\x06\x77\x99\x94\x6a\xb3\x99\xd8\x92\x3c\x22\xc8\xb6\xb5\xc1\x5c\x0b\x97\x8a\x73\xcc\x0e\x1f\x64\xd0\x0c\x1e\xdb\x40\x8a\x5f\xa6\x04\x59\xea\xb9\x6b\x2b\x79\x0e\x8e\xcf\x8c\x64\xa4\x06\x95\x27\xba\xd0\x68\xd5\x82\x3a\x43\x94\x03\xd9\x75\x84\xde\xaf\x72\x2a\xc2\x96\x2d\xcd\xaf\x2b\x5e\xcf\xec\xfc\xe1\xfe\xfe\x7f\xb8\x87\x7d\x5d\xd7\x75\x42\x77\xa4\xa2\x64\x84\x47\x7c\x59\x82\xc7\xf3\x16\x96\xb5\x87\x85\xe6\x0d\x09\x71\xf0\xd5\xe2\x12\x1c\x0e\xa7\xb2\x10\xc2\xa3\xf1\x8a\x55\x1c\x83\x31\x05\x4c\x8c\xa6\x54\x9f\xf7\x79\xaf\x03\xeb\xd0\x78\xdf\x7f\xb0\x7a\x90\xda\x27\x5e\x7f\xc2\xe0\x1a\xfb\x0c\x81\xf4\x0d\xdd\x3f\xec\xf7\x49\x8b\xe6\xc9\xb7\xaa\xa3\x0f\xb8\x28\xa9\x5a\x0a\x32\xb3\xdb\xf8\xb0\xa3\xed\xb7\x09\x80\x26\x82\xea\xd2\x52\x65\xce\x3f\x28\x6b\x9b\x28\x36\x77\x78\xb3\x74\xbc\x9c\xde\xbf\xae\xc3\xef\xc4\xaf\x9d\xf8\x7e\x97\x09\x72\x7c\xda\x64\xf5\xd6\x4f\xa4\x24\xcf\x4e\xfc\x58\x71\xe6\xa1\xc0\xbb\x6e\x63\x37\x2e\x01\xeb\xbb\x4c\x73\x6f\x26\xfe\x9c\x88\xff\x02\x00\x00\xff\xff\xd1\x1c\x1f\xd0\x43\x05\x00\x00")
+var _templatesNetwork_security_rulesTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x92\xb1\x4e\xc3\x30\x10\x86\xf7\x3c\x85\x15\x31\x21\x35\x2a\x51\xa9\xb2\x30\x30\xb2\xb3\x5b\x8e\x7d\x34\x16\xa9\x2f\x3a\xdb\x2d\x50\xf5\xdd\x89\x29\xa9\x12\x9a\xa0\x28\x5b\xaa\x7a\xfe\xfe\xdf\xa7\xbb\x8f\xc0\xa2\x27\x09\x2c\x16\x5f\x9e\x80\xb6\xdc\x80\xdb\x23\xbd\x73\x0b\xd2\x93\x76\x9f\x9c\x7c\x09\x31\x8b\xad\x2d\x62\x76\x88\x18\x33\x62\x0b\x6c\xe0\x3d\xb1\xf8\xee\xb0\x13\x94\x80\xd9\x71\xad\x8e\x8b\x90\xaa\x33\x15\x69\x0c\x65\xfd\x99\x74\xb9\xac\x19\xa5\x09\xa4\xd3\x68\xfa\x7b\x5f\x4c\x8e\xde\xa8\xd0\x26\xa4\x04\x6b\x07\x27\x78\x2e\x4b\xdc\x9f\x7e\x45\x87\x12\xcb\x01\xee\x55\x56\x81\x3a\x2d\x80\x57\x48\x8e\x93\x30\x1b\xe8\x52\xf7\x81\x51\x60\x9d\x36\x22\x4c\x77\x01\xd6\x4c\x9a\xb6\x8a\x84\x52\x54\x8f\xc7\x2b\x82\x37\xfd\xf1\x4f\xd1\x5f\xb0\x61\xe8\xf7\x26\x7c\x43\xe8\x2b\xde\xd9\xf7\xcf\x82\x9b\x53\x75\xc1\x24\x47\x5b\x24\x81\x3e\x86\x96\x8b\x3b\xb6\xda\x3a\x2d\xfd\x60\xa7\xed\x18\x45\x34\x5a\x94\x10\x5c\x88\x0d\x18\x37\xc1\x97\x56\x78\x84\x36\x0f\xf3\xd6\x66\x9d\xad\xb3\x9b\x38\x5d\x71\x4e\xe7\x44\x9a\xea\xce\x39\x3f\x42\x9f\x74\xde\xfa\xa4\x8f\xf5\xbb\xf9\x73\xf6\x47\x12\xa8\xc2\xe7\x13\xcc\x69\x92\x23\x9c\x59\xcd\xdb\x99\x2c\x5b\xad\xae\x5d\x99\x6f\x03\xd0\xce\xeb\xd4\x08\x00\x00")
+
+func templatesNetwork_security_rulesTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNetwork_security_rulesTf,
+		"templates/network_security_rules.tf",
+	)
+}
+
+func templatesNetwork_security_rulesTf() (*asset, error) {
+	bytes, err := templatesNetwork_security_rulesTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/network_security_rules.tf", size: 2260, mode: os.FileMode(480), modTime: time.Unix(1792370640, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNetwork_security_rules_allowed_cidrsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x92\xb1\x4e\xc3\x30\x10\x86\xf7\x3c\x85\x15\x31\x21\xb5\x2a\x51\xa9\xb2\x30\x30\xb2\xb3\x21\x64\xb9\xf6\x91\x58\xa4\xbe\xe8\x6c\xb7\x40\x95\x77\x27\x26\x2d\x24\x34\x41\x51\xb6\x8a\x7a\xfe\xfe\xdf\xa7\xbb\x8f\xc0\xa2\x27\x09\x2c\x16\x1f\x9e\x80\x36\xdc\x80\xdb\x21\xbd\x72\x0b\xd2\x93\x76\xef\x9c\x7c\x01\x31\x8b\xad\xcd\x63\xb6\x8f\x18\x33\x62\x03\x6c\xe0\xdd\xb1\xf8\x6a\xbf\x15\x34\x07\xb3\xe5\x5a\x55\xb3\x90\xaa\x33\x25\x69\x0c\x65\xfd\x99\x64\xb1\xa8\x19\xa5\x09\xa4\xd3\x68\xfa\x7b\x1f\xcc\x1a\xbd\x51\xa1\x4d\x48\x09\xd6\x0e\x4e\x70\x5f\x14\xb8\x6b\x7e\x45\x87\x12\x8b\x01\xee\x51\x96\x81\x6a\x16\xc0\x4b\x24\xc7\x49\x98\x0c\xba\xd4\x75\x60\x14\x58\xa7\x8d\x08\xd3\x9d\x80\x35\x93\x24\xad\x22\xa1\x14\xd5\xe3\xf1\x92\xe0\x45\xbf\x81\x3d\x40\x4f\x87\xcd\x88\x30\x1d\x28\x2e\xb5\x22\x5b\xc5\xcf\xbf\xda\xbb\xe9\x9f\x09\xe8\x70\x28\x9e\x11\xfa\x92\x77\x8e\xf0\xb5\xf5\xe3\xfd\xba\xe0\x7c\x8d\x36\x9f\x07\xba\x0a\x2d\x27\xc7\x6d\xb5\x75\x5a\xfa\xc1\x4e\x5b\x15\x45\x34\xda\x9e\x10\x9c\x89\x0c\x8c\x9b\x20\x51\x2b\x3c\xc2\xa5\x9b\xf3\x76\x69\x95\xae\xd2\x8b\x4d\x23\x6c\x6a\x6e\x8c\x34\x55\xa8\xef\xfc\x08\xa7\x92\xf3\x76\x2a\xb9\xad\xdf\x45\xaa\xbf\xa5\x92\x04\x2a\xf7\xeb\x09\x3a\x1d\x93\x23\x44\x5a\x9e\xb7\x48\x69\xba\x5c\xfe\x4b\x8f\x3e\x01\x2a\xfc\xa5\xc6\x28\x09\x00\x00")
+
+func templatesNetwork_security_rules_allowed_cidrsTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNetwork_security_rules_allowed_cidrsTf,
+		"templates/network_security_rules_allowed_cidrs.tf",
+	)
+}
+
+func templatesNetwork_security_rules_allowed_cidrsTf() (*asset, error) {
+	bytes, err := templatesNetwork_security_rules_allowed_cidrsTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/network_security_rules_allowed_cidrs.tf", size: 2344, mode: os.FileMode(480), modTime: time.Unix(1792370640, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesOutputTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa5\x93\xcd\x6e\x83\x30\x10\x84\xef\x3c\x85\x85\x7a\x68\xa4\x96\xa8\x91\x7a\x89\xd4\x67\xb1\x0c\x2c\xc1\x8d\xc1\xd6\xfa\x27\x49\xa3\xbc\x7b\x4d\x08\x11\x26\x38\xe9\x8f\x8f\x30\xfb\xcd\x30\x8b\xa5\x35\xca\x1a\x92\xba\x16\x0c\x6d\x59\x03\x29\x39\x26\x84\x38\x26\x2c\x90\x0f\x92\x3e\x1d\xd9\x97\x45\xc0\x86\x3a\x8e\xc6\x32\x41\xbd\x70\x27\x71\x9b\xe5\x52\xd7\x59\x37\x71\x4a\x93\x53\x92\xc8\x0b\x48\xdb\xfc\x21\xaa\xd7\xc4\x08\x08\x5a\x5a\x2c\x80\x6e\x50\x5a\x75\x9f\x14\x6a\xa3\x99\x8c\x44\xb6\x01\xca\x8a\x42\xda\xf6\x51\xb8\x50\x1c\x63\x96\x50\x31\x2b\x0c\xd5\x50\x58\xe4\xe6\xd0\x27\x88\x52\x2f\xad\x4d\xe4\x31\x38\xec\x0d\x60\xeb\xcb\xe6\x71\xa2\xb2\xb9\xe0\x85\x57\xf4\x10\xae\x28\x2b\x4b\xdf\x87\x9e\xe4\xe4\x08\x85\xff\xa4\xe1\xed\x84\x57\x1b\xa3\xf4\x7a\xb9\xfc\x09\x77\xbd\x7a\xf7\x27\xa0\x2b\xe4\x8e\x19\xa0\x5b\x38\x8c\xc1\xdd\x39\x87\x35\x42\xd3\x91\xe6\x8c\xa4\xae\xd1\xd9\xe8\x21\x55\xd0\xf8\xcc\x84\x68\x68\x35\x37\xdc\x75\xc1\x0c\x5a\x08\x8c\xfa\x54\xbf\xf7\xb9\xce\x51\xa9\x3c\x5f\xd7\x37\x56\x15\x13\x3a\xf0\xfa\xb4\x8d\xca\xe5\x9e\x5a\x14\x7f\x68\x7f\xbd\x5a\x05\x15\x0d\x9b\x2f\x78\x89\x37\x38\xc7\x30\x1b\x0b\x22\xbb\x9b\xf9\x61\x3b\xdb\xd7\x1e\x00\xad\xa3\xbc\x0c\x47\x79\x7b\xf9\x83\x66\x6d\x85\x2c\x98\xc8\x02\xcd\xec\x2d\xbe\x33\x3c\x52\x44\x9c\x37\xbb\x9b\xd1\x4e\x5d\x4b\x6d\x9e\x67\x02\xbc\x90\xb7\x45\x48\x1a\xf6\x40\xaf\xba\x99\xeb\x70\x1f\xd9\xd5\x33\x60\xfc\x8e\x64\x55\x69\x30\x8b\x48\xcb\xff\xf4\xb9\x72\x26\x46\xdf\x38\x02\x2a\x9c\x63\x05\x00\x00")
 
 func templatesOutputTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -192,7 +234,7 @@ func templatesOutputTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/output.tf", size: 1347, mode: os.FileMode(480), modTime: time.Unix(1521742869, 0)}
+	info := bindataFileInfo{name: "templates/output.tf", size: 1379, mode: os.FileMode(480), modTime: time.Unix(1792360850, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -257,7 +299,7 @@ func templatesTlsTf() (*asset, error) {
 	return a, nil
 }
 
-var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xc1\x6a\xc4\x20\x10\x40\xef\x7e\xc5\x20\x3d\xa7\xcd\xa5\xb7\x7e\x4b\x30\x3a\x2d\x43\xcd\x18\x46\x63\xa1\x21\xff\x5e\x12\xc1\xee\x8a\x2c\x9b\xdc\xe6\xbd\x07\xce\x64\x23\x64\x66\x8f\xa0\x91\xf3\x44\x4e\xc3\x7e\x28\xf5\x3f\x15\xfc\xa2\xc0\xed\x34\xd2\xb2\x7a\x9c\xfa\x49\xdc\xe6\x68\x85\xd6\x44\x81\x3b\x38\x21\x1b\x4e\x1d\x60\x3d\xe1\x23\x10\xd1\x0a\xa6\x16\x32\xa6\x9f\x20\xdf\x93\x25\x27\x1a\x76\x05\xe0\xf0\xd3\x6c\x3e\xc1\x07\xe8\xf1\x6d\xb8\xfe\xd7\xf1\x5d\xab\xbb\x8c\x38\xa1\xb0\xf1\xcf\x75\xab\x84\x4c\x0e\x05\xb4\xf9\xdd\x04\x65\x29\x45\xb3\xe9\x59\xbe\xec\xd9\xc8\xd0\x80\x43\x2b\x80\xba\x37\x94\xaf\xca\x15\x5c\x5a\xbd\x42\xab\x55\x70\xab\x95\x9b\x74\xb4\x02\x8e\xf3\xf5\x7f\x01\x00\x00\xff\xff\x59\x69\xa5\xda\xe3\x01\x00\x00")
+var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x52\x41\x4e\xc3\x30\x10\xbc\xe7\x15\xab\x88\x03\x48\x55\x69\x0f\x54\x08\x09\xf1\x08\x8e\x08\x45\xae\xbd\xa5\x06\xc7\xb6\xd6\x4e\x4a\xa9\xfa\x77\x1c\xbb\xa4\x89\x49\x21\x3e\x65\x67\x66\x3d\x3b\xde\x96\x91\x64\x6b\x85\x50\xa2\x6e\x2b\x29\x4a\x38\x1c\x8b\xa2\xed\xab\x84\x6f\xd2\xe8\xbc\xea\x64\x6d\x15\x56\xd3\x12\xd7\xac\x1d\x27\x69\x7d\x10\x4e\xc0\x1e\x35\xd3\x7e\x02\xe0\x4a\xe2\x5f\x80\x43\x4e\xe8\x73\x50\xa3\xdf\x19\xfa\xa8\xb8\x14\x14\xb0\x02\x40\xe0\x86\x35\xca\xc3\x23\x94\xcb\xc5\x3c\x9e\xdb\xe5\xaa\x2c\x46\x32\xa9\x3d\x92\x66\x6a\x5a\x97\x91\xdf\x9b\xda\xae\xcd\x67\x25\x6d\x65\x36\x1b\x17\x4d\x8c\x04\x77\x63\xbe\x90\x84\xdc\x1b\xba\x2c\x58\x8d\x05\x4c\x29\xb3\x43\x11\xcd\xb8\xc4\xf5\x7b\x8b\x70\xfa\x82\x23\x25\x9d\x2f\x07\x2d\x52\xf9\xe5\x35\x96\xfa\xb8\x3b\xe6\xb3\x69\x88\xa3\x83\x53\x4f\xf0\x06\x08\x19\xdf\x82\xdf\x22\x9c\x26\x01\xa6\x05\xfc\xb8\x9c\x85\xbf\x3d\xb8\x28\x83\xdd\x16\x35\x60\x6d\xfd\x3e\x66\xa0\x0c\x67\xca\x45\x43\xa3\xc4\xba\x8b\xae\x0e\x61\x80\x79\x56\xee\xc2\x83\x27\xe8\x90\xe1\xcb\xc0\x03\xfc\x22\x1f\xbb\x79\xc2\xb2\x04\x5e\xe2\xc0\x7f\x5d\x63\x3c\x51\x70\x9d\x5f\x30\x83\xfb\x19\x2c\x6e\x2e\xdc\x13\x26\xb1\x64\x5a\x29\x90\x42\xd8\x5f\x0d\x21\xd5\x29\xe6\x6c\x59\xcf\x0e\x32\x20\x9a\xed\x57\xf7\xfc\x2e\x89\xdc\x03\x91\xd6\x2f\x72\x4e\xeb\x81\x21\x2d\xad\xf5\x04\x2d\x01\xd1\xfd\x37\x5a\x4c\x0d\x29\xa6\x03\x00\x00")
 
 func templatesVarsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -272,7 +314,7 @@ func templatesVarsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vars.tf", size: 483, mode: os.FileMode(480), modTime: time.Unix(1519854618, 0)}
+	info := bindataFileInfo{name: "templates/vars.tf", size: 934, mode: os.FileMode(480), modTime: time.Unix(1792361470, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -334,6 +376,8 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
 	"templates/network.tf": templatesNetworkTf,
 	"templates/network_security_group.tf": templatesNetwork_security_groupTf,
+	"templates/network_security_rules.tf": templatesNetwork_security_rulesTf,
+	"templates/network_security_rules_allowed_cidrs.tf": templatesNetwork_security_rules_allowed_cidrsTf,
 	"templates/output.tf": templatesOutputTf,
 	"templates/resource_group.tf": templatesResource_groupTf,
 	"templates/storage.tf": templatesStorageTf,
@@ -387,6 +431,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
 		"network.tf": &bintree{templatesNetworkTf, map[string]*bintree{}},
 		"network_security_group.tf": &bintree{templatesNetwork_security_groupTf, map[string]*bintree{}},
+		"network_security_rules.tf": &bintree{templatesNetwork_security_rulesTf, map[string]*bintree{}},
+		"network_security_rules_allowed_cidrs.tf": &bintree{templatesNetwork_security_rules_allowed_cidrsTf, map[string]*bintree{}},
 		"output.tf": &bintree{templatesOutputTf, map[string]*bintree{}},
 		"resource_group.tf": &bintree{templatesResource_groupTf, map[string]*bintree{}},
 		"storage.tf": &bintree{templatesStorageTf, map[string]*bintree{}},
@@ -49,21 +49,21 @@ output "director_name" {
 }
 
 output "internal_cidr" {
-  value = "${var.internal_cidr}"
+  value = "${local.internal_cidr}"
 }
 
 output "subnet_cidr" {
-  value = "${cidrsubnet(var.network_cidr, 8, 0)}"
+  value = "${local.subnet_cidr}"
 }
 
 output "internal_gw" {
-  value = "${cidrhost(var.internal_cidr, 1)}"
+  value = "${cidrhost(local.internal_cidr, 1)}"
 }
 
 output "jumpbox__internal_ip" {
-  value = "${cidrhost(var.internal_cidr, 5)}"
+  value = "${cidrhost(local.internal_cidr, var.jumpbox_ip_offset)}"
 }
 
 output "director__internal_ip" {
-  value = "${cidrhost(var.internal_cidr, 6)}"
+  value = "${cidrhost(local.internal_cidr, var.director_ip_offset)}"
 }
@@ -17,7 +17,26 @@ variable "network_cidr" {
 }
 
 variable "internal_cidr" {
-  default = "10.0.0.0/16"
+  default = ""
+}
+
+variable "jumpbox_ip_offset" {
+  default = 5
+}
+
+variable "director_ip_offset" {
+  default = 6
+}
+
+variable "allowed_cidrs" {
+  type        = "list"
+  default     = []
+  description = "Sources allowed to reach the jumpbox and director, any source when empty"
+}
+
+locals {
+  internal_cidr = "${var.internal_cidr == "" ? var.network_cidr : var.internal_cidr}"
+  subnet_cidr   = "${var.internal_cidr == "" ? cidrsubnet(var.network_cidr, 8, 0) : var.internal_cidr}"
 }
 
 provider "azurerm" {
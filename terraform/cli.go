@@ -1,22 +1,29 @@
 package terraform
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
 )
 
+type exitLogger interface {
+	ChildExited(command string, exitCode int)
+}
+
 type CLI struct {
 	errorBuffer  io.Writer
 	outputBuffer io.Writer
 	tfDataDir    string
+	logger       exitLogger
 }
 
-func NewCLI(errorBuffer, outputBuffer io.Writer, tfDataDir string) CLI {
+func NewCLI(errorBuffer, outputBuffer io.Writer, tfDataDir string, logger exitLogger) CLI {
 	return CLI{
 		errorBuffer:  errorBuffer,
 		outputBuffer: outputBuffer,
 		tfDataDir:    tfDataDir,
+		logger:       logger,
 	}
 }
 
@@ -34,5 +41,21 @@ func (c CLI) RunWithEnv(stdout io.Writer, workingDirectory string, args []string
 	command.Stdout = io.MultiWriter(stdout, c.outputBuffer)
 	command.Stderr = c.errorBuffer
 
-	return command.Run()
+	err := command.Run()
+	c.logger.ChildExited(commandName("terraform", args), exitCode(command))
+	return err
+}
+
+func commandName(name string, args []string) string {
+	if len(args) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s %s", name, args[0])
+}
+
+func exitCode(command *exec.Cmd) int {
+	if command.ProcessState == nil {
+		return -1
+	}
+	return command.ProcessState.ExitCode()
 }
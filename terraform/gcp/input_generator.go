@@ -2,6 +2,7 @@ package gcp
 
 import (
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type InputGenerator struct {
@@ -20,6 +21,8 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 		"system_domain": state.LB.Domain,
 	}
 
+	terraform.AddNetworkInputs(input, state.Network, "subnet_cidr")
+
 	if state.LB.Cert != "" && state.LB.Key != "" {
 		input["ssl_certificate"] = state.LB.Cert
 		input["ssl_certificate_private_key"] = state.LB.Key
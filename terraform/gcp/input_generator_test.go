@@ -51,6 +51,27 @@ var _ = Describe("InputGenerator", func() {
 			}))
 		})
 
+		Context("when a network layout is requested", func() {
+			BeforeEach(func() {
+				state.Network = storage.Network{
+					NetworkCIDR:     "172.16.0.0/16",
+					JumpboxIPOffset: 10,
+					AllowedCIDRs:    []string{"203.0.113.0/24"},
+				}
+			})
+
+			It("passes the network cidr as the subnet cidr", func() {
+				inputs, err := inputGenerator.Generate(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs["subnet_cidr"]).To(Equal("172.16.0.0/16"))
+				Expect(inputs["jumpbox_ip_offset"]).To(Equal(10))
+				Expect(inputs["allowed_cidrs"]).To(Equal([]string{"203.0.113.0/24"}))
+				Expect(inputs).NotTo(HaveKey("internal_cidr"))
+				Expect(inputs).NotTo(HaveKey("director_ip_offset"))
+			})
+		})
+
 		Context("when cert and key are provided", func() {
 			BeforeEach(func() {
 				state.LB.Cert = "some-cert"
@@ -14,6 +14,7 @@ type templates struct {
 	cfLB         string
 	cfDNS        string
 	concourseLB  string
+	cfLBIPv6     string
 }
 
 type TemplateGenerator struct{}
@@ -36,6 +37,10 @@ func (t TemplateGenerator) Generate(state storage.State) string {
 
 		template = strings.Join([]string{template, tmpls.cfLB, instanceGroups, backendService}, "\n")
 
+		if state.Network.DualStack {
+			template = strings.Join([]string{template, tmpls.cfLBIPv6}, "\n")
+		}
+
 		if state.LB.Domain != "" {
 			template = strings.Join([]string{template, tmpls.cfDNS}, "\n")
 		}
@@ -110,6 +115,7 @@ func readTemplates() templates {
 	tmpls.cfLB = string(MustAsset("templates/cf_lb.tf"))
 	tmpls.cfDNS = string(MustAsset("templates/cf_dns.tf"))
 	tmpls.concourseLB = string(MustAsset("templates/concourse_lb.tf"))
+	tmpls.cfLBIPv6 = string(MustAsset("templates/cf_lb_ipv6.tf"))
 
 	return tmpls
 }
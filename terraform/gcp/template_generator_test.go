@@ -153,6 +153,25 @@ output "subnet_cidr_3" {
 				checkTemplate(template, expectedTemplate)
 			})
 		})
+
+		Context("when a CF LB is provided on a dual stack network", func() {
+			BeforeEach(func() {
+				expectedTemplate = expectTemplate("vars", "bosh_director", "jumpbox", "cf_lb")
+				ipv6 := expectTemplate("cf_lb_ipv6")
+				expectedTemplate += "\n" + instanceGroups + "\n" + backendService + "\n" + ipv6 + "\n" + subnetCIDRs
+
+				state = storage.State{
+					GCP:     storage.GCP{Zones: []string{"z1", "z2", "z3"}},
+					LB:      storage.LB{Type: "cf"},
+					Network: storage.Network{DualStack: true},
+				}
+			})
+
+			It("adds the ipv6 forwarding rules", func() {
+				template := templateGenerator.Generate(state)
+				checkTemplate(template, expectedTemplate)
+			})
+		})
 	})
 
 	Describe("GenerateBackendService", func() {
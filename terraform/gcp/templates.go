@@ -3,6 +3,7 @@
 // templates/bosh_director.tf
 // templates/cf_dns.tf
 // templates/cf_lb.tf
+// templates/cf_lb_ipv6.tf
 // templates/concourse_lb.tf
 // templates/jumpbox.tf
 // templates/vars.tf
@@ -73,7 +74,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x96\xdf\x8e\xa2\x30\x14\xc6\xef\x79\x8a\x93\x66\x2f\x66\x12\x64\x15\x45\xd9\x0b\x9f\xc4\x98\xa6\x60\x65\xd8\xa9\x94\x94\xa2\xb3\x99\xf0\xee\x9b\xb6\xfc\x17\x19\x9c\x19\x33\x7a\x01\xd2\xef\x9c\xf3\xf5\xd7\x53\xe9\x99\x88\x98\x04\x8c\x02\xca\xf2\x20\xa1\x12\x87\xf1\x41\x20\x78\xb7\x00\xe4\xbf\x94\x02\x00\x6c\x01\x65\x52\xc4\x49\x84\x2c\x80\x03\x3d\x92\x9c\x49\xf5\x70\x31\x77\xf4\xf7\xf7\x62\x8d\xac\xc2\xb2\x04\xcd\x78\x2e\x42\x0a\x28\xe2\x3c\x62\x14\x87\xfc\x94\xe6\x92\xe2\x84\xca\x0b\x17\xaf\x08\x50\x10\xb0\x59\xfd\x4b\xd5\x48\xc8\x49\xd7\xe8\x7f\xb6\x80\x7e\xbd\x9f\x89\x70\x68\x72\xc6\xf1\xa1\xa8\xa3\x2c\x00\x92\x4b\x8e\x43\x41\x89\xa4\xd8\x98\x56\x23\x19\x6c\xe1\x48\x58\x46\x47\xad\x34\xfa\xd2\x8d\x79\x30\x64\xe6\xca\x42\x29\xb5\x00\xe2\x54\x63\xc2\x82\x24\x11\x6d\x84\x2d\x82\x85\x92\x95\x95\xda\xf9\x86\xc9\x38\x2d\x2e\x4e\x46\xd9\x11\xb3\x38\x79\x2d\xc6\xa9\x1e\x63\x41\x2f\x84\x31\x04\x88\xbe\x49\x2a\x12\xc2\xba\xd3\xb8\x9a\x40\x2d\x6b\x79\x9b\xea\x4a\x25\x2d\x90\x65\x01\x18\x3b\x66\xee\x0a\xfa\x0e\x55\x7d\x30\x47\x7b\x25\x20\x8c\xf1\x8b\x76\x02\x90\x72\x21\x33\x63\x66\x87\x5c\x17\xd9\x80\xd6\xfe\xda\x57\x57\xd7\xf3\x3c\x0f\xed\x8d\x4c\x70\xc9\x43\xce\x94\x1d\x19\xa6\xca\x60\xa1\x52\x49\x22\x22\x2a\xb1\x24\x91\xa9\xd4\x9d\x4f\xc0\xb3\x97\x19\x4f\x69\x82\xf6\x53\x49\x35\x21\xe3\xa8\x1a\xdd\x77\xb0\x9a\xe0\x7f\x3a\x37\x7f\xb5\x5a\xea\xab\xbf\x5a\x7d\x23\xc7\x43\x2c\x68\x28\xb9\xb8\x93\x65\x1d\x36\x81\x67\xad\x7d\x34\xd3\xd6\x5c\xfa\x5c\x3f\x05\x28\x4e\xca\x8d\x33\x99\x4d\x15\x31\x93\x7c\x2a\xa2\xc1\x90\x07\x92\x6a\x4d\x6a\xac\xf9\x56\xae\x69\x3f\xd7\x73\xbd\xb9\xb9\xd9\x6c\x36\x3f\xd1\x6f\x7f\xf3\x53\x1a\xf0\x37\xc5\x47\x3f\x18\xa5\xd9\x13\x3f\x90\x63\x59\x69\xd2\x1e\x5e\x2e\xfd\x3f\x5f\x42\x57\x2f\x9a\x3d\xbe\xc3\xee\x6e\xd4\x89\xcd\xf9\x43\x0d\xd9\x62\x15\x87\xa7\x06\xd6\x94\xad\x7d\x4b\x93\x1f\x3e\xb7\xfd\x19\x0f\x09\xcb\x74\xc2\x6a\x40\xbf\xf2\x0d\x0f\x75\x67\xce\x01\x4f\xbd\x23\x81\x0d\xbe\x0d\xf3\x67\xf3\x56\xe7\xb9\x4c\x73\x09\xa8\x73\x18\x3a\x13\x96\xd3\x7b\xb1\xb6\x92\xb5\x0f\x37\xe3\xf9\x1a\xa5\xd3\x1c\x82\x06\x32\x56\xfd\x84\xd5\x50\x2f\xa9\x6e\xb8\x0e\xa8\x4e\x68\x87\xcd\x95\x1f\x0d\xd1\xe9\x68\x6e\x84\x47\x97\xab\x60\xa5\x7e\xe1\x99\x7c\x1a\xc8\x62\xc3\xa2\x87\xb8\xdc\x9f\x18\xd7\xba\x38\xbd\x33\xa5\xf7\x7c\x03\xcb\x17\x72\xae\x6f\xd9\x54\x4d\xd8\xcd\xb5\xd3\xbd\x7b\xbd\x8a\xd5\x36\x76\xea\x83\x44\xb9\x84\x76\x15\x30\xf4\x3f\xa5\x06\xf7\xc3\xb3\x19\x28\xfd\x51\xd5\x2a\xb8\xac\xbc\x1f\x5c\x43\x49\xa2\xa1\x0e\xba\x9d\xba\x8a\x6c\x5a\xf2\x7f\x00\x00\x00\xff\xff\x08\x23\x4c\x5b\xa8\x0c\x00\x00")
+var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x56\xdb\x6e\xdb\x30\x0c\x7d\xcf\x57\x10\xc6\x1e\x5a\x20\xcd\xd2\xb4\x49\xb3\x01\xc3\x3e\x62\x8f\x45\x61\x28\xb6\xe2\x68\x55\x2c\x43\x92\x93\x0e\x43\xfe\x7d\x94\x6c\xcb\xf2\x25\x9e\xd3\x0b\xea\x3c\x24\x91\x78\x0e\xc9\x43\x4a\xe6\x81\x48\x46\x36\x9c\x42\xa0\xf2\x4d\x4a\x75\x18\xb1\x58\x06\xf0\x77\x02\xa0\xff\x64\x14\xf0\xf9\x81\x7b\x5a\xb2\x34\x09\x70\x31\xa6\x5b\x92\x73\x6d\x16\x6f\xe7\x33\xfb\xf9\x7a\xbb\x0a\x26\xa7\xc9\xe4\xe0\xa8\x08\xe7\xe2\x48\x63\xcb\xa5\x9a\x64\x25\x21\x67\x4a\xfb\x74\xc5\xf2\x63\x50\x51\xce\x83\x27\xbb\xab\x22\xc9\x32\xcd\x44\x6a\x40\xbf\x44\x2e\x23\xaa\xa0\xa4\x07\x2d\x40\x52\x12\xed\x40\xef\x28\xfc\xce\xf7\xd9\x46\xbc\x00\x49\x63\x88\x99\xa4\x91\x16\xb2\x15\x16\x4b\x35\x95\x29\xe1\x17\xe4\xd8\x62\x28\x9d\x84\x2c\x0b\xc5\x76\xab\xa8\x2e\x58\x6a\xc0\xb2\x69\x5f\x05\x72\x1e\xb0\x32\x00\x49\x95\x4d\x0d\x82\x44\x88\x84\xd3\x30\x12\xfb\x2c\xd7\x34\xc4\x82\x1c\x85\x7c\x0e\x20\xd8\x6c\xf8\x8d\xfb\x67\x28\x52\xb2\x77\x82\xfa\x0f\x06\xfd\xe5\x2f\x46\x30\xa3\xe9\x21\x64\xf1\xc9\xa1\x10\x43\x72\x2d\xc2\x08\x35\x43\xea\xa2\xdc\x66\x47\x21\x66\x4b\xb8\xa2\x83\xa1\xd4\xf6\x65\x34\xc5\x42\x5f\x30\x9d\x10\x4a\x53\x34\x44\x1d\x8c\xf8\xa1\x24\x69\x42\x6b\x43\xaf\xf7\x4e\xc6\xac\xf4\xe4\xf3\xf5\x2b\x33\xf3\x74\x99\x29\xca\xb7\x21\x67\xe9\xf3\x29\x18\x4c\x65\x8b\x55\x39\x62\x13\x61\x22\xf4\xa5\x68\x89\x66\x1a\x9d\x04\x9c\x99\x17\xdb\xd8\xa8\x0c\x29\x06\x84\xc8\x22\x9c\x22\x77\x65\xdb\xbd\xf0\xd2\x38\x2e\x27\xec\x7c\x53\x2a\xb3\x66\x83\x02\xc8\x84\xd4\xaa\x3a\x21\x8b\x45\x30\x85\x60\xb5\x5e\xad\xcd\xf7\x62\x89\x8f\x3d\x2b\x68\x26\x85\x16\x91\xe0\x26\x32\x1d\x65\x26\xd6\x93\xa1\xd2\x44\x26\x28\xae\x26\x89\xef\xb4\x4a\x6d\x23\xd4\xee\x46\x64\x34\x45\x96\x91\xa2\xd5\x90\x61\xd5\x6a\xbb\xf7\x90\x6d\x44\xfc\xe3\x75\x5b\xdf\xdf\xdf\xd9\x6f\xfc\xf1\x8e\x3a\xba\x8b\xe7\x32\x2d\x1d\x6c\x84\x9e\xf5\xdd\xf6\xc1\x9a\x7a\xb9\xb4\x75\x7d\x95\x40\xd5\xed\x3b\x5e\x9b\x0a\x71\xa3\xc5\x58\x89\x7a\x21\x1f\xa8\x94\x97\xd4\x50\xf3\xdd\x2f\x8a\xf6\x5b\x2c\x17\xcb\x79\xf1\xe3\xe1\xe1\xe1\x33\xfa\xad\x7c\x83\x19\x7d\xec\xc2\xa0\x9a\x2d\xe3\x0f\xd4\xb1\xf4\x34\xea\x0c\xdf\xdd\xad\xbf\xbd\x49\x3a\x57\xb4\x29\xbc\x8f\xa8\x8e\x70\x5c\x73\x7e\x52\x43\x7a\x5a\xb1\x68\x5f\x8b\x35\xe6\x68\x9f\xb3\xc9\xe3\xd7\x1d\x7f\x2e\x22\x9c\x38\x2c\x61\x63\x2a\xab\x45\x6b\x2d\x9b\x59\x0c\x7e\x82\x7d\x45\xda\x61\xe1\xaa\x35\x37\x4c\x61\x3d\x85\xf9\x35\x7c\x87\x0e\xba\x18\x07\x44\xae\x51\x5d\x08\x1a\x53\xd4\x81\xf0\x9c\x5e\x5a\x04\x8f\xcc\x9f\x8a\x86\xf9\x6a\xcb\x59\x3d\x3d\xf5\x30\xba\x91\xd1\x6c\xb5\x48\x6d\x7b\x36\x64\x6d\x40\x7b\xe6\x5b\x2f\x1e\x2b\xf9\x90\x30\x6e\x2b\x39\x76\xc0\xc6\x7a\x27\x94\xbe\xea\x61\x99\xc2\xed\x75\x93\xa9\x1a\x93\x43\x67\xc7\xb2\x0b\x29\x4d\x8e\x9d\x69\xfb\xfa\x8c\x54\x6f\xf4\xd3\x9d\xd2\xcf\xe5\x63\x7a\xbb\xe9\xe0\xd1\x1e\x89\x6e\xb9\xab\xdb\x61\xe6\xe6\x93\xb2\xd6\xd3\x0a\xd0\x77\xfd\x99\xcd\xa7\xfe\x14\x7b\x5c\xff\xcf\x6b\x05\x2e\x3d\x3f\xf5\x16\x1b\x79\xfb\x5a\xed\x3c\x75\x85\xac\x7b\xf7\x1f\xd3\xee\xe3\xc7\x44\x0e\x00\x00")
 
 func templatesBosh_directorTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -88,7 +89,7 @@ func templatesBosh_directorTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 3240, mode: os.FileMode(480), modTime: time.Unix(1521677188, 0)}
+	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 3652, mode: os.FileMode(480), modTime: time.Unix(1792366161, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -133,6 +134,26 @@ func templatesCf_lbTf() (*asset, error) {
 	return a, nil
 }
 
+var _templatesCf_lb_ipv6Tf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x90\xbb\x4e\xc4\x30\x10\x45\xfb\x7c\x85\x65\xd1\x3a\x42\xda\x15\xa2\xe1\x03\xe8\xa8\x68\x47\x4e\x32\x31\x16\x5e\xdb\x1a\x3f\x00\xad\xf2\xef\x38\x71\x50\x60\x0b\x58\x29\x05\xae\x3c\xaf\x3b\x77\x0e\x61\x70\x89\x7a\x64\x5c\x39\xa7\x0c\x42\xef\x4e\x3e\x45\x04\x65\x5c\x27\x0d\xc8\x61\x20\x0c\x81\x33\xde\x8f\x62\x0d\x84\xf6\xf9\x8e\xb3\x73\xc3\x98\x95\x27\x64\xf5\x3d\x30\x7e\x73\xce\x92\x5a\xb4\x19\xf4\x30\x89\x32\xb0\x34\x96\x36\xed\x21\x23\x05\xed\xec\xdc\xf6\xf8\xf4\x5c\xb2\x53\xd3\xd0\x1f\xcb\x47\x47\x6f\x92\x06\x6d\x15\x50\x32\x58\x4d\xbc\xc4\xe8\xc5\x56\x11\x73\xe5\x7a\x47\xcb\xf4\x37\x5b\xeb\x49\xb5\xf7\x57\x04\xed\x05\x80\x76\x0d\xa6\x59\x29\x4a\x52\x18\xb7\xad\x17\x4a\xb5\x0c\xf3\x72\xf0\xe4\xde\x3f\xda\x2f\x2b\xa6\x13\x35\x11\xd0\x8c\x60\xb4\x7d\x5d\xf4\xbc\xa3\x08\x24\xad\xc2\x59\xef\xfe\x76\x1f\xae\xb0\x8f\x57\xf8\x57\x60\xe1\x27\xb1\x70\x1d\xb2\xe3\xf1\xb0\x30\x73\x29\x16\x41\xc6\xa9\x7c\x90\xc0\x74\xb0\x9d\x9e\xa5\x49\xb8\xef\x90\xa9\xf9\x04\x65\x18\xf2\x9c\x41\x03\x00\x00")
+
+func templatesCf_lb_ipv6TfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesCf_lb_ipv6Tf,
+		"templates/cf_lb_ipv6.tf",
+	)
+}
+
+func templatesCf_lb_ipv6Tf() (*asset, error) {
+	bytes, err := templatesCf_lb_ipv6TfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cf_lb_ipv6.tf", size: 833, mode: os.FileMode(480), modTime: time.Unix(1792372168, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x93\xc1\x6e\xdb\x30\x0c\x86\xef\x7a\x0a\x82\xd8\x71\x32\x0a\xaf\x87\x5e\x76\x1a\x76\xed\x76\xd8\x6d\x28\x04\xc5\xa6\x1d\xa1\xaa\x28\x48\x72\x8c\xa1\xf0\xbb\x0f\xb2\x1d\xc7\x5d\xb3\x24\x40\x10\xa0\x27\x13\x34\xf5\x93\xfa\x7e\x8a\xbb\xe4\xbb\x04\x58\xb1\xab\xb8\x0b\x91\x54\xd2\xa1\xa5\xa4\x3c\xb3\x45\x78\x15\x00\x3b\x6d\x3b\x82\xaf\x80\x9f\x5e\x5b\xe6\xd6\x92\xaa\xf8\xc5\x77\xe9\x4d\x69\x31\xc5\x72\x8c\x9d\x7e\xa1\x01\xc5\x20\xc4\x7b\x79\xbb\x51\xc6\x9f\x13\xd6\x75\x1d\x28\xc6\x62\x39\x26\xf7\x99\xf9\x3b\xa9\x07\x8a\xdc\x85\x8a\x00\xff\x39\xdf\x98\x40\xbd\xb6\x16\x01\xf7\xa1\x5c\xb4\xa6\xe6\x79\x46\x00\x98\xda\xef\x74\x28\xc8\xed\x94\xa9\x87\x43\x9d\x64\x4f\x0e\x73\x29\xa5\x9e\xc3\xf3\xd1\x49\xe7\x7f\xc5\x66\x63\xe5\x3e\x9e\xaf\x2f\x00\xb4\xb5\xdc\x8f\xed\x00\x7c\xe0\xc4\x15\xdb\x2c\x93\x2a\x8f\x53\x92\x43\x8a\xd3\x18\xbf\xf1\xe1\x0e\x3f\x03\xde\xdf\x7f\xc9\x9f\xb2\x2c\x4b\x7c\x12\x00\x43\x16\x9a\x49\x27\xdd\xc6\xb1\xf4\x70\x99\xa7\x93\x20\x66\x5c\xb8\x72\x40\x2e\xb9\x05\xc3\xff\x19\x9c\xc6\xfc\x66\x55\x70\xb5\x01\x17\x6a\x0b\x80\x48\x31\x1a\x76\x4a\x37\x8d\x71\x26\xfd\xc9\xf5\x8f\x3f\x1e\xbf\x9f\xf1\x97\x43\xaf\x43\x6d\x5c\xab\x42\x67\x09\x01\x63\xdc\xca\x43\x56\x4e\xd9\xb5\xcf\x67\xbc\x8e\x71\x8b\x0b\xe7\x55\xf5\x85\x1b\x1f\xc9\x36\xca\x1a\xf7\x3c\x64\x95\xec\xaa\x0a\xda\xb5\x34\xaa\x8c\x56\x0a\x00\xe3\xd5\x7a\x09\x7e\x7d\xfb\x39\x67\x67\x47\x8e\xb7\xbc\xfa\x2d\xbc\x63\xb5\x4d\xc9\xc7\xab\x68\x8d\x0a\x37\xe3\x95\x5f\xc0\x07\xc3\x75\x35\xad\x9b\xc1\x7a\xb8\xbb\x35\xab\xbf\x01\x00\x00\xff\xff\x5f\xcd\x56\x41\x23\x06\x00\x00")
 
 func templatesConcourse_lbTfBytes() ([]byte, error) {
@@ -248,6 +269,7 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/bosh_director.tf": templatesBosh_directorTf,
 	"templates/cf_dns.tf": templatesCf_dnsTf,
 	"templates/cf_lb.tf": templatesCf_lbTf,
+	"templates/cf_lb_ipv6.tf": templatesCf_lb_ipv6Tf,
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
 	"templates/jumpbox.tf": templatesJumpboxTf,
 	"templates/vars.tf": templatesVarsTf,
@@ -297,6 +319,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"bosh_director.tf": &bintree{templatesBosh_directorTf, map[string]*bintree{}},
 		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
 		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
+		"cf_lb_ipv6.tf": &bintree{templatesCf_lb_ipv6Tf, map[string]*bintree{}},
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
 		"jumpbox.tf": &bintree{templatesJumpboxTf, map[string]*bintree{}},
 		"vars.tf": &bintree{templatesVarsTf, map[string]*bintree{}},
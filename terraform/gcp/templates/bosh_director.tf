@@ -3,6 +3,25 @@ variable "subnet_cidr" {
   default = "10.0.0.0/16"
 }
 
+variable "allowed_cidrs" {
+  type        = "list"
+  default     = ["0.0.0.0/0"]
+  description = "Sources allowed to reach the jumpbox and director"
+}
+
+variable "internal_cidr" {
+  type    = "string"
+  default = ""
+}
+
+variable "jumpbox_ip_offset" {
+  default = 5
+}
+
+variable "director_ip_offset" {
+  default = 6
+}
+
 resource "google_compute_network" "bbl-network" {
   name                    = "${var.env_id}-network"
   auto_create_subnetworks = false
@@ -18,7 +37,7 @@ resource "google_compute_firewall" "external" {
   name    = "${var.env_id}-external"
   network = "${google_compute_network.bbl-network.name}"
 
-  source_ranges = ["0.0.0.0/0"]
+  source_ranges = ["${var.allowed_cidrs}"]
 
   allow {
     ports    = ["22", "6868", "25555"]
@@ -105,7 +124,7 @@ resource "google_compute_firewall" "internal" {
 }
 
 locals {
-  internal_cidr = "${cidrsubnet(var.subnet_cidr, 8, 0)}"
+  internal_cidr = "${var.internal_cidr == "" ? cidrsubnet(var.subnet_cidr, 8, 0) : var.internal_cidr}"
 }
 
 output "network" {
@@ -129,11 +148,11 @@ output "internal_gw" {
 }
 
 output "jumpbox__internal_ip" {
-  value = "${cidrhost(local.internal_cidr, 5)}"
+  value = "${cidrhost(local.internal_cidr, var.jumpbox_ip_offset)}"
 }
 
 output "director__internal_ip" {
-  value = "${cidrhost(local.internal_cidr, 6)}"
+  value = "${cidrhost(local.internal_cidr, var.director_ip_offset)}"
 }
 
 output "jumpbox__tags" {
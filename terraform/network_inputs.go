@@ -0,0 +1,27 @@
+package terraform
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+// AddNetworkInputs passes the requested network layout to the templates.
+// Unset values are left out so the template defaults apply. The name of the
+// variable holding the network CIDR differs between IaaSes.
+func AddNetworkInputs(inputs map[string]interface{}, network storage.Network, networkCIDRVar string) {
+	if network.NetworkCIDR != "" {
+		inputs[networkCIDRVar] = network.NetworkCIDR
+	}
+	if network.InternalCIDR != "" {
+		inputs["internal_cidr"] = network.InternalCIDR
+	}
+	if network.JumpboxIPOffset != 0 {
+		inputs["jumpbox_ip_offset"] = network.JumpboxIPOffset
+	}
+	if network.DirectorIPOffset != 0 {
+		inputs["director_ip_offset"] = network.DirectorIPOffset
+	}
+	if network.DualStack {
+		inputs["dual_stack"] = true
+	}
+	if len(network.AllowedCIDRs) > 0 {
+		inputs["allowed_cidrs"] = network.AllowedCIDRs
+	}
+}
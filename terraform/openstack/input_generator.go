@@ -19,8 +19,8 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 		"env_id":                 state.EnvID,
 		"internal_cidr":          cidr,
 		"internal_gw":            parsedCIDR.GetNthIP(1).String(),
-		"director_internal_ip":   parsedCIDR.GetNthIP(6).String(),
-		"jumpbox_internal_ip":    parsedCIDR.GetNthIP(5).String(),
+		"director_internal_ip":   parsedCIDR.GetNthIP(state.Network.DirectorOffset()).String(),
+		"jumpbox_internal_ip":    parsedCIDR.GetNthIP(state.Network.JumpboxOffset()).String(),
 		"external_ip":            state.OpenStack.ExternalIP,
 		"auth_url":               state.OpenStack.AuthURL,
 		"az":                     state.OpenStack.AZ,
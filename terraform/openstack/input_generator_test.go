@@ -53,6 +53,19 @@ var _ = Describe("InputGenerator", func() {
 				"private_key":            "private-key",
 			}))
 		})
+
+		Context("when ip offsets are requested", func() {
+			It("places the jumpbox and director at those offsets", func() {
+				inputs, err := inputGenerator.Generate(storage.State{
+					OpenStack: storage.OpenStack{InternalCidr: "192.168.10.0/24"},
+					Network:   storage.Network{JumpboxIPOffset: 20, DirectorIPOffset: 21},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs["jumpbox_internal_ip"]).To(Equal("192.168.10.20"))
+				Expect(inputs["director_internal_ip"]).To(Equal("192.168.10.21"))
+			})
+		})
 	})
 	Describe("Credentials", func() {
 		It("returns the vsphere credentials", func() {
@@ -18,8 +18,8 @@ func (i InputGenerator) Generate(state storage.State) (map[string]interface{}, e
 	return map[string]interface{}{
 		"env_id":               state.EnvID,
 		"vsphere_subnet":       cidr,
-		"jumpbox_ip":           parsedCIDR.GetNthIP(5).String(),
-		"director_internal_ip": parsedCIDR.GetNthIP(6).String(),
+		"jumpbox_ip":           parsedCIDR.GetNthIP(state.Network.JumpboxOffset()).String(),
+		"director_internal_ip": parsedCIDR.GetNthIP(state.Network.DirectorOffset()).String(),
 		"internal_gw":          parsedCIDR.GetNthIP(1).String(),
 		"network_name":         state.VSphere.Network,
 		"vcenter_cluster":      state.VSphere.VCenterCluster,